@@ -0,0 +1,16 @@
+data "proxmox_backups" "ct_101" {
+  node_name    = "pve"
+  datastore_id = "pbs"
+  vm_id        = 101
+}
+
+action "proxmox_backup_file_restore" "hosts" {
+  config {
+    node_name    = "pve"
+    datastore_id = "pbs"
+    volume_id    = data.proxmox_backups.ct_101.backups[0].id
+    file_path    = "/root.pxar.didx/etc/hosts"
+    destination  = "${path.module}/restored/hosts"
+    overwrite    = true
+  }
+}
@@ -0,0 +1,22 @@
+resource "proxmox_virtual_environment_vm" "web" {
+  node_name = "pve"
+  # ...
+
+  lifecycle {
+    action_trigger {
+      events  = [before_update]
+      actions = [action.proxmox_backup_now.web]
+    }
+  }
+}
+
+action "proxmox_backup_now" "web" {
+  config {
+    node_name      = "pve"
+    vmids          = [proxmox_virtual_environment_vm.web.vm_id]
+    datastore_id   = "pbs"
+    mode           = "snapshot"
+    notes_template = "{{guestname}} before update"
+    protected      = true
+  }
+}
@@ -0,0 +1,19 @@
+action "proxmox_container_exec" "reload_nginx" {
+  config {
+    node_name    = "pve"
+    container_id = 101
+
+    files = [
+      {
+        destination = "/etc/nginx/conf.d/site.conf"
+        content     = file("${path.module}/site.conf")
+        mode        = "0644"
+      },
+    ]
+
+    commands = [
+      "nginx -t",
+      "systemctl reload nginx",
+    ]
+  }
+}
@@ -0,0 +1,19 @@
+resource "proxmox_node_maintenance" "pve2" {
+  node_name = "pve2"
+
+  lifecycle {
+    action_trigger {
+      events  = [before_create]
+      actions = [action.proxmox_node_migrate_all.pve2]
+    }
+  }
+}
+
+action "proxmox_node_migrate_all" "pve2" {
+  config {
+    node_name        = "pve2"
+    target_node      = "pve1"
+    max_workers      = 2
+    with_local_disks = true
+  }
+}
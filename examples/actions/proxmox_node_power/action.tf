@@ -0,0 +1,23 @@
+# drain the node, reboot it and move the guests back
+action "proxmox_node_migrate_all" "drain" {
+  config {
+    node_name   = "pve2"
+    target_node = "pve1"
+  }
+}
+
+action "proxmox_node_power" "reboot" {
+  config {
+    node_name = "pve2"
+    command   = "reboot"
+    timeout   = "15m"
+  }
+}
+
+# power on a lab node that was shut down
+action "proxmox_node_power" "wake" {
+  config {
+    node_name = "pve3"
+    command   = "wakeonlan"
+  }
+}
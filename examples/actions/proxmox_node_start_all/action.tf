@@ -0,0 +1,7 @@
+action "proxmox_node_start_all" "lab" {
+  config {
+    node_name = "pve"
+    vmids     = [100, 101]
+    force     = true
+  }
+}
@@ -0,0 +1,7 @@
+action "proxmox_node_stop_all" "lab" {
+  config {
+    node_name        = "pve"
+    shutdown_timeout = 300
+    force_stop       = true
+  }
+}
@@ -0,0 +1,23 @@
+resource "proxmox_replication" "web" {
+  id     = "100-0"
+  target = "pve2"
+  type   = "local"
+}
+
+resource "terraform_data" "failover" {
+  # ...
+
+  lifecycle {
+    action_trigger {
+      events  = [before_create]
+      actions = [action.proxmox_replication_now.web]
+    }
+  }
+}
+
+action "proxmox_replication_now" "web" {
+  config {
+    id      = proxmox_replication.web.id
+    timeout = "1h"
+  }
+}
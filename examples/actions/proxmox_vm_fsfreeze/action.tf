@@ -0,0 +1,9 @@
+# Quiesce the database VM while the SAN takes a snapshot of its LUN.
+action "proxmox_vm_fsfreeze" "db" {
+  config {
+    node_name = "pve"
+    vm_id     = 100
+    command   = ["/usr/local/bin/san-snapshot", "--lun", "db-data"]
+    timeout   = "2m"
+  }
+}
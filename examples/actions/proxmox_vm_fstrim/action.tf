@@ -0,0 +1,6 @@
+action "proxmox_vm_fstrim" "db" {
+  config {
+    node_name = "pve"
+    vm_id     = 100
+  }
+}
@@ -0,0 +1,14 @@
+# Backups of VM 100 taken within the last week
+data "proxmox_backups" "vm_100" {
+  node_name    = "pve"
+  datastore_id = "pbs"
+  vm_id        = 100
+  max_age      = "168h"
+}
+
+output "latest_verified_backup" {
+  value = [
+    for b in data.proxmox_backups.vm_100.backups : b.id
+    if b.verification_state == "ok"
+  ][0]
+}
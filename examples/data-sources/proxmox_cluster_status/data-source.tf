@@ -0,0 +1,17 @@
+data "proxmox_cluster_status" "cluster" {}
+
+output "cluster_quorate" {
+  value = data.proxmox_cluster_status.cluster.quorate
+}
+
+output "offline_nodes" {
+  value = [for n in data.proxmox_cluster_status.cluster.nodes : n.name if !n.online]
+}
+
+# Fail the plan instead of the apply when the cluster has lost its quorum
+check "cluster_quorum" {
+  assert {
+    condition     = data.proxmox_cluster_status.cluster.quorate
+    error_message = "The cluster is not quorate."
+  }
+}
@@ -0,0 +1,19 @@
+resource "proxmox_haresource" "web" {
+  resource_id = "vm:100"
+  state       = "started"
+}
+
+data "proxmox_ha_status" "current" {
+  depends_on = [proxmox_haresource.web]
+}
+
+# Check that the HA manager has started the VM on the expected node after the apply
+check "web_started" {
+  assert {
+    condition = (
+      data.proxmox_ha_status.current.services["vm:100"].crm_state == "started" &&
+      data.proxmox_ha_status.current.services["vm:100"].node_name == "pve1"
+    )
+    error_message = "vm:100 is not started on pve1."
+  }
+}
@@ -0,0 +1,13 @@
+data "proxmox_node_services" "pve" {
+  node_name = "pve"
+}
+
+check "ha_services" {
+  assert {
+    condition = alltrue([
+      for s in data.proxmox_node_services.pve.services : s.state == "running"
+      if contains(["pve-ha-crm", "pve-ha-lrm"], s.service)
+    ])
+    error_message = "The HA services are not running on node pve."
+  }
+}
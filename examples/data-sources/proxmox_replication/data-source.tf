@@ -11,3 +11,10 @@ output "data_proxmox_replication" {
     guest  = data.proxmox_replication.example.guest
   }
 }
+
+check "replication_lag" {
+  assert {
+    condition     = try(timecmp(timeadd(data.proxmox_replication.example.last_sync, "30m"), plantimestamp()) > 0, false)
+    error_message = "Replication job 100-0 has not synced in the last 30 minutes."
+  }
+}
@@ -0,0 +1,12 @@
+# Failed backups of the last day, on all nodes
+data "proxmox_tasks" "failed_backups" {
+  type   = "vzdump"
+  status = "error"
+  since  = timeadd(plantimestamp(), "-24h")
+}
+
+output "failed_backups" {
+  value = {
+    for t in data.proxmox_tasks.failed_backups.tasks : t.upid => t.status
+  }
+}
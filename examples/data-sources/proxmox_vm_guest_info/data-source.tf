@@ -0,0 +1,15 @@
+data "proxmox_vm_guest_info" "web" {
+  node_name = "pve"
+  vm_id     = 100
+}
+
+output "web_os" {
+  value = data.proxmox_vm_guest_info.web.os.pretty_name
+}
+
+check "web_os_version" {
+  assert {
+    condition     = data.proxmox_vm_guest_info.web.os.version_id == "12"
+    error_message = "VM 100 is expected to run Debian 12."
+  }
+}
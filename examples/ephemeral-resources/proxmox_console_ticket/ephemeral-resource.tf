@@ -0,0 +1,19 @@
+# noVNC console of a VM
+ephemeral "proxmox_console_ticket" "web" {
+  node_name = "pve"
+  vm_id     = 100
+}
+
+# SPICE remote viewer file of a VM with a `qxl` display
+ephemeral "proxmox_console_ticket" "desktop" {
+  node_name = "pve"
+  vm_id     = 101
+  protocol  = "spice"
+  proxy     = "spice.example.com"
+}
+
+# xterm.js shell on the node
+ephemeral "proxmox_console_ticket" "shell" {
+  node_name = "pve"
+  protocol  = "term"
+}
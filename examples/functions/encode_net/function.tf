@@ -0,0 +1,8 @@
+output "net0" {
+  # "model=virtio,bridge=vmbr0,firewall=1,tag=10"
+  value = provider::proxmox::encode_net({
+    bridge   = "vmbr0"
+    firewall = true
+    vlan_id  = 10
+  })
+}
@@ -0,0 +1,5 @@
+resource "proxmox_virtual_environment_haresource" "web" {
+  # "vm:100"
+  resource_id = provider::proxmox::ha_resource_id("vm", proxmox_virtual_environment_vm.web.vm_id)
+  state       = "started"
+}
@@ -0,0 +1,10 @@
+resource "proxmox_virtual_environment_vm" "web" {
+  name      = "web-1"
+  node_name = "pve"
+
+  # the same MAC address for every re-creation of the VM
+  network_device {
+    bridge      = "vmbr0"
+    mac_address = provider::proxmox::mac_from_seed("web-1")
+  }
+}
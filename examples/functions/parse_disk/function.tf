@@ -0,0 +1,8 @@
+locals {
+  # { datastore_id = "local-lvm", path_in_datastore = "vm-100-disk-0", size = 32, ssd = true, ... }
+  disk = provider::proxmox::parse_disk("local-lvm:vm-100-disk-0,iothread=1,size=32G,ssd=1")
+}
+
+output "disk_size" {
+  value = local.disk.size
+}
@@ -0,0 +1,8 @@
+locals {
+  # { node_name = "pve", type = "qmstart", id = "100", user = "root@pam", ... }
+  task = provider::proxmox::parse_upid("UPID:pve:000A1B2C:0001E240:66A0F1B0:qmstart:100:root@pam:")
+}
+
+output "task_started_at" {
+  value = local.task.start_time
+}
@@ -0,0 +1,8 @@
+locals {
+  # { datastore_id = "local", content_type = "iso", file_name = "debian-12.iso" }
+  image = provider::proxmox::parse_volume_id(proxmox_virtual_environment_download_file.debian.id)
+}
+
+output "image_datastore" {
+  value = local.image.datastore_id
+}
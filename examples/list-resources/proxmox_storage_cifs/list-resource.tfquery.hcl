@@ -0,0 +1,7 @@
+list "proxmox_storage_cifs" "pve" {
+  provider = proxmox
+
+  config {
+    node_name = "pve"
+  }
+}
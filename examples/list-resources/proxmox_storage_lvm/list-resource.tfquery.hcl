@@ -0,0 +1,7 @@
+list "proxmox_storage_lvm" "pve" {
+  provider = proxmox
+
+  config {
+    node_name = "pve"
+  }
+}
@@ -0,0 +1,7 @@
+list "proxmox_storage_zfspool" "pve" {
+  provider = proxmox
+
+  config {
+    node_name = "pve"
+  }
+}
@@ -0,0 +1,7 @@
+list "proxmox_virtual_environment_container" "web" {
+  provider = proxmox
+
+  config {
+    tags = ["web"]
+  }
+}
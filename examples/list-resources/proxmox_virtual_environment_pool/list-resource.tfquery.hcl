@@ -0,0 +1,3 @@
+list "proxmox_virtual_environment_pool" "all" {
+  provider = proxmox
+}
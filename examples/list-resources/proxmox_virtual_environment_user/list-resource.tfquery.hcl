@@ -0,0 +1,7 @@
+list "proxmox_virtual_environment_user" "pve" {
+  provider = proxmox
+
+  config {
+    realm = "pve"
+  }
+}
@@ -0,0 +1,7 @@
+list "proxmox_virtual_environment_vm" "prod" {
+  provider = proxmox
+
+  config {
+    pool = "prod"
+  }
+}
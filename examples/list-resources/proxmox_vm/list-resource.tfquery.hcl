@@ -0,0 +1,8 @@
+list "proxmox_vm" "web" {
+  provider = proxmox
+
+  config {
+    node_name = "pve"
+    tags      = ["web", "prod"]
+  }
+}
@@ -0,0 +1,21 @@
+resource "proxmox_container_provisioner" "bootstrap" {
+  node_name    = proxmox_virtual_environment_container.web.node_name
+  container_id = proxmox_virtual_environment_container.web.vm_id
+
+  files = [
+    {
+      destination = "/root/.ssh/authorized_keys"
+      content     = trimspace(tls_private_key.deploy.public_key_openssh)
+      mode        = "0600"
+    },
+  ]
+
+  commands = [
+    "apt-get update",
+    "apt-get install -y nginx",
+  ]
+
+  triggers = {
+    config_hash = sha256(file("${path.module}/site.conf"))
+  }
+}
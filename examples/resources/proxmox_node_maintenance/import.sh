@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+# The maintenance mode of a node can be imported using the node name.
+terraform import proxmox_node_maintenance.pve2 pve2
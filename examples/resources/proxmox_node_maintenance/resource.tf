@@ -0,0 +1,8 @@
+# Evacuate the node before an upgrade; destroying the resource ends the maintenance
+resource "proxmox_node_maintenance" "pve2" {
+  node_name = "pve2"
+
+  timeouts = {
+    create = "1h"
+  }
+}
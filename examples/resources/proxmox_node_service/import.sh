@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+# A service can be imported using the node name and the service ID, e.g.:
+terraform import proxmox_node_service.chrony pve/chrony
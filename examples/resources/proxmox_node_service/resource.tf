@@ -0,0 +1,16 @@
+resource "proxmox_node_service" "chrony" {
+  node_name = "pve"
+  service   = "chrony"
+  state     = "running"
+  enabled   = true
+}
+
+# restart the API proxy when the certificate changes
+resource "proxmox_node_service" "pveproxy" {
+  node_name = "pve"
+  service   = "pveproxy"
+
+  triggers = {
+    certificate = sha256(proxmox_virtual_environment_certificate.pve.certificate)
+  }
+}
@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+# A VM ID range can be imported using its first and last ID, separated by a dash.
+terraform import proxmox_vm_id_allocation.workspace 2000-2099
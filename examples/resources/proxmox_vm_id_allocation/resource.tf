@@ -0,0 +1,11 @@
+resource "proxmox_vm_id_allocation" "workspace" {
+  start = 2000
+  end   = 2099
+  owner = terraform.workspace
+}
+
+resource "proxmox_vm" "web" {
+  count     = 3
+  node_name = "pve"
+  id        = proxmox_vm_id_allocation.workspace.start + count.index
+}
@@ -0,0 +1,82 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.ResourceWithIdentity = (*realmLDAPResource)(nil)
+	_ resource.ResourceWithIdentity = (*realmOpenIDResource)(nil)
+)
+
+// realmIdentityModel is the identity of an authentication realm: its name.
+type realmIdentityModel struct {
+	Realm types.String `tfsdk:"realm"`
+}
+
+// IdentitySchema defines the identity of the resource.
+func (r *realmLDAPResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = realmIdentitySchema()
+}
+
+// IdentitySchema defines the identity of the resource.
+func (r *realmOpenIDResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = realmIdentitySchema()
+}
+
+func realmIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"realm": identityschema.StringAttribute{
+				Description:       "The name of the realm.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// setRealmIdentity stores the identity of the realm.
+func setRealmIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, realm types.String, diags *diag.Diagnostics) {
+	if identity == nil {
+		return
+	}
+
+	diags.Append(identity.Set(ctx, realmIdentityModel{Realm: realm})...)
+}
+
+// importRealm imports a realm by its name, from the identity when it is imported by identity.
+func importRealm(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	realm := req.ID
+
+	if realm == "" && req.Identity != nil {
+		var identity realmIdentityModel
+
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+
+		realm = identity.Realm.ValueString()
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("realm"), realm)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), realm)...)
+}
@@ -282,6 +282,7 @@ func (r *realmLDAPResource) Create(
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	setRealmIdentity(ctx, resp.Identity, plan.Realm, &resp.Diagnostics)
 }
 
 func (r *realmLDAPResource) Read(
@@ -317,6 +318,7 @@ func (r *realmLDAPResource) Read(
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	setRealmIdentity(ctx, resp.Identity, state.Realm, &resp.Diagnostics)
 }
 
 func (r *realmLDAPResource) read(
@@ -368,6 +370,7 @@ func (r *realmLDAPResource) Update(
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	setRealmIdentity(ctx, resp.Identity, plan.Realm, &resp.Diagnostics)
 }
 
 func (r *realmLDAPResource) Delete(
@@ -401,8 +404,7 @@ func (r *realmLDAPResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("realm"), req.ID)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	importRealm(ctx, req, resp)
 }
 
 // Short-name alias: proxmox_realm_ldap.
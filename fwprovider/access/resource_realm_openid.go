@@ -256,6 +256,7 @@ func (r *realmOpenIDResource) Create(
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	setRealmIdentity(ctx, resp.Identity, plan.Realm, &resp.Diagnostics)
 }
 
 func (r *realmOpenIDResource) Read(
@@ -291,6 +292,7 @@ func (r *realmOpenIDResource) Read(
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	setRealmIdentity(ctx, resp.Identity, state.Realm, &resp.Diagnostics)
 }
 
 func (r *realmOpenIDResource) readOpenID(
@@ -351,6 +353,7 @@ func (r *realmOpenIDResource) Update(
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	setRealmIdentity(ctx, resp.Identity, plan.Realm, &resp.Diagnostics)
 }
 
 func (r *realmOpenIDResource) Delete(
@@ -383,8 +386,7 @@ func (r *realmOpenIDResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("realm"), req.ID)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	importRealm(ctx, req, resp)
 }
 
 // Short-name alias: proxmox_realm_openid.
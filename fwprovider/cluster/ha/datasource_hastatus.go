@@ -0,0 +1,254 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ha
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	hastatus "github.com/bpg/terraform-provider-proxmox/proxmox/cluster/ha/status"
+)
+
+var (
+	_ datasource.DataSource              = &haStatusDataSource{}
+	_ datasource.DataSourceWithConfigure = &haStatusDataSource{}
+)
+
+// haStatusModel is the data model for the HA status data source.
+type haStatusModel struct {
+	Quorate      types.Bool                      `tfsdk:"quorate"`
+	MasterNode   types.String                    `tfsdk:"master_node"`
+	MasterStatus types.String                    `tfsdk:"master_status"`
+	Nodes        map[string]haNodeStatusModel    `tfsdk:"nodes"`
+	Services     map[string]haServiceStatusModel `tfsdk:"services"`
+}
+
+// haNodeStatusModel is the status of the local resource manager of a node.
+type haNodeStatusModel struct {
+	State     types.String `tfsdk:"state"`
+	Status    types.String `tfsdk:"status"`
+	Timestamp types.String `tfsdk:"timestamp"`
+}
+
+// haServiceStatusModel is the status of an HA service.
+type haServiceStatusModel struct {
+	NodeName     types.String `tfsdk:"node_name"`
+	State        types.String `tfsdk:"state"`
+	CRMState     types.String `tfsdk:"crm_state"`
+	RequestState types.String `tfsdk:"request_state"`
+	Status       types.String `tfsdk:"status"`
+}
+
+// haStatusDataSource is the implementation of the HA status data source.
+type haStatusDataSource struct {
+	client *hastatus.Client
+}
+
+// NewHAStatusDataSource creates a new HA status data source.
+func NewHAStatusDataSource() datasource.DataSource {
+	return &haStatusDataSource{}
+}
+
+// Metadata defines the name of the data source.
+func (d *haStatusDataSource) Metadata(
+	_ context.Context,
+	_ datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_ha_status"
+}
+
+// Schema defines the schema for the HA status data source.
+func (d *haStatusDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the current High Availability status: the quorum, the active manager, " +
+			"the local resource managers of the nodes and the state of each HA service, e.g. to check " +
+			"that a service is started on the expected node.",
+		Attributes: map[string]schema.Attribute{
+			"quorate": schema.BoolAttribute{
+				Description: "Whether the cluster is quorate.",
+				Computed:    true,
+			},
+			"master_node": schema.StringAttribute{
+				Description: "The node of the active cluster resource manager (CRM). Null while no manager " +
+					"is active, e.g. when there are no HA resources.",
+				Computed: true,
+			},
+			"master_status": schema.StringAttribute{
+				Description: "The status of the active cluster resource manager.",
+				Computed:    true,
+			},
+			"nodes": schema.MapNestedAttribute{
+				Description: "The local resource managers (LRM) of the nodes, by node name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"state": schema.StringAttribute{
+							Description: "The state of the local resource manager, e.g. `active`, `idle` " +
+								"or `maintenance mode`.",
+							Computed: true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The full status of the local resource manager.",
+							Computed:    true,
+						},
+						"timestamp": schema.StringAttribute{
+							Description: "The time of the last update of the local resource manager in " +
+								"RFC 3339 format.",
+							Computed: true,
+						},
+					},
+				},
+			},
+			"services": schema.MapNestedAttribute{
+				Description: "The HA services, by HA resource ID, e.g. `vm:100`.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"node_name": schema.StringAttribute{
+							Description: "The node the service is currently assigned to.",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "The configured state of the service, e.g. `started`.",
+							Computed:    true,
+						},
+						"crm_state": schema.StringAttribute{
+							Description: "The state of the service as seen by the cluster resource manager, " +
+								"e.g. `started`, `migrate` or `error`.",
+							Computed: true,
+						},
+						"request_state": schema.StringAttribute{
+							Description: "The requested state of the service.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The full status of the service.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the data source.
+func (d *haStatusDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client.Cluster().HA().Status()
+}
+
+// Read fetches the HA status from the Proxmox API.
+func (d *haStatusDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	entries, err := d.client.ListCurrent(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read HA Status", err.Error())
+
+		return
+	}
+
+	model := newHAStatusModel(entries)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// newHAStatusModel builds the data source model from the HA status entries.
+func newHAStatusModel(entries []*hastatus.CurrentResponseData) haStatusModel {
+	model := haStatusModel{
+		Quorate:      types.BoolValue(false),
+		MasterNode:   types.StringNull(),
+		MasterStatus: types.StringNull(),
+		Nodes:        map[string]haNodeStatusModel{},
+		Services:     map[string]haServiceStatusModel{},
+	}
+
+	for _, e := range entries {
+		switch e.Type {
+		case "quorum":
+			model.Quorate = types.BoolValue(e.Quorate != nil && bool(*e.Quorate))
+		case "master":
+			model.MasterNode = types.StringValue(e.Node)
+			model.MasterStatus = types.StringPointerValue(e.Status)
+		case "lrm":
+			model.Nodes[e.Node] = haNodeStatusModel{
+				State:     lrmState(e.Status),
+				Status:    types.StringPointerValue(e.Status),
+				Timestamp: timestampValue(e.Timestamp),
+			}
+		case "service":
+			sid := strings.TrimPrefix(e.ID, "service:")
+			if e.SID != nil {
+				sid = *e.SID
+			}
+
+			model.Services[sid] = haServiceStatusModel{
+				NodeName:     types.StringValue(e.Node),
+				State:        types.StringPointerValue(e.State),
+				CRMState:     types.StringPointerValue(e.CRMState),
+				RequestState: types.StringPointerValue(e.RequestState),
+				Status:       types.StringPointerValue(e.Status),
+			}
+		}
+	}
+
+	return model
+}
+
+// lrmState extracts the state from the status of a local resource manager, which has the
+// format `pve1 (active, Mon Mar  3 10:00:00 2025)`.
+func lrmState(status *string) types.String {
+	if status == nil {
+		return types.StringNull()
+	}
+
+	_, details, ok := strings.Cut(*status, "(")
+	if !ok {
+		return types.StringNull()
+	}
+
+	state, _, _ := strings.Cut(strings.TrimSuffix(details, ")"), ",")
+
+	return types.StringValue(strings.TrimSpace(state))
+}
+
+// timestampValue formats a Unix timestamp in RFC 3339 format.
+func timestampValue(ts *int64) types.String {
+	if ts == nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(time.Unix(*ts, 0).UTC().Format(time.RFC3339))
+}
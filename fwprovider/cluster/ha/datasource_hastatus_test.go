@@ -0,0 +1,57 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ha
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hastatus "github.com/bpg/terraform-provider-proxmox/proxmox/cluster/ha/status"
+)
+
+func TestNewHAStatusModel(t *testing.T) {
+	t.Parallel()
+
+	var entries []*hastatus.CurrentResponseData
+
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"id":"quorum","type":"quorum","node":"pve1","quorate":1,"status":"OK"},
+		{"id":"master","type":"master","node":"pve1","status":"pve1 (active, Mon Mar  3 10:00:00 2025)","timestamp":1740996000},
+		{"id":"lrm:pve1","type":"lrm","node":"pve1","status":"pve1 (active, Mon Mar  3 10:00:00 2025)","timestamp":1740996000},
+		{"id":"lrm:pve2","type":"lrm","node":"pve2","status":"pve2 (maintenance mode, Mon Mar  3 10:00:00 2025)","timestamp":1740996000},
+		{"id":"service:vm:100","type":"service","sid":"vm:100","node":"pve1","state":"started","crm_state":"started",
+			"request_state":"started","status":"vm:100 (pve1, started)"}
+	]`), &entries))
+
+	m := newHAStatusModel(entries)
+
+	assert.True(t, m.Quorate.ValueBool())
+	assert.Equal(t, "pve1", m.MasterNode.ValueString())
+	assert.Equal(t, haNodeStatusModel{
+		State:     types.StringValue("maintenance mode"),
+		Status:    types.StringValue("pve2 (maintenance mode, Mon Mar  3 10:00:00 2025)"),
+		Timestamp: types.StringValue("2025-03-03T10:00:00Z"),
+	}, m.Nodes["pve2"])
+	assert.Equal(t, "active", m.Nodes["pve1"].State.ValueString())
+	assert.Equal(t, haServiceStatusModel{
+		NodeName:     types.StringValue("pve1"),
+		State:        types.StringValue("started"),
+		CRMState:     types.StringValue("started"),
+		RequestState: types.StringValue("started"),
+		Status:       types.StringValue("vm:100 (pve1, started)"),
+	}, m.Services["vm:100"])
+
+	m = newHAStatusModel(nil)
+
+	assert.False(t, m.Quorate.ValueBool())
+	assert.True(t, m.MasterNode.IsNull())
+	assert.Empty(t, m.Services)
+}
@@ -0,0 +1,344 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ha
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	hastatus "github.com/bpg/terraform-provider-proxmox/proxmox/cluster/ha/status"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+)
+
+const (
+	defaultMaintenanceCreateTimeout = 30 * time.Minute
+	defaultMaintenanceDeleteTimeout = 5 * time.Minute
+)
+
+var (
+	_ resource.Resource                = &nodeMaintenanceResource{}
+	_ resource.ResourceWithConfigure   = &nodeMaintenanceResource{}
+	_ resource.ResourceWithImportState = &nodeMaintenanceResource{}
+)
+
+// nodeMaintenanceModel is the data model for the node maintenance resource.
+type nodeMaintenanceModel struct {
+	ID                types.String   `tfsdk:"id"`
+	NodeName          types.String   `tfsdk:"node_name"`
+	WaitForEvacuation types.Bool     `tfsdk:"wait_for_evacuation"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+// nodeMaintenanceResource puts a node into the HA maintenance mode while it exists.
+type nodeMaintenanceResource struct {
+	client proxmox.Client
+}
+
+// NewNodeMaintenanceResource creates a new node maintenance resource.
+func NewNodeMaintenanceResource() resource.Resource {
+	return &nodeMaintenanceResource{}
+}
+
+// Metadata defines the name of the resource.
+func (r *nodeMaintenanceResource) Metadata(
+	_ context.Context,
+	_ resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_node_maintenance"
+}
+
+// Schema defines the schema for the resource.
+func (r *nodeMaintenanceResource) Schema(
+	ctx context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Puts a node into the HA maintenance mode while the resource exists.",
+		MarkdownDescription: "Puts a node into the HA maintenance mode while the resource exists, with " +
+			"`ha-manager crm-command node-maintenance` on the node over SSH. In maintenance mode, the HA " +
+			"manager migrates all HA resources away from the node, and does not move them back before the " +
+			"maintenance mode is disabled by destroying the resource. Creating the resource waits until the " +
+			"node is evacuated, and reports the services that block the evacuation. Services that are not " +
+			"managed by HA are not migrated. The HA manager only runs while the cluster has HA resources, so " +
+			"creating the resource fails on a cluster without them. The SSH user must be allowed to run " +
+			"`ha-manager`, see the provider documentation.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ResourceID(),
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9\-]*[a-zA-Z0-9])?$`),
+						"must be a valid node name",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_evacuation": schema.BoolAttribute{
+				Description: "Whether to wait until the HA manager has migrated all HA resources away from " +
+					"the node. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create:            true,
+				CreateDescription: "The time to wait for the evacuation of the node. Defaults to `30m`.",
+				Delete:            true,
+				DeleteDescription: "The time to wait for the node to leave the maintenance mode. Defaults to `5m`.",
+			}),
+		},
+	}
+}
+
+// Configure sets the client for the resource.
+func (r *nodeMaintenanceResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// Create enables the maintenance mode and waits for the evacuation of the node.
+func (r *nodeMaintenanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan nodeMaintenanceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, d := plan.Timeouts.Create(ctx, defaultMaintenanceCreateTimeout)
+	resp.Diagnostics.Append(d...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	nodeName := plan.NodeName.ValueString()
+	haStatus := r.client.Cluster().HA().Status()
+
+	// an idle HA manager never applies the maintenance command, so fail before queuing it
+	data, err := haStatus.GetManagerStatus(ctx)
+	if err == nil {
+		err = data.ManagerStatus.CheckNode(nodeName)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Enable Maintenance Mode of Node %s", nodeName), err.Error())
+
+		return
+	}
+
+	if err := r.setMaintenance(ctx, nodeName, true); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Enable Maintenance Mode of Node %s", nodeName), err.Error())
+
+		return
+	}
+
+	plan.ID = types.StringValue(nodeName)
+
+	// the maintenance mode is enabled from now on, so the resource is saved even if the evacuation fails,
+	// to disable the maintenance mode on destroy
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.WaitForEvacuation.ValueBool() {
+		tflog.Info(ctx, "waiting for the evacuation of the node", map[string]any{"node_name": nodeName})
+
+		if err := haStatus.WaitForNodeEvacuation(ctx, nodeName); err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Unable to Evacuate Node %s", nodeName), err.Error())
+		}
+
+		return
+	}
+
+	if err := haStatus.WaitForNodeState(ctx, nodeName, hastatus.NodeStateMaintenance); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Enable Maintenance Mode of Node %s", nodeName), err.Error())
+	}
+}
+
+// Read checks that the node is still in maintenance mode.
+func (r *nodeMaintenanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state nodeMaintenanceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.WaitForEvacuation.IsNull() {
+		state.WaitForEvacuation = types.BoolValue(true)
+	}
+
+	data, err := r.client.Cluster().HA().Status().GetManagerStatus(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read HA Manager Status", err.Error())
+
+		return
+	}
+
+	if !data.ManagerStatus.Active() {
+		// the node state is unknown while the HA manager is idle, so keep the resource instead of
+		// recreating it with every refresh
+		resp.Diagnostics.AddWarning(
+			"Unable to Verify Maintenance Mode",
+			fmt.Sprintf("The maintenance mode of node %s cannot be verified: %s.",
+				state.NodeName.ValueString(), hastatus.ErrManagerIdle),
+		)
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+		return
+	}
+
+	if data.ManagerStatus.NodeStatus[state.NodeName.ValueString()] != hastatus.NodeStateMaintenance {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update only changes the settings of the resource, as the node name replaces it.
+func (r *nodeMaintenanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan nodeMaintenanceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete disables the maintenance mode and waits until the node is back online.
+func (r *nodeMaintenanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state nodeMaintenanceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, d := state.Timeouts.Delete(ctx, defaultMaintenanceDeleteTimeout)
+	resp.Diagnostics.Append(d...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	nodeName := state.NodeName.ValueString()
+	haStatus := r.client.Cluster().HA().Status()
+
+	data, err := haStatus.GetManagerStatus(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read HA Manager Status", err.Error())
+
+		return
+	}
+
+	if !data.ManagerStatus.Active() {
+		tflog.Info(ctx, "the HA manager is idle, no maintenance mode to disable", map[string]any{"node_name": nodeName})
+
+		return
+	}
+
+	if err := r.setMaintenance(ctx, nodeName, false); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Disable Maintenance Mode of Node %s", nodeName), err.Error())
+
+		return
+	}
+
+	if err := haStatus.WaitForNodeState(ctx, nodeName, hastatus.NodeStateOnline); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Disable Maintenance Mode of Node %s", nodeName), err.Error())
+	}
+}
+
+// ImportState imports the maintenance mode of a node by the node name.
+func (r *nodeMaintenanceResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("node_name"), req.ID)...)
+}
+
+// setMaintenance enables or disables the maintenance mode of the node. The command is only
+// queued for the HA manager, which applies it with its next round.
+func (r *nodeMaintenanceResource) setMaintenance(ctx context.Context, nodeName string, enable bool) error {
+	action := "disable"
+	if enable {
+		action = "enable"
+	}
+
+	_, err := r.client.SSH().ExecuteNodeCommands(ctx, nodeName, []string{
+		ssh.TrySudo,
+		maintenanceCommand(action, nodeName),
+	})
+	if err != nil {
+		return fmt.Errorf("error running ha-manager on node %s: %w", nodeName, err)
+	}
+
+	return nil
+}
+
+// maintenanceCommand returns the node command that enables or disables the maintenance mode.
+func maintenanceCommand(action, nodeName string) string {
+	return fmt.Sprintf(`try_sudo /usr/sbin/ha-manager crm-command node-maintenance %s %s`, action, nodeName)
+}
@@ -0,0 +1,154 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ha
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test/faketest"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+)
+
+// haManagerSSH applies the `ha-manager` maintenance commands to the fake server.
+type haManagerSSH struct {
+	server   *fake.Server
+	commands []string
+}
+
+func (s *haManagerSSH) Username() string {
+	return "root"
+}
+
+func (s *haManagerSSH) ExecuteNodeCommands(_ context.Context, nodeName string, commands []string) ([]byte, error) {
+	for _, c := range commands {
+		if !strings.Contains(c, "ha-manager") {
+			continue
+		}
+
+		s.commands = append(s.commands, c)
+
+		switch c {
+		case maintenanceCommand("enable", nodeName):
+			s.server.SetNodeMaintenance(nodeName, true)
+		case maintenanceCommand("disable", nodeName):
+			s.server.SetNodeMaintenance(nodeName, false)
+		default:
+			return nil, errors.New("unexpected command: " + c)
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *haManagerSSH) NodeUpload(context.Context, string, string, *api.FileUploadRequest) error {
+	return errors.New("not supported")
+}
+
+func (s *haManagerSSH) NodeStreamUpload(context.Context, string, string, *api.FileUploadRequest) error {
+	return errors.New("not supported")
+}
+
+func newMaintenanceResource(t *testing.T, opts ...fake.Option) (*nodeMaintenanceResource, *haManagerSSH) {
+	t.Helper()
+
+	s, _ := faketest.NewClient(t, append([]fake.Option{fake.WithNodes("pve1", "pve2")}, opts...)...)
+	sshClient := &haManagerSSH{server: s}
+
+	return &nodeMaintenanceResource{client: faketest.Client(t, s, sshClient)}, sshClient
+}
+
+// maintenanceState returns the plan or state values of the resource for the node.
+func maintenanceState(t *testing.T, r *nodeMaintenanceResource, nodeName string) tfsdk.State {
+	t.Helper()
+
+	return faketest.ResourceState(t, r, map[string]any{"node_name": nodeName, "wait_for_evacuation": true})
+}
+
+func TestNodeMaintenanceLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	r, sshClient := newMaintenanceResource(t, fake.WithHAManager())
+
+	planned := maintenanceState(t, r, "pve2")
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Raw: planned.Raw.Copy(), Schema: planned.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(planned)}, &createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	var m nodeMaintenanceModel
+
+	require.False(t, createResp.State.Get(ctx, &m).HasError())
+	assert.Equal(t, types.StringValue("pve2"), m.ID)
+	assert.Equal(t, []string{maintenanceCommand("enable", "pve2")}, sshClient.commands)
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.False(t, readResp.State.Raw.IsNull(), "the node is in maintenance")
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	assert.Equal(t, maintenanceCommand("disable", "pve2"), sshClient.commands[1])
+
+	readResp = resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.IsNull(), "the node has left the maintenance mode")
+}
+
+func TestNodeMaintenanceIdleManager(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	r, sshClient := newMaintenanceResource(t)
+
+	planned := maintenanceState(t, r, "pve2")
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Raw: planned.Raw.Copy(), Schema: planned.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(planned)}, &createResp)
+	require.True(t, createResp.Diagnostics.HasError())
+	assert.Contains(t, createResp.Diagnostics.Errors()[0].Detail(), "HA manager is idle")
+	assert.Empty(t, sshClient.commands, "the command is not queued")
+
+	readResp := resource.ReadResponse{State: planned}
+	r.Read(ctx, resource.ReadRequest{State: planned}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.Len(t, readResp.Diagnostics.Warnings(), 1)
+	assert.False(t, readResp.State.Raw.IsNull(), "the resource is kept")
+
+	deleteResp := resource.DeleteResponse{State: planned}
+	r.Delete(ctx, resource.DeleteRequest{State: planned}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	assert.Empty(t, sshClient.commands)
+}
+
+func TestNodeMaintenanceUnknownNode(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	r, sshClient := newMaintenanceResource(t, fake.WithHAManager())
+
+	planned := maintenanceState(t, r, "pve3")
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Raw: planned.Raw.Copy(), Schema: planned.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(planned)}, &createResp)
+	require.True(t, createResp.Diagnostics.HasError())
+	assert.Contains(t, createResp.Diagnostics.Errors()[0].Detail(), "not managed by the HA manager")
+	assert.Empty(t, sshClient.commands)
+}
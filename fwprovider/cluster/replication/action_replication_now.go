@@ -0,0 +1,182 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+)
+
+// defaultReplicationNowTimeout is the default time to wait for the run of the job, which the
+// scheduler only starts within a minute.
+const defaultReplicationNowTimeout = 30 * time.Minute
+
+var (
+	_ action.Action              = &replicationNowAction{}
+	_ action.ActionWithConfigure = &replicationNowAction{}
+)
+
+// replicationNowModel is the data model for the replication now action.
+type replicationNowModel struct {
+	ID      types.String `tfsdk:"id"`
+	Timeout types.String `tfsdk:"timeout"`
+}
+
+// replicationNowAction runs a replication job immediately.
+type replicationNowAction struct {
+	client proxmox.Client
+}
+
+// NewReplicationNowAction creates a new replication now action.
+func NewReplicationNowAction() action.Action {
+	return &replicationNowAction{}
+}
+
+// Metadata defines the name of the action.
+func (a *replicationNowAction) Metadata(
+	_ context.Context,
+	_ action.MetadataRequest,
+	resp *action.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_replication_now"
+}
+
+// Schema defines the schema for the action.
+func (a *replicationNowAction) Schema(
+	_ context.Context,
+	_ action.SchemaRequest,
+	resp *action.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Runs a storage replication job immediately, and waits for the run to finish, " +
+			"e.g. to make sure that the replica is current before a planned failover.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Replication Job ID. The ID is composed of a Guest ID and a job number, " +
+					"separated by a hyphen, i.e. '<GUEST>-<JOBNUM>'.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[0-9]+-[0-9]+$`),
+						"id must be <GUEST>-<JOBNUM>",
+					),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "The maximum time to wait for the run to finish, e.g. `1h`. Defaults to `30m`.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.IsValidDuration(),
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the action.
+func (a *replicationNowAction) Configure(
+	_ context.Context,
+	req action.ConfigureRequest,
+	resp *action.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Action)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected config.Action, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = cfg.Client
+}
+
+// Invoke schedules the job to run now and waits for the run to finish.
+func (a *replicationNowAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model replicationNowModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := defaultReplicationNowTimeout
+	if !model.Timeout.IsNull() {
+		// the value is validated by the schema
+		timeout, _ = time.ParseDuration(model.Timeout.ValueString())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	id := model.ID.ValueString()
+
+	// the ID is validated by the schema
+	guestID, _, _ := strings.Cut(id, "-")
+	guest, _ := strconv.ParseInt(guestID, 10, 64)
+
+	jc, err := jobClient(ctx, a.client, id, guest)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Run Replication Job %s", id), err.Error())
+
+		return
+	}
+
+	before, err := jc.GetStatus(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Run Replication Job %s", id), err.Error())
+
+		return
+	}
+
+	if err = jc.ScheduleNow(ctx); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Run Replication Job %s", id), err.Error())
+
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Replicating guest %d to node %s", guest, before.Target),
+	})
+
+	after, err := jc.WaitForRun(ctx, before.LastTryTime())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Run Replication Job %s", id), err.Error())
+
+		return
+	}
+
+	duration := 0.0
+	if after.Duration != nil {
+		duration = float64(*after.Duration)
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Replicated guest %d to node %s at %s in %.1fs",
+			guest, after.Target, timestampValue(after.LastSync).ValueString(), duration),
+	})
+}
@@ -19,8 +19,8 @@ import (
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/migration"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
-	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
 )
 
 var _ datasource.DataSource = &DataSource{}
@@ -28,7 +28,7 @@ var _ datasource.DataSource = &DataSource{}
 var _ datasource.DataSourceWithConfigure = &DataSource{}
 
 type DataSource struct {
-	client *cluster.Client
+	client proxmox.Client
 }
 
 func NewDataSource() datasource.DataSource {
@@ -62,13 +62,13 @@ func (d *DataSource) Configure(
 		return
 	}
 
-	d.client = cfg.Client.Cluster()
+	d.client = cfg.Client
 }
 
 func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		DeprecationMessage: migration.DeprecationMessage("proxmox_replication"),
-		Description:        "Retrieves information about an existing Replication.",
+		Description:        "Retrieves information about an existing Replication, including the state of its last run.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Required:    true,
@@ -116,6 +116,26 @@ func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp
 				Computed:    true,
 				Description: "For internal use, to detect if the guest was stolen.",
 			},
+			"last_sync": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time of the last successful sync in RFC 3339 format. Null if the job has not synced yet.",
+			},
+			"next_sync": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time of the next scheduled sync in RFC 3339 format.",
+			},
+			"duration": schema.Float64Attribute{
+				Computed:    true,
+				Description: "The duration of the last run in seconds.",
+			},
+			"fail_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of consecutive failed runs.",
+			},
+			"error": schema.StringAttribute{
+				Computed:    true,
+				Description: "The error of the last run. Null if it succeeded.",
+			},
 		},
 	}
 }
@@ -129,7 +149,7 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 		return
 	}
 
-	repl, err := d.client.Replication(readModel.ID.ValueString()).GetReplication(ctx)
+	repl, err := d.client.Cluster().Replication(readModel.ID.ValueString()).GetReplication(ctx)
 	if err != nil {
 		if errors.Is(err, api.ErrResourceDoesNotExist) {
 			resp.Diagnostics.AddError("Replication Not Found", fmt.Sprintf("Replication with ID '%s' was not found", readModel.ID.ValueString()))
@@ -144,6 +164,15 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 	state := model{}
 	state.fromAPI(readModel.ID.ValueString(), repl)
 
+	status, err := readStatus(ctx, d.client, readModel.ID.ValueString(), repl.Guest)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Replication Status", err.Error())
+
+		return
+	}
+
+	state.statusFromAPI(status)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -7,9 +7,12 @@
 package replication
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/replications"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/replication"
 	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
 )
 
@@ -24,6 +27,12 @@ type model struct {
 	Source   types.String  `tfsdk:"source"`
 	Guest    types.Int64   `tfsdk:"guest"`
 	JobNum   types.Int64   `tfsdk:"jobnum"`
+
+	LastSync  types.String  `tfsdk:"last_sync"`
+	NextSync  types.String  `tfsdk:"next_sync"`
+	Duration  types.Float64 `tfsdk:"duration"`
+	FailCount types.Int64   `tfsdk:"fail_count"`
+	Error     types.String  `tfsdk:"error"`
 }
 
 func (m *model) fromAPI(id string, data *replications.ReplicationData) {
@@ -47,6 +56,40 @@ func (m *model) fromAPI(id string, data *replications.ReplicationData) {
 	m.JobNum = types.Int64Value(data.JobNum)
 }
 
+// statusFromAPI sets the state of the last run of the job. A nil status, e.g. when the
+// status could not be read, clears it.
+func (m *model) statusFromAPI(data *replication.StatusResponseData) {
+	if data == nil {
+		m.LastSync = types.StringNull()
+		m.NextSync = types.StringNull()
+		m.Duration = types.Float64Null()
+		m.FailCount = types.Int64Null()
+		m.Error = types.StringNull()
+
+		return
+	}
+
+	m.LastSync = timestampValue(data.LastSync)
+	m.NextSync = timestampValue(data.NextSync)
+	m.Duration = types.Float64PointerValue(data.Duration.PointerFloat64())
+	m.FailCount = types.Int64PointerValue(data.FailCount)
+	m.Error = types.StringNull()
+
+	if data.Error != nil && *data.Error != "" {
+		m.Error = types.StringValue(*data.Error)
+	}
+}
+
+// timestampValue formats a Unix timestamp in RFC 3339 format. Proxmox reports 0 for the
+// runs that did not happen yet, which are null.
+func timestampValue(ts *int64) types.String {
+	if ts == nil || *ts == 0 {
+		return types.StringNull()
+	}
+
+	return types.StringValue(time.Unix(*ts, 0).UTC().Format(time.RFC3339))
+}
+
 func (m *model) toAPICreate() *replications.ReplicationCreate {
 	data := &replications.ReplicationCreate{}
 
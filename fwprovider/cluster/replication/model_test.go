@@ -0,0 +1,45 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/replication"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+func TestModelStatusFromAPI(t *testing.T) {
+	t.Parallel()
+
+	var m model
+
+	m.statusFromAPI(&replication.StatusResponseData{
+		LastSync:  new(int64(1700000600)),
+		NextSync:  new(int64(1700001500)),
+		Duration:  new(proxmoxtypes.CustomFloat64(3.5)),
+		FailCount: new(int64(0)),
+		Error:     new(""),
+	})
+
+	assert.Equal(t, "2023-11-14T22:23:20Z", m.LastSync.ValueString())
+	assert.Equal(t, "2023-11-14T22:38:20Z", m.NextSync.ValueString())
+	assert.InDelta(t, 3.5, m.Duration.ValueFloat64(), 0.001)
+	assert.Equal(t, int64(0), m.FailCount.ValueInt64())
+	assert.True(t, m.Error.IsNull())
+
+	// a job that has not synced yet reports 0
+	m.statusFromAPI(&replication.StatusResponseData{LastSync: new(int64(0)), Error: new("no space left")})
+	assert.True(t, m.LastSync.IsNull())
+	assert.Equal(t, "no space left", m.Error.ValueString())
+
+	m.statusFromAPI(nil)
+	assert.True(t, m.Duration.IsNull())
+	assert.True(t, m.FailCount.IsNull())
+}
@@ -13,6 +13,7 @@ import (
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -23,8 +24,8 @@ import (
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/migration"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
-	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
 )
 
 var (
@@ -34,7 +35,7 @@ var (
 )
 
 type Resource struct {
-	client *cluster.Client
+	client proxmox.Client
 }
 
 func NewResource() resource.Resource {
@@ -64,7 +65,7 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, r
 		return
 	}
 
-	r.client = cfg.Client.Cluster()
+	r.client = cfg.Client
 }
 
 func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -134,6 +135,26 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				Computed:    true,
 				Description: "Unique, sequential ID assigned to each job.",
 			},
+			"last_sync": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time of the last successful sync in RFC 3339 format. Null if the job has not synced yet.",
+			},
+			"next_sync": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time of the next scheduled sync in RFC 3339 format.",
+			},
+			"duration": schema.Float64Attribute{
+				Computed:    true,
+				Description: "The duration of the last run in seconds.",
+			},
+			"fail_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of consecutive failed runs.",
+			},
+			"error": schema.StringAttribute{
+				Computed:    true,
+				Description: "The error of the last run. Null if it succeeded.",
+			},
 		},
 	}
 }
@@ -149,14 +170,14 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	repl := plan.toAPICreate()
 
-	err := r.client.Replication(plan.ID.ValueString()).CreateReplication(ctx, repl)
+	err := r.client.Cluster().Replication(plan.ID.ValueString()).CreateReplication(ctx, repl)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to Create Replication", err.Error())
 		return
 	}
 
 	// Read the created Replication to get the actual state including pending
-	data, err := r.client.Replication(plan.ID.ValueString()).GetReplication(ctx)
+	data, err := r.client.Cluster().Replication(plan.ID.ValueString()).GetReplication(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to Read Replication After Creation", err.Error())
 		return
@@ -164,6 +185,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	readModel := &model{}
 	readModel.fromAPI(plan.ID.ValueString(), data)
+	r.readStatus(ctx, readModel, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
 }
@@ -177,7 +199,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
-	data, err := r.client.Replication(state.ID.ValueString()).GetReplication(ctx)
+	data, err := r.client.Cluster().Replication(state.ID.ValueString()).GetReplication(ctx)
 	if err != nil {
 		if errors.Is(err, api.ErrResourceDoesNotExist) {
 			resp.State.RemoveResource(ctx)
@@ -191,6 +213,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 
 	readModel := &model{}
 	readModel.fromAPI(state.ID.ValueString(), data)
+	r.readStatus(ctx, readModel, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
 }
@@ -218,14 +241,14 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	repl.Delete = toDelete
 
-	err := r.client.Replication(plan.ID.ValueString()).UpdateReplication(ctx, repl)
+	err := r.client.Cluster().Replication(plan.ID.ValueString()).UpdateReplication(ctx, repl)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to Update Replication", err.Error())
 		return
 	}
 
 	// Read the updated Replication to get the actual state including pending
-	data, err := r.client.Replication(plan.ID.ValueString()).GetReplication(ctx)
+	data, err := r.client.Cluster().Replication(plan.ID.ValueString()).GetReplication(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to Read Replication After Update", err.Error())
 		return
@@ -233,6 +256,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	readModel := &model{}
 	readModel.fromAPI(plan.ID.ValueString(), data)
+	r.readStatus(ctx, readModel, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
 }
@@ -248,14 +272,14 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 
 	repl := state.toAPIDelete()
 
-	err := r.client.Replication(state.ID.ValueString()).DeleteReplication(ctx, repl)
+	err := r.client.Cluster().Replication(state.ID.ValueString()).DeleteReplication(ctx, repl)
 	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
 		resp.Diagnostics.AddError("Unable to Delete Replication", err.Error())
 	}
 }
 
 func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	data, err := r.client.Replication(req.ID).GetReplication(ctx)
+	data, err := r.client.Cluster().Replication(req.ID).GetReplication(ctx)
 	if err != nil {
 		if errors.Is(err, api.ErrResourceDoesNotExist) {
 			resp.Diagnostics.AddError("Replication Not Found", fmt.Sprintf("Replication with ID '%s' was not found", req.ID))
@@ -269,10 +293,22 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 
 	readModel := &model{}
 	readModel.fromAPI(req.ID, data)
+	r.readStatus(ctx, readModel, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
 }
 
+// readStatus sets the state of the last run of the job. The job itself is read from the
+// cluster, but its state from the node of the guest, so a failure only warns.
+func (r *Resource) readStatus(ctx context.Context, m *model, diags *diag.Diagnostics) {
+	data, err := readStatus(ctx, r.client, m.ID.ValueString(), m.Guest.ValueInt64())
+	if err != nil {
+		diags.AddWarning("Unable to Read Replication Status", err.Error())
+	}
+
+	m.statusFromAPI(data)
+}
+
 // Short-name alias for the replication resource (ADR-007).
 
 var (
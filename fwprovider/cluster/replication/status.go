@@ -0,0 +1,36 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/replication"
+)
+
+// jobClient returns the client for the replication job on the node the guest currently
+// runs on, which is the source of the replication.
+func jobClient(ctx context.Context, client proxmox.Client, id string, guest int64) (*replication.Client, error) {
+	nodeName, err := client.Cluster().GetVMNodeName(ctx, int(guest))
+	if err != nil {
+		return nil, fmt.Errorf("error finding the node of guest %d: %w", guest, err)
+	}
+
+	return client.Node(*nodeName).Replication(id), nil
+}
+
+// readStatus retrieves the state of the replication job from its source node.
+func readStatus(ctx context.Context, client proxmox.Client, id string, guest int64) (*replication.StatusResponseData, error) {
+	jc, err := jobClient(ctx, client, id, guest)
+	if err != nil {
+		return nil, err
+	}
+
+	return jc.GetStatus(ctx)
+}
@@ -0,0 +1,250 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package status
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+)
+
+var (
+	_ datasource.DataSource              = &statusDataSource{}
+	_ datasource.DataSourceWithConfigure = &statusDataSource{}
+)
+
+// statusModel is the data model for the cluster status data source.
+type statusModel struct {
+	ClusterName types.String `tfsdk:"cluster_name"`
+	Quorate     types.Bool   `tfsdk:"quorate"`
+	Version     types.Int64  `tfsdk:"version"`
+	LocalNode   types.String `tfsdk:"local_node"`
+	Nodes       []nodeModel  `tfsdk:"nodes"`
+}
+
+// nodeModel represents a node of the cluster in the data source output.
+type nodeModel struct {
+	Name   types.String            `tfsdk:"name"`
+	NodeID types.Int64             `tfsdk:"node_id"`
+	IP     types.String            `tfsdk:"ip"`
+	Online types.Bool              `tfsdk:"online"`
+	Local  types.Bool              `tfsdk:"local"`
+	Level  types.String            `tfsdk:"level"`
+	Links  map[string]types.String `tfsdk:"links"`
+}
+
+// statusDataSource is the implementation of the cluster status data source.
+type statusDataSource struct {
+	client proxmox.Client
+}
+
+// NewDataSource creates a new cluster status data source.
+func NewDataSource() datasource.DataSource {
+	return &statusDataSource{}
+}
+
+// Metadata defines the name of the data source.
+func (d *statusDataSource) Metadata(
+	_ context.Context,
+	_ datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_cluster_status"
+}
+
+// Schema defines the schema for the cluster status data source.
+func (d *statusDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the status of the cluster: its quorum, the nodes and whether they are online, " +
+			"and the corosync links of the nodes. A standalone node is reported as a quorate cluster of one node.",
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Description: "The name of the cluster. Null for a standalone node.",
+				Computed:    true,
+			},
+			"quorate": schema.BoolAttribute{
+				Description: "Whether the cluster is quorate. Without quorum the cluster configuration is " +
+					"read-only, so most changes fail.",
+				Computed: true,
+			},
+			"version": schema.Int64Attribute{
+				Description: "The version of the cluster configuration. Null for a standalone node.",
+				Computed:    true,
+			},
+			"local_node": schema.StringAttribute{
+				Description: "The name of the node the provider is connected to.",
+				Computed:    true,
+			},
+			"nodes": schema.ListNestedAttribute{
+				Description: "The nodes of the cluster, ordered by node ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the node.",
+							Computed:    true,
+						},
+						"node_id": schema.Int64Attribute{
+							Description: "The corosync ID of the node.",
+							Computed:    true,
+						},
+						"ip": schema.StringAttribute{
+							Description: "The IP address of the node.",
+							Computed:    true,
+						},
+						"online": schema.BoolAttribute{
+							Description: "Whether the node is online.",
+							Computed:    true,
+						},
+						"local": schema.BoolAttribute{
+							Description: "Whether the provider is connected to this node.",
+							Computed:    true,
+						},
+						"level": schema.StringAttribute{
+							Description: "The subscription level of the node, e.g. `c` for community. " +
+								"Empty without a subscription.",
+							Computed: true,
+						},
+						"links": schema.MapAttribute{
+							Description: "The addresses of the corosync links of the node, by link number. " +
+								"Null for a standalone node.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the data source.
+func (d *statusDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read fetches the cluster status from the Proxmox API.
+func (d *statusDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	status, err := d.client.Cluster().GetStatus(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Cluster Status", err.Error())
+
+		return
+	}
+
+	model := statusModel{
+		ClusterName: types.StringNull(),
+		Quorate:     types.BoolValue(true),
+		Version:     types.Int64Null(),
+		LocalNode:   types.StringNull(),
+		Nodes:       []nodeModel{},
+	}
+
+	var links map[string]map[string]types.String
+
+	for _, s := range status {
+		if s.Type != "cluster" {
+			continue
+		}
+
+		model.ClusterName = types.StringValue(s.Name)
+		model.Quorate = types.BoolValue(s.Quorate != nil && bool(*s.Quorate))
+		model.Version = types.Int64PointerValue(s.Version)
+
+		links, err = d.nodeLinks(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Cluster Status", err.Error())
+
+			return
+		}
+	}
+
+	for _, s := range status {
+		if s.Type != "node" {
+			continue
+		}
+
+		n := nodeModel{
+			Name:   types.StringValue(s.Name),
+			NodeID: types.Int64PointerValue(s.NodeID),
+			IP:     types.StringPointerValue(s.IP),
+			Online: types.BoolValue(s.Online != nil && bool(*s.Online)),
+			Local:  types.BoolValue(s.Local != nil && bool(*s.Local)),
+			Level:  types.StringPointerValue(s.Level),
+		}
+
+		if links != nil {
+			n.Links = links[s.Name]
+			if n.Links == nil {
+				n.Links = map[string]types.String{}
+			}
+		}
+
+		if n.Local.ValueBool() {
+			model.LocalNode = n.Name
+		}
+
+		model.Nodes = append(model.Nodes, n)
+	}
+
+	slices.SortStableFunc(model.Nodes, func(a, b nodeModel) int {
+		return cmp.Compare(a.NodeID.ValueInt64(), b.NodeID.ValueInt64())
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// nodeLinks returns the addresses of the corosync links of the cluster nodes, by node name.
+func (d *statusDataSource) nodeLinks(ctx context.Context) (map[string]map[string]types.String, error) {
+	configNodes, err := d.client.Cluster().ListConfigNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make(map[string]map[string]types.String, len(configNodes))
+
+	for _, n := range configNodes {
+		links[n.Name] = make(map[string]types.String, len(n.Links))
+
+		for link, addr := range n.Links {
+			links[n.Name][strconv.Itoa(link)] = types.StringValue(addr)
+		}
+	}
+
+	return links, nil
+}
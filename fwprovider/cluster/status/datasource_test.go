@@ -0,0 +1,76 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package status
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test/faketest"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+)
+
+func readStatus(t *testing.T, client proxmox.Client) statusModel {
+	t.Helper()
+
+	return faketest.ReadDataSource[statusModel](t, &statusDataSource{}, client, nil)
+}
+
+func TestReadCluster(t *testing.T) {
+	t.Parallel()
+
+	s, client := faketest.NewClient(t, fake.WithNodes("pve", "pve2", "pve3"))
+
+	s.SetNodeOnline("pve3", false)
+
+	m := readStatus(t, client)
+
+	assert.Equal(t, "fake", m.ClusterName.ValueString())
+	assert.True(t, m.Quorate.ValueBool())
+	assert.Equal(t, int64(3), m.Version.ValueInt64())
+	assert.Equal(t, "pve", m.LocalNode.ValueString())
+
+	require.Len(t, m.Nodes, 3)
+	assert.Equal(t, nodeModel{
+		Name:   types.StringValue("pve2"),
+		NodeID: types.Int64Value(2),
+		IP:     types.StringValue("192.0.2.2"),
+		Online: types.BoolValue(true),
+		Local:  types.BoolValue(false),
+		Level:  types.StringValue(""),
+		Links: map[string]types.String{
+			"0": types.StringValue("192.0.2.2"),
+			"1": types.StringValue("198.51.100.2"),
+		},
+	}, m.Nodes[1])
+	assert.False(t, m.Nodes[2].Online.ValueBool())
+
+	s.SetNodeOnline("pve2", false)
+
+	assert.False(t, readStatus(t, client).Quorate.ValueBool())
+}
+
+func TestReadStandaloneNode(t *testing.T) {
+	t.Parallel()
+
+	_, client := faketest.NewClient(t)
+
+	m := readStatus(t, client)
+
+	assert.True(t, m.ClusterName.IsNull())
+	assert.True(t, m.Quorate.ValueBool())
+	assert.True(t, m.Version.IsNull())
+	assert.Equal(t, fake.DefaultNode, m.LocalNode.ValueString())
+
+	require.Len(t, m.Nodes, 1)
+	assert.True(t, m.Nodes[0].Online.ValueBool())
+	assert.Nil(t, m.Nodes[0].Links)
+}
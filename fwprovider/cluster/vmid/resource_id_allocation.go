@@ -0,0 +1,319 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vmid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+)
+
+var (
+	_ resource.Resource                   = &allocationResource{}
+	_ resource.ResourceWithConfigure      = &allocationResource{}
+	_ resource.ResourceWithImportState    = &allocationResource{}
+	_ resource.ResourceWithValidateConfig = &allocationResource{}
+)
+
+// allocationModel is the data model for the VM ID allocation resource.
+type allocationModel struct {
+	ID    types.String `tfsdk:"id"`
+	Start types.Int64  `tfsdk:"start"`
+	End   types.Int64  `tfsdk:"end"`
+	Owner types.String `tfsdk:"owner"`
+}
+
+func (m *allocationModel) toAPI() cluster.IDRange {
+	return cluster.IDRange{
+		Start: int(m.Start.ValueInt64()),
+		End:   int(m.End.ValueInt64()),
+		Owner: m.Owner.ValueString(),
+	}
+}
+
+func (m *allocationModel) fromAPI(r *cluster.IDRange) {
+	m.ID = types.StringValue(fmt.Sprintf("%d-%d", r.Start, r.End))
+	m.Start = types.Int64Value(int64(r.Start))
+	m.End = types.Int64Value(int64(r.End))
+	m.Owner = types.StringValue(r.Owner)
+}
+
+// allocationResource claims a range of VM IDs in the cluster for a workspace.
+type allocationResource struct {
+	client *cluster.Client
+}
+
+// NewAllocationResource creates a new VM ID allocation resource.
+func NewAllocationResource() resource.Resource {
+	return &allocationResource{}
+}
+
+// Metadata defines the name of the resource.
+func (r *allocationResource) Metadata(
+	_ context.Context,
+	_ resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_vm_id_allocation"
+}
+
+// Schema defines the schema for the resource.
+func (r *allocationResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Claims a range of VM / Container IDs in the cluster for a workspace.",
+		MarkdownDescription: "Claims a range of VM / Container IDs in the cluster for a workspace. The claim " +
+			"fails if the range overlaps a range claimed already, so the IDs of the range are unique to the " +
+			"workspace regardless of where Terraform runs. Set the `vm_id` of the VMs and containers of the " +
+			"workspace from the range; the provider does not generate IDs from claimed ranges, whether or not " +
+			"`vm_id_leases` is set. The claim is stored as an empty resource pool named `terraform-vmid-range-<start>-<end>`, which requires the " +
+			"`Pool.Allocate` privilege.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ResourceID("The ID range, as `<start>-<end>`."),
+			"start": schema.Int64Attribute{
+				Description: "The first ID of the range.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(100, 999999999),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"end": schema.Int64Attribute{
+				Description: "The last ID of the range.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(100, 999999999),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Description: "The owner of the range, e.g. the name of the workspace.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the resource.
+func (r *allocationResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster()
+}
+
+// ValidateConfig checks that the range is not empty.
+func (r *allocationResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var data allocationModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Start.IsUnknown() || data.End.IsUnknown() {
+		return
+	}
+
+	if data.End.ValueInt64() < data.Start.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("end"),
+			"Invalid ID Range",
+			fmt.Sprintf("The end of the range (%d) must not be lower than its start (%d).",
+				data.End.ValueInt64(), data.Start.ValueInt64()),
+		)
+	}
+}
+
+// Create claims the range.
+func (r *allocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan allocationModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	idRange := plan.toAPI()
+
+	if err := r.client.ClaimIDRange(ctx, idRange); err != nil {
+		resp.Diagnostics.AddError("Unable to Claim VM ID Range", err.Error())
+
+		return
+	}
+
+	plan.fromAPI(&idRange)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the owner of the range, and removes the range from the state if it is no
+// longer claimed.
+func (r *allocationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state allocationModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	idRange, err := r.client.GetIDRange(ctx, int(state.Start.ValueInt64()), int(state.End.ValueInt64()))
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Unable to Read VM ID Range", err.Error())
+
+		return
+	}
+
+	state.fromAPI(idRange)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update changes the owner of the range.
+func (r *allocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan allocationModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	idRange := plan.toAPI()
+
+	if err := r.client.UpdateIDRangeOwner(ctx, idRange); err != nil {
+		resp.Diagnostics.AddError("Unable to Update VM ID Range", err.Error())
+
+		return
+	}
+
+	plan.fromAPI(&idRange)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete releases the range.
+func (r *allocationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state allocationModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.ReleaseIDRange(ctx, int(state.Start.ValueInt64()), int(state.End.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Release VM ID Range", err.Error())
+	}
+}
+
+// ImportState imports a claimed range by its `<start>-<end>` ID.
+func (r *allocationResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	start, end, err := parseRangeID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+
+		return
+	}
+
+	idRange, err := r.client.GetIDRange(ctx, start, end)
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.Diagnostics.AddError(
+				"VM ID Range Not Found",
+				fmt.Sprintf("VM ID range %q is not claimed", req.ID),
+			)
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Unable to Import VM ID Range", err.Error())
+
+		return
+	}
+
+	var state allocationModel
+
+	state.fromAPI(idRange)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func parseRangeID(id string) (int, int, error) {
+	startStr, endStr, ok := strings.Cut(id, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("expected an ID of the form `<start>-<end>`, got %q", id)
+	}
+
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid start of range %q: %w", id, err)
+	}
+
+	end, err := strconv.Atoi(endStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid end of range %q: %w", id, err)
+	}
+
+	return start, end, nil
+}
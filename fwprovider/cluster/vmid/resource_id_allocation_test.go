@@ -0,0 +1,127 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vmid
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test/faketest"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+)
+
+func newAllocationResource(t *testing.T) (*allocationResource, proxmox.Client) {
+	t.Helper()
+
+	_, client := faketest.NewClient(t)
+
+	return &allocationResource{client: client.Cluster()}, client
+}
+
+// allocationState returns the plan or state values of the resource for the range.
+func allocationState(t *testing.T, r *allocationResource, start, end int64, owner string) tfsdk.State {
+	t.Helper()
+
+	return faketest.ResourceState(t, r, map[string]any{"start": start, "end": end, "owner": owner})
+}
+
+func createAllocation(t *testing.T, r *allocationResource, planned tfsdk.State) resource.CreateResponse {
+	t.Helper()
+
+	resp := resource.CreateResponse{State: tfsdk.State{Raw: planned.Raw.Copy(), Schema: planned.Schema}}
+	r.Create(t.Context(), resource.CreateRequest{Plan: tfsdk.Plan(planned)}, &resp)
+
+	return resp
+}
+
+func TestAllocationLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	r, client := newAllocationResource(t)
+
+	createResp := createAllocation(t, r, allocationState(t, r, 1000, 1099, "staging"))
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	var m allocationModel
+
+	require.False(t, createResp.State.Get(ctx, &m).HasError())
+	assert.Equal(t, types.StringValue("1000-1099"), m.ID)
+
+	_, err := client.Pool().GetPool(ctx, "terraform-vmid-range-1000-1099")
+	require.NoError(t, err, "the range is stored as a pool")
+
+	planned := allocationState(t, r, 1000, 1099, "production")
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan(planned), State: createResp.State}, &updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+
+	readResp := resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	require.False(t, readResp.State.Get(ctx, &m).HasError())
+	assert.Equal(t, types.StringValue("production"), m.Owner)
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+
+	readResp = resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.IsNull(), "the range is no longer claimed")
+
+	list, err := client.Pool().ListPools(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, list, "the lock is released")
+}
+
+func TestAllocationOverlap(t *testing.T) {
+	t.Parallel()
+
+	r, _ := newAllocationResource(t)
+
+	createResp := createAllocation(t, r, allocationState(t, r, 1000, 1099, "staging"))
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	createResp = createAllocation(t, r, allocationState(t, r, 1050, 1199, "production"))
+	require.True(t, createResp.Diagnostics.HasError())
+	assert.Contains(t, createResp.Diagnostics.Errors()[0].Detail(), `overlaps range 1000-1099 claimed by "staging"`)
+}
+
+func TestAllocationImport(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	r, _ := newAllocationResource(t)
+
+	createResp := createAllocation(t, r, allocationState(t, r, 1000, 1099, "staging"))
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	empty := allocationState(t, r, 0, 0, "")
+	empty.Raw = tftypes.NewValue(empty.Schema.Type().TerraformType(ctx), nil)
+
+	importResp := resource.ImportStateResponse{State: empty}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "1000-1099"}, &importResp)
+	require.False(t, importResp.Diagnostics.HasError(), importResp.Diagnostics)
+
+	var m allocationModel
+
+	require.False(t, importResp.State.Get(ctx, &m).HasError())
+	assert.Equal(t, types.StringValue("staging"), m.Owner)
+
+	importResp = resource.ImportStateResponse{State: empty}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "2000-2099"}, &importResp)
+	require.True(t, importResp.Diagnostics.HasError())
+	assert.Equal(t, "VM ID Range Not Found", importResp.Diagnostics.Errors()[0].Summary())
+}
@@ -0,0 +1,264 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package functions
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var _ function.Function = &encodeNetFunction{}
+
+// netAttributes are the supported attributes of the network device, named after the network_device block of
+// the VM resource.
+//
+//nolint:gochecknoglobals
+var netAttributes = []string{
+	"bridge",
+	"disconnected",
+	"firewall",
+	"mac_address",
+	"model",
+	"mtu",
+	"queues",
+	"rate_limit",
+	"trunks",
+	"vlan_id",
+}
+
+// encodeNetFunction encodes a network device into the property string of a VM network interface.
+type encodeNetFunction struct{}
+
+// NewEncodeNetFunction creates the encode_net function.
+func NewEncodeNetFunction() function.Function {
+	return &encodeNetFunction{}
+}
+
+// Metadata defines the name of the function.
+func (f *encodeNetFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "encode_net"
+}
+
+// Definition defines the parameters and the return type of the function.
+func (f *encodeNetFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Encodes a network device",
+		MarkdownDescription: "Encodes a network device into the property string of a `netN` option of a VM " +
+			"(e.g. `model=virtio,bridge=vmbr0,firewall=1`), the same way as the provider does. " +
+			"The device is an object with the attributes of the `network_device` block of " +
+			"`proxmox_virtual_environment_vm`, all of which are optional: " +
+			"`bridge`, `disconnected`, `firewall`, `mac_address`, `model` (defaults to `virtio`), `mtu`, `queues`, " +
+			"`rate_limit`, `trunks` (a `;` separated string or a list of numbers) and `vlan_id`.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "device",
+				Description: "The network device to encode.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run encodes the network device.
+func (f *encodeNetFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var device types.Dynamic
+
+	resp.Error = req.Arguments.Get(ctx, &device)
+	if resp.Error != nil {
+		return
+	}
+
+	dev, err := networkDeviceFromValue(ctx, device.UnderlyingValue())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+
+		return
+	}
+
+	values := url.Values{}
+
+	if err := dev.EncodeValues("net", &values); err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, values.Get("net"))
+}
+
+// networkDeviceFromValue builds a network device from an object or a map of its attributes.
+func networkDeviceFromValue(ctx context.Context, value attr.Value) (*vms.CustomNetworkDevice, error) {
+	var attrs map[string]attr.Value
+
+	switch v := value.(type) {
+	case types.Object:
+		attrs = v.Attributes()
+	case types.Map:
+		attrs = v.Elements()
+	default:
+		return nil, fmt.Errorf("expected an object with the network device attributes, got %s", value.Type(ctx))
+	}
+
+	dev := &vms.CustomNetworkDevice{
+		Enabled: true,
+		Model:   "virtio",
+	}
+
+	var err error
+
+	for name, v := range attrs {
+		if !slices.Contains(netAttributes, name) {
+			return nil, fmt.Errorf("unsupported attribute %q, expected one of: %s", name, strings.Join(netAttributes, ", "))
+		}
+
+		if v.IsNull() {
+			continue
+		}
+
+		switch name {
+		case "bridge":
+			dev.Bridge, err = stringAttribute(name, v)
+		case "disconnected":
+			dev.LinkDown, err = boolAttribute(name, v)
+		case "firewall":
+			dev.Firewall, err = boolAttribute(name, v)
+		case "mac_address":
+			dev.MACAddress, err = stringAttribute(name, v)
+		case "model":
+			var model *string
+
+			model, err = stringAttribute(name, v)
+			if model != nil {
+				dev.Model = *model
+			}
+		case "mtu":
+			dev.MTU, err = intAttribute(name, v)
+		case "queues":
+			dev.Queues, err = intAttribute(name, v)
+		case "rate_limit":
+			dev.RateLimit, err = floatAttribute(name, v)
+		case "trunks":
+			dev.Trunks, err = trunksAttribute(v)
+		case "vlan_id":
+			dev.Tag, err = intAttribute(name, v)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dev, nil
+}
+
+func stringAttribute(name string, v attr.Value) (*string, error) {
+	s, ok := v.(types.String)
+	if !ok {
+		return nil, fmt.Errorf("attribute %q must be a string", name)
+	}
+
+	return s.ValueStringPointer(), nil
+}
+
+func boolAttribute(name string, v attr.Value) (*proxmoxtypes.CustomBool, error) {
+	switch b := v.(type) {
+	case types.Bool:
+		return proxmoxtypes.CustomBool(b.ValueBool()).Pointer(), nil
+	case types.String:
+		// values of a map(string) are converted to strings by Terraform
+		parsed, err := strconv.ParseBool(b.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q must be a bool: %w", name, err)
+		}
+
+		return proxmoxtypes.CustomBool(parsed).Pointer(), nil
+	default:
+		return nil, fmt.Errorf("attribute %q must be a bool", name)
+	}
+}
+
+func floatAttribute(name string, v attr.Value) (*float64, error) {
+	switch n := v.(type) {
+	case types.Number:
+		f, _ := n.ValueBigFloat().Float64()
+
+		return &f, nil
+	case types.String:
+		f, err := strconv.ParseFloat(n.ValueString(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q must be a number: %w", name, err)
+		}
+
+		return &f, nil
+	default:
+		return nil, fmt.Errorf("attribute %q must be a number", name)
+	}
+}
+
+func intAttribute(name string, v attr.Value) (*int, error) {
+	f, err := floatAttribute(name, v)
+	if err != nil {
+		return nil, err
+	}
+
+	if *f != float64(int(*f)) {
+		return nil, fmt.Errorf("attribute %q must be a whole number", name)
+	}
+
+	return new(int(*f)), nil
+}
+
+func trunksAttribute(v attr.Value) ([]int, error) {
+	var elems []attr.Value
+
+	switch t := v.(type) {
+	case types.String:
+		for s := range strings.SplitSeq(t.ValueString(), ";") {
+			elems = append(elems, types.StringValue(s))
+		}
+	case types.Tuple:
+		elems = t.Elements()
+	case types.List:
+		elems = t.Elements()
+	case types.Set:
+		elems = t.Elements()
+	default:
+		return nil, fmt.Errorf("attribute %q must be a string or a list of numbers", "trunks")
+	}
+
+	trunks := make([]int, 0, len(elems))
+
+	for i, e := range elems {
+		trunk, err := intAttribute(fmt.Sprintf("trunks[%d]", i), e)
+		if err != nil {
+			return nil, err
+		}
+
+		trunks = append(trunks, *trunk)
+	}
+
+	return trunks, nil
+}
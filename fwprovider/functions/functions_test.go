@@ -0,0 +1,232 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package functions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func run(t *testing.T, f function.Function, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+
+	ctx := t.Context()
+
+	var def function.DefinitionResponse
+
+	f.Definition(ctx, function.DefinitionRequest{}, &def)
+
+	result, funcErr := def.Definition.Return.NewResultData(ctx)
+	require.Nil(t, funcErr)
+
+	resp := function.RunResponse{Result: result}
+
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+
+	return resp.Result.Value(), resp.Error
+}
+
+func runObject(t *testing.T, f function.Function, target any, args ...attr.Value) *function.FuncError {
+	t.Helper()
+
+	value, funcErr := run(t, f, args...)
+	if funcErr != nil {
+		return funcErr
+	}
+
+	obj, ok := value.(types.Object)
+	require.True(t, ok)
+	require.False(t, obj.As(t.Context(), target, basetypes.ObjectAsOptions{}).HasError())
+
+	return nil
+}
+
+func bigFloat(f float64) *big.Float {
+	return big.NewFloat(f)
+}
+
+func TestParseVolumeID(t *testing.T) {
+	t.Parallel()
+
+	var m volumeIDModel
+
+	require.Nil(t, runObject(t, NewParseVolumeIDFunction(), &m, types.StringValue("local:iso/debian-12.iso")))
+	assert.Equal(t, volumeIDModel{
+		DatastoreID: types.StringValue("local"),
+		ContentType: types.StringValue("iso"),
+		FileName:    types.StringValue("debian-12.iso"),
+	}, m)
+
+	assert.NotNil(t, runObject(t, NewParseVolumeIDFunction(), &m, types.StringValue("local:debian-12.iso")))
+}
+
+func TestEncodeNet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		device  attr.Value
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "object",
+			device: types.ObjectValueMust(
+				map[string]attr.Type{
+					"bridge":   types.StringType,
+					"firewall": types.BoolType,
+					"mtu":      types.NumberType,
+					"trunks":   types.TupleType{ElemTypes: []attr.Type{types.NumberType, types.NumberType}},
+					"vlan_id":  types.NumberType,
+				},
+				map[string]attr.Value{
+					"bridge":   types.StringValue("vmbr0"),
+					"firewall": types.BoolValue(true),
+					"mtu":      types.NumberValue(bigFloat(1500)),
+					"trunks": types.TupleValueMust(
+						[]attr.Type{types.NumberType, types.NumberType},
+						[]attr.Value{types.NumberValue(bigFloat(10)), types.NumberValue(bigFloat(20))},
+					),
+					"vlan_id": types.NumberValue(bigFloat(10)),
+				},
+			),
+			want: "model=virtio,bridge=vmbr0,firewall=1,tag=10,mtu=1500,trunks=10;20",
+		},
+		{
+			name: "map of strings",
+			device: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"model":        types.StringValue("e1000"),
+				"mac_address":  types.StringValue("BC:24:11:00:00:01"),
+				"disconnected": types.StringValue("true"),
+				"trunks":       types.StringValue("10;20"),
+			}),
+			want: "model=e1000,link_down=1,macaddr=BC:24:11:00:00:01,trunks=10;20",
+		},
+		{
+			name: "unsupported attribute",
+			device: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"vlan": types.StringValue("10"),
+			}),
+			wantErr: true,
+		},
+		{
+			name: "invalid number",
+			device: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"mtu": types.StringValue("jumbo"),
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "not an object",
+			device:  types.StringValue("model=virtio"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			value, funcErr := run(t, NewEncodeNetFunction(), types.DynamicValue(tt.device))
+			if tt.wantErr {
+				assert.NotNil(t, funcErr)
+
+				return
+			}
+
+			require.Nil(t, funcErr)
+			assert.Equal(t, types.StringValue(tt.want), value)
+		})
+	}
+}
+
+func TestParseDisk(t *testing.T) {
+	t.Parallel()
+
+	var m diskModel
+
+	require.Nil(t, runObject(t, NewParseDiskFunction(), &m,
+		types.StringValue("local-lvm:vm-100-disk-0,iothread=1,mbps_rd=100,size=32G,ssd=1"),
+	))
+
+	assert.Equal(t, "local-lvm:vm-100-disk-0", m.FileVolume.ValueString())
+	assert.Equal(t, "local-lvm", m.DatastoreID.ValueString())
+	assert.Equal(t, "vm-100-disk-0", m.PathInDatastore.ValueString())
+	assert.Equal(t, int64(32), m.Size.ValueInt64())
+	assert.True(t, m.IOThread.ValueBool())
+	assert.True(t, m.SSD.ValueBool())
+	assert.True(t, m.Backup.IsNull())
+	assert.Equal(t, int64(100), m.Speed.Read.ValueInt64())
+	assert.True(t, m.Speed.Write.IsNull())
+
+	require.Nil(t, runObject(t, NewParseDiskFunction(), &m, types.StringValue("/dev/sdb,backup=0")))
+	assert.True(t, m.DatastoreID.IsNull())
+	assert.Equal(t, "/dev/sdb", m.PathInDatastore.ValueString())
+	assert.False(t, m.Backup.ValueBool())
+
+	assert.NotNil(t, runObject(t, NewParseDiskFunction(), &m, types.StringValue("size=32G")))
+	assert.NotNil(t, runObject(t, NewParseDiskFunction(), &m, types.StringValue("local:1,size=big")))
+}
+
+func TestParseUPID(t *testing.T) {
+	t.Parallel()
+
+	var m upidModel
+
+	require.Nil(t, runObject(t, NewParseUPIDFunction(), &m,
+		types.StringValue("UPID:pve:000A1B2C:0001E240:66A0F1B0:qmstart:100:root@pam:"),
+	))
+	assert.Equal(t, upidModel{
+		NodeName:  types.StringValue("pve"),
+		PID:       types.Int64Value(0xA1B2C),
+		PStart:    types.Int64Value(0x1E240),
+		StartTime: types.StringValue("2024-07-24T12:21:04Z"),
+		Type:      types.StringValue("qmstart"),
+		ID:        types.StringValue("100"),
+		User:      types.StringValue("root@pam"),
+	}, m)
+
+	assert.NotNil(t, runObject(t, NewParseUPIDFunction(), &m, types.StringValue("pve:qmstart:100")))
+}
+
+func TestHAResourceID(t *testing.T) {
+	t.Parallel()
+
+	value, funcErr := run(t, NewHAResourceIDFunction(), types.StringValue("ct"), types.Int64Value(101))
+	require.Nil(t, funcErr)
+	assert.Equal(t, types.StringValue("ct:101"), value)
+
+	_, funcErr = run(t, NewHAResourceIDFunction(), types.StringValue("lxc"), types.Int64Value(101))
+	assert.NotNil(t, funcErr)
+
+	_, funcErr = run(t, NewHAResourceIDFunction(), types.StringValue("vm"), types.Int64Value(99))
+	assert.NotNil(t, funcErr)
+}
+
+func TestMACFromSeed(t *testing.T) {
+	t.Parallel()
+
+	first, funcErr := run(t, NewMACFromSeedFunction(), types.StringValue("web-1"))
+	require.Nil(t, funcErr)
+
+	second, funcErr := run(t, NewMACFromSeedFunction(), types.StringValue("web-1"))
+	require.Nil(t, funcErr)
+
+	other, funcErr := run(t, NewMACFromSeedFunction(), types.StringValue("web-2"))
+	require.Nil(t, funcErr)
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+	assert.Regexp(t, `^BC:24:11(:[0-9A-F]{2}){3}$`, first.(types.String).ValueString())
+}
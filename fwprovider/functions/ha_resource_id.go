@@ -0,0 +1,87 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var _ function.Function = &haResourceIDFunction{}
+
+// haResourceIDFunction builds the ID of a HA resource.
+type haResourceIDFunction struct{}
+
+// NewHAResourceIDFunction creates the ha_resource_id function.
+func NewHAResourceIDFunction() function.Function {
+	return &haResourceIDFunction{}
+}
+
+// Metadata defines the name of the function.
+func (f *haResourceIDFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "ha_resource_id"
+}
+
+// Definition defines the parameters and the return type of the function.
+func (f *haResourceIDFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Builds a HA resource ID",
+		MarkdownDescription: "Builds the ID of a HA resource, in the format `type:id` (e.g. `vm:100`), " +
+			"as expected by the `resource_id` of `proxmox_virtual_environment_haresource`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "type",
+				Description: "The type of the HA resource, either `vm` or `ct`.",
+			},
+			function.Int64Parameter{
+				Name:        "id",
+				Description: "The ID of the VM or container.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds and validates the HA resource ID.
+func (f *haResourceIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		resType string
+		id      int64
+	)
+
+	resp.Error = req.Arguments.Get(ctx, &resType, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	if _, err := types.ParseHAResourceType(resType); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+
+		return
+	}
+
+	resID, err := types.ParseHAResourceID(fmt.Sprintf("%s:%d", resType, id))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, resID.String())
+}
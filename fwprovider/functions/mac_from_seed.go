@@ -0,0 +1,78 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package functions
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &macFromSeedFunction{}
+
+// macPrefix is the OUI of Proxmox Server Solutions GmbH, which Proxmox VE uses for the generated MAC addresses.
+const macPrefix = "BC:24:11"
+
+// macFromSeedFunction derives a stable MAC address from a seed.
+type macFromSeedFunction struct{}
+
+// NewMACFromSeedFunction creates the mac_from_seed function.
+func NewMACFromSeedFunction() function.Function {
+	return &macFromSeedFunction{}
+}
+
+// Metadata defines the name of the function.
+func (f *macFromSeedFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "mac_from_seed"
+}
+
+// Definition defines the parameters and the return type of the function.
+func (f *macFromSeedFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Derives a stable MAC address from a seed",
+		MarkdownDescription: "Derives a MAC address from a seed string, such as a VM name or a hostname. " +
+			"The same seed always gives the same address, which keeps DHCP reservations stable across re-creations. " +
+			"The address uses the `BC:24:11` prefix that Proxmox VE uses for the addresses it generates, " +
+			"followed by the first three bytes of the SHA-256 hash of the seed.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "seed",
+				Description: "The seed to derive the MAC address from.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run derives the MAC address from the seed.
+func (f *macFromSeedFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var seed string
+
+	resp.Error = req.Arguments.Get(ctx, &seed)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, macFromSeed(seed))
+}
+
+// macFromSeed returns the MAC address with the Proxmox prefix and the first bytes of the SHA-256 hash of the seed.
+func macFromSeed(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+
+	return fmt.Sprintf("%s:%02X:%02X:%02X", macPrefix, sum[0], sum[1], sum[2])
+}
@@ -0,0 +1,204 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+)
+
+var _ function.Function = &parseDiskFunction{}
+
+// parseDiskFunction parses the property string of a VM disk.
+type parseDiskFunction struct{}
+
+// diskModel is the options of a parsed disk.
+type diskModel struct {
+	FileVolume         types.String   `tfsdk:"file_volume"`
+	DatastoreID        types.String   `tfsdk:"datastore_id"`
+	PathInDatastore    types.String   `tfsdk:"path_in_datastore"`
+	FileFormat         types.String   `tfsdk:"file_format"`
+	Size               types.Int64    `tfsdk:"size"`
+	AIO                types.String   `tfsdk:"aio"`
+	Backup             types.Bool     `tfsdk:"backup"`
+	Cache              types.String   `tfsdk:"cache"`
+	Discard            types.String   `tfsdk:"discard"`
+	ImportFrom         types.String   `tfsdk:"import_from"`
+	IOThread           types.Bool     `tfsdk:"iothread"`
+	IopsRead           types.Int64    `tfsdk:"iops_read"`
+	IopsReadBurstable  types.Int64    `tfsdk:"iops_read_burstable"`
+	IopsWrite          types.Int64    `tfsdk:"iops_write"`
+	IopsWriteBurstable types.Int64    `tfsdk:"iops_write_burstable"`
+	Media              types.String   `tfsdk:"media"`
+	Queues             types.Int64    `tfsdk:"queues"`
+	Replicate          types.Bool     `tfsdk:"replicate"`
+	Serial             types.String   `tfsdk:"serial"`
+	Speed              diskSpeedModel `tfsdk:"speed"`
+	SSD                types.Bool     `tfsdk:"ssd"`
+}
+
+// diskSpeedModel is the speed limits of a parsed disk, in MB/s.
+type diskSpeedModel struct {
+	Read           types.Int64 `tfsdk:"read"`
+	ReadBurstable  types.Int64 `tfsdk:"read_burstable"`
+	Write          types.Int64 `tfsdk:"write"`
+	WriteBurstable types.Int64 `tfsdk:"write_burstable"`
+}
+
+// NewParseDiskFunction creates the parse_disk function.
+func NewParseDiskFunction() function.Function {
+	return &parseDiskFunction{}
+}
+
+// Metadata defines the name of the function.
+func (f *parseDiskFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "parse_disk"
+}
+
+// Definition defines the parameters and the return type of the function.
+func (f *parseDiskFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Parses a disk property string",
+		MarkdownDescription: "Parses the property string of a disk of a VM (e.g. `local-lvm:vm-100-disk-0,size=32G,ssd=1`), " +
+			"as returned by the `scsiN`, `virtioN`, `sataN` and `ideN` options of the VM configuration, " +
+			"the same way as the provider does. The attributes are named after the `disk` block of " +
+			"`proxmox_virtual_environment_vm`, the size is in gigabytes, and the options that are not " +
+			"in the string are `null`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "disk",
+				Description: "The disk property string to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"file_volume":          types.StringType,
+				"datastore_id":         types.StringType,
+				"path_in_datastore":    types.StringType,
+				"file_format":          types.StringType,
+				"size":                 types.Int64Type,
+				"aio":                  types.StringType,
+				"backup":               types.BoolType,
+				"cache":                types.StringType,
+				"discard":              types.StringType,
+				"import_from":          types.StringType,
+				"iothread":             types.BoolType,
+				"iops_read":            types.Int64Type,
+				"iops_read_burstable":  types.Int64Type,
+				"iops_write":           types.Int64Type,
+				"iops_write_burstable": types.Int64Type,
+				"media":                types.StringType,
+				"queues":               types.Int64Type,
+				"replicate":            types.BoolType,
+				"serial":               types.StringType,
+				"speed": types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"read":            types.Int64Type,
+						"read_burstable":  types.Int64Type,
+						"write":           types.Int64Type,
+						"write_burstable": types.Int64Type,
+					},
+				},
+				"ssd": types.BoolType,
+			},
+		},
+	}
+}
+
+// Run parses the disk property string.
+func (f *parseDiskFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var disk string
+
+	resp.Error = req.Arguments.Get(ctx, &disk)
+	if resp.Error != nil {
+		return
+	}
+
+	// the property string is parsed by the same unmarshaler as the VM configuration returned by the API
+	b, err := json.Marshal(disk)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+
+		return
+	}
+
+	var d vms.CustomStorageDevice
+
+	if err = d.UnmarshalJSON(b); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+
+		return
+	}
+
+	if d.FileVolume == "" {
+		resp.Error = function.NewArgumentFuncError(0, "the disk property string has no file volume")
+
+		return
+	}
+
+	m := diskModel{
+		FileVolume:         types.StringValue(d.FileVolume),
+		DatastoreID:        types.StringNull(),
+		PathInDatastore:    types.StringPointerValue(d.PathInDatastore()),
+		FileFormat:         types.StringPointerValue(d.Format),
+		Size:               types.Int64Null(),
+		AIO:                types.StringPointerValue(d.AIO),
+		Backup:             types.BoolPointerValue(d.Backup.PointerBool()),
+		Cache:              types.StringPointerValue(d.Cache),
+		Discard:            types.StringPointerValue(d.Discard),
+		ImportFrom:         types.StringPointerValue(d.ImportFrom),
+		IOThread:           types.BoolPointerValue(d.IOThread.PointerBool()),
+		IopsRead:           int64ValueFromIntPtr(d.IopsRead),
+		IopsReadBurstable:  int64ValueFromIntPtr(d.MaxIopsRead),
+		IopsWrite:          int64ValueFromIntPtr(d.IopsWrite),
+		IopsWriteBurstable: int64ValueFromIntPtr(d.MaxIopsWrite),
+		Media:              types.StringPointerValue(d.Media),
+		Queues:             int64ValueFromIntPtr(d.Queues),
+		Replicate:          types.BoolPointerValue(d.Replicate.PointerBool()),
+		Serial:             types.StringPointerValue(d.Serial),
+		Speed: diskSpeedModel{
+			Read:           int64ValueFromIntPtr(d.MaxReadSpeedMbps),
+			ReadBurstable:  int64ValueFromIntPtr(d.BurstableReadSpeedMbps),
+			Write:          int64ValueFromIntPtr(d.MaxWriteSpeedMbps),
+			WriteBurstable: int64ValueFromIntPtr(d.BurstableWriteSpeedMbps),
+		},
+		SSD: types.BoolPointerValue(d.SSD.PointerBool()),
+	}
+
+	if datastoreID, _, ok := strings.Cut(d.FileVolume, ":"); ok {
+		m.DatastoreID = types.StringValue(datastoreID)
+	}
+
+	if d.Size != nil {
+		m.Size = types.Int64Value(d.Size.InGigabytes())
+	}
+
+	resp.Error = resp.Result.Set(ctx, m)
+}
+
+func int64ValueFromIntPtr(p *int) types.Int64 {
+	if p == nil {
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(int64(*p))
+}
@@ -0,0 +1,106 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package functions
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/tasks"
+)
+
+var _ function.Function = &parseUPIDFunction{}
+
+// parseUPIDFunction parses the UPID of a task.
+type parseUPIDFunction struct{}
+
+// upidModel is the parts of a UPID.
+type upidModel struct {
+	NodeName  types.String `tfsdk:"node_name"`
+	PID       types.Int64  `tfsdk:"pid"`
+	PStart    types.Int64  `tfsdk:"pstart"`
+	StartTime types.String `tfsdk:"start_time"`
+	Type      types.String `tfsdk:"type"`
+	ID        types.String `tfsdk:"id"`
+	User      types.String `tfsdk:"user"`
+}
+
+// NewParseUPIDFunction creates the parse_upid function.
+func NewParseUPIDFunction() function.Function {
+	return &parseUPIDFunction{}
+}
+
+// Metadata defines the name of the function.
+func (f *parseUPIDFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "parse_upid"
+}
+
+// Definition defines the parameters and the return type of the function.
+func (f *parseUPIDFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Parses a task UPID",
+		MarkdownDescription: "Parses the unique process ID (UPID) of a task, in the format " +
+			"`UPID:node:pid:pstart:starttime:type:id:user@realm:`. The start time is returned in RFC 3339 format, " +
+			"and the ID is empty for the tasks that are not bound to an object.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "upid",
+				Description: "The UPID to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"node_name":  types.StringType,
+				"pid":        types.Int64Type,
+				"pstart":     types.Int64Type,
+				"start_time": types.StringType,
+				"type":       types.StringType,
+				"id":         types.StringType,
+				"user":       types.StringType,
+			},
+		},
+	}
+}
+
+// Run parses the UPID.
+func (f *parseUPIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var upid string
+
+	resp.Error = req.Arguments.Get(ctx, &upid)
+	if resp.Error != nil {
+		return
+	}
+
+	taskID, err := tasks.ParseTaskID(upid)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, upidModel{
+		NodeName:  types.StringValue(taskID.NodeName),
+		PID:       types.Int64Value(taskID.PID),
+		PStart:    types.Int64Value(taskID.PStart),
+		StartTime: types.StringValue(taskID.StartTime.Format(time.RFC3339)),
+		Type:      types.StringValue(taskID.Type),
+		ID:        types.StringValue(taskID.ID),
+		User:      types.StringValue(taskID.User),
+	})
+}
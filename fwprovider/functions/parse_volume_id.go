@@ -0,0 +1,93 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/storage"
+)
+
+var _ function.Function = &parseVolumeIDFunction{}
+
+// parseVolumeIDFunction parses the ID of a file stored in a datastore.
+type parseVolumeIDFunction struct{}
+
+// volumeIDModel is the parts of a volume ID.
+type volumeIDModel struct {
+	DatastoreID types.String `tfsdk:"datastore_id"`
+	ContentType types.String `tfsdk:"content_type"`
+	FileName    types.String `tfsdk:"file_name"`
+}
+
+// NewParseVolumeIDFunction creates the parse_volume_id function.
+func NewParseVolumeIDFunction() function.Function {
+	return &parseVolumeIDFunction{}
+}
+
+// Metadata defines the name of the function.
+func (f *parseVolumeIDFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "parse_volume_id"
+}
+
+// Definition defines the parameters and the return type of the function.
+func (f *parseVolumeIDFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Parses a file volume ID",
+		MarkdownDescription: "Parses the ID of a file stored in a datastore, in the format " +
+			"`datastore_id:content_type/file_name` (e.g. `local:iso/debian-12.iso`), " +
+			"as used by the `id` of `proxmox_virtual_environment_file`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "volume_id",
+				Description: "The volume ID to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"datastore_id": types.StringType,
+				"content_type": types.StringType,
+				"file_name":    types.StringType,
+			},
+		},
+	}
+}
+
+// Run parses the volume ID.
+func (f *parseVolumeIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	volID, err := storage.ParseVolumeID(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, volumeIDModel{
+		DatastoreID: types.StringValue(volID.DatastoreID),
+		ContentType: types.StringValue(volID.ContentType),
+		FileName:    types.StringValue(volID.FileName),
+	})
+}
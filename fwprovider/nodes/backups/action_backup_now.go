@@ -0,0 +1,200 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backups
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var (
+	_ action.Action              = &backupNowAction{}
+	_ action.ActionWithConfigure = &backupNowAction{}
+)
+
+// backupNowModel is the data model for the backup now action.
+type backupNowModel struct {
+	NodeName      types.String  `tfsdk:"node_name"`
+	VMIDs         []types.Int64 `tfsdk:"vmids"`
+	DatastoreID   types.String  `tfsdk:"datastore_id"`
+	Mode          types.String  `tfsdk:"mode"`
+	Compress      types.String  `tfsdk:"compress"`
+	NotesTemplate types.String  `tfsdk:"notes_template"`
+	Protected     types.Bool    `tfsdk:"protected"`
+}
+
+// backupNowAction takes a one-off vzdump backup of guests on a node.
+type backupNowAction struct {
+	client proxmox.Client
+}
+
+// NewBackupNowAction creates a new backup now action.
+func NewBackupNowAction() action.Action {
+	return &backupNowAction{}
+}
+
+// Metadata defines the name of the action.
+func (a *backupNowAction) Metadata(
+	_ context.Context,
+	_ action.MetadataRequest,
+	resp *action.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_backup_now"
+}
+
+// Schema defines the schema for the action.
+func (a *backupNowAction) Schema(
+	_ context.Context,
+	_ action.SchemaRequest,
+	resp *action.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Takes a one-off vzdump backup of VMs and containers on a node, and waits for it to finish.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node the guests are on.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"vmids": schema.ListAttribute{
+				Description: "The IDs of the VMs and containers to back up.",
+				ElementType: types.Int64Type,
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueInt64sAre(int64validator.Between(100, 999999999)),
+				},
+			},
+			"datastore_id": schema.StringAttribute{
+				Description: "The identifier of the datastore to store the backups in. " +
+					"Defaults to the storage configured in `/etc/vzdump.conf`, or `local`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"mode": schema.StringAttribute{
+				Description: "The backup mode, one of `snapshot`, `suspend` or `stop`. Defaults to `snapshot`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(nodes.BackupModeSnapshot, nodes.BackupModeSuspend, nodes.BackupModeStop),
+				},
+			},
+			"compress": schema.StringAttribute{
+				Description: "The compression algorithm, one of `0` (none), `gzip`, `lzo` or `zstd`. " +
+					"Ignored by Proxmox Backup Server datastores.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						nodes.BackupCompressNone,
+						nodes.BackupCompressGzip,
+						nodes.BackupCompressLZO,
+						nodes.BackupCompressZstd,
+					),
+				},
+			},
+			"notes_template": schema.StringAttribute{
+				Description: "The template for the notes of the backups. It can contain the " +
+					"`{{cluster}}`, `{{guestname}}`, `{{node}}` and `{{vmid}}` variables.",
+				Optional: true,
+			},
+			"protected": schema.BoolAttribute{
+				Description: "Mark the backups as protected, so that they are not pruned or removed. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// Configure sets the client for the action.
+func (a *backupNowAction) Configure(
+	_ context.Context,
+	req action.ConfigureRequest,
+	resp *action.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Action)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected config.Action, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = cfg.Client
+}
+
+// Invoke runs the backup and waits for it to finish.
+func (a *backupNowAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model backupNowModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := model.toAPI()
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Backing up guests %s on node %s", body.VMID, model.NodeName.ValueString()),
+	})
+
+	result := a.client.Node(model.NodeName.ValueString()).BackupGuests(ctx, body)
+	if result.AddDiags(&resp.Diagnostics, fmt.Sprintf("Unable to Back Up Guests %s", body.VMID)) {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Backed up guests %s", body.VMID),
+	})
+}
+
+func (m *backupNowModel) toAPI() *nodes.VzdumpRequestBody {
+	ids := make([]string, 0, len(m.VMIDs))
+	for _, id := range m.VMIDs {
+		ids = append(ids, strconv.FormatInt(id.ValueInt64(), 10))
+	}
+
+	body := &nodes.VzdumpRequestBody{
+		VMID:          strings.Join(ids, ","),
+		Storage:       m.DatastoreID.ValueStringPointer(),
+		Mode:          m.Mode.ValueStringPointer(),
+		Compress:      m.Compress.ValueStringPointer(),
+		NotesTemplate: m.NotesTemplate.ValueStringPointer(),
+	}
+
+	if m.Protected.ValueBool() {
+		body.Protected = new(proxmoxtypes.CustomBool(true))
+	}
+
+	return body
+}
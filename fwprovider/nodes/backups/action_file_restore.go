@@ -0,0 +1,226 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/storage"
+)
+
+var (
+	_ action.Action              = &fileRestoreAction{}
+	_ action.ActionWithConfigure = &fileRestoreAction{}
+)
+
+// fileRestoreModel is the data model for the file restore action.
+type fileRestoreModel struct {
+	NodeName    types.String `tfsdk:"node_name"`
+	DatastoreID types.String `tfsdk:"datastore_id"`
+	VolumeID    types.String `tfsdk:"volume_id"`
+	FilePath    types.String `tfsdk:"file_path"`
+	Destination types.String `tfsdk:"destination"`
+	Tar         types.Bool   `tfsdk:"tar"`
+	Overwrite   types.Bool   `tfsdk:"overwrite"`
+}
+
+// fileRestoreAction extracts a file or directory from a Proxmox Backup Server backup.
+type fileRestoreAction struct {
+	client proxmox.Client
+}
+
+// NewFileRestoreAction creates a new backup file restore action.
+func NewFileRestoreAction() action.Action {
+	return &fileRestoreAction{}
+}
+
+// Metadata defines the name of the action.
+func (a *fileRestoreAction) Metadata(
+	_ context.Context,
+	_ action.MetadataRequest,
+	resp *action.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_backup_file_restore"
+}
+
+// Schema defines the schema for the action.
+func (a *fileRestoreAction) Schema(
+	_ context.Context,
+	_ action.SchemaRequest,
+	resp *action.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Extracts a file or directory from a backup stored on a Proxmox Backup Server datastore " +
+			"into a local path, using the file-restore API.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node to run the file restore on.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"datastore_id": schema.StringAttribute{
+				Description: "The identifier of the Proxmox Backup Server datastore.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"volume_id": schema.StringAttribute{
+				Description: "The volume ID of the backup, e.g. `pbs:backup/vm/100/2025-03-01T12:00:00Z`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"file_path": schema.StringAttribute{
+				Description: "The path of the file or directory inside the backup, starting with the archive, " +
+					"e.g. `/root.pxar.didx/etc/hostname` or `/drive-scsi0.img.fidx/part/1/etc`.",
+				Required: true,
+				Validators: []validator.String{
+					validators.AbsoluteFilePathValidator(),
+				},
+			},
+			"destination": schema.StringAttribute{
+				Description: "The local path to write the file to. Directories are written as an archive.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"tar": schema.BoolAttribute{
+				Description: "Download directories as a zstd compressed tar archive instead of a zip archive. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"overwrite": schema.BoolAttribute{
+				Description: "Replace the destination if it already exists. Defaults to `false`.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// Configure sets the client for the action.
+func (a *fileRestoreAction) Configure(
+	_ context.Context,
+	req action.ConfigureRequest,
+	resp *action.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Action)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected config.Action, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = cfg.Client
+}
+
+// Invoke downloads the file from the backup into the destination.
+func (a *fileRestoreAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model fileRestoreModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	storageClient := a.client.Node(model.NodeName.ValueString()).Storage(model.DatastoreID.ValueString())
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Restoring %s from backup %s", model.FilePath.ValueString(), model.VolumeID.ValueString()),
+	})
+
+	err := restoreFile(
+		ctx,
+		storageClient,
+		model.VolumeID.ValueString(),
+		model.FilePath.ValueString(),
+		model.Destination.ValueString(),
+		model.Tar.ValueBool(),
+		model.Overwrite.ValueBool(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Restore File from Backup",
+			fmt.Sprintf("Unable to restore '%s' from backup '%s': %s",
+				model.FilePath.ValueString(), model.VolumeID.ValueString(), err.Error()),
+		)
+
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Restored %s to %s", model.FilePath.ValueString(), model.Destination.ValueString()),
+	})
+}
+
+// restoreFile downloads the file into a temporary file next to the destination and renames it
+// once the download is complete, so that a failed restore never leaves a partial file behind.
+func restoreFile(
+	ctx context.Context,
+	c *storage.Client,
+	volumeID, filePath, destination string,
+	tar, overwrite bool,
+) error {
+	if !overwrite {
+		if _, err := os.Stat(destination); err == nil {
+			return fmt.Errorf("destination %s already exists, set `overwrite` to replace it", destination)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("error checking destination %s: %w", destination, err)
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(destination), "."+filepath.Base(destination)+".*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file: %w", err)
+	}
+
+	// removing the temporary file fails harmlessly once it has been renamed
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	err = c.DownloadRestoreFile(ctx, volumeID, filePath, tar, tmp)
+	if err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("error writing %s: %w", tmp.Name(), err)
+	}
+
+	if err = os.Rename(tmp.Name(), destination); err != nil {
+		return fmt.Errorf("error moving restored file to %s: %w", destination, err)
+	}
+
+	return nil
+}
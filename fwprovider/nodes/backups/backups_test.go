@@ -0,0 +1,136 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backups
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test/faketest"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/storage"
+)
+
+func TestFilterBackups(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	backup := func(volid string, age time.Duration) *storage.DatastoreFileListResponseData {
+		return &storage.DatastoreFileListResponseData{VolumeID: volid, CreationTime: new(now.Add(-age).Unix())}
+	}
+
+	backups := []*storage.DatastoreFileListResponseData{
+		backup("old", 72*time.Hour),
+		backup("new", time.Hour),
+		nil,
+		backup("mid", 25*time.Hour),
+		{VolumeID: "unknown"},
+	}
+
+	ids := func(bs []*storage.DatastoreFileListResponseData) []string {
+		res := make([]string, 0, len(bs))
+		for _, b := range bs {
+			res = append(res, b.VolumeID)
+		}
+
+		return res
+	}
+
+	assert.Equal(t, []string{"new", "mid", "old", "unknown"}, ids(filterBackups(backups, 0, now)))
+	assert.Equal(t, []string{"new", "mid"}, ids(filterBackups(backups, 48*time.Hour, now)))
+	assert.Equal(t, []string{"new"}, ids(filterBackups(backups, 24*time.Hour, now)))
+}
+
+func TestNewBackupEntry(t *testing.T) {
+	t.Parallel()
+
+	e := newBackupEntry(&storage.DatastoreFileListResponseData{
+		VolumeID:     "pbs:backup/ct/101/2025-03-01T12:00:00Z",
+		FileFormat:   "pbs-ct",
+		FileSize:     1024,
+		VMID:         new(101),
+		CreationTime: new(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC).Unix()),
+		Encrypted:    new("1"),
+		Subtype:      new("lxc"),
+		Verification: &storage.DatastoreFileVerification{State: "failed"},
+	})
+
+	assert.Equal(t, int64(101), e.VMID.ValueInt64())
+	assert.Equal(t, "2025-03-01T12:00:00Z", e.CreationTime.ValueString())
+	assert.True(t, e.Encrypted.ValueBool())
+	assert.False(t, e.Protected.ValueBool())
+	assert.True(t, e.Notes.IsNull())
+	assert.Equal(t, "failed", e.VerificationState.ValueString())
+}
+
+func TestRestoreFile(t *testing.T) {
+	t.Parallel()
+
+	s, client := faketest.NewClient(t, fake.WithStorage("pbs", "pbs", true, "backup"))
+
+	volumeID, err := s.AddBackup(fake.DefaultNode, "pbs", 101, fake.Backup{
+		Container: true,
+		Files:     map[string]string{"/root.pxar.didx/etc/hostname": "ct101\n"},
+	})
+	require.NoError(t, err)
+
+	c := client.Node(fake.DefaultNode).Storage("pbs")
+
+	dir := t.TempDir()
+	destination := filepath.Join(dir, "hostname")
+
+	require.NoError(t, restoreFile(t.Context(), c, volumeID, "/root.pxar.didx/etc/hostname", destination, false, false))
+
+	content, err := os.ReadFile(destination)
+	require.NoError(t, err)
+	assert.Equal(t, "ct101\n", string(content))
+
+	err = restoreFile(t.Context(), c, volumeID, "/root.pxar.didx/etc/hostname", destination, false, false)
+	require.ErrorContains(t, err, "already exists")
+
+	require.NoError(t, restoreFile(t.Context(), c, volumeID, "/root.pxar.didx/etc/hostname", destination, false, true))
+
+	err = restoreFile(t.Context(), c, volumeID, "/root.pxar.didx/missing", filepath.Join(dir, "missing"), false, false)
+	require.Error(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "failed restores must not leave temporary files behind")
+}
+
+func TestBackupNowToAPI(t *testing.T) {
+	t.Parallel()
+
+	m := backupNowModel{
+		NodeName:      types.StringValue(fake.DefaultNode),
+		VMIDs:         []types.Int64{types.Int64Value(100), types.Int64Value(101)},
+		DatastoreID:   types.StringNull(),
+		Mode:          types.StringValue("stop"),
+		Compress:      types.StringNull(),
+		NotesTemplate: types.StringValue("{{guestname}}"),
+		Protected:     types.BoolValue(true),
+	}
+
+	body := m.toAPI()
+
+	assert.Equal(t, "100,101", body.VMID)
+	assert.Nil(t, body.Storage)
+	assert.Nil(t, body.Compress)
+	assert.Equal(t, "stop", *body.Mode)
+	assert.Equal(t, "{{guestname}}", *body.NotesTemplate)
+	require.NotNil(t, body.Protected)
+	assert.True(t, bool(*body.Protected))
+
+	m.Protected = types.BoolNull()
+	assert.Nil(t, m.toAPI().Protected)
+}
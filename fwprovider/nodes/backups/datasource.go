@@ -0,0 +1,306 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backups
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/storage"
+)
+
+var (
+	_ datasource.DataSource              = &backupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &backupsDataSource{}
+)
+
+// backupsModel is the data model for the backups data source.
+type backupsModel struct {
+	NodeName    types.String  `tfsdk:"node_name"`
+	DatastoreID types.String  `tfsdk:"datastore_id"`
+	VMID        types.Int64   `tfsdk:"vm_id"`
+	MaxAge      types.String  `tfsdk:"max_age"`
+	Backups     []backupEntry `tfsdk:"backups"`
+}
+
+// backupEntry represents a single backup in the data source output.
+type backupEntry struct {
+	ID                types.String `tfsdk:"id"`
+	VMID              types.Int64  `tfsdk:"vm_id"`
+	Subtype           types.String `tfsdk:"subtype"`
+	Format            types.String `tfsdk:"format"`
+	Size              types.Int64  `tfsdk:"size"`
+	CreationTime      types.String `tfsdk:"creation_time"`
+	Notes             types.String `tfsdk:"notes"`
+	Protected         types.Bool   `tfsdk:"protected"`
+	Encrypted         types.Bool   `tfsdk:"encrypted"`
+	VerificationState types.String `tfsdk:"verification_state"`
+}
+
+// backupsDataSource is the implementation of the backups data source.
+type backupsDataSource struct {
+	client proxmox.Client
+}
+
+// NewDataSource creates a new backups data source.
+func NewDataSource() datasource.DataSource {
+	return &backupsDataSource{}
+}
+
+// Metadata defines the name of the data source.
+func (d *backupsDataSource) Metadata(
+	_ context.Context,
+	_ datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_backups"
+}
+
+// Schema defines the schema for the backups data source.
+func (d *backupsDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the backups stored in a datastore on a specific Proxmox VE node, " +
+			"including their notes, protection, verification and encryption state.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"datastore_id": schema.StringAttribute{
+				Description: "The identifier of the datastore.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				Description: "Only return the backups of the VM or container with this ID.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(100, 999999999),
+				},
+			},
+			"max_age": schema.StringAttribute{
+				Description: "Only return the backups created within this duration, e.g. `24h` or `168h`.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.IsValidDuration(),
+				},
+			},
+			"backups": schema.ListNestedAttribute{
+				Description: "The list of backups, newest first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The volume ID of the backup, e.g. " +
+								"`pbs:backup/vm/100/2025-03-01T12:00:00Z`.",
+							Computed: true,
+						},
+						"vm_id": schema.Int64Attribute{
+							Description: "The ID of the backed up VM or container.",
+							Computed:    true,
+						},
+						"subtype": schema.StringAttribute{
+							Description: "The guest type of the backup, `qemu` or `lxc`.",
+							Computed:    true,
+						},
+						"format": schema.StringAttribute{
+							Description: "The format of the backup, e.g. `pbs-vm` or `vma.zst`.",
+							Computed:    true,
+						},
+						"size": schema.Int64Attribute{
+							Description: "The size of the backup in bytes.",
+							Computed:    true,
+						},
+						"creation_time": schema.StringAttribute{
+							Description: "The creation time of the backup in RFC 3339 format.",
+							Computed:    true,
+						},
+						"notes": schema.StringAttribute{
+							Description: "The notes of the backup.",
+							Computed:    true,
+						},
+						"protected": schema.BoolAttribute{
+							Description: "Whether the backup is protected against removal.",
+							Computed:    true,
+						},
+						"encrypted": schema.BoolAttribute{
+							Description: "Whether the backup is encrypted. Only reported by Proxmox Backup Server datastores.",
+							Computed:    true,
+						},
+						"verification_state": schema.StringAttribute{
+							Description: "The state of the last verification of the backup, e.g. `ok` or `failed`. " +
+								"Only reported by Proxmox Backup Server datastores.",
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the data source.
+func (d *backupsDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read fetches the list of backups from the Proxmox API.
+func (d *backupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model backupsModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var vmID *int
+
+	if !model.VMID.IsNull() && !model.VMID.IsUnknown() {
+		vmID = new(int(model.VMID.ValueInt64()))
+	}
+
+	var maxAge time.Duration
+
+	if !model.MaxAge.IsNull() && !model.MaxAge.IsUnknown() {
+		var err error
+
+		maxAge, err = time.ParseDuration(model.MaxAge.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Backups", fmt.Sprintf("Invalid max_age: %s", err.Error()))
+
+			return
+		}
+	}
+
+	storageClient := d.client.Node(model.NodeName.ValueString()).Storage(model.DatastoreID.ValueString())
+
+	apiBackups, err := storageClient.ListDatastoreBackups(ctx, vmID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Backups",
+			fmt.Sprintf("Unable to list backups from datastore '%s' on node '%s': %s",
+				model.DatastoreID.ValueString(), model.NodeName.ValueString(), err.Error()),
+		)
+
+		return
+	}
+
+	apiBackups = filterBackups(apiBackups, maxAge, time.Now())
+
+	model.Backups = make([]backupEntry, 0, len(apiBackups))
+
+	for _, b := range apiBackups {
+		model.Backups = append(model.Backups, newBackupEntry(b))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// filterBackups drops the backups older than maxAge (if set) and sorts the rest newest first.
+func filterBackups(
+	backups []*storage.DatastoreFileListResponseData,
+	maxAge time.Duration,
+	now time.Time,
+) []*storage.DatastoreFileListResponseData {
+	res := make([]*storage.DatastoreFileListResponseData, 0, len(backups))
+
+	for _, b := range backups {
+		if b == nil {
+			continue
+		}
+
+		if maxAge > 0 && (b.CreationTime == nil || now.Sub(time.Unix(*b.CreationTime, 0)) > maxAge) {
+			continue
+		}
+
+		res = append(res, b)
+	}
+
+	slices.SortStableFunc(res, func(a, b *storage.DatastoreFileListResponseData) int {
+		return cmp.Compare(creationTime(b), creationTime(a))
+	})
+
+	return res
+}
+
+func creationTime(b *storage.DatastoreFileListResponseData) int64 {
+	if b.CreationTime == nil {
+		return 0
+	}
+
+	return *b.CreationTime
+}
+
+func newBackupEntry(b *storage.DatastoreFileListResponseData) backupEntry {
+	e := backupEntry{
+		ID:                types.StringValue(b.VolumeID),
+		VMID:              types.Int64Null(),
+		Subtype:           types.StringPointerValue(b.Subtype),
+		Format:            types.StringValue(b.FileFormat),
+		Size:              types.Int64Value(b.FileSize),
+		CreationTime:      types.StringNull(),
+		Notes:             types.StringPointerValue(b.Notes),
+		Protected:         types.BoolValue(b.Protected != nil && bool(*b.Protected)),
+		Encrypted:         types.BoolValue(b.IsEncrypted()),
+		VerificationState: types.StringNull(),
+	}
+
+	if b.VMID != nil {
+		e.VMID = types.Int64Value(int64(*b.VMID))
+	}
+
+	if b.CreationTime != nil {
+		e.CreationTime = types.StringValue(time.Unix(*b.CreationTime, 0).UTC().Format(time.RFC3339))
+	}
+
+	if b.Verification != nil {
+		e.VerificationState = types.StringValue(b.Verification.State)
+	}
+
+	return e
+}
@@ -0,0 +1,127 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package console
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test/faketest"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/containers"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+)
+
+func TestConsoleURL(t *testing.T) {
+	t.Parallel()
+
+	vm := consoleTarget{node: "pve", kind: "qemu", vmID: 100}
+	node := consoleTarget{node: "pve"}
+
+	assert.Equal(t,
+		"https://pve.example.com:8006/?console=kvm&node=pve&novnc=1&resize=scale&vmid=100",
+		consoleURL("https://pve.example.com:8006/", vm, protocolVNC, ""))
+	assert.Equal(t,
+		"https://pve.example.com:8006/?cmd=upgrade&console=shell&node=pve&xtermjs=1",
+		consoleURL("https://pve.example.com:8006", node, protocolTerm, "upgrade"))
+
+	assert.Equal(t,
+		"wss://pve.example.com:8006/api2/json/nodes/pve/qemu/100/vncwebsocket?port=5900&vncticket=PVEVNC%3A1%3A%3Aabc%2B",
+		websocketURL("https://pve.example.com:8006/", vm, 5900, "PVEVNC:1::abc+"))
+	assert.Equal(t,
+		"wss://pve.example.com:8006/api2/json/nodes/pve/vncwebsocket?port=5901&vncticket=t",
+		websocketURL("https://pve.example.com:8006", node, 5901, "t"))
+}
+
+func TestSPICEConfigFile(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"[virt-viewer]\ndelete-this-file=1\ntls-port=61000\ntype=spice\n",
+		spiceConfigFile(map[string]any{"type": "spice", "tls-port": float64(61000), "delete-this-file": float64(1)}))
+}
+
+func TestOpenTicket(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	s, client := faketest.NewClient(t)
+	nodeAPI := client.Node(fake.DefaultNode)
+
+	require.NoError(t, nodeAPI.VM(0).CreateVM(ctx, &vms.CreateRequestBody{VMID: 100, Name: new("web")}).Err())
+	tmpl, err := s.AddVolume(fake.DefaultNode, "local", "vztmpl", "debian-12-standard_12.7-1_amd64.tar.zst", 1<<20)
+	require.NoError(t, err)
+
+	require.NoError(t, nodeAPI.Container(101).CreateContainer(ctx, &containers.CreateRequestBody{
+		VMID:                 new(101),
+		OSTemplateFileVolume: &tmpl,
+	}).Err())
+
+	open := func(m ticketModel) (ticketModel, diag.Diagnostics) {
+		var diags diag.Diagnostics
+
+		m.NodeName = types.StringValue(fake.DefaultNode)
+		openTicket(ctx, client, s.URL, &m, &diags)
+
+		return m, diags
+	}
+
+	_, diags := open(ticketModel{VMID: types.Int64Value(100)})
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags.Errors()[0].Detail(), "VM 100 not running")
+
+	require.NoError(t, nodeAPI.VM(100).StartVM(ctx, 30).Err())
+
+	m, diags := open(ticketModel{VMID: types.Int64Value(100)})
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.True(t, strings.HasPrefix(m.Ticket.ValueString(), "PVEVNC:"))
+	assert.GreaterOrEqual(t, m.Port.ValueInt64(), int64(5900))
+	assert.Equal(t, "root@pam", m.User.ValueString())
+	assert.Contains(t, m.URL.ValueString(), "console=kvm")
+	assert.True(t, m.SPICEConfig.IsNull())
+
+	wsURL, err := url.Parse(m.WebSocketURL.ValueString())
+	require.NoError(t, err)
+	assert.Equal(t, "wss", wsURL.Scheme)
+	assert.Equal(t, "/api2/json/nodes/"+fake.DefaultNode+"/qemu/100/vncwebsocket", wsURL.Path)
+	assert.Equal(t, m.Ticket.ValueString(), wsURL.Query().Get("vncticket"))
+
+	_, diags = open(ticketModel{VMID: types.Int64Value(100), Protocol: types.StringValue(protocolSPICE)})
+	require.True(t, diags.HasError(), "the VM has no SPICE display")
+
+	require.NoError(t, nodeAPI.VM(100).UpdateVM(ctx, &vms.UpdateRequestBody{VGADevice: &vms.CustomVGADevice{Type: new("qxl")}}))
+
+	m, diags = open(ticketModel{
+		VMID:     types.Int64Value(100),
+		Protocol: types.StringValue(protocolSPICE),
+		Proxy:    types.StringValue("spice.example.com"),
+	})
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.True(t, m.Ticket.IsNull())
+	assert.True(t, strings.HasPrefix(m.SPICEConfig.ValueString(), "[virt-viewer]\n"))
+	assert.Contains(t, m.SPICEConfig.ValueString(), "proxy=http://spice.example.com:3128\n")
+	assert.Contains(t, m.SPICEConfig.ValueString(), "title=VM 100 - web\n")
+
+	require.NoError(t, nodeAPI.Container(101).StartContainer(ctx).Err())
+
+	m, diags = open(ticketModel{ContainerID: types.Int64Value(101), Protocol: types.StringValue(protocolTerm)})
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Contains(t, m.URL.ValueString(), "console=lxc")
+	assert.Contains(t, m.URL.ValueString(), "xtermjs=1")
+
+	m, diags = open(ticketModel{Command: types.StringValue("upgrade")})
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Contains(t, m.URL.ValueString(), "console=shell")
+	assert.Contains(t, m.WebSocketURL.ValueString(), "/nodes/"+fake.DefaultNode+"/vncwebsocket?")
+}
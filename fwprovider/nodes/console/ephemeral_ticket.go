@@ -0,0 +1,459 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package console
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/containers"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// Console protocols.
+const (
+	protocolSPICE = "spice"
+	protocolTerm  = "term"
+	protocolVNC   = "vnc"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &ticketEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &ticketEphemeralResource{}
+)
+
+// ticketModel is the data model for the console ticket ephemeral resource.
+type ticketModel struct {
+	NodeName     types.String `tfsdk:"node_name"`
+	VMID         types.Int64  `tfsdk:"vm_id"`
+	ContainerID  types.Int64  `tfsdk:"container_id"`
+	Protocol     types.String `tfsdk:"protocol"`
+	Command      types.String `tfsdk:"command"`
+	Proxy        types.String `tfsdk:"proxy"`
+	Ticket       types.String `tfsdk:"ticket"`
+	Port         types.Int64  `tfsdk:"port"`
+	User         types.String `tfsdk:"user"`
+	URL          types.String `tfsdk:"url"`
+	WebSocketURL types.String `tfsdk:"websocket_url"`
+	SPICEConfig  types.String `tfsdk:"spice_config"`
+}
+
+// ticketEphemeralResource opens a console proxy to a VM, a container or a node shell.
+type ticketEphemeralResource struct {
+	client   proxmox.Client
+	endpoint string
+}
+
+// NewTicketEphemeralResource creates a new console ticket ephemeral resource.
+func NewTicketEphemeralResource() ephemeral.EphemeralResource {
+	return &ticketEphemeralResource{}
+}
+
+// Metadata defines the name of the ephemeral resource.
+func (r *ticketEphemeralResource) Metadata(
+	_ context.Context,
+	_ ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_console_ticket"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *ticketEphemeralResource) Schema(
+	_ context.Context,
+	_ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Opens a console proxy to a VM, a container or a shell on a node, and returns its " +
+			"short-lived ticket.",
+		MarkdownDescription: "Opens a console proxy to a VM, a container or a shell on a node, and returns its " +
+			"short-lived ticket, together with a noVNC or xterm.js URL of the web interface, or a `.vv` file " +
+			"for a SPICE remote viewer. The proxy only accepts a connection for a few seconds after it is opened. " +
+			"Without `vm_id` and `container_id`, the console is a shell on the node.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				Description: "The ID of the VM to open the console of.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(100, 999999999),
+					int64validator.ConflictsWith(path.MatchRoot("container_id")),
+				},
+			},
+			"container_id": schema.Int64Attribute{
+				Description: "The ID of the container to open the console of.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(100, 999999999),
+				},
+			},
+			"protocol": schema.StringAttribute{
+				Description: "The console protocol, one of `vnc`, `spice` or `term`. Defaults to `vnc`.",
+				MarkdownDescription: "The console protocol, one of `vnc`, `spice` or `term` (a terminal, e.g. the " +
+					"serial console of a VM). Defaults to `vnc`. SPICE requires a VM with a `qxl` display.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(protocolVNC, protocolSPICE, protocolTerm),
+				},
+			},
+			"command": schema.StringAttribute{
+				Description: "The command to run in the node shell, one of `login`, `upgrade` or `ceph_install`. " +
+					"Defaults to a login shell.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(nodes.ShellCommandLogin, nodes.ShellCommandUpgrade, nodes.ShellCommandCephInstall),
+					stringvalidator.ConflictsWith(path.MatchRoot("vm_id"), path.MatchRoot("container_id")),
+				},
+			},
+			"proxy": schema.StringAttribute{
+				Description: "The host name or IP address of the SPICE proxy. Defaults to the node.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"ticket": schema.StringAttribute{
+				Description: "The ticket of the VNC or terminal proxy.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"port": schema.Int64Attribute{
+				Description: "The port of the VNC or terminal proxy.",
+				Computed:    true,
+			},
+			"user": schema.StringAttribute{
+				Description: "The user the proxy was opened for.",
+				Computed:    true,
+			},
+			"url": schema.StringAttribute{
+				Description: "The URL of the noVNC or xterm.js console in the web interface. Opening it " +
+					"requires a login to the web interface.",
+				Computed: true,
+			},
+			"websocket_url": schema.StringAttribute{
+				Description: "The URL of the websocket of the VNC or terminal proxy, including the ticket.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"spice_config": schema.StringAttribute{
+				Description: "The content of a `.vv` file to open the SPICE console with a remote viewer.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+// Configure sets the client for the ephemeral resource.
+func (r *ticketEphemeralResource) Configure(
+	_ context.Context,
+	req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.EphemeralResource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected config.EphemeralResource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+	r.endpoint = cfg.Endpoint
+}
+
+// Open opens the console proxy.
+func (r *ticketEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model ticketModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	openTicket(ctx, r.client, r.endpoint, &model, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, model)...)
+}
+
+// consoleTarget identifies the console of a VM, a container or a node shell.
+type consoleTarget struct {
+	node string
+	// kind is the guest type in the API path, i.e. `qemu` or `lxc`, or empty for a node shell.
+	kind string
+	vmID int
+}
+
+func (m *ticketModel) target() consoleTarget {
+	t := consoleTarget{node: m.NodeName.ValueString()}
+
+	switch {
+	case !m.VMID.IsNull():
+		t.kind = "qemu"
+		t.vmID = int(m.VMID.ValueInt64())
+	case !m.ContainerID.IsNull():
+		t.kind = "lxc"
+		t.vmID = int(m.ContainerID.ValueInt64())
+	}
+
+	return t
+}
+
+// label describes the console target in diagnostics.
+func (t consoleTarget) label() string {
+	switch t.kind {
+	case "qemu":
+		return fmt.Sprintf("VM %d", t.vmID)
+	case "lxc":
+		return fmt.Sprintf("Container %d", t.vmID)
+	default:
+		return "Shell on Node " + t.node
+	}
+}
+
+// openTicket opens the console proxy of the model and fills out its computed attributes.
+func openTicket(
+	ctx context.Context,
+	client proxmox.Client,
+	endpoint string,
+	m *ticketModel,
+	diags *diag.Diagnostics,
+) {
+	target := m.target()
+
+	protocol := m.Protocol.ValueString()
+	if protocol == "" {
+		protocol = protocolVNC
+	}
+
+	m.Ticket = types.StringNull()
+	m.Port = types.Int64Null()
+	m.User = types.StringNull()
+	m.URL = types.StringNull()
+	m.WebSocketURL = types.StringNull()
+	m.SPICEConfig = types.StringNull()
+
+	nodeAPI := client.Node(target.node)
+	summary := fmt.Sprintf("Unable to Open %s Console of %s", strings.ToUpper(protocol), target.label())
+
+	if protocol == protocolSPICE {
+		var (
+			settings map[string]any
+			err      error
+		)
+
+		proxy := m.Proxy.ValueStringPointer()
+
+		switch target.kind {
+		case "qemu":
+			settings, err = nodeAPI.VM(target.vmID).CreateSPICEProxy(ctx, &vms.SPICEProxyRequestBody{Proxy: proxy})
+		case "lxc":
+			settings, err = nodeAPI.Container(target.vmID).
+				CreateSPICEProxy(ctx, &containers.SPICEProxyRequestBody{Proxy: proxy})
+		default:
+			settings, err = nodeAPI.CreateSPICEShell(ctx, &nodes.SPICEShellRequestBody{
+				Command: m.Command.ValueStringPointer(),
+				Proxy:   proxy,
+			})
+		}
+
+		if err != nil {
+			diags.AddError(summary, err.Error())
+			return
+		}
+
+		m.SPICEConfig = types.StringValue(spiceConfigFile(settings))
+
+		return
+	}
+
+	port, ticket, user, err := openProxy(ctx, nodeAPI, target, protocol, m.Command.ValueStringPointer())
+	if err != nil {
+		diags.AddError(summary, err.Error())
+		return
+	}
+
+	m.Ticket = types.StringValue(ticket)
+	m.Port = types.Int64Value(int64(port))
+	m.User = types.StringValue(user)
+
+	if endpoint != "" {
+		m.URL = types.StringValue(consoleURL(endpoint, target, protocol, m.Command.ValueString()))
+		m.WebSocketURL = types.StringValue(websocketURL(endpoint, target, port, ticket))
+	}
+}
+
+// openProxy opens a VNC or terminal proxy and returns its port, ticket and user.
+func openProxy(
+	ctx context.Context,
+	nodeAPI *nodes.Client,
+	target consoleTarget,
+	protocol string,
+	command *string,
+) (int, string, string, error) {
+	websocket := proxmoxtypes.CustomBool(true).Pointer()
+
+	switch target.kind {
+	case "qemu":
+		vmAPI := nodeAPI.VM(target.vmID)
+
+		var (
+			res *vms.ConsoleProxyResponseData
+			err error
+		)
+
+		if protocol == protocolVNC {
+			res, err = vmAPI.CreateVNCProxy(ctx, &vms.VNCProxyRequestBody{WebSocket: websocket})
+		} else {
+			res, err = vmAPI.CreateTermProxy(ctx, &vms.TermProxyRequestBody{})
+		}
+
+		if err != nil {
+			return 0, "", "", err
+		}
+
+		return int(res.Port), res.Ticket, res.User, nil
+	case "lxc":
+		ctAPI := nodeAPI.Container(target.vmID)
+
+		var (
+			res *containers.ConsoleProxyResponseData
+			err error
+		)
+
+		if protocol == protocolVNC {
+			res, err = ctAPI.CreateVNCProxy(ctx, &containers.VNCProxyRequestBody{WebSocket: websocket})
+		} else {
+			res, err = ctAPI.CreateTermProxy(ctx)
+		}
+
+		if err != nil {
+			return 0, "", "", err
+		}
+
+		return int(res.Port), res.Ticket, res.User, nil
+	default:
+		var (
+			res *nodes.ConsoleProxyResponseData
+			err error
+		)
+
+		if protocol == protocolVNC {
+			res, err = nodeAPI.CreateVNCShell(ctx, &nodes.VNCShellRequestBody{Command: command, WebSocket: websocket})
+		} else {
+			res, err = nodeAPI.CreateTermProxy(ctx, &nodes.TermProxyRequestBody{Command: command})
+		}
+
+		if err != nil {
+			return 0, "", "", err
+		}
+
+		return int(res.Port), res.Ticket, res.User, nil
+	}
+}
+
+// consoleURL returns the URL of the console in the web interface, like the console
+// buttons of the web interface open.
+func consoleURL(endpoint string, target consoleTarget, protocol, command string) string {
+	q := url.Values{}
+
+	switch target.kind {
+	case "qemu":
+		q.Set("console", "kvm")
+		q.Set("vmid", strconv.Itoa(target.vmID))
+	case "lxc":
+		q.Set("console", "lxc")
+		q.Set("vmid", strconv.Itoa(target.vmID))
+	default:
+		q.Set("console", "shell")
+
+		if command != "" {
+			q.Set("cmd", command)
+		}
+	}
+
+	q.Set("node", target.node)
+
+	if protocol == protocolTerm {
+		q.Set("xtermjs", "1")
+	} else {
+		q.Set("novnc", "1")
+		q.Set("resize", "scale")
+	}
+
+	return strings.TrimRight(endpoint, "/") + "/?" + q.Encode()
+}
+
+// websocketURL returns the URL of the websocket of a VNC or terminal proxy.
+func websocketURL(endpoint string, target consoleTarget, port int, ticket string) string {
+	base := strings.TrimRight(endpoint, "/")
+	if rest, ok := strings.CutPrefix(base, "https://"); ok {
+		base = "wss://" + rest
+	} else if rest, ok := strings.CutPrefix(base, "http://"); ok {
+		base = "ws://" + rest
+	}
+
+	p := "/api2/json/nodes/" + url.PathEscape(target.node)
+	if target.kind != "" {
+		p += "/" + target.kind + "/" + strconv.Itoa(target.vmID)
+	}
+
+	q := url.Values{}
+	q.Set("port", strconv.Itoa(port))
+	q.Set("vncticket", ticket)
+
+	return base + p + "/vncwebsocket?" + q.Encode()
+}
+
+// spiceConfigFile renders the remote viewer settings of a SPICE proxy as a `.vv` file.
+func spiceConfigFile(settings map[string]any) string {
+	var sb strings.Builder
+
+	sb.WriteString("[virt-viewer]\n")
+
+	for _, k := range slices.Sorted(maps.Keys(settings)) {
+		fmt.Fprintf(&sb, "%s=%v\n", k, settings[k])
+	}
+
+	return sb.String()
+}
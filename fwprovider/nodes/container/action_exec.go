@@ -0,0 +1,188 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package container
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+)
+
+var (
+	_ action.Action              = &execAction{}
+	_ action.ActionWithConfigure = &execAction{}
+)
+
+// execModel is the data model for the container exec action.
+type execModel struct {
+	NodeName    types.String   `tfsdk:"node_name"`
+	ContainerID types.Int64    `tfsdk:"container_id"`
+	Files       []fileModel    `tfsdk:"files"`
+	Commands    []types.String `tfsdk:"commands"`
+}
+
+// execAction pushes files into a container and runs commands in it.
+type execAction struct {
+	client proxmox.Client
+}
+
+// NewExecAction creates a new container exec action.
+func NewExecAction() action.Action {
+	return &execAction{}
+}
+
+// Metadata defines the name of the action.
+func (a *execAction) Metadata(
+	_ context.Context,
+	_ action.MetadataRequest,
+	resp *action.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_container_exec"
+}
+
+// Schema defines the schema for the action.
+func (a *execAction) Schema(
+	_ context.Context,
+	_ action.SchemaRequest,
+	resp *action.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Pushes files into a running container and runs commands in it, with `pct push` and " +
+			"`pct exec` on the node over SSH.",
+		MarkdownDescription: "Pushes files into a running container and runs commands in it, with `pct push` and " +
+			"`pct exec` on the node over SSH. The files are pushed first, then the commands run in order " +
+			"until one fails. The SSH user must be allowed to run `pct`, see the provider documentation.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node the container is on.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"container_id": schema.Int64Attribute{
+				Description: "The ID of the container.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(100, 999999999),
+				},
+			},
+			"files": schema.ListNestedAttribute{
+				Description: "The files to push into the container.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"destination": schema.StringAttribute{
+							Description: "The absolute path of the file in the container.",
+							Required:    true,
+							Validators:  []validator.String{destinationValidator()},
+						},
+						"content": schema.StringAttribute{
+							Description: "The content of the file.",
+							Required:    true,
+						},
+						"user": schema.StringAttribute{
+							Description: "The owner of the file, as a user name or ID. Defaults to `root`.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"group": schema.StringAttribute{
+							Description: "The group of the file, as a group name or ID. Defaults to `root`.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"mode": schema.StringAttribute{
+							Description: "The octal mode of the file, e.g. `0644`.",
+							Optional:    true,
+							Validators:  []validator.String{modeValidator()},
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.AtLeastOneOf(path.MatchRoot("commands")),
+				},
+			},
+			"commands": schema.ListAttribute{
+				Description: "The commands to run in the container, each with `/bin/sh -c`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the action.
+func (a *execAction) Configure(
+	_ context.Context,
+	req action.ConfigureRequest,
+	resp *action.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Action)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected config.Action, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = cfg.Client
+}
+
+// Invoke pushes the files and runs the commands.
+func (a *execAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model execModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vmID := int(model.ContainerID.ValueInt64())
+
+	err := execInContainer(ctx, a.client.SSH(), model.NodeName.ValueString(), vmID, model.Files, model.Commands,
+		func(msg string) {
+			resp.SendProgress(action.InvokeProgressEvent{Message: msg})
+		})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Run Commands in Container %d", vmID), err.Error())
+	}
+}
+
+func destinationValidator() validator.String {
+	return stringvalidator.RegexMatches(absolutePathRegex, "must be an absolute path")
+}
+
+func modeValidator() validator.String {
+	return stringvalidator.RegexMatches(fileModeRegex, "must be an octal file mode, e.g. `0644`")
+}
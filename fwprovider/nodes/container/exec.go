@@ -0,0 +1,163 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package container
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+)
+
+// pushTempDir is the directory on the node that files are staged in before they are pushed
+// into the container.
+const pushTempDir = "/tmp"
+
+// pushTempPrefix is the file name prefix of the files staged on the node.
+const pushTempPrefix = "terraform-pct-push-"
+
+var (
+	// absolutePathRegex matches an absolute path in the container.
+	absolutePathRegex = regexp.MustCompile(`^/[^\x00]*[^/\x00]$`)
+	// fileModeRegex matches an octal file mode, e.g. `0644`.
+	fileModeRegex = regexp.MustCompile(`^[0-7]{3,4}$`)
+)
+
+// fileModel is a file pushed into the container.
+type fileModel struct {
+	Destination types.String `tfsdk:"destination"`
+	Content     types.String `tfsdk:"content"`
+	User        types.String `tfsdk:"user"`
+	Group       types.String `tfsdk:"group"`
+	Mode        types.String `tfsdk:"mode"`
+}
+
+// shellQuote quotes a value for a POSIX shell.
+func shellQuote(s string) string {
+	return `'` + strings.ReplaceAll(s, `'`, `'"'"'`) + `'`
+}
+
+// execCommand returns the node command that runs a shell command inside the container.
+func execCommand(vmID int, command string) string {
+	return fmt.Sprintf(`try_sudo /usr/sbin/pct exec %d -- /bin/sh -c %s`, vmID, shellQuote(command))
+}
+
+// pushCommand returns the node command that pushes a file staged on the node into the container.
+func pushCommand(vmID int, source string, f fileModel) string {
+	cmd := fmt.Sprintf(`try_sudo /usr/sbin/pct push %d %s %s`,
+		vmID, shellQuote(source), shellQuote(f.Destination.ValueString()))
+
+	if v := f.User.ValueString(); v != "" {
+		cmd += " --user " + shellQuote(v)
+	}
+
+	if v := f.Group.ValueString(); v != "" {
+		cmd += " --group " + shellQuote(v)
+	}
+
+	if v := f.Mode.ValueString(); v != "" {
+		cmd += " --perms " + shellQuote(v)
+	}
+
+	return cmd
+}
+
+// pushFile stages the file on the node and pushes it into the container. The staged file is
+// removed whether the push succeeds or not.
+func pushFile(ctx context.Context, sshClient ssh.Client, nodeName string, vmID int, f fileModel) error {
+	local, err := os.CreateTemp("", pushTempPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file: %w", err)
+	}
+
+	defer func() {
+		_ = local.Close()
+		_ = os.Remove(local.Name())
+	}()
+
+	if _, err = local.WriteString(f.Content.ValueString()); err != nil {
+		return fmt.Errorf("error writing temporary file: %w", err)
+	}
+
+	if _, err = local.Seek(0, 0); err != nil {
+		return fmt.Errorf("error rewinding temporary file: %w", err)
+	}
+
+	staged := pushTempPrefix + uuid.NewString()
+
+	err = sshClient.NodeStreamUpload(ctx, nodeName, pushTempDir, &api.FileUploadRequest{
+		FileName: staged,
+		File:     local,
+	})
+	if err != nil {
+		return fmt.Errorf("error staging file %s on node %s: %w", f.Destination.ValueString(), nodeName, err)
+	}
+
+	source := path.Join(pushTempDir, staged)
+
+	_, err = sshClient.ExecuteNodeCommands(ctx, nodeName, []string{
+		ssh.TrySudo,
+		`rc=0`,
+		pushCommand(vmID, source, f) + ` || rc=$?`,
+		`try_sudo /usr/bin/rm -f ` + shellQuote(source),
+		`exit $rc`,
+	})
+	if err != nil {
+		return fmt.Errorf("error pushing file %s into container %d: %w", f.Destination.ValueString(), vmID, err)
+	}
+
+	return nil
+}
+
+// execInContainer pushes the files into the container, then runs the commands in order and
+// stops at the first failing command. The output of each command is passed to progress.
+func execInContainer(
+	ctx context.Context,
+	sshClient ssh.Client,
+	nodeName string,
+	vmID int,
+	files []fileModel,
+	commands []types.String,
+	progress func(string),
+) error {
+	for _, f := range files {
+		progress(fmt.Sprintf("Pushing %s into container %d", f.Destination.ValueString(), vmID))
+
+		if err := pushFile(ctx, sshClient, nodeName, vmID, f); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range commands {
+		command := c.ValueString()
+
+		tflog.Debug(ctx, "running command in container", map[string]any{
+			"vm_id":   vmID,
+			"command": command,
+		})
+
+		out, err := sshClient.ExecuteNodeCommands(ctx, nodeName, []string{ssh.TrySudo, execCommand(vmID, command)})
+		if err != nil {
+			return fmt.Errorf("error running command %q in container %d: %w", command, vmID, err)
+		}
+
+		if output := strings.TrimSpace(string(out)); output != "" {
+			progress(output)
+		}
+	}
+
+	return nil
+}
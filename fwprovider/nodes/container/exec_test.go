@@ -0,0 +1,126 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package container
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// stubSSH records the commands and uploads, and fails the commands containing failOn.
+type stubSSH struct {
+	commands [][]string
+	uploads  map[string]string
+	failOn   string
+}
+
+func (s *stubSSH) Username() string {
+	return "root"
+}
+
+func (s *stubSSH) ExecuteNodeCommands(_ context.Context, _ string, commands []string) ([]byte, error) {
+	s.commands = append(s.commands, commands)
+
+	if s.failOn != "" && strings.Contains(strings.Join(commands, "; "), s.failOn) {
+		return nil, errors.New("exit status 1")
+	}
+
+	return []byte("ok\n"), nil
+}
+
+func (s *stubSSH) NodeUpload(ctx context.Context, nodeName, dir string, req *api.FileUploadRequest) error {
+	return s.NodeStreamUpload(ctx, nodeName, dir, req)
+}
+
+func (s *stubSSH) NodeStreamUpload(_ context.Context, _, dir string, req *api.FileUploadRequest) error {
+	content, err := io.ReadAll(req.File)
+	if err != nil {
+		return err
+	}
+
+	if s.uploads == nil {
+		s.uploads = map[string]string{}
+	}
+
+	s.uploads[dir+"/"+req.FileName] = string(content)
+
+	return nil
+}
+
+func TestExecCommand(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		`try_sudo /usr/sbin/pct exec 100 -- /bin/sh -c 'echo '"'"'hello'"'"' > /tmp/x'`,
+		execCommand(100, `echo 'hello' > /tmp/x`),
+	)
+}
+
+func TestExecInContainer(t *testing.T) {
+	t.Parallel()
+
+	s := &stubSSH{}
+
+	var progress []string
+
+	err := execInContainer(t.Context(), s, "pve", 100,
+		[]fileModel{{
+			Destination: types.StringValue("/etc/motd"),
+			Content:     types.StringValue("hello\n"),
+			User:        types.StringValue("www-data"),
+			Mode:        types.StringValue("0644"),
+		}},
+		[]types.String{types.StringValue("cat /etc/motd")},
+		func(msg string) { progress = append(progress, msg) },
+	)
+	require.NoError(t, err)
+
+	require.Len(t, s.uploads, 1)
+
+	var staged string
+
+	for name, content := range s.uploads {
+		staged = name
+
+		assert.Equal(t, "hello\n", content)
+	}
+
+	assert.True(t, strings.HasPrefix(staged, pushTempDir+"/"+pushTempPrefix))
+
+	require.Len(t, s.commands, 2)
+
+	push := strings.Join(s.commands[0], "; ")
+	assert.Contains(t, push,
+		`try_sudo /usr/sbin/pct push 100 '`+staged+`' '/etc/motd' --user 'www-data' --perms '0644' || rc=$?`)
+	assert.Contains(t, push, `try_sudo /usr/bin/rm -f '`+staged+`'`)
+	assert.True(t, strings.HasSuffix(push, `exit $rc`))
+
+	assert.Contains(t, s.commands[1], `try_sudo /usr/sbin/pct exec 100 -- /bin/sh -c 'cat /etc/motd'`)
+	assert.Equal(t, []string{"Pushing /etc/motd into container 100", "ok"}, progress)
+}
+
+func TestExecInContainerStopsOnFailure(t *testing.T) {
+	t.Parallel()
+
+	s := &stubSSH{failOn: "false"}
+
+	err := execInContainer(t.Context(), s, "pve", 100, nil,
+		[]types.String{types.StringValue("true"), types.StringValue("false"), types.StringValue("echo never")},
+		func(string) {},
+	)
+	require.ErrorContains(t, err, `error running command "false" in container 100`)
+	assert.Len(t, s.commands, 2)
+}
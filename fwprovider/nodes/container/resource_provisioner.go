@@ -0,0 +1,249 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package container
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+)
+
+var (
+	_ resource.Resource              = &provisionerResource{}
+	_ resource.ResourceWithConfigure = &provisionerResource{}
+)
+
+// provisionerModel is the data model for the container provisioner resource.
+type provisionerModel struct {
+	ID          types.String   `tfsdk:"id"`
+	NodeName    types.String   `tfsdk:"node_name"`
+	ContainerID types.Int64    `tfsdk:"container_id"`
+	Files       []fileModel    `tfsdk:"files"`
+	Commands    []types.String `tfsdk:"commands"`
+	Triggers    types.Map      `tfsdk:"triggers"`
+}
+
+// provisionerResource pushes files into a container and runs commands in it once, when it is
+// created. Any change of its arguments replaces it, which runs them again.
+type provisionerResource struct {
+	client proxmox.Client
+}
+
+// NewProvisionerResource creates a new container provisioner resource.
+func NewProvisionerResource() resource.Resource {
+	return &provisionerResource{}
+}
+
+// Metadata defines the name of the resource.
+func (r *provisionerResource) Metadata(
+	_ context.Context,
+	_ resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_container_provisioner"
+}
+
+// Schema defines the schema for the resource.
+func (r *provisionerResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	replace := []planmodifier.List{listplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Pushes files into a running container and runs commands in it once, when the resource " +
+			"is created.",
+		MarkdownDescription: "Pushes files into a running container and runs commands in it once, when the " +
+			"resource is created, with `pct push` and `pct exec` on the node over SSH. Any change of the " +
+			"arguments, including `triggers`, replaces the resource and runs them again. Destroying the " +
+			"resource only removes it from the state. The SSH user must be allowed to run `pct`, see the " +
+			"provider documentation.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ResourceID(),
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node the container is on.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"container_id": schema.Int64Attribute{
+				Description: "The ID of the container.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(100, 999999999),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"files": schema.ListNestedAttribute{
+				Description: "The files to push into the container.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"destination": schema.StringAttribute{
+							Description: "The absolute path of the file in the container.",
+							Required:    true,
+							Validators:  []validator.String{destinationValidator()},
+						},
+						"content": schema.StringAttribute{
+							Description: "The content of the file.",
+							Required:    true,
+						},
+						"user": schema.StringAttribute{
+							Description: "The owner of the file, as a user name or ID. Defaults to `root`.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"group": schema.StringAttribute{
+							Description: "The group of the file, as a group name or ID. Defaults to `root`.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"mode": schema.StringAttribute{
+							Description: "The octal mode of the file, e.g. `0644`.",
+							Optional:    true,
+							Validators:  []validator.String{modeValidator()},
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.AtLeastOneOf(path.MatchRoot("commands")),
+				},
+				PlanModifiers: replace,
+			},
+			"commands": schema.ListAttribute{
+				Description: "The commands to run in the container, each with `/bin/sh -c`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				PlanModifiers: replace,
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that replace the resource, and so run the commands again, " +
+					"when they change.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the resource.
+func (r *provisionerResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// Create pushes the files and runs the commands.
+func (r *provisionerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan provisionerModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vmID := int(plan.ContainerID.ValueInt64())
+
+	err := execInContainer(ctx, r.client.SSH(), plan.NodeName.ValueString(), vmID, plan.Files, plan.Commands,
+		func(msg string) {
+			tflog.Info(ctx, msg, map[string]any{"vm_id": vmID})
+		})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Run Commands in Container %d", vmID), err.Error())
+
+		return
+	}
+
+	plan.ID = types.StringValue(uuid.NewString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read keeps the state as is, as there is nothing to read back from the container.
+func (r *provisionerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state provisionerModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update is never called with a change, as all arguments replace the resource.
+func (r *provisionerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan provisionerModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the resource from the state only.
+func (r *provisionerResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
@@ -0,0 +1,157 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package power
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var (
+	_ action.Action              = &migrateAllAction{}
+	_ action.ActionWithConfigure = &migrateAllAction{}
+)
+
+// migrateAllModel is the data model for the migrate all action.
+type migrateAllModel struct {
+	NodeName       types.String  `tfsdk:"node_name"`
+	VMIDs          []types.Int64 `tfsdk:"vmids"`
+	TargetNode     types.String  `tfsdk:"target_node"`
+	MaxWorkers     types.Int64   `tfsdk:"max_workers"`
+	WithLocalDisks types.Bool    `tfsdk:"with_local_disks"`
+}
+
+// migrateAllAction migrates the guests of a node to another node.
+type migrateAllAction struct {
+	client proxmox.Client
+}
+
+// NewMigrateAllAction creates a new migrate all action.
+func NewMigrateAllAction() action.Action {
+	return &migrateAllAction{}
+}
+
+// Metadata defines the name of the action.
+func (a *migrateAllAction) Metadata(
+	_ context.Context,
+	_ action.MetadataRequest,
+	resp *action.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_node_migrate_all"
+}
+
+// Schema defines the schema for the action.
+func (a *migrateAllAction) Schema(
+	_ context.Context,
+	_ action.SchemaRequest,
+	resp *action.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Migrates the guests of a node to another node, e.g. to drain it before a maintenance, " +
+			"and waits for the migrations to finish. Running guests are migrated online.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"vmids": vmIDsAttribute(),
+			"target_node": schema.StringAttribute{
+				Description: "The name of the node to migrate the guests to.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"max_workers": schema.Int64Attribute{
+				Description: "The maximum number of parallel migrations. Defaults to the `max_workers` " +
+					"datacenter option.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"with_local_disks": schema.BoolAttribute{
+				Description: "Migrate the guests with local disks, by copying the disks to the target node. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// Configure sets the client for the action.
+func (a *migrateAllAction) Configure(
+	_ context.Context,
+	req action.ConfigureRequest,
+	resp *action.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Action)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected config.Action, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = cfg.Client
+}
+
+// Invoke migrates the guests and waits for the migrations to finish.
+func (a *migrateAllAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model migrateAllModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeName := model.NodeName.ValueString()
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Migrating guests from node %s to node %s", nodeName, model.TargetNode.ValueString()),
+	})
+
+	result := a.client.Node(nodeName).MigrateAllGuests(ctx, model.toAPI())
+	if result.AddDiags(&resp.Diagnostics, fmt.Sprintf("Unable to Migrate Guests from Node %s", nodeName)) {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Migrated guests from node %s to node %s", nodeName, model.TargetNode.ValueString()),
+	})
+}
+
+func (m *migrateAllModel) toAPI() *nodes.MigrateAllRequestBody {
+	return &nodes.MigrateAllRequestBody{
+		Target:         m.TargetNode.ValueString(),
+		MaxWorkers:     m.MaxWorkers.ValueInt64Pointer(),
+		VMs:            vmIDsValue(m.VMIDs),
+		WithLocalDisks: (*proxmoxtypes.CustomBool)(m.WithLocalDisks.ValueBoolPointer()),
+	}
+}
@@ -0,0 +1,211 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package power
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+)
+
+const (
+	// commandWakeOnLAN powers on the node with a Wake-on-LAN packet.
+	commandWakeOnLAN = "wakeonlan"
+
+	// defaultPowerTimeout is the default time to wait for the node to reboot, shut down or boot.
+	defaultPowerTimeout = 10 * time.Minute
+)
+
+var (
+	_ action.Action              = &powerAction{}
+	_ action.ActionWithConfigure = &powerAction{}
+)
+
+// powerModel is the data model for the node power action.
+type powerModel struct {
+	NodeName types.String `tfsdk:"node_name"`
+	Command  types.String `tfsdk:"command"`
+	Wait     types.Bool   `tfsdk:"wait"`
+	Timeout  types.String `tfsdk:"timeout"`
+}
+
+// powerAction reboots, shuts down or wakes a node.
+type powerAction struct {
+	client proxmox.Client
+}
+
+// NewPowerAction creates a new node power action.
+func NewPowerAction() action.Action {
+	return &powerAction{}
+}
+
+// Metadata defines the name of the action.
+func (a *powerAction) Metadata(
+	_ context.Context,
+	_ action.MetadataRequest,
+	resp *action.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_node_power"
+}
+
+// Schema defines the schema for the action.
+func (a *powerAction) Schema(
+	_ context.Context,
+	_ action.SchemaRequest,
+	resp *action.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Reboots, shuts down or wakes a node, and waits for it to be back online or offline.",
+		MarkdownDescription: "Reboots, shuts down or wakes a node, and waits for it to be back online or offline. " +
+			"The guests of the node are shut down with it, use `proxmox_node_migrate_all` first to keep them " +
+			"running. Waking a node with Wake-on-LAN requires its MAC address in the `wakeonlan` option of the " +
+			"node configuration, the packet is sent by the node that serves the API.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"command": schema.StringAttribute{
+				Description: "The power command, one of `reboot`, `shutdown` or `wakeonlan`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(nodes.PowerCommandReboot, nodes.PowerCommandShutdown, commandWakeOnLAN),
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Whether to wait until the node has rebooted, is offline after a shutdown, or is " +
+					"online after a wake-up. Defaults to `true`.",
+				Optional: true,
+			},
+			"timeout": schema.StringAttribute{
+				Description: "The maximum time to wait for the node, e.g. `15m`. Defaults to `10m`.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.IsValidDuration(),
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the action.
+func (a *powerAction) Configure(
+	_ context.Context,
+	req action.ConfigureRequest,
+	resp *action.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Action)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected config.Action, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = cfg.Client
+}
+
+// Invoke runs the power command and waits for the node.
+func (a *powerAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model powerModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := defaultPowerTimeout
+	if !model.Timeout.IsNull() {
+		// the value is validated by the schema
+		timeout, _ = time.ParseDuration(model.Timeout.ValueString())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	nodeName := model.NodeName.ValueString()
+	command := model.Command.ValueString()
+	node := a.client.Node(nodeName)
+	summary := fmt.Sprintf("Unable to Run %s on Node %s", command, nodeName)
+
+	since := time.Now()
+
+	if command == commandWakeOnLAN {
+		mac, err := node.WakeOnLAN(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(summary, err.Error())
+
+			return
+		}
+
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Sent Wake-on-LAN packet to node %s (%s)", nodeName, mac),
+		})
+	} else {
+		if err := node.ExecutePowerCommand(ctx, command); err != nil {
+			resp.Diagnostics.AddError(summary, err.Error())
+
+			return
+		}
+
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Sent %s command to node %s", command, nodeName),
+		})
+	}
+
+	if !model.Wait.IsNull() && !model.Wait.ValueBool() {
+		return
+	}
+
+	var (
+		err   error
+		state string
+	)
+
+	switch command {
+	case nodes.PowerCommandReboot:
+		state = "back online"
+		err = node.WaitForReboot(ctx, since)
+	case nodes.PowerCommandShutdown:
+		state = nodes.NodeStatusOffline
+		err = node.WaitForStatus(ctx, state)
+	default:
+		state = nodes.NodeStatusOnline
+		err = node.WaitForStatus(ctx, state)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(summary, err.Error())
+
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Node %s is %s", nodeName, state),
+	})
+}
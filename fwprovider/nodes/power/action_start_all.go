@@ -0,0 +1,136 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package power
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var (
+	_ action.Action              = &startAllAction{}
+	_ action.ActionWithConfigure = &startAllAction{}
+)
+
+// startAllModel is the data model for the start all action.
+type startAllModel struct {
+	NodeName types.String  `tfsdk:"node_name"`
+	VMIDs    []types.Int64 `tfsdk:"vmids"`
+	Force    types.Bool    `tfsdk:"force"`
+}
+
+// startAllAction starts the guests of a node.
+type startAllAction struct {
+	client proxmox.Client
+}
+
+// NewStartAllAction creates a new start all action.
+func NewStartAllAction() action.Action {
+	return &startAllAction{}
+}
+
+// Metadata defines the name of the action.
+func (a *startAllAction) Metadata(
+	_ context.Context,
+	_ action.MetadataRequest,
+	resp *action.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_node_start_all"
+}
+
+// Schema defines the schema for the action.
+func (a *startAllAction) Schema(
+	_ context.Context,
+	_ action.SchemaRequest,
+	resp *action.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Starts the guests of a node that are started on boot, in their startup order, and waits " +
+			"for them to be started, e.g. after powering the node on.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"vmids": vmIDsAttribute(),
+			"force": schema.BoolAttribute{
+				Description: "Start the guests regardless of their `on_boot` setting. Defaults to `false`.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// Configure sets the client for the action.
+func (a *startAllAction) Configure(
+	_ context.Context,
+	req action.ConfigureRequest,
+	resp *action.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Action)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected config.Action, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = cfg.Client
+}
+
+// Invoke starts the guests and waits for them to be started.
+func (a *startAllAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model startAllModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeName := model.NodeName.ValueString()
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Starting guests on node %s", nodeName),
+	})
+
+	result := a.client.Node(nodeName).StartAllGuests(ctx, model.toAPI())
+	if result.AddDiags(&resp.Diagnostics, fmt.Sprintf("Unable to Start Guests on Node %s", nodeName)) {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Started guests on node %s", nodeName),
+	})
+}
+
+func (m *startAllModel) toAPI() *nodes.StartAllRequestBody {
+	return &nodes.StartAllRequestBody{
+		Force: (*proxmoxtypes.CustomBool)(m.Force.ValueBoolPointer()),
+		VMs:   vmIDsValue(m.VMIDs),
+	}
+}
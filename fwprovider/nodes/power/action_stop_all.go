@@ -0,0 +1,146 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package power
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var (
+	_ action.Action              = &stopAllAction{}
+	_ action.ActionWithConfigure = &stopAllAction{}
+)
+
+// stopAllModel is the data model for the stop all action.
+type stopAllModel struct {
+	NodeName        types.String  `tfsdk:"node_name"`
+	VMIDs           []types.Int64 `tfsdk:"vmids"`
+	ForceStop       types.Bool    `tfsdk:"force_stop"`
+	ShutdownTimeout types.Int64   `tfsdk:"shutdown_timeout"`
+}
+
+// stopAllAction stops the guests of a node.
+type stopAllAction struct {
+	client proxmox.Client
+}
+
+// NewStopAllAction creates a new stop all action.
+func NewStopAllAction() action.Action {
+	return &stopAllAction{}
+}
+
+// Metadata defines the name of the action.
+func (a *stopAllAction) Metadata(
+	_ context.Context,
+	_ action.MetadataRequest,
+	resp *action.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_node_stop_all"
+}
+
+// Schema defines the schema for the action.
+func (a *stopAllAction) Schema(
+	_ context.Context,
+	_ action.SchemaRequest,
+	resp *action.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Shuts down the running guests of a node, in their reverse startup order, and waits " +
+			"for them to be stopped, e.g. before powering the node off.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"vmids": vmIDsAttribute(),
+			"force_stop": schema.BoolAttribute{
+				Description: "Hard stop the guests that did not shut down within the timeout. Defaults to `true`.",
+				Optional:    true,
+			},
+			"shutdown_timeout": schema.Int64Attribute{
+				Description: "The time in seconds the guests are given to shut down. Defaults to `180`.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 7200),
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the action.
+func (a *stopAllAction) Configure(
+	_ context.Context,
+	req action.ConfigureRequest,
+	resp *action.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Action)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected config.Action, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = cfg.Client
+}
+
+// Invoke stops the guests and waits for them to be stopped.
+func (a *stopAllAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model stopAllModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeName := model.NodeName.ValueString()
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Stopping guests on node %s", nodeName),
+	})
+
+	result := a.client.Node(nodeName).StopAllGuests(ctx, model.toAPI())
+	if result.AddDiags(&resp.Diagnostics, fmt.Sprintf("Unable to Stop Guests on Node %s", nodeName)) {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Stopped guests on node %s", nodeName),
+	})
+}
+
+func (m *stopAllModel) toAPI() *nodes.StopAllRequestBody {
+	return &nodes.StopAllRequestBody{
+		ForceStop: (*proxmoxtypes.CustomBool)(m.ForceStop.ValueBoolPointer()),
+		Timeout:   m.ShutdownTimeout.ValueInt64Pointer(),
+		VMs:       vmIDsValue(m.VMIDs),
+	}
+}
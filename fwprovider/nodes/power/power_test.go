@@ -0,0 +1,48 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package power
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVMIDsValue(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, vmIDsValue(nil))
+	assert.Equal(t, "100,101", *vmIDsValue([]types.Int64{types.Int64Value(100), types.Int64Value(101)}))
+}
+
+func TestBulkModelsToAPI(t *testing.T) {
+	t.Parallel()
+
+	start := (&startAllModel{NodeName: types.StringValue("pve"), Force: types.BoolNull()}).toAPI()
+	assert.Nil(t, start.Force)
+	assert.Nil(t, start.VMs)
+
+	stop := (&stopAllModel{
+		VMIDs:           []types.Int64{types.Int64Value(100)},
+		ForceStop:       types.BoolValue(false),
+		ShutdownTimeout: types.Int64Value(60),
+	}).toAPI()
+	assert.False(t, bool(*stop.ForceStop))
+	assert.Equal(t, int64(60), *stop.Timeout)
+	assert.Equal(t, "100", *stop.VMs)
+
+	migrate := (&migrateAllModel{
+		TargetNode:     types.StringValue("pve2"),
+		MaxWorkers:     types.Int64Value(2),
+		WithLocalDisks: types.BoolValue(true),
+	}).toAPI()
+	assert.Equal(t, "pve2", migrate.Target)
+	assert.Equal(t, int64(2), *migrate.MaxWorkers)
+	assert.True(t, bool(*migrate.WithLocalDisks))
+	assert.Nil(t, migrate.VMs)
+}
@@ -0,0 +1,47 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package power
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// vmIDsAttribute returns the attribute that restricts a bulk action to some guests of the node.
+func vmIDsAttribute() schema.ListAttribute {
+	return schema.ListAttribute{
+		Description: "The IDs of the VMs and containers to act on. Defaults to all guests of the node.",
+		ElementType: types.Int64Type,
+		Optional:    true,
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+			listvalidator.UniqueValues(),
+			listvalidator.ValueInt64sAre(int64validator.Between(100, 999999999)),
+		},
+	}
+}
+
+// vmIDsValue returns the guest IDs as the comma-separated list of the bulk actions, or nil for
+// all guests.
+func vmIDsValue(vmIDs []types.Int64) *string {
+	if len(vmIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(vmIDs))
+	for _, id := range vmIDs {
+		ids = append(ids, strconv.FormatInt(id.ValueInt64(), 10))
+	}
+
+	return new(strings.Join(ids, ","))
+}
@@ -0,0 +1,178 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/services"
+)
+
+var (
+	_ datasource.DataSource              = &servicesDataSource{}
+	_ datasource.DataSourceWithConfigure = &servicesDataSource{}
+)
+
+// servicesModel is the data model for the node services data source.
+type servicesModel struct {
+	NodeName types.String        `tfsdk:"node_name"`
+	Services []serviceStateModel `tfsdk:"services"`
+}
+
+// serviceStateModel is the state of a service.
+type serviceStateModel struct {
+	Service     types.String `tfsdk:"service"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	State       types.String `tfsdk:"state"`
+	ActiveState types.String `tfsdk:"active_state"`
+	UnitState   types.String `tfsdk:"unit_state"`
+}
+
+// servicesDataSource is the implementation of the node services data source.
+type servicesDataSource struct {
+	client proxmox.Client
+}
+
+// NewServicesDataSource creates a new node services data source.
+func NewServicesDataSource() datasource.DataSource {
+	return &servicesDataSource{}
+}
+
+// Metadata defines the name of the data source.
+func (d *servicesDataSource) Metadata(
+	_ context.Context,
+	_ datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_node_services"
+}
+
+// Schema defines the schema for the data source.
+func (d *servicesDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the state of the services of a node that can be managed with the " +
+			"Proxmox VE API.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"services": schema.ListNestedAttribute{
+				Description: "The services, sorted by ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"service": schema.StringAttribute{
+							Description: "The ID of the service in the Proxmox VE API.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the systemd unit of the service.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the service.",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "The state of the service, e.g. `running` or `stopped`.",
+							Computed:    true,
+						},
+						"active_state": schema.StringAttribute{
+							Description: "The systemd active state of the service, e.g. `active` or `failed`.",
+							Computed:    true,
+						},
+						"unit_state": schema.StringAttribute{
+							Description: "The systemd unit file state of the service, e.g. `enabled` or `disabled`.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the data source.
+func (d *servicesDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read fetches the services of the node from the Proxmox API.
+func (d *servicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model servicesModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, err := d.client.Node(model.NodeName.ValueString()).Services().ListServices(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Services", err.Error())
+
+		return
+	}
+
+	model.Services = newServiceStateModels(list)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// newServiceStateModels builds the service states of the data source.
+func newServiceStateModels(list []*services.StateResponseData) []serviceStateModel {
+	res := make([]serviceStateModel, 0, len(list))
+
+	for _, s := range list {
+		res = append(res, serviceStateModel{
+			Service:     types.StringValue(s.Service),
+			Name:        types.StringPointerValue(s.Name),
+			Description: types.StringPointerValue(s.Description),
+			State:       types.StringPointerValue(s.State),
+			ActiveState: types.StringPointerValue(s.ActiveState),
+			UnitState:   types.StringPointerValue(s.UnitState),
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,391 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/services"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+)
+
+var (
+	_ resource.Resource                = &serviceResource{}
+	_ resource.ResourceWithConfigure   = &serviceResource{}
+	_ resource.ResourceWithImportState = &serviceResource{}
+)
+
+// serviceModel is the data model for the node service resource.
+type serviceModel struct {
+	ID          types.String `tfsdk:"id"`
+	NodeName    types.String `tfsdk:"node_name"`
+	Service     types.String `tfsdk:"service"`
+	State       types.String `tfsdk:"state"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	Triggers    types.Map    `tfsdk:"triggers"`
+	Description types.String `tfsdk:"description"`
+	ActiveState types.String `tfsdk:"active_state"`
+	UnitState   types.String `tfsdk:"unit_state"`
+}
+
+// serviceResource keeps a service of a node in the desired state.
+type serviceResource struct {
+	client proxmox.Client
+}
+
+// NewServiceResource creates a new node service resource.
+func NewServiceResource() resource.Resource {
+	return &serviceResource{}
+}
+
+// Metadata defines the name of the resource.
+func (r *serviceResource) Metadata(
+	_ context.Context,
+	_ resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_node_service"
+}
+
+// Schema defines the schema for the resource.
+func (r *serviceResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Keeps a service of a node running or stopped, and restarts it when the triggers change.",
+		MarkdownDescription: "Keeps a service of a node running or stopped, and restarts it when the triggers " +
+			"change, e.g. `pveproxy` after the deployment of a certificate. Only the services known to the " +
+			"Proxmox VE API can be managed, see the `proxmox_node_services` data source. Destroying the " +
+			"resource leaves the service in its current state. Enabling and disabling the service runs " +
+			"`systemctl` on the node over SSH, the SSH user must be allowed to run it, see the provider " +
+			"documentation.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ResourceID(),
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service": schema.StringAttribute{
+				Description: "The ID of the service in the Proxmox VE API, e.g. `pveproxy`, `chrony` or `sshd`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z0-9][a-z0-9\-]*$`), "must be a service ID"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"state": schema.StringAttribute{
+				Description: "The desired state of the service, `running` or `stopped`. Defaults to `running`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(services.StateRunning),
+				Validators: []validator.String{
+					stringvalidator.OneOf(services.StateRunning, services.StateStopped),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the service is started on boot. Not managed if not set.",
+				Optional:    true,
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that restart the running service when they change.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the service.",
+				Computed:    true,
+			},
+			"active_state": schema.StringAttribute{
+				Description: "The systemd active state of the service, e.g. `active` or `failed`.",
+				Computed:    true,
+			},
+			"unit_state": schema.StringAttribute{
+				Description: "The systemd unit file state of the service, e.g. `enabled` or `disabled`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure sets the client for the resource.
+func (r *serviceResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// Create brings the service into the desired state.
+func (r *serviceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan serviceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.NodeName.ValueString() + "/" + plan.Service.ValueString())
+
+	r.apply(ctx, &plan, false, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the state of the service.
+func (r *serviceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state serviceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, err := r.services(state.NodeName.ValueString()).GetServiceState(ctx, state.Service.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Read Service %s", state.Service.ValueString()), err.Error())
+
+		return
+	}
+
+	state.fromAPI(data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update brings the service into the desired state, and restarts it when the triggers changed.
+func (r *serviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state serviceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, !plan.Triggers.Equal(state.Triggers), &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete only removes the service from the state, as stopping e.g. `pveproxy` would take the
+// node down.
+func (r *serviceResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// ImportState imports a service by `<node_name>/<service>`.
+func (r *serviceResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	nodeName, service, ok := strings.Cut(req.ID, "/")
+	if !ok || nodeName == "" || service == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <node_name>/<service>. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	data, err := r.services(nodeName).GetServiceState(ctx, service)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Import Service %s", service), err.Error())
+
+		return
+	}
+
+	state := serviceModel{
+		ID:       types.StringValue(req.ID),
+		NodeName: types.StringValue(nodeName),
+		Service:  types.StringValue(service),
+		Enabled:  types.BoolNull(),
+		Triggers: types.MapNull(types.StringType),
+	}
+
+	state.fromAPI(data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *serviceResource) services(nodeName string) *services.Client {
+	return r.client.Node(nodeName).Services()
+}
+
+// apply enables or disables the service, starts or stops it, and restarts it if requested and
+// it was already running. The model is then updated with the state of the service.
+func (r *serviceResource) apply(ctx context.Context, m *serviceModel, restart bool, diags *diag.Diagnostics) {
+	nodeName := m.NodeName.ValueString()
+	service := m.Service.ValueString()
+	client := r.services(nodeName)
+	summary := fmt.Sprintf("Unable to Update Service %s on Node %s", service, nodeName)
+
+	data, err := client.GetServiceState(ctx, service)
+	if err != nil {
+		diags.AddError(summary, err.Error())
+
+		return
+	}
+
+	if !m.Enabled.IsNull() {
+		enabled := data.UnitState != nil && *data.UnitState == services.UnitStateEnabled
+		if enabled != m.Enabled.ValueBool() {
+			if err = r.setEnabled(ctx, nodeName, unitName(data), m.Enabled.ValueBool()); err != nil {
+				diags.AddError(summary, err.Error())
+
+				return
+			}
+		}
+	}
+
+	running := data.State != nil && *data.State == services.StateRunning
+
+	var command string
+
+	switch {
+	case m.State.ValueString() == services.StateStopped && running:
+		command = services.CommandStop
+	case m.State.ValueString() == services.StateRunning && !running:
+		command = services.CommandStart
+	case m.State.ValueString() == services.StateRunning && restart:
+		command = services.CommandRestart
+	}
+
+	if command != "" {
+		tflog.Info(ctx, "running service command", map[string]any{
+			"node_name": nodeName,
+			"service":   service,
+			"command":   command,
+		})
+
+		if client.ExecuteServiceCommand(ctx, service, command).AddDiags(diags, summary) {
+			return
+		}
+	}
+
+	data, err = client.GetServiceState(ctx, service)
+	if err != nil {
+		diags.AddError(summary, err.Error())
+
+		return
+	}
+
+	m.fromAPI(data)
+
+	if data.State == nil || *data.State != m.State.ValueString() {
+		diags.AddError(summary, fmt.Sprintf("service %s is %s, expected %s",
+			service, describe(data.State), m.State.ValueString()))
+	}
+}
+
+// setEnabled enables or disables the systemd unit of the service.
+func (r *serviceResource) setEnabled(ctx context.Context, nodeName, unit string, enable bool) error {
+	action := "disable"
+	if enable {
+		action = "enable"
+	}
+
+	_, err := r.client.SSH().ExecuteNodeCommands(ctx, nodeName, []string{
+		ssh.TrySudo,
+		systemctlCommand(action, unit),
+	})
+	if err != nil {
+		return fmt.Errorf("error running systemctl on node %s: %w", nodeName, err)
+	}
+
+	return nil
+}
+
+// systemctlCommand returns the node command that enables or disables a unit.
+func systemctlCommand(action, unit string) string {
+	return fmt.Sprintf(`try_sudo /usr/bin/systemctl %s %s`, action, unit)
+}
+
+// unitName returns the systemd unit of the service, which differs from the service ID for some
+// services, e.g. `ssh` for `sshd`.
+func unitName(data *services.StateResponseData) string {
+	if data.Name != nil && *data.Name != "" {
+		return *data.Name
+	}
+
+	return data.Service
+}
+
+// fromAPI sets the state of the service. The desired enablement is only updated when it is
+// managed, so that a drift shows in the plan.
+func (m *serviceModel) fromAPI(data *services.StateResponseData) {
+	m.State = types.StringPointerValue(data.State)
+	m.Description = types.StringPointerValue(data.Description)
+	m.ActiveState = types.StringPointerValue(data.ActiveState)
+	m.UnitState = types.StringPointerValue(data.UnitState)
+
+	if !m.Enabled.IsNull() {
+		m.Enabled = types.BoolValue(data.UnitState != nil && *data.UnitState == services.UnitStateEnabled)
+	}
+}
+
+// describe returns the service state for the error messages.
+func describe(s *string) string {
+	if s == nil {
+		return "unknown"
+	}
+
+	return *s
+}
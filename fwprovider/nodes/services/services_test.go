@@ -0,0 +1,100 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package services
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test/faketest"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/services"
+)
+
+func TestApplyServiceState(t *testing.T) {
+	t.Parallel()
+
+	s, client := faketest.NewClient(t)
+	r := &serviceResource{client: client}
+
+	s.SetServiceState(fake.DefaultNode, "postfix", false, true)
+
+	m := serviceModel{
+		NodeName: types.StringValue(fake.DefaultNode),
+		Service:  types.StringValue("postfix"),
+		State:    types.StringValue(services.StateRunning),
+		Enabled:  types.BoolNull(),
+	}
+
+	var diags diag.Diagnostics
+
+	r.apply(t.Context(), &m, false, &diags)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, services.StateRunning, m.State.ValueString())
+	assert.Equal(t, "active", m.ActiveState.ValueString())
+	assert.Equal(t, "Postfix Mail Transport Agent", m.Description.ValueString())
+	assert.True(t, m.Enabled.IsNull(), "the enablement is not managed")
+
+	r.apply(t.Context(), &m, true, &diags)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, services.StateRunning, m.State.ValueString())
+
+	m.State = types.StringValue(services.StateStopped)
+
+	r.apply(t.Context(), &m, true, &diags)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, services.StateStopped, m.State.ValueString())
+	assert.Equal(t, "inactive", m.ActiveState.ValueString())
+}
+
+func TestServiceFromAPI(t *testing.T) {
+	t.Parallel()
+
+	data := &services.StateResponseData{
+		Service:   "sshd",
+		Name:      new("ssh"),
+		State:     new(services.StateRunning),
+		UnitState: new(services.UnitStateDisabled),
+	}
+
+	m := serviceModel{Enabled: types.BoolValue(true)}
+	m.fromAPI(data)
+	assert.False(t, m.Enabled.ValueBool(), "a managed enablement shows the drift")
+
+	m = serviceModel{Enabled: types.BoolNull()}
+	m.fromAPI(data)
+	assert.True(t, m.Enabled.IsNull())
+
+	assert.Equal(t, "ssh", unitName(data))
+	assert.Equal(t, "try_sudo /usr/bin/systemctl enable ssh", systemctlCommand("enable", unitName(data)))
+}
+
+func TestServiceStateModels(t *testing.T) {
+	t.Parallel()
+
+	s, client := faketest.NewClient(t)
+
+	s.SetServiceState(fake.DefaultNode, "chrony", false, false)
+
+	list, err := client.Node(fake.DefaultNode).Services().ListServices(t.Context())
+	require.NoError(t, err)
+
+	models := newServiceStateModels(list)
+	require.Len(t, models, len(list))
+	assert.Equal(t, serviceStateModel{
+		Service:     types.StringValue("chrony"),
+		Name:        types.StringValue("chrony"),
+		Description: types.StringValue("chrony, an NTP client/server"),
+		State:       types.StringValue(services.StateStopped),
+		ActiveState: types.StringValue("inactive"),
+		UnitState:   types.StringValue(services.UnitStateDisabled),
+	}, models[0])
+}
@@ -0,0 +1,367 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package tasks
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+)
+
+// defaultLimit is the default maximum number of listed tasks, which is also the default of the API.
+const defaultLimit = 50
+
+var (
+	_ datasource.DataSource              = &tasksDataSource{}
+	_ datasource.DataSourceWithConfigure = &tasksDataSource{}
+)
+
+// tasksModel is the data model for the tasks data source.
+type tasksModel struct {
+	NodeName types.String `tfsdk:"node_name"`
+	Type     types.String `tfsdk:"type"`
+	User     types.String `tfsdk:"user"`
+	VMID     types.Int64  `tfsdk:"vm_id"`
+	Status   types.String `tfsdk:"status"`
+	Since    types.String `tfsdk:"since"`
+	Until    types.String `tfsdk:"until"`
+	Limit    types.Int64  `tfsdk:"limit"`
+	Tasks    []taskEntry  `tfsdk:"tasks"`
+}
+
+// taskEntry represents a single task in the data source output.
+type taskEntry struct {
+	UPID      types.String `tfsdk:"upid"`
+	NodeName  types.String `tfsdk:"node_name"`
+	Type      types.String `tfsdk:"type"`
+	ID        types.String `tfsdk:"id"`
+	User      types.String `tfsdk:"user"`
+	Status    types.String `tfsdk:"status"`
+	StartTime types.String `tfsdk:"start_time"`
+	EndTime   types.String `tfsdk:"end_time"`
+}
+
+// tasksDataSource is the implementation of the tasks data source.
+type tasksDataSource struct {
+	client proxmox.Client
+}
+
+// NewDataSource creates a new tasks data source.
+func NewDataSource() datasource.DataSource {
+	return &tasksDataSource{}
+}
+
+// Metadata defines the name of the data source.
+func (d *tasksDataSource) Metadata(
+	_ context.Context,
+	_ datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_tasks"
+}
+
+// Schema defines the schema for the tasks data source.
+func (d *tasksDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	rfc3339 := validators.NewParseValidator(func(s string) (time.Time, error) {
+		return time.Parse(time.RFC3339, s)
+	}, "must be a valid RFC3339 date")
+
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the tasks of a node or of the whole cluster with their status, e.g. to find out why a clone " +
+			"or a backup failed.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "Only return the tasks of this node. Defaults to the tasks of all online nodes.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Only return the tasks of this type, e.g. `qmclone` or `vzdump`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"user": schema.StringAttribute{
+				Description: "Only return the tasks of the users that contain this string, e.g. `root@pam`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				Description: "Only return the tasks of the VM or container with this ID.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(100, 999999999),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Only return the tasks with this status, one of `running`, `ok`, `warning` or `error`. " +
+					"Defaults to all tasks.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("running", "ok", "warning", "error"),
+				},
+			},
+			"since": schema.StringAttribute{
+				Description: "Only return the tasks started at or after this time, in RFC 3339 format.",
+				Optional:    true,
+				Validators:  []validator.String{rfc3339},
+			},
+			"until": schema.StringAttribute{
+				Description: "Only return the tasks started at or before this time, in RFC 3339 format.",
+				Optional:    true,
+				Validators:  []validator.String{rfc3339},
+			},
+			"limit": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of tasks to return. Defaults to `%d`.", defaultLimit),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"tasks": schema.ListNestedAttribute{
+				Description: "The list of tasks, newest first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"upid": schema.StringAttribute{
+							Description: "The unique process ID of the task.",
+							Computed:    true,
+						},
+						"node_name": schema.StringAttribute{
+							Description: "The name of the node the task ran on.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the task, e.g. `qmclone`.",
+							Computed:    true,
+						},
+						"id": schema.StringAttribute{
+							Description: "The ID of the object of the task, e.g. the ID of a VM. " +
+								"Empty for the tasks that are not bound to an object.",
+							Computed: true,
+						},
+						"user": schema.StringAttribute{
+							Description: "The user who started the task.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The exit status of the task, e.g. `OK`, `WARNINGS: 1` or the error message, " +
+								"or `running` for the tasks that are still running.",
+							Computed: true,
+						},
+						"start_time": schema.StringAttribute{
+							Description: "The start time of the task in RFC 3339 format.",
+							Computed:    true,
+						},
+						"end_time": schema.StringAttribute{
+							Description: "The end time of the task in RFC 3339 format. Null for the running tasks.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the data source.
+func (d *tasksDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read fetches the tasks from the Proxmox API.
+func (d *tasksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model tasksModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, err := listRequest(model)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Tasks", err.Error())
+
+		return
+	}
+
+	nodeNames := []string{model.NodeName.ValueString()}
+
+	if model.NodeName.IsNull() {
+		nodeNames, err = d.onlineNodes(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Tasks", err.Error())
+
+			return
+		}
+	}
+
+	var apiTasks []*nodes.ListTasksResponseData
+
+	for _, nodeName := range nodeNames {
+		nodeTasks, err := d.client.Node(nodeName).ListTasks(ctx, body)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Tasks", err.Error())
+
+			return
+		}
+
+		apiTasks = append(apiTasks, nodeTasks...)
+	}
+
+	slices.SortStableFunc(apiTasks, func(a, b *nodes.ListTasksResponseData) int {
+		return cmp.Or(cmp.Compare(b.StartTime, a.StartTime), strings.Compare(b.UPID, a.UPID))
+	})
+
+	apiTasks = apiTasks[:min(len(apiTasks), *body.Limit)]
+
+	model.Tasks = make([]taskEntry, 0, len(apiTasks))
+
+	for _, t := range apiTasks {
+		model.Tasks = append(model.Tasks, newTaskEntry(t))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// onlineNodes returns the names of the online nodes of the cluster.
+func (d *tasksDataSource) onlineNodes(ctx context.Context) ([]string, error) {
+	nodes, err := d.client.Node("").ListNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing nodes: %w", err)
+	}
+
+	names := make([]string, 0, len(nodes))
+
+	for _, n := range nodes {
+		if n.Status == nil || *n.Status == "online" {
+			names = append(names, n.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// listRequest builds the filters of the task list request from the configuration.
+func listRequest(model tasksModel) (*nodes.ListTasksRequestBody, error) {
+	body := &nodes.ListTasksRequestBody{
+		Limit:      new(defaultLimit),
+		Source:     new("all"),
+		TypeFilter: model.Type.ValueStringPointer(),
+		UserFilter: model.User.ValueStringPointer(),
+	}
+
+	if !model.Limit.IsNull() {
+		body.Limit = new(int(model.Limit.ValueInt64()))
+	}
+
+	if !model.VMID.IsNull() {
+		body.VMID = new(int(model.VMID.ValueInt64()))
+	}
+
+	switch status := model.Status.ValueString(); status {
+	case "":
+	case "running":
+		body.Source = new("active")
+	default:
+		// the status filter only applies to the finished tasks
+		body.Source = new("archive")
+		body.StatusFilter = &status
+	}
+
+	var err error
+
+	if !model.Since.IsNull() {
+		if body.Since, err = unixTime(model.Since.ValueString()); err != nil {
+			return nil, err
+		}
+	}
+
+	if !model.Until.IsNull() {
+		if body.Until, err = unixTime(model.Until.ValueString()); err != nil {
+			return nil, err
+		}
+	}
+
+	return body, nil
+}
+
+// unixTime converts a time in RFC 3339 format to a Unix timestamp.
+func unixTime(s string) (*int64, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time %q: %w", s, err)
+	}
+
+	return new(t.Unix()), nil
+}
+
+func newTaskEntry(t *nodes.ListTasksResponseData) taskEntry {
+	e := taskEntry{
+		UPID:      types.StringValue(t.UPID),
+		NodeName:  types.StringValue(t.NodeName),
+		Type:      types.StringValue(t.Type),
+		ID:        types.StringValue(t.ID),
+		User:      types.StringValue(t.User),
+		Status:    types.StringValue("running"),
+		StartTime: types.StringValue(time.Unix(t.StartTime, 0).UTC().Format(time.RFC3339)),
+		EndTime:   types.StringNull(),
+	}
+
+	if t.EndTime != nil {
+		e.EndTime = types.StringValue(time.Unix(*t.EndTime, 0).UTC().Format(time.RFC3339))
+
+		if t.Status != nil {
+			e.Status = types.StringValue(*t.Status)
+		}
+	}
+
+	return e
+}
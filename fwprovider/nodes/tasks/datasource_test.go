@@ -0,0 +1,96 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test/faketest"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+)
+
+func TestListRequest(t *testing.T) {
+	t.Parallel()
+
+	body, err := listRequest(tasksModel{
+		Type:  types.StringValue("vzdump"),
+		VMID:  types.Int64Value(100),
+		Since: types.StringValue("2025-03-01T00:00:00Z"),
+		Limit: types.Int64Null(),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &nodes.ListTasksRequestBody{
+		Limit:      new(defaultLimit),
+		Since:      new(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC).Unix()),
+		Source:     new("all"),
+		TypeFilter: new("vzdump"),
+		VMID:       new(100),
+	}, body)
+
+	body, err = listRequest(tasksModel{Status: types.StringValue("running"), Limit: types.Int64Value(5)})
+	require.NoError(t, err)
+	assert.Equal(t, "active", *body.Source)
+	assert.Nil(t, body.StatusFilter)
+	assert.Equal(t, 5, *body.Limit)
+
+	body, err = listRequest(tasksModel{Status: types.StringValue("error")})
+	require.NoError(t, err)
+	assert.Equal(t, "archive", *body.Source)
+	assert.Equal(t, "error", *body.StatusFilter)
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	s, client := faketest.NewClient(t, fake.WithNodes("pve", "pve2"))
+
+	require.NoError(t, client.Node("pve").VM(0).CreateVM(ctx, &vms.CreateRequestBody{VMID: 100}).Err())
+	require.NoError(t, client.Node("pve2").VM(0).CreateVM(ctx, &vms.CreateRequestBody{VMID: 101}).Err())
+
+	s.SetTaskResult("qmstart", "start failed: QEMU exited with code 1", "kvm: failed to initialize KVM")
+	require.Error(t, client.Node("pve2").VM(101).StartVM(ctx, 60).Err())
+
+	read := func(filter tasksModel) []taskEntry {
+		t.Helper()
+
+		return faketest.ReadDataSource(t, &tasksDataSource{}, client, &filter).Tasks
+	}
+
+	all := read(tasksModel{})
+	require.Len(t, all, 3)
+
+	for _, e := range all {
+		assert.Contains(t, []string{"qmcreate", "qmstart"}, e.Type.ValueString())
+		assert.False(t, e.EndTime.IsNull())
+	}
+
+	failed := read(tasksModel{Status: types.StringValue("error")})
+	require.Len(t, failed, 1)
+	assert.Equal(t, "pve2", failed[0].NodeName.ValueString())
+	assert.Equal(t, "qmstart", failed[0].Type.ValueString())
+	assert.Equal(t, "101", failed[0].ID.ValueString())
+	assert.Equal(t, "start failed: QEMU exited with code 1", failed[0].Status.ValueString())
+
+	created := read(tasksModel{NodeName: types.StringValue("pve"), Type: types.StringValue("qmcreate")})
+	require.Len(t, created, 1)
+	assert.Equal(t, "100", created[0].ID.ValueString())
+	assert.Equal(t, "OK", created[0].Status.ValueString())
+
+	assert.Len(t, read(tasksModel{Limit: types.Int64Value(2)}), 2)
+	assert.Empty(t, read(tasksModel{Status: types.StringValue("running")}))
+	assert.Empty(t, read(tasksModel{VMID: types.Int64Value(102)}))
+}
@@ -0,0 +1,264 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+)
+
+const (
+	// defaultFreezeTimeout is the default time the filesystems of a guest may stay frozen.
+	defaultFreezeTimeout = time.Minute
+
+	// thawTimeout is the time allowed to thaw the filesystems of a guest, independently of the
+	// freeze timeout and of the cancellation of the action.
+	thawTimeout = 30 * time.Second
+)
+
+var (
+	_ action.Action              = &fsFreezeAction{}
+	_ action.ActionWithConfigure = &fsFreezeAction{}
+)
+
+// fsFreezeModel is the data model for the filesystem freeze action.
+type fsFreezeModel struct {
+	NodeName types.String   `tfsdk:"node_name"`
+	VMID     types.Int64    `tfsdk:"vm_id"`
+	Command  []types.String `tfsdk:"command"`
+	Timeout  types.String   `tfsdk:"timeout"`
+}
+
+// fsFreezeAction freezes the filesystems of a VM, runs a local command and thaws them again.
+type fsFreezeAction struct {
+	client proxmox.Client
+}
+
+// NewFSFreezeAction creates a new filesystem freeze action.
+func NewFSFreezeAction() action.Action {
+	return &fsFreezeAction{}
+}
+
+// Metadata defines the name of the action.
+func (a *fsFreezeAction) Metadata(
+	_ context.Context,
+	_ action.MetadataRequest,
+	resp *action.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_vm_fsfreeze"
+}
+
+// Schema defines the schema for the action.
+func (a *fsFreezeAction) Schema(
+	_ context.Context,
+	_ action.SchemaRequest,
+	resp *action.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Freezes the filesystems of a VM with the QEMU guest agent, runs a local command while " +
+			"the guest is quiesced, e.g. to take a storage-level snapshot, and thaws the filesystems again.",
+		MarkdownDescription: "Freezes the filesystems of a VM with the QEMU guest agent, runs a local command " +
+			"while the guest is quiesced, e.g. to take a storage-level snapshot, and thaws the filesystems again. " +
+			"The filesystems are thawed even if the command fails, the timeout expires or the action is cancelled. " +
+			"The command runs on the machine running Terraform, with the `PROXMOX_VE_NODE_NAME` and " +
+			"`PROXMOX_VE_VM_ID` environment variables set.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node the VM is on.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				Description: "The ID of the VM.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(100, 999999999),
+				},
+			},
+			"command": schema.ListAttribute{
+				Description: "The command to run while the filesystems are frozen, as the program followed by " +
+					"its arguments.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "The maximum time the filesystems stay frozen, e.g. `5m`. The command is killed " +
+					"when it expires. Defaults to `1m`.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.IsValidDuration(),
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the action.
+func (a *fsFreezeAction) Configure(
+	_ context.Context,
+	req action.ConfigureRequest,
+	resp *action.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Action)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected config.Action, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = cfg.Client
+}
+
+// Invoke freezes the filesystems, runs the command and thaws the filesystems.
+func (a *fsFreezeAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model fsFreezeModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := defaultFreezeTimeout
+	if !model.Timeout.IsNull() {
+		// the value is validated by the schema
+		timeout, _ = time.ParseDuration(model.Timeout.ValueString())
+	}
+
+	nodeName := model.NodeName.ValueString()
+	vmID := int(model.VMID.ValueInt64())
+
+	args := make([]string, 0, len(model.Command))
+	for _, arg := range model.Command {
+		args = append(args, arg.ValueString())
+	}
+
+	run := func(ctx context.Context) error {
+		//nolint:gosec // running the user-supplied command is the purpose of the action
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Env = append(os.Environ(),
+			"PROXMOX_VE_NODE_NAME="+nodeName,
+			"PROXMOX_VE_VM_ID="+strconv.Itoa(vmID),
+		)
+
+		out, err := cmd.CombinedOutput()
+		if output := strings.TrimSpace(string(out)); output != "" {
+			resp.SendProgress(action.InvokeProgressEvent{Message: output})
+		}
+
+		if err != nil {
+			return fmt.Errorf("error running command %q: %w", args[0], err)
+		}
+
+		return nil
+	}
+
+	freezeAndRun(ctx, a.client.Node(nodeName).VM(vmID), timeout, run, func(msg string) {
+		resp.SendProgress(action.InvokeProgressEvent{Message: msg})
+	}, &resp.Diagnostics)
+}
+
+// freezeAndRun freezes the filesystems of the VM, calls run with a context that expires after the
+// timeout, and thaws the filesystems whatever the outcome of run.
+func freezeAndRun(
+	ctx context.Context,
+	vmAPI *vms.Client,
+	timeout time.Duration,
+	run func(context.Context) error,
+	progress func(string),
+	diags *diag.Diagnostics,
+) {
+	vmID := vmAPI.VMID
+
+	progress(fmt.Sprintf("Freezing filesystems of VM %d", vmID))
+
+	// thaw also after a freeze error, the guest may have frozen part of its filesystems
+	defer thaw(ctx, vmAPI, progress, diags)
+
+	frozen, err := vmAPI.FreezeFilesystems(ctx)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Unable to Freeze Filesystems of VM %d", vmID), err.Error())
+		return
+	}
+
+	progress(fmt.Sprintf("Froze %d filesystems of VM %d", frozen, vmID))
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err = run(runCtx)
+	if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("filesystems of VM %d were frozen for longer than %s: %w", vmID, timeout, runCtx.Err())
+	}
+
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Unable to Run Command While VM %d Is Frozen", vmID), err.Error())
+	}
+}
+
+// thaw thaws the filesystems of the VM and checks that the guest is no longer frozen. It does
+// not use the cancellation of ctx, so that a cancelled action does not leave the guest frozen.
+func thaw(ctx context.Context, vmAPI *vms.Client, progress func(string), diags *diag.Diagnostics) {
+	vmID := vmAPI.VMID
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), thawTimeout)
+	defer cancel()
+
+	summary := fmt.Sprintf("Unable to Thaw Filesystems of VM %d", vmID)
+
+	thawed, err := vmAPI.ThawFilesystems(ctx)
+	if err != nil {
+		diags.AddError(summary, err.Error())
+		return
+	}
+
+	status, err := vmAPI.GetFilesystemFreezeStatus(ctx)
+	if err != nil {
+		diags.AddError(summary, err.Error())
+		return
+	}
+
+	if status != vms.AgentFSFreezeStatusThawed {
+		diags.AddError(summary, fmt.Sprintf("the filesystem freeze status of VM %d is %q after thawing", vmID, status))
+		return
+	}
+
+	progress(fmt.Sprintf("Thawed %d filesystems of VM %d", thawed, vmID))
+}
@@ -0,0 +1,150 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+)
+
+var (
+	_ action.Action              = &fsTrimAction{}
+	_ action.ActionWithConfigure = &fsTrimAction{}
+)
+
+// fsTrimModel is the data model for the filesystem trim action.
+type fsTrimModel struct {
+	NodeName types.String `tfsdk:"node_name"`
+	VMID     types.Int64  `tfsdk:"vm_id"`
+}
+
+// fsTrimAction discards the unused blocks of the filesystems of a VM.
+type fsTrimAction struct {
+	client proxmox.Client
+}
+
+// NewFSTrimAction creates a new filesystem trim action.
+func NewFSTrimAction() action.Action {
+	return &fsTrimAction{}
+}
+
+// Metadata defines the name of the action.
+func (a *fsTrimAction) Metadata(
+	_ context.Context,
+	_ action.MetadataRequest,
+	resp *action.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_vm_fstrim"
+}
+
+// Schema defines the schema for the action.
+func (a *fsTrimAction) Schema(
+	_ context.Context,
+	_ action.SchemaRequest,
+	resp *action.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Discards the unused blocks of the mounted filesystems of a VM with the QEMU guest agent, " +
+			"so that thin-provisioned storage can reclaim them.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node the VM is on.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				Description: "The ID of the VM.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(100, 999999999),
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the action.
+func (a *fsTrimAction) Configure(
+	_ context.Context,
+	req action.ConfigureRequest,
+	resp *action.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Action)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected config.Action, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = cfg.Client
+}
+
+// Invoke trims the filesystems of the VM.
+func (a *fsTrimAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model fsTrimModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vmAPI := a.client.Node(model.NodeName.ValueString()).VM(int(model.VMID.ValueInt64()))
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Trimming filesystems of VM %d", vmAPI.VMID),
+	})
+
+	trim(ctx, vmAPI, func(msg string) {
+		resp.SendProgress(action.InvokeProgressEvent{Message: msg})
+	}, &resp.Diagnostics)
+}
+
+// trim trims the filesystems of the VM and reports the result of each filesystem. Filesystems
+// that cannot be trimmed, e.g. because they are read-only, are reported as warnings.
+func trim(ctx context.Context, vmAPI *vms.Client, progress func(string), diags *diag.Diagnostics) {
+	res, err := vmAPI.TrimFilesystems(ctx)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Unable to Trim Filesystems of VM %d", vmAPI.VMID), err.Error())
+		return
+	}
+
+	for _, p := range res.Paths {
+		switch {
+		case p.Error != nil:
+			diags.AddWarning(
+				fmt.Sprintf("Unable to Trim Filesystem %s of VM %d", p.Path, vmAPI.VMID),
+				*p.Error,
+			)
+		case p.Trimmed != nil:
+			progress(fmt.Sprintf("Trimmed %d bytes on %s", *p.Trimmed, p.Path))
+		default:
+			progress("Trimmed " + p.Path)
+		}
+	}
+}
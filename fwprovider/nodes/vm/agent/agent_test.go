@@ -0,0 +1,239 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test/faketest"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// newRunningVM starts a fake server with a running VM 100 and returns the VM client.
+func newRunningVM(t *testing.T, agentEnabled bool) (*fake.Server, *vms.Client) {
+	t.Helper()
+
+	s, client := faketest.NewClient(t)
+	vmAPI := client.Node(fake.DefaultNode).VM(100)
+
+	require.NoError(t, vmAPI.CreateVM(t.Context(), &vms.CreateRequestBody{
+		VMID:     100,
+		Name:     new("web"),
+		CPUCores: new(int64(2)),
+		Agent:    &vms.CustomAgent{Enabled: proxmoxtypes.CustomBool(agentEnabled).Pointer()},
+	}).Err())
+	require.NoError(t, vmAPI.StartVM(t.Context(), 30).Err())
+
+	return s, vmAPI
+}
+
+func TestReadGuestInfo(t *testing.T) {
+	t.Parallel()
+
+	s, vmAPI := newRunningVM(t, true)
+
+	s.SetAgentResult(100, "get-users", []map[string]any{
+		{"user": "admin", "domain": "CORP", "login-time": 1735732800.5},
+	})
+	s.SetAgentResult(100, "get-fsinfo", []map[string]any{{
+		"name":        "sda1",
+		"mountpoint":  "/",
+		"type":        "ext4",
+		"used-bytes":  1 << 30,
+		"total-bytes": 8 << 30,
+		"disk":        []map[string]any{{"bus-type": "scsi", "dev": "/dev/sda1"}},
+	}})
+
+	var (
+		model guestInfoModel
+		diags diag.Diagnostics
+	)
+
+	readGuestInfo(t.Context(), vmAPI, &model, &diags)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, diags)
+
+	assert.Equal(t, "web", model.HostName.ValueString())
+	require.NotNil(t, model.OS)
+	assert.Equal(t, "debian", model.OS.ID.ValueString())
+	assert.Equal(t, "12", model.OS.VersionID.ValueString())
+	assert.True(t, model.OS.Variant.IsNull())
+	require.NotNil(t, model.Timezone)
+	assert.Equal(t, "UTC", model.Timezone.Zone.ValueString())
+
+	assert.Equal(t, []filesystemModel{{
+		Name:       types.StringValue("sda1"),
+		MountPoint: types.StringValue("/"),
+		Type:       types.StringValue("ext4"),
+		UsedBytes:  types.Int64Value(1 << 30),
+		TotalBytes: types.Int64Value(8 << 30),
+		Devices:    []types.String{types.StringValue("/dev/sda1")},
+	}}, model.Filesystems)
+
+	assert.Equal(t, []userModel{{
+		Name:      types.StringValue("admin"),
+		Domain:    types.StringValue("CORP"),
+		LoginTime: types.StringValue("2025-01-01T12:00:00Z"),
+	}}, model.Users)
+
+	require.Len(t, model.VCPUs, 2)
+	assert.True(t, model.VCPUs[1].Online.ValueBool())
+}
+
+func TestReadGuestInfoAgentDisabled(t *testing.T) {
+	t.Parallel()
+
+	_, vmAPI := newRunningVM(t, false)
+
+	var (
+		model guestInfoModel
+		diags diag.Diagnostics
+	)
+
+	readGuestInfo(t.Context(), vmAPI, &model, &diags)
+	require.True(t, diags.HasError())
+	assert.Len(t, diags, 1)
+	assert.Contains(t, diags.Errors()[0].Detail(), "No QEMU guest agent configured")
+}
+
+func TestFreezeAndRun(t *testing.T) {
+	t.Parallel()
+
+	_, vmAPI := newRunningVM(t, true)
+
+	var (
+		diags    diag.Diagnostics
+		progress []string
+		status   string
+	)
+
+	freezeAndRun(t.Context(), vmAPI, time.Minute, func(ctx context.Context) error {
+		var err error
+
+		status, err = vmAPI.GetFilesystemFreezeStatus(ctx)
+
+		return err
+	}, func(msg string) { progress = append(progress, msg) }, &diags)
+
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, vms.AgentFSFreezeStatusFrozen, status)
+	assert.Equal(t, []string{
+		"Freezing filesystems of VM 100",
+		"Froze 1 filesystems of VM 100",
+		"Thawed 1 filesystems of VM 100",
+	}, progress)
+
+	status, err := vmAPI.GetFilesystemFreezeStatus(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, vms.AgentFSFreezeStatusThawed, status)
+}
+
+func TestFreezeAndRunThawsOnFailure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ctx     func(t *testing.T) context.Context
+		timeout time.Duration
+		run     func(ctx context.Context) error
+		detail  string
+	}{
+		{
+			name:    "command error",
+			ctx:     func(t *testing.T) context.Context { return t.Context() },
+			timeout: time.Minute,
+			run:     func(context.Context) error { return errors.New("snapshot failed") },
+			detail:  "snapshot failed",
+		},
+		{
+			name:    "timeout",
+			ctx:     func(t *testing.T) context.Context { return t.Context() },
+			timeout: 10 * time.Millisecond,
+			run: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			detail: "were frozen for longer than 10ms",
+		},
+		{
+			name: "cancelled",
+			ctx: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithCancel(t.Context())
+				t.Cleanup(cancel)
+
+				go func() {
+					time.Sleep(10 * time.Millisecond)
+					cancel()
+				}()
+
+				return ctx
+			},
+			timeout: time.Minute,
+			run: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			detail: "context canceled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, vmAPI := newRunningVM(t, true)
+
+			var diags diag.Diagnostics
+
+			freezeAndRun(tt.ctx(t), vmAPI, tt.timeout, tt.run, func(string) {}, &diags)
+			require.Len(t, diags.Errors(), 1, "%v", diags)
+			assert.Contains(t, diags.Errors()[0].Detail(), tt.detail)
+
+			status, err := vmAPI.GetFilesystemFreezeStatus(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, vms.AgentFSFreezeStatusThawed, status)
+		})
+	}
+}
+
+func TestTrim(t *testing.T) {
+	t.Parallel()
+
+	s, vmAPI := newRunningVM(t, true)
+
+	var (
+		diags    diag.Diagnostics
+		progress []string
+	)
+
+	trim(t.Context(), vmAPI, func(msg string) { progress = append(progress, msg) }, &diags)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []string{"Trimmed 1048576 bytes on /"}, progress)
+
+	s.SetAgentResult(100, "fstrim", map[string]any{"paths": []map[string]any{
+		{"path": "/", "trimmed": 4096, "minimum": 0},
+		{"path": "/boot", "error": "Operation not supported"},
+	}})
+
+	progress = nil
+
+	trim(t.Context(), vmAPI, func(msg string) { progress = append(progress, msg) }, &diags)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []string{"Trimmed 4096 bytes on /"}, progress)
+	require.Len(t, diags.Warnings(), 1)
+	assert.Equal(t, "Operation not supported", diags.Warnings()[0].Detail())
+}
@@ -0,0 +1,433 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+)
+
+// guestInfoCommands is the number of agent commands the guest info data source runs.
+const guestInfoCommands = 6
+
+var (
+	_ datasource.DataSource              = &guestInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &guestInfoDataSource{}
+)
+
+// guestInfoModel is the data model for the guest info data source.
+type guestInfoModel struct {
+	NodeName    types.String      `tfsdk:"node_name"`
+	VMID        types.Int64       `tfsdk:"vm_id"`
+	HostName    types.String      `tfsdk:"host_name"`
+	OS          *osInfoModel      `tfsdk:"os"`
+	Timezone    *timezoneModel    `tfsdk:"timezone"`
+	Filesystems []filesystemModel `tfsdk:"filesystems"`
+	Users       []userModel       `tfsdk:"users"`
+	VCPUs       []vcpuModel       `tfsdk:"vcpus"`
+}
+
+type osInfoModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	PrettyName    types.String `tfsdk:"pretty_name"`
+	Version       types.String `tfsdk:"version"`
+	VersionID     types.String `tfsdk:"version_id"`
+	Variant       types.String `tfsdk:"variant"`
+	VariantID     types.String `tfsdk:"variant_id"`
+	KernelRelease types.String `tfsdk:"kernel_release"`
+	KernelVersion types.String `tfsdk:"kernel_version"`
+	Machine       types.String `tfsdk:"machine"`
+}
+
+type timezoneModel struct {
+	Zone   types.String `tfsdk:"zone"`
+	Offset types.Int64  `tfsdk:"offset"`
+}
+
+type filesystemModel struct {
+	Name       types.String   `tfsdk:"name"`
+	MountPoint types.String   `tfsdk:"mount_point"`
+	Type       types.String   `tfsdk:"type"`
+	UsedBytes  types.Int64    `tfsdk:"used_bytes"`
+	TotalBytes types.Int64    `tfsdk:"total_bytes"`
+	Devices    []types.String `tfsdk:"devices"`
+}
+
+type userModel struct {
+	Name      types.String `tfsdk:"name"`
+	Domain    types.String `tfsdk:"domain"`
+	LoginTime types.String `tfsdk:"login_time"`
+}
+
+type vcpuModel struct {
+	LogicalID  types.Int64 `tfsdk:"logical_id"`
+	Online     types.Bool  `tfsdk:"online"`
+	CanOffline types.Bool  `tfsdk:"can_offline"`
+}
+
+// guestInfoDataSource is the implementation of the guest info data source.
+type guestInfoDataSource struct {
+	client proxmox.Client
+}
+
+// NewGuestInfoDataSource creates a new guest info data source.
+func NewGuestInfoDataSource() datasource.DataSource {
+	return &guestInfoDataSource{}
+}
+
+// Metadata defines the name of the data source.
+func (d *guestInfoDataSource) Metadata(
+	_ context.Context,
+	_ datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_vm_guest_info"
+}
+
+// Schema defines the schema for the guest info data source.
+func (d *guestInfoDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the operating system, host name, timezone, filesystems, logged in users and " +
+			"virtual CPUs of a running VM from its QEMU guest agent.",
+		MarkdownDescription: "Retrieves the operating system, host name, timezone, filesystems, logged in users and " +
+			"virtual CPUs of a running VM from its QEMU guest agent. The VM must be running with the agent enabled. " +
+			"Information the agent does not support, e.g. because the command is disabled in the guest, " +
+			"is reported as a warning and left empty.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				Description: "The ID of the VM.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(100, 999999999),
+				},
+			},
+			"host_name": schema.StringAttribute{
+				Description: "The host name of the guest.",
+				Computed:    true,
+			},
+			"os": schema.SingleNestedAttribute{
+				Description: "The operating system of the guest.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Description: "The operating system ID, e.g. `debian` or `mswindows`.",
+						Computed:    true,
+					},
+					"name": schema.StringAttribute{
+						Description: "The operating system name, e.g. `Debian GNU/Linux`.",
+						Computed:    true,
+					},
+					"pretty_name": schema.StringAttribute{
+						Description: "The human readable operating system name and version.",
+						Computed:    true,
+					},
+					"version": schema.StringAttribute{
+						Description: "The operating system version, e.g. `12 (bookworm)`.",
+						Computed:    true,
+					},
+					"version_id": schema.StringAttribute{
+						Description: "The machine readable operating system version, e.g. `12`.",
+						Computed:    true,
+					},
+					"variant": schema.StringAttribute{
+						Description: "The operating system variant, e.g. `server`.",
+						Computed:    true,
+					},
+					"variant_id": schema.StringAttribute{
+						Description: "The machine readable operating system variant.",
+						Computed:    true,
+					},
+					"kernel_release": schema.StringAttribute{
+						Description: "The kernel release, e.g. `6.1.0-28-amd64`.",
+						Computed:    true,
+					},
+					"kernel_version": schema.StringAttribute{
+						Description: "The kernel version.",
+						Computed:    true,
+					},
+					"machine": schema.StringAttribute{
+						Description: "The machine hardware name, e.g. `x86_64`.",
+						Computed:    true,
+					},
+				},
+			},
+			"timezone": schema.SingleNestedAttribute{
+				Description: "The timezone of the guest.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"zone": schema.StringAttribute{
+						Description: "The name of the timezone, e.g. `UTC`.",
+						Computed:    true,
+					},
+					"offset": schema.Int64Attribute{
+						Description: "The offset to UTC in seconds, negative for timezones west of Greenwich.",
+						Computed:    true,
+					},
+				},
+			},
+			"filesystems": schema.ListNestedAttribute{
+				Description: "The mounted filesystems of the guest.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the filesystem device, e.g. `sda1`.",
+							Computed:    true,
+						},
+						"mount_point": schema.StringAttribute{
+							Description: "The mount point of the filesystem.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the filesystem, e.g. `ext4`.",
+							Computed:    true,
+						},
+						"used_bytes": schema.Int64Attribute{
+							Description: "The used space in bytes.",
+							Computed:    true,
+						},
+						"total_bytes": schema.Int64Attribute{
+							Description: "The total space in bytes.",
+							Computed:    true,
+						},
+						"devices": schema.ListAttribute{
+							Description: "The block devices backing the filesystem, e.g. `/dev/sda1`.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"users": schema.ListNestedAttribute{
+				Description: "The users logged in to the guest.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The user name.",
+							Computed:    true,
+						},
+						"domain": schema.StringAttribute{
+							Description: "The logon domain of the user. Only reported by Windows guests.",
+							Computed:    true,
+						},
+						"login_time": schema.StringAttribute{
+							Description: "The time of the first login of the user in RFC 3339 format.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"vcpus": schema.ListNestedAttribute{
+				Description: "The virtual CPUs of the guest.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"logical_id": schema.Int64Attribute{
+							Description: "The logical ID of the virtual CPU.",
+							Computed:    true,
+						},
+						"online": schema.BoolAttribute{
+							Description: "Whether the virtual CPU is online.",
+							Computed:    true,
+						},
+						"can_offline": schema.BoolAttribute{
+							Description: "Whether the virtual CPU can be taken offline.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the data source.
+func (d *guestInfoDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read fetches the guest information from the QEMU guest agent.
+func (d *guestInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model guestInfoModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vmAPI := d.client.Node(model.NodeName.ValueString()).VM(int(model.VMID.ValueInt64()))
+
+	readGuestInfo(ctx, vmAPI, &model, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// readGuestInfo fills the model with the results of the agent commands. Commands that fail are
+// reported as warnings, unless all of them fail, e.g. because the agent is not running.
+func readGuestInfo(ctx context.Context, vmAPI *vms.Client, model *guestInfoModel, diags *diag.Diagnostics) {
+	model.HostName = types.StringNull()
+
+	var failures []error
+
+	if res, err := vmAPI.GetAgentHostName(ctx); err != nil {
+		failures = append(failures, err)
+	} else {
+		model.HostName = types.StringValue(res.HostName)
+	}
+
+	if res, err := vmAPI.GetAgentOSInfo(ctx); err != nil {
+		failures = append(failures, err)
+	} else {
+		model.OS = newOSInfoModel(res)
+	}
+
+	if res, err := vmAPI.GetAgentTimezone(ctx); err != nil {
+		failures = append(failures, err)
+	} else {
+		model.Timezone = &timezoneModel{
+			Zone:   types.StringPointerValue(res.Zone),
+			Offset: types.Int64Value(int64(res.Offset)),
+		}
+	}
+
+	if res, err := vmAPI.GetAgentFSInfo(ctx); err != nil {
+		failures = append(failures, err)
+	} else {
+		model.Filesystems = make([]filesystemModel, 0, len(res))
+		for _, fs := range res {
+			model.Filesystems = append(model.Filesystems, newFilesystemModel(fs))
+		}
+	}
+
+	if res, err := vmAPI.GetAgentUsers(ctx); err != nil {
+		failures = append(failures, err)
+	} else {
+		model.Users = make([]userModel, 0, len(res))
+		for _, u := range res {
+			model.Users = append(model.Users, newUserModel(u))
+		}
+	}
+
+	if res, err := vmAPI.GetAgentVCPUs(ctx); err != nil {
+		failures = append(failures, err)
+	} else {
+		model.VCPUs = make([]vcpuModel, 0, len(res))
+		for _, c := range res {
+			model.VCPUs = append(model.VCPUs, vcpuModel{
+				LogicalID:  types.Int64Value(int64(c.LogicalID)),
+				Online:     types.BoolValue(c.Online),
+				CanOffline: types.BoolPointerValue(c.CanOffline),
+			})
+		}
+	}
+
+	summary := fmt.Sprintf("Unable to Read Guest Agent Information of VM %d", vmAPI.VMID)
+
+	if len(failures) == guestInfoCommands {
+		diags.AddError(summary, failures[0].Error())
+
+		return
+	}
+
+	for _, err := range failures {
+		diags.AddWarning(summary, err.Error())
+	}
+}
+
+func newOSInfoModel(res *vms.AgentOSInfo) *osInfoModel {
+	return &osInfoModel{
+		ID:            types.StringPointerValue(res.ID),
+		Name:          types.StringPointerValue(res.Name),
+		PrettyName:    types.StringPointerValue(res.PrettyName),
+		Version:       types.StringPointerValue(res.Version),
+		VersionID:     types.StringPointerValue(res.VersionID),
+		Variant:       types.StringPointerValue(res.Variant),
+		VariantID:     types.StringPointerValue(res.VariantID),
+		KernelRelease: types.StringPointerValue(res.KernelRelease),
+		KernelVersion: types.StringPointerValue(res.KernelVersion),
+		Machine:       types.StringPointerValue(res.Machine),
+	}
+}
+
+func newFilesystemModel(fs vms.AgentFSInfo) filesystemModel {
+	m := filesystemModel{
+		Name:       types.StringValue(fs.Name),
+		MountPoint: types.StringValue(fs.MountPoint),
+		Type:       types.StringValue(fs.Type),
+		UsedBytes:  types.Int64PointerValue(fs.UsedBytes),
+		TotalBytes: types.Int64PointerValue(fs.TotalBytes),
+		Devices:    []types.String{},
+	}
+
+	for _, disk := range fs.Disks {
+		if disk.Dev != nil {
+			m.Devices = append(m.Devices, types.StringValue(*disk.Dev))
+		}
+	}
+
+	return m
+}
+
+func newUserModel(u vms.AgentUser) userModel {
+	sec, frac := math.Modf(u.LoginTime)
+
+	return userModel{
+		Name:      types.StringValue(u.User),
+		Domain:    types.StringPointerValue(u.Domain),
+		LoginTime: types.StringValue(time.Unix(int64(sec), int64(frac*1e9)).UTC().Format(time.RFC3339)),
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// guestPasswordWO reads the write-only guest password from the configuration.
+func guestPasswordWO(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) types.String {
+	var password types.String
+
+	diags.Append(config.GetAttribute(ctx, path.Root("guest_password_wo"), &password)...)
+
+	return password
+}
+
+// guestPasswordChanged reports whether the guest password has to be set again, i.e. when the
+// user or the password version changes. The password itself is not stored in the state.
+func guestPasswordChanged(plan, state Model) bool {
+	return !plan.GuestUsername.Equal(state.GuestUsername) ||
+		!plan.GuestPasswordWOVersion.Equal(state.GuestPasswordWOVersion)
+}
+
+// setGuestPassword sets the password of the guest user with the QEMU guest agent. The VM has
+// to be running with the agent enabled. The agent of a VM that has just started is not
+// responsive until the guest has booted, so the password is set once the agent answers.
+func (r *Resource) setGuestPassword(ctx context.Context, plan Model, password types.String, diags *diag.Diagnostics) {
+	if password.IsNull() || password.IsUnknown() {
+		return
+	}
+
+	vmID := int(plan.ID.ValueInt64())
+	username := plan.GuestUsername.ValueString()
+	vmAPI := r.client.Node(plan.NodeName.ValueString()).VM(vmID)
+
+	tflog.Info(ctx, fmt.Sprintf("Setting the password of guest user %q of VM %d", username, vmID))
+
+	err := vmAPI.WaitForAgentReady(ctx)
+	if err == nil {
+		err = vmAPI.SetUserPassword(ctx, username, password.ValueString(), false)
+	}
+
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Unable to Set Password of Guest User %s of VM %d", username, vmID), err.Error())
+	}
+}
+
+// setInitialGuestPassword sets the guest password of a created VM if it is running. A stopped
+// VM does not run the guest agent, so the password is then set on a later version change only.
+// The VM is in the state already, so failures are reported as warnings: an error would taint
+// the VM, and the password is set again when `guest_password_wo_version` is incremented.
+func (r *Resource) setInitialGuestPassword(
+	ctx context.Context,
+	plan Model,
+	password types.String,
+	diags *diag.Diagnostics,
+) {
+	if password.IsNull() || password.IsUnknown() {
+		return
+	}
+
+	vmID := int(plan.ID.ValueInt64())
+
+	status, err := r.client.Node(plan.NodeName.ValueString()).VM(vmID).GetVMStatus(ctx)
+	if err != nil {
+		diags.AddWarning(fmt.Sprintf("Unable to Read VM %d Status", vmID), err.Error())
+		return
+	}
+
+	if status.Status != "running" {
+		diags.AddWarning(
+			fmt.Sprintf("Password of Guest User Not Set on VM %d", vmID),
+			"The QEMU guest agent is only available while the VM is running. "+
+				"Increment `guest_password_wo_version` to set the password once the VM runs.",
+		)
+
+		return
+	}
+
+	var passwordDiags diag.Diagnostics
+
+	r.setGuestPassword(ctx, plan, password, &passwordDiags)
+
+	for _, d := range passwordDiags.Errors() {
+		diags.AddWarning(d.Summary(), d.Detail()+
+			"\n\nIncrement `guest_password_wo_version` to set the password again.")
+	}
+}
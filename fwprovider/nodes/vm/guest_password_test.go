@@ -0,0 +1,113 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test/faketest"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+func TestGuestPasswordChanged(t *testing.T) {
+	t.Parallel()
+
+	state := Model{GuestUsername: types.StringValue("Administrator"), GuestPasswordWOVersion: types.Int64Value(1)}
+
+	assert.False(t, guestPasswordChanged(state, state))
+
+	plan := state
+	plan.GuestPasswordWOVersion = types.Int64Value(2)
+	assert.True(t, guestPasswordChanged(plan, state))
+
+	plan = state
+	plan.GuestUsername = types.StringValue("admin")
+	assert.True(t, guestPasswordChanged(plan, state))
+}
+
+func TestSetGuestPassword(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	s, client := faketest.NewClient(t)
+	r := &Resource{client: client}
+
+	vmAPI := client.Node(fake.DefaultNode).VM(100)
+	require.NoError(t, vmAPI.CreateVM(ctx, &vms.CreateRequestBody{
+		VMID:  100,
+		Agent: &vms.CustomAgent{Enabled: proxmoxtypes.CustomBool(true).Pointer()},
+	}).Err())
+
+	plan := Model{
+		ID:                     types.Int64Value(100),
+		NodeName:               types.StringValue(fake.DefaultNode),
+		GuestUsername:          types.StringValue("Administrator"),
+		GuestPasswordWOVersion: types.Int64Value(1),
+	}
+
+	var diags diag.Diagnostics
+
+	r.setInitialGuestPassword(ctx, plan, types.StringValue("s3cr3t!"), &diags)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Len(t, diags.Warnings(), 1, "a stopped VM has no running agent")
+
+	_, ok := s.GuestPassword(100, "Administrator")
+	assert.False(t, ok)
+
+	require.NoError(t, vmAPI.StartVM(ctx, 30).Err())
+
+	diags = nil
+
+	r.setGuestPassword(ctx, plan, types.StringValue("s3cr3t!"), &diags)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	password, ok := s.GuestPassword(100, "Administrator")
+	require.True(t, ok)
+	assert.Equal(t, "s3cr3t!", password)
+
+	diags = nil
+
+	r.setGuestPassword(ctx, plan, types.StringNull(), &diags)
+	assert.Empty(t, diags, "no password is configured")
+}
+
+// TestSetInitialGuestPasswordWarns tests that a created VM whose password cannot be set is
+// kept, with a warning instead of an error.
+func TestSetInitialGuestPasswordWarns(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	_, client := faketest.NewClient(t)
+	r := &Resource{client: client}
+
+	vmAPI := client.Node(fake.DefaultNode).VM(100)
+	require.NoError(t, vmAPI.CreateVM(ctx, &vms.CreateRequestBody{VMID: 100}).Err())
+	require.NoError(t, vmAPI.StartVM(ctx, 30).Err())
+
+	plan := Model{
+		ID:            types.Int64Value(100),
+		NodeName:      types.StringValue(fake.DefaultNode),
+		GuestUsername: types.StringValue("root"),
+	}
+
+	var diags diag.Diagnostics
+
+	r.setInitialGuestPassword(ctx, plan, types.StringValue("s3cr3t!"), &diags)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Len(t, diags.Warnings(), 1)
+	assert.Contains(t, diags.Warnings()[0].Detail(), "No QEMU guest agent configured")
+	assert.Contains(t, diags.Warnings()[0].Detail(), "Increment `guest_password_wo_version`")
+}
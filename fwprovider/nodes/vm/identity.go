@@ -0,0 +1,74 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithIdentity = &Resource{}
+
+// identityModel is the identity of a VM: the node it is on and its ID. The node changes when
+// the VM is migrated.
+type identityModel struct {
+	NodeName types.String `tfsdk:"node_name"`
+	ID       types.Int64  `tfsdk:"id"`
+}
+
+// IdentitySchema defines the identity of the resource.
+func (r *Resource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = identitySchema()
+}
+
+func identitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"node_name": identityschema.StringAttribute{
+				Description:       "The name of the node the VM is on.",
+				RequiredForImport: true,
+			},
+			"id": identityschema.Int64Attribute{
+				Description:       "The ID of the VM.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// setIdentity stores the identity of the VM of the model.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, m Model, diags *diag.Diagnostics) {
+	if identity == nil {
+		return
+	}
+
+	diags.Append(identity.Set(ctx, identityModel{NodeName: m.NodeName, ID: m.ID})...)
+}
+
+// importID returns the `node_name/id` import identifier, from the identity when the resource is
+// imported by identity.
+func importID(ctx context.Context, req resource.ImportStateRequest, diags *diag.Diagnostics) string {
+	if req.ID != "" || req.Identity == nil {
+		return req.ID
+	}
+
+	var identity identityModel
+
+	diags.Append(req.Identity.Get(ctx, &identity)...)
+
+	return fmt.Sprintf("%s/%d", identity.NodeName.ValueString(), identity.ID.ValueInt64())
+}
@@ -0,0 +1,180 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/query"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+)
+
+var (
+	_ list.ListResource              = &ListResource{}
+	_ list.ListResourceWithConfigure = &ListResource{}
+)
+
+// ListResource lists the VMs of the cluster, as instances of proxmox_vm.
+type ListResource struct {
+	client proxmox.Client
+}
+
+// NewListResource creates the list resource of proxmox_vm.
+func NewListResource() list.ListResource {
+	return &ListResource{}
+}
+
+// Metadata defines the name of the list resource, which is the name of the managed resource.
+func (r *ListResource) Metadata(
+	_ context.Context,
+	_ resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = shortResourceTypeName
+}
+
+// ListResourceConfigSchema defines the filters of the list resource.
+func (r *ListResource) ListResourceConfigSchema(
+	_ context.Context,
+	_ list.ListResourceSchemaRequest,
+	resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = query.GuestFilterSchema("VMs")
+}
+
+// Configure sets the client for the list resource.
+func (r *ListResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// List lists the VMs that match the filters.
+func (r *ListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter query.GuestFilterModel
+
+	diags := req.Config.Get(ctx, &filter)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	guests, err := query.ListGuests(ctx, r.client.Cluster(), query.GuestTypeVM, filter)
+	if err != nil {
+		diags.AddError("Unable to List VMs", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, g := range guests {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			if !push(r.listResult(ctx, req, g)) {
+				return
+			}
+		}
+	}
+}
+
+func (r *ListResource) listResult(ctx context.Context, req list.ListRequest, g *cluster.ResourcesListResponseData) list.ListResult {
+	result := req.NewListResult(ctx)
+
+	result.DisplayName = g.Name
+	if result.DisplayName == "" {
+		result.DisplayName = strconv.Itoa(g.VMID)
+	}
+
+	m := Model{
+		ID:       types.Int64Value(int64(g.VMID)),
+		NodeName: types.StringValue(g.NodeName),
+	}
+
+	setIdentity(ctx, result.Identity, m, &result.Diagnostics)
+
+	if !req.IncludeResource {
+		result.Resource = nil
+
+		return result
+	}
+
+	readListedVM(ctx, r.client, result.Resource.GetAttribute, &m, &result.Diagnostics)
+
+	if result.Diagnostics.HasError() {
+		return result
+	}
+
+	result.Diagnostics.Append(result.Resource.Set(ctx, m)...)
+
+	return result
+}
+
+// readListedVM reads a listed VM, with the same defaults as an imported one.
+func readListedVM(
+	ctx context.Context,
+	client proxmox.Client,
+	getAttribute func(context.Context, path.Path, any) diag.Diagnostics,
+	m *Model,
+	diags *diag.Diagnostics,
+) {
+	var ts timeouts.Value
+
+	diags.Append(getAttribute(ctx, path.Root("timeouts"), &ts)...)
+
+	if diags.HasError() {
+		return
+	}
+
+	m.Timeouts = ts
+
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+
+	if !read(ctx, client, m, diags) {
+		diags.AddError(
+			fmt.Sprintf("Unable to Read VM %d", m.ID.ValueInt64()),
+			fmt.Sprintf("VM does not exist on node %q", m.NodeName.ValueString()),
+		)
+
+		return
+	}
+
+	m.StopOnDestroy = types.BoolValue(false)
+	m.PurgeOnDestroy = types.BoolValue(true)
+	m.DeleteUnreferencedDisksOnDestroy = types.BoolValue(true)
+}
@@ -0,0 +1,170 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/query"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test/faketest"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/containers"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/pools"
+)
+
+func TestListResource(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	s, client := faketest.NewClient(t, fake.WithNodes("pve", "pve2"))
+
+	require.NoError(t, client.Pool().CreatePool(ctx, &pools.PoolCreateRequestBody{ID: "prod"}))
+
+	createVM := func(node string, vmid int, name string, tags string, pool *string) {
+		t.Helper()
+
+		require.NoError(t, client.Node(node).VM(0).CreateVM(ctx, &vms.CreateRequestBody{
+			VMID:   vmid,
+			Name:   &name,
+			Tags:   &tags,
+			PoolID: pool,
+		}).Err())
+	}
+
+	createVM("pve", 102, "db", "db;prod", new("prod"))
+	createVM("pve", 100, "web", "web;prod", new("prod"))
+	createVM("pve2", 101, "web-staging", "web", nil)
+
+	tmpl, err := s.AddVolume("pve", "local", "vztmpl", "debian-12-standard_12.7-1_amd64.tar.zst", 1<<20)
+	require.NoError(t, err)
+
+	require.NoError(t, client.Node("pve").Container(103).CreateContainer(ctx, &containers.CreateRequestBody{
+		VMID:                 new(103),
+		OSTemplateFileVolume: &tmpl,
+		Tags:                 new("web;prod"),
+	}).Err())
+
+	lr := &ListResource{}
+
+	var configureResp resource.ConfigureResponse
+
+	lr.Configure(ctx, resource.ConfigureRequest{ProviderData: config.Resource{Client: client}}, &configureResp)
+	require.False(t, configureResp.Diagnostics.HasError())
+
+	var schemaResp resource.SchemaResponse
+
+	(&Resource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	filterSchema := query.GuestFilterSchema("VMs")
+
+	listRequest := func(filter query.GuestFilterModel, limit int64, includeResource bool) list.ListRequest {
+		t.Helper()
+
+		// the config is built as a state, which can be set from the model
+		cfg := tfsdk.State{
+			Raw:    tftypes.NewValue(filterSchema.Type().TerraformType(ctx), nil),
+			Schema: filterSchema,
+		}
+
+		require.False(t, cfg.Set(ctx, filter).HasError())
+
+		return list.ListRequest{
+			Config:                 tfsdk.Config{Raw: cfg.Raw, Schema: cfg.Schema},
+			IncludeResource:        includeResource,
+			Limit:                  limit,
+			ResourceSchema:         schemaResp.Schema,
+			ResourceIdentitySchema: identitySchema(),
+		}
+	}
+
+	listVMs := func(filter query.GuestFilterModel, limit int64) []identityModel {
+		t.Helper()
+
+		stream := list.ListResultsStream{}
+
+		lr.List(ctx, listRequest(filter, limit, false), &stream)
+
+		var ids []identityModel
+
+		for result := range stream.Results {
+			require.False(t, result.Diagnostics.HasError(), result.Diagnostics)
+			assert.Nil(t, result.Resource)
+
+			var id identityModel
+
+			require.False(t, result.Identity.Get(ctx, &id).HasError())
+
+			ids = append(ids, id)
+		}
+
+		return ids
+	}
+
+	vm := func(node string, id int64) identityModel {
+		return identityModel{NodeName: types.StringValue(node), ID: types.Int64Value(id)}
+	}
+
+	assert.Equal(t,
+		[]identityModel{vm("pve", 100), vm("pve2", 101), vm("pve", 102)},
+		listVMs(query.GuestFilterModel{}, 0),
+		"all VMs, ordered by ID, without the container",
+	)
+
+	assert.Equal(t,
+		[]identityModel{vm("pve", 100), vm("pve2", 101)},
+		listVMs(query.GuestFilterModel{}, 2),
+	)
+
+	assert.Equal(t,
+		[]identityModel{vm("pve", 100), vm("pve", 102)},
+		listVMs(query.GuestFilterModel{NodeName: types.StringValue("pve")}, 0),
+	)
+
+	assert.Equal(t,
+		[]identityModel{vm("pve", 100)},
+		listVMs(query.GuestFilterModel{Tags: []types.String{types.StringValue("prod"), types.StringValue("web")}}, 0),
+	)
+
+	assert.Equal(t,
+		[]identityModel{vm("pve", 100), vm("pve", 102)},
+		listVMs(query.GuestFilterModel{Pool: types.StringValue("prod")}, 0),
+	)
+
+	assert.Empty(t, listVMs(query.GuestFilterModel{Pool: types.StringValue("prod"), NodeName: types.StringValue("pve2")}, 0))
+
+	stream := list.ListResultsStream{}
+
+	lr.List(ctx, listRequest(query.GuestFilterModel{NodeName: types.StringValue("pve2")}, 0, true), &stream)
+
+	count := 0
+
+	for result := range stream.Results {
+		count++
+
+		require.False(t, result.Diagnostics.HasError(), result.Diagnostics)
+		assert.Equal(t, "web-staging", result.DisplayName)
+
+		var m Model
+
+		require.False(t, result.Resource.Get(ctx, &m).HasError())
+		assert.Equal(t, "web-staging", m.Name.ValueString())
+		assert.True(t, m.PurgeOnDestroy.ValueBool())
+	}
+
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,137 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vm
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/tasks"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// MigrationModel represents the migration settings used when the VM moves to another node.
+type MigrationModel struct {
+	WithLocalDisks types.Bool        `tfsdk:"with_local_disks"`
+	TargetStorage  map[string]string `tfsdk:"target_storage"`
+	Network        types.String      `tfsdk:"network"`
+	BandwidthLimit types.Int64       `tfsdk:"bandwidth_limit"`
+}
+
+func migrationResourceSchema() schema.Attribute {
+	return schema.SingleNestedAttribute{
+		Description: "The settings used to migrate the VM when `node_name` changes.",
+		MarkdownDescription: "The settings used to migrate the VM when `node_name` changes. A running VM is " +
+			"migrated online, a stopped VM offline. The migration task log is streamed to the provider log.",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"with_local_disks": schema.BoolAttribute{
+				Description: "Whether to live-migrate local disks along with a running VM.",
+				MarkdownDescription: "Whether to live-migrate local disks along with a running VM " +
+					"(defaults to `false`). Local disks of stopped VMs are always migrated.",
+				Optional: true,
+			},
+			"target_storage": schema.MapAttribute{
+				Description: "The mapping of source storages to storages on the target node.",
+				MarkdownDescription: "The mapping of source storages to storages on the target node. " +
+					"The `*` key sets the target storage for all storages without a mapping. " +
+					"Local disks stay on a storage with the same name by default.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"network": schema.StringAttribute{
+				Description: "The CIDR of the network used for the migration, e.g. `10.1.2.0/24`.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.NewParseValidator(netip.ParsePrefix, "value must be a valid CIDR"),
+				},
+			},
+			"bandwidth_limit": schema.Int64Attribute{
+				Description: "The bandwidth limit of the migration in KiB/s.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// toAPI builds the request to migrate the VM to the target node.
+func (m *MigrationModel) toAPI(target string, online bool) *vms.MigrateRequestBody {
+	body := &vms.MigrateRequestBody{TargetNode: target}
+
+	if online {
+		body.OnlineMigration = proxmoxtypes.CustomBool(true).Pointer()
+	}
+
+	if m == nil {
+		return body
+	}
+
+	if m.WithLocalDisks.ValueBool() {
+		body.WithLocalDisks = proxmoxtypes.CustomBool(true).Pointer()
+	}
+
+	if len(m.TargetStorage) > 0 {
+		body.TargetStorage = new(proxmoxtypes.TargetStorageMapping(m.TargetStorage).String())
+	}
+
+	body.MigrationNetwork = m.Network.ValueStringPointer()
+
+	if !m.BandwidthLimit.IsNull() {
+		body.BandwidthLimit = new(int(m.BandwidthLimit.ValueInt64()))
+	}
+
+	return body
+}
+
+// migrate moves the VM from the node in the state to the node in the plan, streaming the
+// migration task log to the provider log.
+func (r *Resource) migrate(ctx context.Context, plan, state Model, diags *diag.Diagnostics) {
+	vmID := int(state.ID.ValueInt64())
+	source := state.NodeName.ValueString()
+	target := plan.NodeName.ValueString()
+
+	vmAPI := r.client.Node(source).VM(vmID)
+
+	status, err := vmAPI.GetVMStatus(ctx)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Unable to Read VM %d Status", vmID), err.Error())
+		return
+	}
+
+	online := status.Status == "running"
+
+	tflog.Info(ctx, fmt.Sprintf("Migrating VM %d from node %q to node %q", vmID, source, target), map[string]any{
+		"online": online,
+	})
+
+	result := vmAPI.MigrateVM(ctx, plan.Migration.toAPI(target, online), tasks.WithLogHandler(func(line string) {
+		tflog.Info(ctx, "VM migration: "+line, map[string]any{
+			"vm_id": vmID,
+		})
+	}))
+
+	result.AddDiags(diags, fmt.Sprintf("Unable to Migrate VM %d to Node %s", vmID, target))
+}
@@ -0,0 +1,86 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test/faketest"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+func TestMigrationToAPI(t *testing.T) {
+	t.Parallel()
+
+	var empty *MigrationModel
+
+	assert.Equal(t, &vms.MigrateRequestBody{TargetNode: "pve2"}, empty.toAPI("pve2", false))
+
+	m := &MigrationModel{
+		WithLocalDisks: types.BoolValue(true),
+		TargetStorage:  map[string]string{"local-lvm": "fast", "*": "slow", "ceph": "ceph"},
+		Network:        types.StringValue("10.1.2.0/24"),
+		BandwidthLimit: types.Int64Value(102400),
+	}
+
+	assert.Equal(t, &vms.MigrateRequestBody{
+		BandwidthLimit:   new(102400),
+		MigrationNetwork: new("10.1.2.0/24"),
+		OnlineMigration:  proxmoxtypes.CustomBool(true).Pointer(),
+		TargetNode:       "pve2",
+		TargetStorage:    new("slow,ceph:ceph,local-lvm:fast"),
+		WithLocalDisks:   proxmoxtypes.CustomBool(true).Pointer(),
+	}, m.toAPI("pve2", true))
+}
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	_, client := faketest.NewClient(t, fake.WithNodes("pve1", "pve2"))
+	r := &Resource{client: client}
+
+	createBody := &vms.CreateRequestBody{VMID: 100}
+	createBody.AddCustomStorageDevice("scsi0", vms.CustomStorageDevice{FileVolume: "local-lvm:8"})
+
+	vmAPI := client.Node("pve1").VM(100)
+	require.NoError(t, vmAPI.CreateVM(ctx, createBody).Err())
+	require.NoError(t, vmAPI.StartVM(ctx, 30).Err())
+
+	state := Model{ID: types.Int64Value(100), NodeName: types.StringValue("pve1")}
+	plan := Model{ID: types.Int64Value(100), NodeName: types.StringValue("pve2")}
+
+	var diags diag.Diagnostics
+
+	r.migrate(ctx, plan, state, &diags)
+	require.True(t, diags.HasError(), "running VMs with local disks need with_local_disks")
+	assert.Contains(t, diags.Errors()[0].Detail(), "with-local-disks")
+
+	plan.Migration = &MigrationModel{
+		WithLocalDisks: types.BoolValue(true),
+		TargetStorage:  map[string]string{"*": "local-lvm"},
+	}
+	diags = nil
+
+	r.migrate(ctx, plan, state, &diags)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	status, err := client.Node("pve2").VM(100).GetVMStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "running", status.Status)
+
+	_, err = vmAPI.GetVM(ctx)
+	require.Error(t, err)
+}
@@ -34,7 +34,11 @@ type Model struct {
 	Description                      types.String    `tfsdk:"description"`
 	CDROM                            cdrom.Value     `tfsdk:"cdrom"`
 	CPU                              cpu.Value       `tfsdk:"cpu"`
+	GuestUsername                    types.String    `tfsdk:"guest_username"`
+	GuestPasswordWO                  types.String    `tfsdk:"guest_password_wo"`
+	GuestPasswordWOVersion           types.Int64     `tfsdk:"guest_password_wo_version"`
 	ID                               types.Int64     `tfsdk:"id"`
+	Migration                        *MigrationModel `tfsdk:"migration"`
 	Name                             types.String    `tfsdk:"name"`
 	NodeName                         types.String    `tfsdk:"node_name"`
 	RNG                              rng.Value       `tfsdk:"rng"`
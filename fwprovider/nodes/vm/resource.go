@@ -69,6 +69,7 @@ func (r *Resource) Metadata(
 	resp *resource.MetadataResponse,
 ) {
 	resp.TypeName = req.ProviderTypeName + "_vm2"
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 // Configure sets the client for the resource.
@@ -125,6 +126,12 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	password := guestPasswordWO(ctx, req.Config, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	r.create(ctx, plan, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
@@ -146,6 +153,11 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	// set state to the updated plan data
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	setIdentity(ctx, resp.Identity, plan, &resp.Diagnostics)
+
+	// the VM is in the state before the guest agent is involved, so that a failure to set the
+	// password does not leave the created VM unmanaged
+	r.setInitialGuestPassword(ctx, plan, password, &resp.Diagnostics)
 }
 
 func (r *Resource) create(ctx context.Context, plan Model, diags *diag.Diagnostics) {
@@ -214,6 +226,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 
 	// store updated state
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	setIdentity(ctx, resp.Identity, state, &resp.Diagnostics)
 }
 
 // Update updates the VM with the new configuration.
@@ -233,8 +246,18 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	password := guestPasswordWO(ctx, req.Config, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	r.update(ctx, plan, state, &resp.Diagnostics)
 
+	if !resp.Diagnostics.HasError() && guestPasswordChanged(plan, state) {
+		r.setGuestPassword(ctx, plan, password, &resp.Diagnostics)
+	}
+
 	// read back the VM from the PVE API to populate computed fields
 	exists := read(ctx, r.client, &plan, &resp.Diagnostics)
 	if !exists {
@@ -250,10 +273,19 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	// set state to the updated plan data
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	setIdentity(ctx, resp.Identity, plan, &resp.Diagnostics)
 }
 
 // update updates the VM with the new configuration.
 func (r *Resource) update(ctx context.Context, plan, state Model, diags *diag.Diagnostics) {
+	if !plan.NodeName.Equal(state.NodeName) {
+		r.migrate(ctx, plan, state, diags)
+
+		if diags.HasError() {
+			return
+		}
+	}
+
 	vmAPI := r.client.Node(plan.NodeName.ValueString()).VM(int(plan.ID.ValueInt64()))
 
 	updateBody := &vms.UpdateRequestBody{}
@@ -387,13 +419,19 @@ func (r *Resource) ImportState(
 	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
 	defer cancel()
 
-	nodeName, vmid, found := strings.Cut(req.ID, "/")
+	importIdentifier := importID(ctx, req, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeName, vmid, found := strings.Cut(importIdentifier, "/")
 
 	id, err := strconv.Atoi(vmid)
 	if !found || err != nil || id == 0 {
 		resp.Diagnostics.AddError(
 			"Unable to Import VM",
-			fmt.Sprintf("Expected import identifier with format: `node_name/id`. Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: `node_name/id`. Got: %q", importIdentifier),
 		)
 
 		return
@@ -432,6 +470,7 @@ func (r *Resource) ImportState(
 
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
+	setIdentity(ctx, resp.Identity, state, &resp.Diagnostics)
 }
 
 // Shutdown the VM, then wait for it to actually shut down (it may not be shut down immediately if
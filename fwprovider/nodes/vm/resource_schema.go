@@ -11,7 +11,9 @@ import (
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -47,6 +49,43 @@ func (r *Resource) Schema(
 				Description: "The description of the VM.",
 				Optional:    true,
 			},
+			"guest_username": schema.StringAttribute{
+				Description: "The name of the guest user whose password is set with `guest_password_wo`.",
+				MarkdownDescription: "The name of the guest user whose password is set with `guest_password_wo`, " +
+					"e.g. `Administrator` or `root`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("guest_password_wo")),
+				},
+			},
+			"guest_password_wo": schema.StringAttribute{
+				Description: "The password of the guest user (write-only), set with the QEMU guest agent.",
+				MarkdownDescription: "The password of the guest user, set with the QEMU guest agent and supplied as a " +
+					"[write-only argument](https://developer.hashicorp.com/terraform/language/resources/ephemeral/write-only) " +
+					"so it is stored neither in the VM configuration nor in Terraform state. Requires Terraform 1.11+. " +
+					"The VM must be running with the agent enabled. This resource does not start the VM, so the " +
+					"password is not set when the VM is created; start the VM and increment " +
+					"`guest_password_wo_version` to set it. The password is set whenever `guest_username` or " +
+					"`guest_password_wo_version` changes, once the guest agent responds.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(5),
+					stringvalidator.AlsoRequires(path.MatchRoot("guest_username")),
+				},
+			},
+			"guest_password_wo_version": schema.Int64Attribute{
+				Description: "Version counter for guest_password_wo.",
+				MarkdownDescription: "Version counter for `guest_password_wo`. Because write-only values are not stored in " +
+					"state, Terraform cannot detect when `guest_password_wo` changes; increment this value to rotate " +
+					"the password of the guest user.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("guest_password_wo")),
+				},
+			},
 			"id": schema.Int64Attribute{
 				Computed: true,
 				Optional: true,
@@ -56,6 +95,7 @@ func (r *Resource) Schema(
 				},
 				Description: "The unique identifier of the VM in the Proxmox cluster.",
 			},
+			"migration": migrationResourceSchema(),
 			"name": schema.StringAttribute{
 				Description:         "The name of the VM.",
 				MarkdownDescription: "The name of the VM. Doesn't have to be unique.",
@@ -69,7 +109,9 @@ func (r *Resource) Schema(
 			},
 			"node_name": schema.StringAttribute{
 				Description: "The name of the node where the VM is provisioned.",
-				Required:    true,
+				MarkdownDescription: "The name of the node where the VM is provisioned. Changing it migrates " +
+					"the VM to the new node, see `migration`.",
+				Required: true,
 			},
 			"rng": rng.ResourceSchema(),
 			"stop_on_destroy": schema.BoolAttribute{
@@ -37,6 +37,7 @@ func NewShortResource() resource.Resource {
 // Metadata defines the short resource type name.
 func (r *resourceShort) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = shortResourceTypeName
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 // Schema returns the schema with no deprecation message (this is the canonical name).
@@ -7,15 +7,21 @@
 package fwprovider
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -40,20 +46,31 @@ import (
 	sdnsubnet "github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/subnet"
 	sdnvnet "github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/vnet"
 	sdnzone "github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/zone"
+	clusterstatus "github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/status"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/vmid"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/functions"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/apt"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/backups"
 	cephpool "github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/ceph/pool"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/clonedvm"
 	nodeconfig "github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/console"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/container"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/datastores"
 	diskzfs "github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/disks/zfs"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/file"
 	nodefirewall "github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/firewall"
 	nodeHardware "github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/hardware"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/network"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/power"
+	nodeservices "github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/services"
+	nodetasks "github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/tasks"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/vm"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/vm/agent"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/pools"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/query"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/storage"
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
@@ -64,7 +81,13 @@ import (
 )
 
 // Ensure the implementation satisfies the expected interfaces.
-var _ provider.Provider = &proxmoxProvider{}
+var (
+	_ provider.Provider                       = &proxmoxProvider{}
+	_ provider.ProviderWithActions            = &proxmoxProvider{}
+	_ provider.ProviderWithEphemeralResources = &proxmoxProvider{}
+	_ provider.ProviderWithFunctions          = &proxmoxProvider{}
+	_ provider.ProviderWithListResources      = &proxmoxProvider{}
+)
 
 // New is a helper function to simplify provider server and testing implementation.
 func New(version string) func() provider.Provider {
@@ -105,18 +128,32 @@ type proxmoxProviderModel struct {
 		Socks5Username  types.String `tfsdk:"socks5_username"`
 		Socks5Password  types.String `tfsdk:"socks5_password"`
 
+		KnownHostsFile        types.String `tfsdk:"known_hosts_file"`
+		StrictHostKeyChecking types.Bool   `tfsdk:"strict_host_key_checking"`
+
 		NodeAddressSource types.String `tfsdk:"node_address_source"`
 
+		Bastion []struct {
+			Address    types.String `tfsdk:"address"`
+			Port       types.Int64  `tfsdk:"port"`
+			Username   types.String `tfsdk:"username"`
+			Password   types.String `tfsdk:"password"`
+			PrivateKey types.String `tfsdk:"private_key"`
+		} `tfsdk:"bastion"`
+
 		Nodes []struct {
-			Name    types.String `tfsdk:"name"`
-			Address types.String `tfsdk:"address"`
-			Port    types.Int64  `tfsdk:"port"`
+			Name     types.String   `tfsdk:"name"`
+			Address  types.String   `tfsdk:"address"`
+			Port     types.Int64    `tfsdk:"port"`
+			HostKeys []types.String `tfsdk:"host_keys"`
 		} `tfsdk:"node"`
 	} `tfsdk:"ssh"`
 	TmpDir         types.String `tfsdk:"tmp_dir"`
 	RandomVMIDs    types.Bool   `tfsdk:"random_vm_ids"`
 	RandomVMIDStat types.Int64  `tfsdk:"random_vm_id_start"`
 	RandomVMIDEnd  types.Int64  `tfsdk:"random_vm_id_end"`
+	RequireQuorum  types.Bool   `tfsdk:"require_quorum"`
+	VMIDLeases     types.Bool   `tfsdk:"vm_id_leases"`
 }
 
 func (p *proxmoxProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -184,6 +221,12 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:    true,
 				Validators:  []validator.Int64{int64validator.Between(100, 999999999)},
 			},
+			"require_quorum": schema.BoolAttribute{
+				Description: "Whether to refuse the changes when the cluster is not quorate or when the " +
+					"target node is offline, instead of failing halfway through an operation. The cluster status is " +
+					"checked before every modifying API request and every SSH command or upload.",
+				Optional: true,
+			},
 			"tmp_dir": schema.StringAttribute{
 				Description: "The alternative temporary directory.",
 				Optional:    true,
@@ -192,6 +235,12 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Description: "The username for the Proxmox VE API.",
 				Optional:    true,
 			},
+			"vm_id_leases": schema.BoolAttribute{
+				Description: "Whether to lease generated VM / Container IDs cluster-wide, so that " +
+					"provider instances on different machines do not generate the same ID. Leases are stored as " +
+					"resource pools, which requires the `Pool.Allocate` privilege.",
+				Optional: true,
+			},
 		},
 		Blocks: map[string]schema.Block{
 			// have to define it as a list due to backwards compatibility
@@ -251,6 +300,19 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 								"Defaults to the value of the `PROXMOX_VE_SSH_SOCKS5_USERNAME` environment variable.",
 							Optional: true,
 						},
+						"known_hosts_file": schema.StringAttribute{
+							Description: "The path to the known_hosts file used to verify the host keys of " +
+								"the Proxmox VE nodes. Defaults to the value of the " +
+								"`PROXMOX_VE_SSH_KNOWN_HOSTS_FILE` environment variable, or `~/.ssh/known_hosts` if not set.",
+							Optional: true,
+						},
+						"strict_host_key_checking": schema.BoolAttribute{
+							Description: "Whether to reject nodes whose host key is neither pinned in a `node` " +
+								"block nor present in the known_hosts file, instead of adding them to the file " +
+								"on first use. Defaults to the value of the " +
+								"`PROXMOX_VE_SSH_STRICT_HOST_KEY_CHECKING` environment variable, or `false` if not set.",
+							Optional: true,
+						},
 						"node_address_source": schema.StringAttribute{
 							Description: "The method used to resolve node IP addresses for SSH connections. " +
 								"Set to `dns` to skip the Proxmox API-based resolution and use local DNS instead. " +
@@ -270,6 +332,44 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 						},
 					},
 					Blocks: map[string]schema.Block{
+						"bastion": schema.ListNestedBlock{
+							Description: "The SSH jump host (bastion) the connections to the Proxmox VE nodes " +
+								"are tunnelled through, like `ssh -J`. If a SOCKS5 proxy is configured, the " +
+								"bastion is reached through it.",
+							Validators: []validator.List{
+								listvalidator.SizeAtMost(1),
+							},
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"address": schema.StringAttribute{
+										Description: "The address of the bastion host.",
+										Required:    true,
+									},
+									"port": schema.Int64Attribute{
+										Description: "The SSH port of the bastion host. Defaults to `22`.",
+										Optional:    true,
+										Validators:  []validator.Int64{int64validator.Between(1, 65535)},
+									},
+									"username": schema.StringAttribute{
+										Description: "The username used for the bastion host. " +
+											"Defaults to the SSH `username`.",
+										Optional: true,
+									},
+									"password": schema.StringAttribute{
+										Description: "The password used for the bastion host. " +
+											"Defaults to the SSH `password`.",
+										Optional:  true,
+										Sensitive: true,
+									},
+									"private_key": schema.StringAttribute{
+										Description: "The unencrypted private key (in PEM format) used for the " +
+											"bastion host. Defaults to the SSH `private_key`.",
+										Optional:  true,
+										Sensitive: true,
+									},
+								},
+							},
+						},
 						"node": schema.ListNestedBlock{
 							Description: "Overrides for SSH connection configuration for a Proxmox VE node.",
 							NestedObject: schema.NestedBlockObject{
@@ -287,6 +387,13 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 										Optional:    true,
 										Validators:  []validator.Int64{int64validator.Between(1, 65535)},
 									},
+									"host_keys": schema.ListAttribute{
+										Description: "The pinned SSH host keys of the Proxmox VE node in the " +
+											"`authorized_keys` format, e.g. `ssh-ed25519 AAAA...`. If set, " +
+											"the known_hosts file is not used for the node.",
+										Optional:    true,
+										ElementType: types.StringType,
+									},
 								},
 							},
 						},
@@ -428,6 +535,13 @@ func (p *proxmoxProvider) Configure(
 		)
 	}
 
+	var quorumGuard *cluster.QuorumGuard
+
+	if cfg.RequireQuorum.ValueBool() {
+		quorumGuard = cluster.NewQuorumGuard(apiClient)
+		apiClient = quorumGuard.API()
+	}
+
 	sshUsername := utils.GetAnyStringEnv("PROXMOX_VE_SSH_USERNAME")
 	sshPassword := utils.GetAnyStringEnv("PROXMOX_VE_SSH_PASSWORD")
 	sshAgent := utils.GetAnyBoolEnv("PROXMOX_VE_SSH_AGENT")
@@ -437,6 +551,13 @@ func (p *proxmoxProvider) Configure(
 	sshSocks5Server := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_SERVER")
 	sshSocks5Username := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_USERNAME")
 	sshSocks5Password := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_PASSWORD")
+	sshKnownHostsFile := utils.GetAnyStringEnv("PROXMOX_VE_SSH_KNOWN_HOSTS_FILE")
+	sshStrictHostKeyChecking := utils.GetAnyBoolEnv("PROXMOX_VE_SSH_STRICT_HOST_KEY_CHECKING")
+	sshBastionAddress := utils.GetAnyStringEnv("PROXMOX_VE_SSH_BASTION_ADDRESS")
+	sshBastionPort := utils.GetAnyStringEnv("PROXMOX_VE_SSH_BASTION_PORT")
+	sshBastionUsername := utils.GetAnyStringEnv("PROXMOX_VE_SSH_BASTION_USERNAME")
+	sshBastionPassword := ""
+	sshBastionPrivateKey := ""
 	nodeOverrides := map[string]ssh.ProxmoxNode{}
 
 	//nolint: nestif
@@ -477,15 +598,56 @@ func (p *proxmoxProvider) Configure(
 			sshSocks5Password = cfg.SSH[0].Socks5Password.ValueString()
 		}
 
+		if !cfg.SSH[0].KnownHostsFile.IsNull() {
+			sshKnownHostsFile = cfg.SSH[0].KnownHostsFile.ValueString()
+		}
+
+		if !cfg.SSH[0].StrictHostKeyChecking.IsNull() {
+			sshStrictHostKeyChecking = cfg.SSH[0].StrictHostKeyChecking.ValueBool()
+		}
+
+		if len(cfg.SSH[0].Bastion) > 0 {
+			b := cfg.SSH[0].Bastion[0]
+
+			sshBastionAddress = b.Address.ValueString()
+			sshBastionPort = ""
+			sshBastionPassword = b.Password.ValueString()
+			sshBastionPrivateKey = b.PrivateKey.ValueString()
+
+			if !b.Port.IsNull() {
+				sshBastionPort = strconv.FormatInt(b.Port.ValueInt64(), 10)
+			}
+
+			if !b.Username.IsNull() {
+				sshBastionUsername = b.Username.ValueString()
+			}
+		}
+
 		for _, n := range cfg.SSH[0].Nodes {
 			nodePort := int32(n.Port.ValueInt64())
 			if nodePort == 0 {
 				nodePort = 22
 			}
 
+			hostKeys := make([]string, 0, len(n.HostKeys))
+			for _, k := range n.HostKeys {
+				hostKeys = append(hostKeys, k.ValueString())
+			}
+
+			pinnedKeys, err := ssh.ParseHostKeys(hostKeys)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					fmt.Sprintf("Invalid SSH host key for node %q", n.Name.ValueString()),
+					err.Error(),
+				)
+
+				return
+			}
+
 			nodeOverrides[n.Name.ValueString()] = ssh.ProxmoxNode{
-				Address: n.Address.ValueString(),
-				Port:    nodePort,
+				Address:  n.Address.ValueString(),
+				Port:     nodePort,
+				HostKeys: pinnedKeys,
 			}
 		}
 	}
@@ -518,10 +680,39 @@ func (p *proxmoxProvider) Configure(
 		}
 	}
 
+	sshOptions := []ssh.ClientOption{
+		ssh.WithKnownHostsFile(sshKnownHostsFile),
+		ssh.WithStrictHostKeyChecking(sshStrictHostKeyChecking),
+	}
+
+	if sshBastionAddress != "" {
+		bastion := &ssh.Bastion{
+			Address:    sshBastionAddress,
+			Username:   sshBastionUsername,
+			Password:   cmp.Or(sshBastionPassword, sshPassword),
+			PrivateKey: cmp.Or(sshBastionPrivateKey, sshPrivateKey),
+			Agent:      sshAgent,
+		}
+
+		if sshBastionPort != "" {
+			port, e := strconv.ParseUint(sshBastionPort, 10, 16)
+			if e != nil {
+				resp.Diagnostics.AddError("Invalid SSH bastion port", e.Error())
+
+				return
+			}
+
+			bastion.Port = int32(port)
+		}
+
+		sshOptions = append(sshOptions, ssh.WithBastion(bastion))
+	}
+
 	sshClient, err := ssh.NewClient(
 		sshUsername, sshPassword, sshAgent, sshAgentSocket, sshAgentForwarding, sshPrivateKey,
 		sshSocks5Server, sshSocks5Username, sshSocks5Password,
 		nodeResolver,
+		sshOptions...,
 	)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -534,6 +725,10 @@ func (p *proxmoxProvider) Configure(
 		return
 	}
 
+	if quorumGuard != nil {
+		sshClient = quorumGuard.SSH(sshClient)
+	}
+
 	// Intentionally use 'PROXMOX_VE_TMPDIR' with 'TMP' instead of 'TEMP', to match os.TempDir's use of $TMPDIR
 	tmpDirOverride := utils.GetAnyStringEnv("PROXMOX_VE_TMPDIR", "PM_VE_TMPDIR")
 
@@ -543,21 +738,34 @@ func (p *proxmoxProvider) Configure(
 
 	client := proxmox.NewClient(apiClient, sshClient, tmpDirOverride)
 
-	resp.ResourceData = config.Resource{
+	resourceData := config.Resource{
 		Client: client,
 		IDGenerator: cluster.NewIDGenerator(
 			client.Cluster(),
 			cluster.IDGeneratorConfig{
-				RandomIDs:    cfg.RandomVMIDs.ValueBool(),
-				RandomIDStat: int(cfg.RandomVMIDStat.ValueInt64()),
-				RandomIDEnd:  int(cfg.RandomVMIDEnd.ValueInt64()),
+				RandomIDs:     cfg.RandomVMIDs.ValueBool(),
+				RandomIDStat:  int(cfg.RandomVMIDStat.ValueInt64()),
+				RandomIDEnd:   int(cfg.RandomVMIDEnd.ValueInt64()),
+				ClusterLeases: cfg.VMIDLeases.ValueBool(),
 			},
 		),
 	}
 
+	resp.ResourceData = resourceData
+	resp.ListResourceData = resourceData
+
 	resp.DataSourceData = config.DataSource{
 		Client: client,
 	}
+
+	resp.ActionData = config.Action{
+		Client: client,
+	}
+
+	resp.EphemeralResourceData = config.EphemeralResource{
+		Client:   client,
+		Endpoint: endpoint,
+	}
 }
 
 func (p *proxmoxProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -584,13 +792,15 @@ func (p *proxmoxProvider) Resources(_ context.Context) []func() resource.Resourc
 		cephpool.NewCephPoolResource,
 		clonedvm.NewResource,
 		clonedvm.NewShortResource,
+		container.NewProvisionerResource, // proxmox_container_provisioner
 		diskzfs.NewZFSPoolResource,
 		ha.NewHAGroupResource,
 		ha.NewHAGroupShortResource, // proxmox_hagroup
 		ha.NewHAResourceResource,
 		ha.NewHAResourceShortResource, // proxmox_haresource
 		ha.NewHARuleResource,
-		ha.NewHARuleShortResource, // proxmox_harule
+		ha.NewHARuleShortResource,     // proxmox_harule
+		ha.NewNodeMaintenanceResource, // proxmox_node_maintenance
 		hardwaremapping.NewDirResource,
 		hardwaremapping.NewDirResourceShort, // proxmox_hardware_mapping_dir
 		hardwaremapping.NewPCIResource,
@@ -613,6 +823,7 @@ func (p *proxmoxProvider) Resources(_ context.Context) []func() resource.Resourc
 		nodeconfig.NewNodeConfigResource,
 		nodefirewall.NewNodeFirewallOptionsResource,
 		nodefirewall.NewShortNodeFirewallOptionsResource,
+		nodeservices.NewServiceResource, // proxmox_node_service
 		options.NewClusterOptionsResource,
 		options.NewClusterOptionsShortResource,
 		pools.NewPoolMembershipResource,
@@ -658,6 +869,7 @@ func (p *proxmoxProvider) Resources(_ context.Context) []func() resource.Resourc
 		storage.NewZFSPoolStorageShortResource,
 		vm.NewResource,
 		vm.NewShortResource,
+		vmid.NewAllocationResource, // proxmox_vm_id_allocation
 		replication.NewResource,
 		replication.NewShortResource,
 	}
@@ -680,7 +892,9 @@ func (p *proxmoxProvider) DataSources(_ context.Context) []func() datasource.Dat
 		apt.NewStandardRepositoryDataSource,
 		apt.NewShortStandardRepositoryDataSource,
 		backup.NewDataSource,
-		cephstatus.NewDataSource, // proxmox_ceph_status
+		backups.NewDataSource,       // proxmox_backups
+		cephstatus.NewDataSource,    // proxmox_ceph_status
+		clusterstatus.NewDataSource, // proxmox_cluster_status
 		datastores.NewDataSource,
 		datastores.NewShortDataSource,
 		nodeconfig.NewNodeConfigDataSource,
@@ -693,6 +907,7 @@ func (p *proxmoxProvider) DataSources(_ context.Context) []func() datasource.Dat
 		ha.NewHAResourceShortDataSource, // proxmox_haresource
 		ha.NewHAResourcesDataSource,
 		ha.NewHAResourcesShortDataSource, // proxmox_haresources
+		ha.NewHAStatusDataSource,         // proxmox_ha_status
 		hardwaremapping.NewDataSource,
 		hardwaremapping.NewDataSourceShort, // proxmox_hardware_mappings
 		hardwaremapping.NewDirDataSource,
@@ -735,10 +950,62 @@ func (p *proxmoxProvider) DataSources(_ context.Context) []func() datasource.Dat
 		sdncontroller.NewEVPNControllerDataSource, // proxmox_sdn_controller_evpn
 		vm.NewDataSource,
 		vm.NewShortDataSource,
+		agent.NewGuestInfoDataSource, // proxmox_vm_guest_info
 		replication.NewDataSource,
 		replication.NewShortDataSource,
 		replication.NewReplicationsDataSource,
 		replication.NewReplicationsShortDataSource,
+		nodetasks.NewDataSource,            // proxmox_tasks
+		nodeservices.NewServicesDataSource, // proxmox_node_services
+	}
+}
+
+func (p *proxmoxProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		console.NewTicketEphemeralResource, // proxmox_console_ticket
+	}
+}
+
+func (p *proxmoxProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		query.NewContainerListResource, // proxmox_virtual_environment_container
+		query.NewPoolListResource,      // proxmox_virtual_environment_pool
+		query.NewUserListResource,      // proxmox_virtual_environment_user
+		query.NewVMListResource,        // proxmox_virtual_environment_vm
+		storage.NewCIFSStorageListResource,
+		storage.NewDirectoryStorageListResource,
+		storage.NewLVMPoolStorageListResource,
+		storage.NewLVMThinPoolStorageListResource,
+		storage.NewNFSStorageListResource,
+		storage.NewProxmoxBackupServerStorageListResource,
+		storage.NewZFSPoolStorageListResource,
+		vm.NewListResource, // proxmox_vm
+	}
+}
+
+func (p *proxmoxProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewEncodeNetFunction,
+		functions.NewHAResourceIDFunction,
+		functions.NewMACFromSeedFunction,
+		functions.NewParseDiskFunction,
+		functions.NewParseUPIDFunction,
+		functions.NewParseVolumeIDFunction,
+	}
+}
+
+func (p *proxmoxProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		backups.NewBackupNowAction,          // proxmox_backup_now
+		backups.NewFileRestoreAction,        // proxmox_backup_file_restore
+		agent.NewFSFreezeAction,             // proxmox_vm_fsfreeze
+		agent.NewFSTrimAction,               // proxmox_vm_fstrim
+		container.NewExecAction,             // proxmox_container_exec
+		replication.NewReplicationNowAction, // proxmox_replication_now
+		power.NewPowerAction,                // proxmox_node_power
+		power.NewStartAllAction,             // proxmox_node_start_all
+		power.NewStopAllAction,              // proxmox_node_stop_all
+		power.NewMigrateAllAction,           // proxmox_node_migrate_all
 	}
 }
 
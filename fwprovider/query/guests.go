@@ -0,0 +1,122 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+)
+
+// Guest types of the cluster resources.
+const (
+	GuestTypeVM        = "qemu"
+	GuestTypeContainer = "lxc"
+)
+
+// GuestFilterModel is the configuration of the list resources of VMs and containers.
+type GuestFilterModel struct {
+	NodeName types.String   `tfsdk:"node_name"`
+	Tags     []types.String `tfsdk:"tags"`
+	Pool     types.String   `tfsdk:"pool"`
+}
+
+// GuestFilterSchema returns the configuration schema of the list resources of VMs and containers.
+func GuestFilterSchema(guests string) schema.Schema {
+	return schema.Schema{
+		Description: fmt.Sprintf("Lists the %s of the cluster.", guests),
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: fmt.Sprintf("Only list the %s on this node.", guests),
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"tags": schema.ListAttribute{
+				Description: fmt.Sprintf("Only list the %s that have all of these tags.", guests),
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"pool": schema.StringAttribute{
+				Description: fmt.Sprintf("Only list the %s in this resource pool.", guests),
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// ListGuests returns the guests of the type that match the filter, ordered by ID.
+func ListGuests(
+	ctx context.Context,
+	client *cluster.Client,
+	guestType string,
+	filter GuestFilterModel,
+) ([]*cluster.ResourcesListResponseData, error) {
+	resources, err := client.GetClusterResources(ctx, "vm")
+	if err != nil {
+		return nil, fmt.Errorf("error listing guests: %w", err)
+	}
+
+	var guests []*cluster.ResourcesListResponseData
+
+	for _, r := range resources {
+		if r.Type != guestType || !filter.matches(r) {
+			continue
+		}
+
+		guests = append(guests, r)
+	}
+
+	slices.SortFunc(guests, func(a, b *cluster.ResourcesListResponseData) int {
+		return a.VMID - b.VMID
+	})
+
+	return guests, nil
+}
+
+func (f *GuestFilterModel) matches(r *cluster.ResourcesListResponseData) bool {
+	if !f.NodeName.IsNull() && r.NodeName != f.NodeName.ValueString() {
+		return false
+	}
+
+	if !f.Pool.IsNull() && r.Pool != f.Pool.ValueString() {
+		return false
+	}
+
+	tags := ParseTags(r.Tags)
+
+	for _, t := range f.Tags {
+		if !slices.Contains(tags, t.ValueString()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ParseTags splits the tags of a guest, as returned by the API.
+func ParseTags(tags string) []string {
+	return strings.FieldsFunc(tags, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' '
+	})
+}
@@ -0,0 +1,190 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package query
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/pools"
+	sdkresource "github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource"
+	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource/pool"
+)
+
+var (
+	_ list.ListResourceWithRawV6Schemas = &poolListResource{}
+	_ list.ListResourceWithConfigure    = &poolListResource{}
+	_ list.ListResourceWithRawV6Schemas = &userListResource{}
+	_ list.ListResourceWithConfigure    = &userListResource{}
+)
+
+// poolListResource lists the resource pools of the cluster.
+type poolListResource struct {
+	sdkListResource
+}
+
+// NewPoolListResource creates the list resource of proxmox_virtual_environment_pool.
+func NewPoolListResource() list.ListResource {
+	return &poolListResource{
+		sdkListResource: sdkListResource{
+			typeName: "proxmox_virtual_environment_pool",
+			resource: pool.Pool,
+		},
+	}
+}
+
+// ListResourceConfigSchema defines the schema of the list resource, which has no filters.
+func (r *poolListResource) ListResourceConfigSchema(
+	_ context.Context,
+	_ list.ListResourceSchemaRequest,
+	resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the resource pools of the cluster. The pools that store the VM ID claims of " +
+			"`vm_id_leases` and `proxmox_vm_id_allocation` are not listed.",
+	}
+}
+
+// List lists the resource pools, except the pools that store VM ID claims.
+func (r *poolListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	poolList, err := r.client.Pool().ListPools(ctx)
+	if err != nil {
+		stream.Results = listError("Unable to List Resource Pools", err)
+
+		return
+	}
+
+	poolList = slices.DeleteFunc(poolList, func(p *pools.PoolListResponseData) bool {
+		return cluster.IsIDClaimPool(p.ID)
+	})
+
+	slices.SortFunc(poolList, func(a, b *pools.PoolListResponseData) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, p := range poolList {
+			if limitReached(req, i) {
+				return
+			}
+
+			identity := map[string]any{
+				"pool_id": types.StringValue(p.ID),
+			}
+
+			attrs := map[string]any{
+				"id":      types.StringValue(p.ID),
+				"pool_id": types.StringValue(p.ID),
+				"comment": types.StringValue(stringOrEmpty(p.Comment)),
+			}
+
+			if !push(newSDKListResult(ctx, req, p.ID, identity, attrs)) {
+				return
+			}
+		}
+	}
+}
+
+// userListModel is the configuration of the list resource of users.
+type userListModel struct {
+	Realm types.String `tfsdk:"realm"`
+}
+
+// userListResource lists the users of the cluster.
+type userListResource struct {
+	sdkListResource
+}
+
+// NewUserListResource creates the list resource of proxmox_virtual_environment_user.
+func NewUserListResource() list.ListResource {
+	return &userListResource{
+		sdkListResource: sdkListResource{
+			typeName: "proxmox_virtual_environment_user",
+			resource: sdkresource.User,
+		},
+	}
+}
+
+// ListResourceConfigSchema defines the filters of the list resource.
+func (r *userListResource) ListResourceConfigSchema(
+	_ context.Context,
+	_ list.ListResourceSchemaRequest,
+	resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the users of the cluster.",
+		Attributes: map[string]schema.Attribute{
+			"realm": schema.StringAttribute{
+				Description: "Only list the users of this authentication realm, e.g. `pve`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// List lists the users of the realm.
+func (r *userListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter userListModel
+
+	diags := req.Config.Get(ctx, &filter)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	userList, err := r.client.Access().ListUsers(ctx)
+	if err != nil {
+		stream.Results = listError("Unable to List Users", err)
+
+		return
+	}
+
+	userList = slices.DeleteFunc(userList, func(u *access.UserListResponseData) bool {
+		_, realm, _ := strings.Cut(u.ID, "@")
+
+		return !filter.Realm.IsNull() && realm != filter.Realm.ValueString()
+	})
+
+	slices.SortFunc(userList, func(a, b *access.UserListResponseData) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, u := range userList {
+			if limitReached(req, i) {
+				return
+			}
+
+			identity := map[string]any{
+				"user_id": types.StringValue(u.ID),
+			}
+
+			attrs := map[string]any{
+				"id":      types.StringValue(u.ID),
+				"user_id": types.StringValue(u.ID),
+				"comment": types.StringValue(stringOrEmpty(u.Comment)),
+			}
+
+			if !push(newSDKListResult(ctx, req, u.ID, identity, attrs)) {
+				return
+			}
+		}
+	}
+}
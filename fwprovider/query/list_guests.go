@@ -0,0 +1,115 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package query
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	container "github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource/container"
+	vm "github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource/vm"
+)
+
+var (
+	_ list.ListResourceWithRawV6Schemas = &guestListResource{}
+	_ list.ListResourceWithConfigure    = &guestListResource{}
+)
+
+// guestListResource lists the VMs or containers of the cluster, as instances of the SDK
+// provider's VM or container resource.
+type guestListResource struct {
+	sdkListResource
+
+	guestType string
+	guests    string
+	title     string
+}
+
+// NewVMListResource creates the list resource of proxmox_virtual_environment_vm.
+func NewVMListResource() list.ListResource {
+	return &guestListResource{
+		sdkListResource: sdkListResource{
+			typeName: "proxmox_virtual_environment_vm",
+			resource: vm.VM,
+		},
+		guestType: GuestTypeVM,
+		guests:    "VMs",
+		title:     "VMs",
+	}
+}
+
+// NewContainerListResource creates the list resource of proxmox_virtual_environment_container.
+func NewContainerListResource() list.ListResource {
+	return &guestListResource{
+		sdkListResource: sdkListResource{
+			typeName: "proxmox_virtual_environment_container",
+			resource: container.Container,
+		},
+		guestType: GuestTypeContainer,
+		guests:    "containers",
+		title:     "Containers",
+	}
+}
+
+// ListResourceConfigSchema defines the filters of the list resource.
+func (r *guestListResource) ListResourceConfigSchema(
+	_ context.Context,
+	_ list.ListResourceSchemaRequest,
+	resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = GuestFilterSchema(r.guests)
+}
+
+// List lists the guests that match the filters.
+func (r *guestListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter GuestFilterModel
+
+	diags := req.Config.Get(ctx, &filter)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	guests, err := ListGuests(ctx, r.client.Cluster(), r.guestType, filter)
+	if err != nil {
+		stream.Results = listError("Unable to List "+r.title, err)
+
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, g := range guests {
+			if limitReached(req, i) {
+				return
+			}
+
+			displayName := g.Name
+			if displayName == "" {
+				displayName = strconv.Itoa(g.VMID)
+			}
+
+			identity := map[string]any{
+				"node_name": types.StringValue(g.NodeName),
+				"vm_id":     types.Int64Value(int64(g.VMID)),
+			}
+
+			attrs := map[string]any{
+				"id":        types.StringValue(strconv.Itoa(g.VMID)),
+				"node_name": types.StringValue(g.NodeName),
+				"vm_id":     types.Int64Value(int64(g.VMID)),
+			}
+
+			if !push(newSDKListResult(ctx, req, displayName, identity, attrs)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+)
+
+// sdkListResource is the base of the list resources of the managed resources of the SDK
+// provider. The listed instances carry their identity, and only the attributes that identify
+// them; Terraform reads the rest when it imports them.
+type sdkListResource struct {
+	client   proxmox.Client
+	typeName string
+	resource func() *schema.Resource
+}
+
+// Metadata defines the name of the list resource, which is the name of the managed resource.
+func (r *sdkListResource) Metadata(
+	_ context.Context,
+	_ resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = r.typeName
+}
+
+// RawV6Schemas returns the schemas of the managed resource.
+func (r *sdkListResource) RawV6Schemas(
+	ctx context.Context,
+	_ list.RawV6SchemaRequest,
+	resp *list.RawV6SchemaResponse,
+) {
+	sdkRawV6Schemas(ctx, r.resource(), resp)
+}
+
+// Configure sets the client for the list resource.
+func (r *sdkListResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// newSDKListResult returns a list result with the identity and, if requested, the attributes
+// of the resource.
+func newSDKListResult(
+	ctx context.Context,
+	req list.ListRequest,
+	displayName string,
+	identity map[string]any,
+	attrs map[string]any,
+) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = displayName
+
+	for name, val := range identity {
+		result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root(name), val)...)
+	}
+
+	if !req.IncludeResource {
+		result.Resource = nil
+
+		return result
+	}
+
+	for name, val := range attrs {
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root(name), val)...)
+	}
+
+	return result
+}
+
+// limitReached reports whether the number of results reached the limit of the request.
+func limitReached(req list.ListRequest, count int) bool {
+	return req.Limit > 0 && int64(count) >= req.Limit
+}
+
+// listError returns the results of a list that failed.
+func listError(summary string, err error) iter.Seq[list.ListResult] {
+	var diags diag.Diagnostics
+
+	diags.AddError(summary, err.Error())
+
+	return list.ListResultsStreamDiagnostics(diags)
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
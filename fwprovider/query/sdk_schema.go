@@ -0,0 +1,100 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package query
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// sdkRawV6Schemas sets the schemas of a managed resource of the SDK provider, which the list
+// resources of the resources that are not Framework resources must provide. The SDK provider
+// serves protocol 5, and is upgraded to protocol 6 for the mux server, so the schemas are
+// upgraded the same way.
+func sdkRawV6Schemas(ctx context.Context, r *schema.Resource, resp *list.RawV6SchemaResponse) {
+	resp.ProtoV6Schema = schemaToV6(r.ProtoSchema(ctx)())
+	resp.ProtoV6IdentitySchema = identitySchemaToV6(r.ProtoIdentitySchema(ctx)())
+}
+
+func schemaToV6(s *tfprotov5.Schema) *tfprotov6.Schema {
+	if s == nil {
+		return nil
+	}
+
+	return &tfprotov6.Schema{
+		Version: s.Version,
+		Block:   blockToV6(s.Block),
+	}
+}
+
+func blockToV6(b *tfprotov5.SchemaBlock) *tfprotov6.SchemaBlock {
+	if b == nil {
+		return nil
+	}
+
+	block := &tfprotov6.SchemaBlock{
+		Version:            b.Version,
+		Description:        b.Description,
+		DescriptionKind:    tfprotov6.StringKind(b.DescriptionKind),
+		Deprecated:         b.Deprecated,
+		DeprecationMessage: b.DeprecationMessage,
+	}
+
+	for _, a := range b.Attributes {
+		block.Attributes = append(block.Attributes, &tfprotov6.SchemaAttribute{
+			Name:               a.Name,
+			Type:               a.Type,
+			Description:        a.Description,
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			DescriptionKind:    tfprotov6.StringKind(a.DescriptionKind),
+			Deprecated:         a.Deprecated,
+			WriteOnly:          a.WriteOnly,
+			DeprecationMessage: a.DeprecationMessage,
+		})
+	}
+
+	for _, nb := range b.BlockTypes {
+		block.BlockTypes = append(block.BlockTypes, &tfprotov6.SchemaNestedBlock{
+			TypeName: nb.TypeName,
+			Block:    blockToV6(nb.Block),
+			Nesting:  tfprotov6.SchemaNestedBlockNestingMode(nb.Nesting),
+			MinItems: nb.MinItems,
+			MaxItems: nb.MaxItems,
+		})
+	}
+
+	return block
+}
+
+func identitySchemaToV6(s *tfprotov5.ResourceIdentitySchema) *tfprotov6.ResourceIdentitySchema {
+	if s == nil {
+		return nil
+	}
+
+	identity := &tfprotov6.ResourceIdentitySchema{
+		Version: s.Version,
+	}
+
+	for _, a := range s.IdentityAttributes {
+		identity.IdentityAttributes = append(identity.IdentityAttributes, &tfprotov6.ResourceIdentitySchemaAttribute{
+			Name:              a.Name,
+			Type:              a.Type,
+			RequiredForImport: a.RequiredForImport,
+			OptionalForImport: a.OptionalForImport,
+			Description:       a.Description,
+		})
+	}
+
+	return identity
+}
@@ -0,0 +1,57 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.ResourceWithIdentity = &cifsStorageResource{}
+	_ resource.ResourceWithIdentity = &directoryStorageResource{}
+	_ resource.ResourceWithIdentity = &lvmPoolStorageResource{}
+	_ resource.ResourceWithIdentity = &lvmThinPoolStorageResource{}
+	_ resource.ResourceWithIdentity = &nfsStorageResource{}
+	_ resource.ResourceWithIdentity = &pbsStorageResource{}
+	_ resource.ResourceWithIdentity = &zfsPoolStorageResource{}
+)
+
+// identityModel is the identity of a storage: its ID.
+type identityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+// IdentitySchema defines the identity of the resource.
+func (r *storageResource[T, M]) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "The ID of the storage.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// setIdentity stores the identity of the storage.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id types.String, diags *diag.Diagnostics) {
+	if identity == nil {
+		return
+	}
+
+	diags.Append(identity.Set(ctx, identityModel{ID: id})...)
+}
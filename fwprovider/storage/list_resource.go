@@ -0,0 +1,249 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/storage"
+)
+
+// storageListModel is the configuration of the storage list resources.
+type storageListModel struct {
+	NodeName types.String `tfsdk:"node_name"`
+	Pool     types.String `tfsdk:"pool"`
+}
+
+// storageListResource is a generic list resource of the storages of a type.
+type storageListResource[T interface {
+	*M
+	storageModel
+}, M any] struct {
+	client       proxmox.Client
+	storageType  string
+	resourceName string
+}
+
+// NewCIFSStorageListResource creates the list resource of proxmox_storage_cifs.
+func NewCIFSStorageListResource() list.ListResource {
+	return &storageListResource[*CIFSStorageModel, CIFSStorageModel]{
+		storageType:  "cifs",
+		resourceName: "proxmox_storage_cifs",
+	}
+}
+
+// NewDirectoryStorageListResource creates the list resource of proxmox_storage_directory.
+func NewDirectoryStorageListResource() list.ListResource {
+	return &storageListResource[*DirectoryStorageModel, DirectoryStorageModel]{
+		storageType:  "dir",
+		resourceName: "proxmox_storage_directory",
+	}
+}
+
+// NewLVMPoolStorageListResource creates the list resource of proxmox_storage_lvm.
+func NewLVMPoolStorageListResource() list.ListResource {
+	return &storageListResource[*LVMStorageModel, LVMStorageModel]{
+		storageType:  "lvm",
+		resourceName: "proxmox_storage_lvm",
+	}
+}
+
+// NewLVMThinPoolStorageListResource creates the list resource of proxmox_storage_lvmthin.
+func NewLVMThinPoolStorageListResource() list.ListResource {
+	return &storageListResource[*LVMThinStorageModel, LVMThinStorageModel]{
+		storageType:  "lvmthin",
+		resourceName: "proxmox_storage_lvmthin",
+	}
+}
+
+// NewNFSStorageListResource creates the list resource of proxmox_storage_nfs.
+func NewNFSStorageListResource() list.ListResource {
+	return &storageListResource[*NFSStorageModel, NFSStorageModel]{
+		storageType:  "nfs",
+		resourceName: "proxmox_storage_nfs",
+	}
+}
+
+// NewProxmoxBackupServerStorageListResource creates the list resource of proxmox_storage_pbs.
+func NewProxmoxBackupServerStorageListResource() list.ListResource {
+	return &storageListResource[*PBSStorageModel, PBSStorageModel]{
+		storageType:  "pbs",
+		resourceName: "proxmox_storage_pbs",
+	}
+}
+
+// NewZFSPoolStorageListResource creates the list resource of proxmox_storage_zfspool.
+func NewZFSPoolStorageListResource() list.ListResource {
+	return &storageListResource[*ZFSStorageModel, ZFSStorageModel]{
+		storageType:  "zfspool",
+		resourceName: "proxmox_storage_zfspool",
+	}
+}
+
+// Metadata defines the name of the list resource, which is the name of the managed resource.
+func (r *storageListResource[T, M]) Metadata(
+	_ context.Context,
+	_ resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = r.resourceName
+}
+
+// ListResourceConfigSchema defines the filters of the list resource.
+func (r *storageListResource[T, M]) ListResourceConfigSchema(
+	_ context.Context,
+	_ list.ListResourceSchemaRequest,
+	resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Lists the %s storages of the cluster.", r.storageType),
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "Only list the storages available on this node.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"pool": schema.StringAttribute{
+				Description: "Only list the storages in this resource pool.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the client for the list resource.
+func (r *storageListResource[T, M]) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// List lists the storages of the type that match the filters.
+func (r *storageListResource[T, M]) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter storageListModel
+
+	diags := req.Config.Get(ctx, &filter)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	datastores, err := r.listDatastores(ctx, filter)
+	if err != nil {
+		diags.AddError("Unable to List Storages", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, ds := range datastores {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = *ds.ID
+
+			setIdentity(ctx, result.Identity, types.StringValue(*ds.ID), &result.Diagnostics)
+
+			if req.IncludeResource {
+				var m T = new(M)
+
+				if err := m.fromAPI(ctx, ds); err != nil {
+					result.Diagnostics.AddError(fmt.Sprintf("Unable to Read Storage %s", *ds.ID), err.Error())
+				} else {
+					result.Diagnostics.Append(result.Resource.Set(ctx, m)...)
+				}
+			} else {
+				result.Resource = nil
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+func (r *storageListResource[T, M]) listDatastores(
+	ctx context.Context,
+	filter storageListModel,
+) ([]*storage.DatastoreGetResponseData, error) {
+	datastores, err := r.client.Storage().ListDatastore(ctx, &storage.DatastoreListRequest{Type: &r.storageType})
+	if err != nil {
+		return nil, err
+	}
+
+	var poolStorages []string
+
+	if !filter.Pool.IsNull() {
+		pool, err := r.client.Pool().GetPool(ctx, filter.Pool.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("error reading pool %q: %w", filter.Pool.ValueString(), err)
+		}
+
+		for _, m := range pool.Members {
+			if m.Type == "storage" && m.DatastoreID != nil {
+				poolStorages = append(poolStorages, *m.DatastoreID)
+			}
+		}
+	}
+
+	datastores = slices.DeleteFunc(datastores, func(ds *storage.DatastoreGetResponseData) bool {
+		if ds.ID == nil {
+			return true
+		}
+
+		if !filter.NodeName.IsNull() && ds.Nodes != nil && len(*ds.Nodes) > 0 &&
+			!slices.Contains(*ds.Nodes, filter.NodeName.ValueString()) {
+			return true
+		}
+
+		return !filter.Pool.IsNull() && !slices.Contains(poolStorages, *ds.ID)
+	})
+
+	slices.SortFunc(datastores, func(a, b *storage.DatastoreGetResponseData) int {
+		return strings.Compare(*a.ID, *b.ID)
+	})
+
+	return datastores, nil
+}
@@ -50,7 +50,7 @@ type storageResource[T interface {
 }
 
 func (r *storageResource[T, M]) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 // Configure is the generic configuration function.
@@ -106,6 +106,8 @@ func (r *storageResource[T, M]) Create(ctx context.Context, req resource.CreateR
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+
+	setIdentity(ctx, resp.Identity, plan.GetID(), &resp.Diagnostics)
 }
 
 // Read is the generic read function.
@@ -141,6 +143,8 @@ func (r *storageResource[T, M]) Read(ctx context.Context, req resource.ReadReque
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
+
+	setIdentity(ctx, resp.Identity, state.GetID(), &resp.Diagnostics)
 }
 
 // Update is the generic update function.
@@ -181,6 +185,8 @@ func (r *storageResource[T, M]) Update(ctx context.Context, req resource.UpdateR
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+
+	setIdentity(ctx, resp.Identity, plan.GetID(), &resp.Diagnostics)
 }
 
 // Delete is the generic delete function.
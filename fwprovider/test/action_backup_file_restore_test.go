@@ -0,0 +1,166 @@
+//go:build acceptance || all
+
+//testacc:tier=heavy
+//testacc:resource=backup
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/storage"
+	"github.com/bpg/terraform-provider-proxmox/utils"
+)
+
+// TestAccActionBackupFileRestore backs up a container to a Proxmox Backup Server datastore
+// and restores its /etc/hostname from the backup into a local file.
+func TestAccActionBackupFileRestore(t *testing.T) {
+	te := InitEnvironment(t)
+
+	pbsDatastoreID := utils.GetAnyStringEnv("PROXMOX_VE_ACC_PBS_DATASTORE_ID")
+	if pbsDatastoreID == "" {
+		t.Skip("PROXMOX_VE_ACC_PBS_DATASTORE_ID is not set")
+	}
+
+	imageFileName := fmt.Sprintf("%d-alpine-3.22-default_20250617_amd64.tar.xz", time.Now().UnixMicro())
+	testAccDownloadContainerTemplate(t, te, imageFileName)
+
+	containerID := 100000 + rand.Intn(99999)
+	destination := filepath.Join(t.TempDir(), "hostname")
+
+	te.AddTemplateVars(map[string]any{
+		"ImageFileName":   imageFileName,
+		"TestContainerID": containerID,
+		"PBSDatastoreID":  pbsDatastoreID,
+		"Destination":     destination,
+	})
+
+	pbsStorage := &storage.Client{Client: te.NodeClient(), StorageName: pbsDatastoreID}
+
+	t.Cleanup(func() {
+		backups, err := pbsStorage.ListDatastoreBackups(context.Background(), &containerID)
+		require.NoError(t, err)
+
+		for _, b := range backups {
+			require.NoError(t, pbsStorage.DeleteDatastoreFile(context.Background(), b.VolumeID))
+		}
+	})
+
+	containerConfig := `
+		resource "proxmox_virtual_environment_container" "test" {
+			node_name      = "{{.NodeName}}"
+			vm_id          = {{.TestContainerID}}
+			timeout_delete = 300
+			unprivileged   = true
+			started        = true
+
+			disk {
+				datastore_id = "local-lvm"
+				size         = 4
+			}
+
+			initialization {
+				hostname = "test-file-restore"
+			}
+
+			network_interface {
+				name = "vmbr0"
+			}
+
+			operating_system {
+				template_file_id = "local:vztmpl/{{.ImageFileName}}"
+				type             = "alpine"
+			}
+		}
+	`
+
+	checkRestored := func(*terraform.State) error {
+		content, err := os.ReadFile(destination)
+		if err != nil {
+			return fmt.Errorf("the file is not restored: %w", err)
+		}
+
+		if string(content) != "test-file-restore\n" {
+			return fmt.Errorf("the restored file contains %q, expected the host name of the container", content)
+		}
+
+		return nil
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(containerConfig, WithRootUser()),
+			},
+			{
+				PreConfig: func() {
+					result := te.NodeClient().BackupGuests(context.Background(), &nodes.VzdumpRequestBody{
+						VMID:    strconv.Itoa(containerID),
+						Storage: new(pbsDatastoreID),
+						Mode:    new(nodes.BackupModeSnapshot),
+					})
+					require.NoError(t, result.Err())
+				},
+				Config: te.RenderConfig(containerConfig+`
+					data "proxmox_backups" "test" {
+						node_name    = proxmox_virtual_environment_container.test.node_name
+						datastore_id = "{{.PBSDatastoreID}}"
+						vm_id        = proxmox_virtual_environment_container.test.vm_id
+					}
+
+					action "proxmox_backup_file_restore" "hostname" {
+						config {
+							node_name    = proxmox_virtual_environment_container.test.node_name
+							datastore_id = "{{.PBSDatastoreID}}"
+							volume_id    = data.proxmox_backups.test.backups[0].id
+							file_path    = "/root.pxar.didx/etc/hostname"
+							destination  = "{{.Destination}}"
+						}
+					}
+
+					resource "terraform_data" "restore" {
+						input = data.proxmox_backups.test.backups[0].id
+
+						lifecycle {
+							action_trigger {
+								events  = [after_create]
+								actions = [action.proxmox_backup_file_restore.hostname]
+							}
+						}
+					}
+				`, WithRootUser()),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes("data.proxmox_backups.test", map[string]string{
+						"backups.#":         "1",
+						"backups.0.subtype": "lxc",
+						"backups.0.format":  "pbs-ct",
+					}),
+					checkRestored,
+				),
+			},
+		},
+	})
+}
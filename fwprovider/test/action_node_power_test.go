@@ -0,0 +1,196 @@
+//go:build acceptance || all
+
+//testacc:tier=medium
+//testacc:resource=misc
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	"github.com/bpg/terraform-provider-proxmox/utils"
+)
+
+// TestAccActionNodeStartStopAll starts and stops a single VM with the bulk actions of the
+// node, limited to the VM by its ID, so the other guests of the node are not touched.
+func TestAccActionNodeStartStopAll(t *testing.T) {
+	te := InitEnvironment(t)
+
+	vmID := 100000 + rand.Intn(99999)
+
+	te.AddTemplateVars(map[string]any{"VMID": vmID})
+
+	checkVMStatus := func(status string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			s, err := te.NodeClient().VM(vmID).GetVMStatus(context.Background())
+			if err != nil {
+				return err
+			}
+
+			if s.Status != status {
+				return fmt.Errorf("VM %d is %s, expected %s", vmID, s.Status, status)
+			}
+
+			return nil
+		}
+	}
+
+	// the VM is started and stopped by the actions, not by the resource
+	vmConfig := `
+		resource "proxmox_virtual_environment_vm" "test" {
+			node_name       = "{{.NodeName}}"
+			vm_id           = {{.VMID}}
+			started         = false
+			on_boot         = false
+			stop_on_destroy = true
+
+			lifecycle {
+				ignore_changes = [started]
+			}
+		}
+	`
+
+	startConfig := vmConfig + `
+		action "proxmox_node_start_all" "test" {
+			config {
+				node_name = proxmox_virtual_environment_vm.test.node_name
+				vmids     = [proxmox_virtual_environment_vm.test.vm_id]
+				force     = true
+			}
+		}
+
+		resource "terraform_data" "start" {
+			input = proxmox_virtual_environment_vm.test.vm_id
+
+			lifecycle {
+				action_trigger {
+					events  = [after_create]
+					actions = [action.proxmox_node_start_all.test]
+				}
+			}
+		}
+	`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(vmConfig),
+				Check:  checkVMStatus("stopped"),
+			},
+			{
+				Config: te.RenderConfig(startConfig),
+				Check:  checkVMStatus("running"),
+			},
+			{
+				Config: te.RenderConfig(startConfig + `
+					action "proxmox_node_stop_all" "test" {
+						config {
+							node_name        = proxmox_virtual_environment_vm.test.node_name
+							vmids            = [proxmox_virtual_environment_vm.test.vm_id]
+							shutdown_timeout = 10
+							force_stop       = true
+						}
+					}
+
+					resource "terraform_data" "stop" {
+						input = proxmox_virtual_environment_vm.test.vm_id
+
+						lifecycle {
+							action_trigger {
+								events  = [after_create]
+								actions = [action.proxmox_node_stop_all.test]
+							}
+						}
+					}
+				`),
+				Check: checkVMStatus("stopped"),
+			},
+		},
+	})
+}
+
+// TestAccActionNodePowerReboot reboots the second node and waits until it is back online.
+// It only runs when PROXMOX_VE_ACC_NODE_REBOOT is set, as the node is unavailable to the
+// other tests while it reboots.
+func TestAccActionNodePowerReboot(t *testing.T) {
+	te := InitEnvironment(t)
+
+	if utils.GetAnyStringEnv("PROXMOX_VE_ACC_NODE_REBOOT") == "" {
+		t.Skip("PROXMOX_VE_ACC_NODE_REBOOT is not set")
+	}
+
+	if te.Node2Name == "" {
+		t.Skip("PROXMOX_VE_ACC_NODE_2_NAME is not set")
+	}
+
+	started := time.Now()
+
+	checkRebooted := func(*terraform.State) error {
+		info, err := (&nodes.Client{Client: te.Client(), NodeName: te.Node2Name}).GetInfo(context.Background())
+		if err != nil {
+			return fmt.Errorf("node %s is not back online: %w", te.Node2Name, err)
+		}
+
+		if info.Uptime == nil {
+			return fmt.Errorf("node %s did not report its uptime", te.Node2Name)
+		}
+
+		if uptime := time.Duration(*info.Uptime) * time.Second; uptime > time.Since(started) {
+			return fmt.Errorf("node %s has not rebooted, it is up for %s", te.Node2Name, uptime)
+		}
+
+		return nil
+	}
+
+	// not parallel, the other tests may use the node that is rebooted
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+					action "proxmox_node_power" "reboot" {
+						config {
+							node_name = "{{.Node2Name}}"
+							command   = "reboot"
+							timeout   = "15m"
+						}
+					}
+
+					resource "terraform_data" "reboot" {
+						input = "{{.Node2Name}}"
+
+						lifecycle {
+							action_trigger {
+								events  = [after_create]
+								actions = [action.proxmox_node_power.reboot]
+							}
+						}
+					}
+				`),
+				Check: checkRebooted,
+			},
+		},
+	})
+}
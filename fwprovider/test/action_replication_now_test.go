@@ -0,0 +1,160 @@
+//go:build acceptance || all
+
+//testacc:tier=medium
+//testacc:resource=replication
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/storage"
+)
+
+// TestAccActionReplicationNow runs a replication job of a container on demand and reads its
+// status before and after the run.
+func TestAccActionReplicationNow(t *testing.T) {
+	te := InitEnvironment(t)
+
+	if te.Node2Name == "" {
+		t.Skip("PROXMOX_VE_ACC_NODE_2_NAME is not set")
+	}
+
+	if te.ZfsDatastoreID == "" {
+		t.Skip("PROXMOX_VE_ACC_ZFS_DATASTORE_ID is not set")
+	}
+
+	imageFileName := fmt.Sprintf("%d-ubuntu-24.04-standard_24.04-2_amd64.tar.zst", time.Now().UnixMicro())
+
+	err := te.NodeStorageClient().DownloadFileByURL(context.Background(), &storage.DownloadURLPostRequestBody{
+		Content:  new("vztmpl"),
+		FileName: new(imageFileName),
+		Node:     new(te.NodeName),
+		Storage:  new(te.DatastoreID),
+		URL:      new(fmt.Sprintf("%s/images/system/ubuntu-24.04-standard_24.04-2_amd64.tar.zst", te.ContainerImagesServer)),
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		e := te.NodeStorageClient().DeleteDatastoreFile(context.Background(), fmt.Sprintf("vztmpl/%s", imageFileName))
+		require.NoError(t, e)
+	})
+
+	containerID := 100000 + rand.Intn(99999)
+	jobID := fmt.Sprintf("%d-0", containerID)
+
+	te.AddTemplateVars(map[string]any{
+		"ImageFileName":   imageFileName,
+		"TestContainerID": containerID,
+	})
+
+	// the schedule is far enough away that only the action syncs the job
+	config := `
+		resource "proxmox_virtual_environment_container" "test" {
+			node_name = "{{.NodeName}}"
+			vm_id     = {{.TestContainerID}}
+			started   = false
+
+			disk {
+				datastore_id = "{{.ZfsDatastoreID}}"
+				size         = 4
+			}
+
+			operating_system {
+				template_file_id = "local:vztmpl/{{.ImageFileName}}"
+				type             = "ubuntu"
+			}
+
+			initialization {
+				hostname = "test-replication"
+			}
+		}
+
+		resource "proxmox_replication" "test" {
+			id       = "${proxmox_virtual_environment_container.test.vm_id}-0"
+			target   = "{{.Node2Name}}"
+			type     = "local"
+			schedule = "mon 03:00"
+		}
+	`
+
+	runConfig := config + `
+		action "proxmox_replication_now" "test" {
+			config {
+				id      = proxmox_replication.test.id
+				timeout = "10m"
+			}
+		}
+
+		resource "terraform_data" "sync" {
+			input = proxmox_replication.test.id
+
+			lifecycle {
+				action_trigger {
+					events  = [after_create]
+					actions = [action.proxmox_replication_now.test]
+				}
+			}
+		}
+	`
+
+	datasourceName := "data.proxmox_replication.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(config + `
+					data "proxmox_replication" "test" {
+						id = proxmox_replication.test.id
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes(datasourceName, map[string]string{
+						"id":         jobID,
+						"target":     te.Node2Name,
+						"fail_count": "0",
+					}),
+					ResourceAttributesSet(datasourceName, []string{"next_sync"}),
+					NoResourceAttributesSet(datasourceName, []string{"last_sync", "error"}),
+				),
+			},
+			{
+				Config: te.RenderConfig(runConfig),
+			},
+			// the data source is read after the action has run
+			{
+				Config: te.RenderConfig(runConfig + `
+					data "proxmox_replication" "test" {
+						id = proxmox_replication.test.id
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes(datasourceName, map[string]string{
+						"id":         jobID,
+						"fail_count": "0",
+					}),
+					ResourceAttributesSet(datasourceName, []string{"last_sync", "duration", "next_sync"}),
+					NoResourceAttributesSet(datasourceName, []string{"error"}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,274 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package cassette records Proxmox VE API traffic into fixture files ("cassettes") and
+// replays it from a local HTTPS server, so that acceptance tests can run without a
+// live Proxmox VE host.
+package cassette
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// formatVersion is the version of the cassette file format.
+const formatVersion = 1
+
+// redacted replaces secret values in recorded requests and responses.
+const redacted = "REDACTED"
+
+const (
+	// EnvMode selects the cassette mode of acceptance tests: `record` or `replay`.
+	EnvMode = "PROXMOX_VE_ACC_CASSETTE"
+
+	// EnvDir overrides the directory cassettes are read from and written to.
+	EnvDir = "PROXMOX_VE_ACC_CASSETTE_DIR"
+
+	// DefaultDir is the cassette directory, relative to the test package, used when EnvDir is not set.
+	DefaultDir = "testdata/cassettes"
+
+	// ReplayAPIToken is a placeholder API token used in replay mode when no token is configured.
+	ReplayAPIToken = "root@pam!replay=00000000-0000-0000-0000-000000000000"
+)
+
+// Mode is the cassette mode of a test run.
+type Mode string
+
+const (
+	// ModeDisabled runs tests against the live Proxmox VE API without recording.
+	ModeDisabled Mode = ""
+
+	// ModeRecord proxies requests to the live Proxmox VE API and records them.
+	ModeRecord Mode = "record"
+
+	// ModeReplay serves previously recorded responses without a live Proxmox VE API.
+	ModeReplay Mode = "replay"
+)
+
+// ParseMode parses a cassette mode string.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case ModeDisabled, ModeRecord, ModeReplay:
+		return m, nil
+	default:
+		return ModeDisabled, fmt.Errorf("unsupported cassette mode %q, must be one of: record, replay", s)
+	}
+}
+
+// Cassette is a recorded sequence of API interactions.
+type Cassette struct {
+	Version      int            `json:"version"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded API request.
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded API response.
+type Response struct {
+	StatusCode  int    `json:"status_code"`
+	Status      string `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// key returns the strict matching key of a request.
+func (r Request) key() string {
+	return r.Method + " " + r.Path + "?" + r.Query + "#" + r.Body
+}
+
+// looseKey returns the relaxed matching key of a request, which ignores the request body.
+func (r Request) looseKey() string {
+	return r.Method + " " + r.Path + "?" + r.Query
+}
+
+var invalidFileChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// FileName returns the cassette file name for a test name, e.g. `TestAccFoo/create` becomes
+// `TestAccFoo/create.json` with unsafe characters replaced.
+func FileName(testName string) string {
+	parts := strings.Split(testName, "/")
+	for i, p := range parts {
+		parts[i] = strings.Trim(invalidFileChars.ReplaceAllString(p, "_"), "_")
+	}
+
+	return filepath.Join(parts...) + ".json"
+}
+
+// Load reads a cassette from a file.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette %s: %w", path, err)
+	}
+
+	c := &Cassette{}
+
+	if err = json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+	}
+
+	if c.Version != formatVersion {
+		return nil, fmt.Errorf("unsupported cassette version %d in %s, expected %d", c.Version, path, formatVersion)
+	}
+
+	return c, nil
+}
+
+// Save writes a cassette to a file, creating parent directories as needed.
+func (c *Cassette) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+
+	c.Version = formatVersion
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	if err = os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write cassette %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// isSecretField returns true if a form or JSON field holds a credential that must not be recorded.
+func isSecretField(name string) bool {
+	n := strings.ToLower(name)
+
+	return strings.Contains(n, "password") ||
+		strings.Contains(n, "secret") ||
+		n == "ticket" ||
+		n == "csrfpreventiontoken"
+}
+
+// normalizeRequestBody returns a canonical, redacted representation of a request body, so that
+// requests can be matched regardless of form field order. Non-form bodies (e.g. multipart
+// uploads) are not recorded.
+func normalizeRequestBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "application/x-www-form-urlencoded" {
+		return ""
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return string(body)
+	}
+
+	return redactValues(values).Encode()
+}
+
+// normalizeQuery returns a canonical, redacted representation of a query string.
+func normalizeQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+
+	return redactValues(values).Encode()
+}
+
+func redactValues(values url.Values) url.Values {
+	for k := range values {
+		if isSecretField(k) {
+			values[k] = []string{redacted}
+		}
+	}
+
+	return values
+}
+
+// redactResponseBody replaces secret fields anywhere in a JSON response body.
+func redactResponseBody(body []byte) string {
+	var v any
+
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+
+	if !redactJSON(v) {
+		return string(body)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+
+	return string(data)
+}
+
+// redactJSON redacts secret string fields in place and reports whether anything was changed.
+func redactJSON(v any) bool {
+	changed := false
+
+	switch t := v.(type) {
+	case map[string]any:
+		// the secret of a newly created API token is returned next to its ID
+		_, isToken := t["full-tokenid"]
+
+		for k, val := range t {
+			if _, ok := val.(string); ok && (isSecretField(k) || (isToken && k == "value")) {
+				t[k] = redacted
+				changed = true
+
+				continue
+			}
+
+			changed = redactJSON(val) || changed
+		}
+	case []any:
+		for _, val := range t {
+			changed = redactJSON(val) || changed
+		}
+	}
+
+	return changed
+}
+
+// ErrNoInteraction is returned when a replayed request has no recorded counterpart.
+var ErrNoInteraction = errors.New("no recorded interaction matches the request")
+
+// newRequest builds a recorded request from an incoming HTTP request and its body.
+func newRequest(r *http.Request, body []byte) Request {
+	return Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  normalizeQuery(r.URL.RawQuery),
+		Body:   normalizeRequestBody(r.Header.Get("Content-Type"), body),
+	}
+}
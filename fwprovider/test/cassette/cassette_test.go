@@ -0,0 +1,292 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cassette
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+func newTestClient(t *testing.T, endpoint string) api.Client {
+	t.Helper()
+
+	conn, err := api.NewConnection(endpoint, true, "")
+	require.NoError(t, err)
+
+	creds, err := api.NewCredentials("", "", "", "root@pam!test=00000000-0000-0000-0000-000000000000", "", "")
+	require.NoError(t, err)
+
+	client, err := api.NewClient(creds, conn)
+	require.NoError(t, err)
+
+	return client
+}
+
+// newUpstream starts a fake Proxmox VE API that reports a running task twice before it stops.
+func newUpstream(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var polls atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api2/json/version", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"release":"9.0","version":"9.0.3"}}`))
+	})
+	mux.HandleFunc("POST /api2/json/access/users/test@pve/token/t1", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"full-tokenid":"test@pve!t1","value":"abcdef","info":{"privsep":1}}}`))
+	})
+	mux.HandleFunc("GET /api2/json/nodes/pve/tasks/upid/status", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if polls.Add(1) < 3 {
+			_, _ = w.Write([]byte(`{"data":{"status":"running"}}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"status":"stopped","exitstatus":"OK"}}`))
+	})
+
+	// set a custom reason phrase like Proxmox VE does
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api2/json/nodes/pve/qemu/999/config" {
+			if writeRawResponse(w, Response{
+				StatusCode:  http.StatusInternalServerError,
+				Status:      "500 Configuration file 'nodes/pve/qemu-server/999.conf' does not exist",
+				ContentType: "application/json",
+				Body:        `{"data":null}`,
+			}) {
+				return
+			}
+		}
+
+		mux.ServeHTTP(w, r)
+	}))
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+type taskStatus struct {
+	Data struct {
+		Status     string `json:"status"`
+		ExitStatus string `json:"exitstatus"`
+	} `json:"data"`
+}
+
+// exercise issues the same requests against the given endpoint in record and replay mode.
+func exercise(t *testing.T, endpoint string) {
+	t.Helper()
+
+	ctx := context.Background()
+	client := newTestClient(t, endpoint)
+
+	version := map[string]any{}
+	require.NoError(t, client.DoRequest(ctx, http.MethodGet, "version", nil, &version))
+	assert.Equal(t, "9.0.3", version["data"].(map[string]any)["version"])
+
+	token := map[string]any{}
+	require.NoError(t, client.DoRequest(
+		ctx, http.MethodPost, "access/users/test@pve/token/t1",
+		&struct {
+			Comment string `url:"comment"`
+			Privsep int    `url:"privsep"`
+		}{Comment: "test", Privsep: 1},
+		&token,
+	))
+
+	status := &taskStatus{}
+	for status.Data.Status != "stopped" {
+		require.NoError(t, client.DoRequest(ctx, http.MethodGet, "nodes/pve/tasks/upid/status", nil, status))
+	}
+
+	assert.Equal(t, "OK", status.Data.ExitStatus)
+
+	err := client.DoRequest(ctx, http.MethodGet, "nodes/pve/qemu/999/config", nil, nil)
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), FileName("TestRecordAndReplay/sub test"))
+	upstream := newUpstream(t)
+
+	t.Run("record", func(t *testing.T) {
+		s := Start(t, ModeRecord, path, upstream.URL)
+		exercise(t, s.URL)
+	})
+
+	c, err := Load(path)
+	require.NoError(t, err)
+
+	for _, i := range c.Interactions {
+		assert.NotContains(t, i.Response.Body, "abcdef", "token secret must be redacted")
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		upstream.Close()
+
+		s := Start(t, ModeReplay, path, "")
+		exercise(t, s.URL)
+	})
+}
+
+func TestReplayUnmatched(t *testing.T) {
+	t.Parallel()
+
+	s := NewReplayServer(&Cassette{Version: formatVersion})
+	t.Cleanup(s.Close)
+
+	err := newTestClient(t, s.URL).DoRequest(context.Background(), http.MethodGet, "version", nil, nil)
+	require.Error(t, err)
+
+	unmatched := s.Unmatched()
+	require.Len(t, unmatched, 1)
+	assert.Equal(t, "/api2/json/version", unmatched[0].Path)
+}
+
+func TestReplayMatchesBodyRegardlessOfFieldOrder(t *testing.T) {
+	t.Parallel()
+
+	rp := newReplayer(&Cassette{Interactions: []*Interaction{
+		{
+			Request:  Request{Method: http.MethodPost, Path: "/a", Body: "x=1&y=2"},
+			Response: Response{StatusCode: http.StatusOK, Body: "first"},
+		},
+		{
+			Request:  Request{Method: http.MethodPost, Path: "/a", Body: "x=3"},
+			Response: Response{StatusCode: http.StatusOK, Body: "second"},
+		},
+	}})
+
+	i := rp.next(Request{Method: http.MethodPost, Path: "/a", Body: normalizeRequestBody(
+		"application/x-www-form-urlencoded", []byte("x=3"),
+	)})
+	require.NotNil(t, i)
+	assert.Equal(t, "second", i.Response.Body)
+
+	i = rp.next(Request{Method: http.MethodPost, Path: "/a", Body: normalizeRequestBody(
+		"application/x-www-form-urlencoded", []byte("y=2&x=1"),
+	)})
+	require.NotNil(t, i)
+	assert.Equal(t, "first", i.Response.Body)
+}
+
+func TestReplayInteractionsOnce(t *testing.T) {
+	t.Parallel()
+
+	rp := newReplayer(&Cassette{Interactions: []*Interaction{
+		{
+			Request:  Request{Method: http.MethodPost, Path: "/a", Body: "x=1"},
+			Response: Response{StatusCode: http.StatusOK, Body: "first"},
+		},
+		{
+			Request:  Request{Method: http.MethodPost, Path: "/a", Body: "x=1"},
+			Response: Response{StatusCode: http.StatusOK, Body: "second"},
+		},
+	}})
+
+	for _, want := range []string{"first", "second", "second"} {
+		i := rp.next(Request{Method: http.MethodPost, Path: "/a", Body: "x=1"})
+		require.NotNil(t, i)
+		assert.Equal(t, want, i.Response.Body)
+	}
+}
+
+func TestReplayBodyMismatch(t *testing.T) {
+	t.Parallel()
+
+	rp := newReplayer(&Cassette{Interactions: []*Interaction{
+		{
+			Request:  Request{Method: http.MethodGet, Path: "/a"},
+			Response: Response{StatusCode: http.StatusOK, Body: "list"},
+		},
+		{
+			Request:  Request{Method: http.MethodPut, Path: "/a", Body: "x=1"},
+			Response: Response{StatusCode: http.StatusOK, Body: "first"},
+		},
+		{
+			Request:  Request{Method: http.MethodPut, Path: "/a", Body: "x=2"},
+			Response: Response{StatusCode: http.StatusOK, Body: "second"},
+		},
+	}})
+
+	i := rp.next(Request{Method: http.MethodPut, Path: "/a", Body: "x=1"})
+	require.NotNil(t, i)
+	assert.Equal(t, "first", i.Response.Body)
+
+	// a different body must not be answered with the remaining recording
+	require.Nil(t, rp.next(Request{Method: http.MethodPut, Path: "/a", Body: "x=3"}))
+	assert.Empty(t, rp.unmatchedRequests())
+
+	diverged := rp.divergences()
+	require.Len(t, diverged, 1)
+	assert.Equal(t, 2, diverged[0].Index)
+	assert.Equal(t, "x=2", diverged[0].Recorded.Body)
+	assert.Contains(t, diverged[0].String(), `sent body "x=3", recorded body "x=2"`)
+
+	// the recording that was not replayed is still available
+	i = rp.next(Request{Method: http.MethodPut, Path: "/a", Body: "x=2"})
+	require.NotNil(t, i)
+	assert.Equal(t, "second", i.Response.Body)
+
+	// once all are replayed, a mismatch is reported against the last recording
+	require.Nil(t, rp.next(Request{Method: http.MethodPut, Path: "/a", Body: "x=4"}))
+	diverged = rp.divergences()
+	require.Len(t, diverged, 2)
+	assert.Equal(t, 2, diverged[1].Index)
+}
+
+func TestRedaction(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"password=REDACTED&username=root%40pam",
+		normalizeRequestBody("application/x-www-form-urlencoded", []byte("username=root%40pam&password=s3cr3t")),
+	)
+	assert.Empty(t, normalizeRequestBody("multipart/form-data; boundary=x", []byte("--x")))
+
+	assert.JSONEq(t,
+		`{"data":{"ticket":"REDACTED","CSRFPreventionToken":"REDACTED","username":"root@pam"}}`,
+		redactResponseBody([]byte(`{"data":{"ticket":"t","CSRFPreventionToken":"c","username":"root@pam"}}`)),
+	)
+	assert.JSONEq(t,
+		`{"data":[{"key":"k","value":"v"}]}`,
+		redactResponseBody([]byte(`{"data":[{"key":"k","value":"v"}]}`)),
+	)
+}
+
+func TestFileName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, filepath.Join("TestAccVM", "create_with_disk.json"), FileName("TestAccVM/create with disk"))
+}
+
+func TestParseMode(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"", "record", " Replay "} {
+		_, err := ParseMode(s)
+		require.NoError(t, err, s)
+	}
+
+	_, err := ParseMode("rewind")
+	require.Error(t, err)
+}
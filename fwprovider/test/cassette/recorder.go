@@ -0,0 +1,97 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Recorder is an http.RoundTripper that forwards requests to the wrapped transport and
+// records each request and response into a cassette. Secrets are redacted before recording.
+type Recorder struct {
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette *Cassette
+}
+
+// NewRecorder creates a new recorder wrapping the given transport.
+// If transport is nil, http.DefaultTransport is used.
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Recorder{
+		transport: transport,
+		cassette:  &Cassette{Version: formatVersion},
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+
+		reqBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: newRequest(req, reqBody),
+		Response: Response{
+			StatusCode:  res.StatusCode,
+			Status:      res.Status,
+			ContentType: res.Header.Get("Content-Type"),
+			Body:        redactResponseBody(resBody),
+		},
+	})
+
+	return res, nil
+}
+
+// Cassette returns a snapshot of the interactions recorded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := &Cassette{
+		Version:      formatVersion,
+		Interactions: make([]*Interaction, len(r.cassette.Interactions)),
+	}
+	copy(c.Interactions, r.cassette.Interactions)
+
+	return c
+}
@@ -0,0 +1,398 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cassette
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// Server is a local HTTPS server that either proxies requests to a live Proxmox VE API while
+// recording them (ModeRecord), or answers them from a cassette (ModeReplay).
+type Server struct {
+	*httptest.Server
+
+	mode     Mode
+	recorder *Recorder
+	replayer *replayer
+}
+
+// NewRecordingServer starts a server that proxies all requests to the given upstream
+// Proxmox VE endpoint and records them. The upstream TLS certificate is not verified.
+func NewRecordingServer(upstream string) (*Server, error) {
+	target, err := url.Parse(upstream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse upstream endpoint %q: %w", upstream, err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	//nolint:gosec
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+
+	recorder := NewRecorder(transport)
+
+	return &Server{
+		Server:   httptest.NewTLSServer(&proxy{target: target, recorder: recorder}),
+		mode:     ModeRecord,
+		recorder: recorder,
+	}, nil
+}
+
+// NewReplayServer starts a server that answers requests from the given cassette.
+// Requests are matched by method, path, query and normalized form body, in recorded order.
+// A request that differs from a recording only by its body is reported by Diverged.
+// When all recorded responses for a request have been served, the last one is repeated,
+// which covers polling loops (e.g. task status) that may run a different number of times.
+// Unmatched requests are answered with `501 Not Implemented`.
+func NewReplayServer(c *Cassette) *Server {
+	rp := newReplayer(c)
+
+	return &Server{
+		Server:   httptest.NewTLSServer(rp),
+		mode:     ModeReplay,
+		replayer: rp,
+	}
+}
+
+// Mode returns the mode of the server.
+func (s *Server) Mode() Mode {
+	return s.mode
+}
+
+// Cassette returns the recorded interactions in record mode, or nil in replay mode.
+func (s *Server) Cassette() *Cassette {
+	if s.recorder == nil {
+		return nil
+	}
+
+	return s.recorder.Cassette()
+}
+
+// Unmatched returns the requests that could not be answered from the cassette in replay mode.
+func (s *Server) Unmatched() []Request {
+	if s.replayer == nil {
+		return nil
+	}
+
+	return s.replayer.unmatchedRequests()
+}
+
+// Diverged returns the requests in replay mode that match a recorded interaction by method,
+// path and query, but were sent with a different body.
+func (s *Server) Diverged() []Divergence {
+	if s.replayer == nil {
+		return nil
+	}
+
+	return s.replayer.divergences()
+}
+
+// proxy forwards requests to the upstream endpoint through the recorder. Unlike
+// httputil.ReverseProxy it preserves the upstream status line.
+type proxy struct {
+	target   *url.URL
+	recorder *Recorder
+}
+
+// ServeHTTP implements http.Handler.
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	out := r.Clone(r.Context())
+	out.RequestURI = ""
+	out.URL.Scheme = p.target.Scheme
+	out.URL.Host = p.target.Host
+	out.Host = p.target.Host
+
+	// let the transport negotiate compression, so recorded bodies are plain text
+	out.Header.Del("Accept-Encoding")
+
+	res, err := p.recorder.RoundTrip(out)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+
+		return
+	}
+
+	defer res.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+
+		return
+	}
+
+	writeResponse(w, Response{
+		StatusCode:  res.StatusCode,
+		Status:      res.Status,
+		ContentType: res.Header.Get("Content-Type"),
+		Body:        string(body),
+	})
+}
+
+type replayer struct {
+	mu     sync.Mutex
+	strict map[string][]*Interaction
+	loose  map[string][]*Interaction
+	last   map[string]*Interaction
+	index  map[*Interaction]int
+	// used holds the interactions that have been replayed, an interaction is queued both by
+	// its strict and its loose key, but must be replayed only once.
+	used      map[*Interaction]bool
+	unmatched []Request
+	diverged  []Divergence
+}
+
+// Divergence is a replayed request that matches a recorded interaction by method, path and
+// query, but not by body.
+type Divergence struct {
+	Request Request
+	// Index is the position of the recorded interaction in the cassette.
+	Index    int
+	Recorded Request
+}
+
+func (d Divergence) String() string {
+	return fmt.Sprintf(
+		"request %s %s?%s diverged from recorded interaction #%d: sent body %q, recorded body %q",
+		d.Request.Method, d.Request.Path, d.Request.Query, d.Index, d.Request.Body, d.Recorded.Body,
+	)
+}
+
+func newReplayer(c *Cassette) *replayer {
+	rp := &replayer{
+		strict: map[string][]*Interaction{},
+		loose:  map[string][]*Interaction{},
+		last:   map[string]*Interaction{},
+		index:  map[*Interaction]int{},
+		used:   map[*Interaction]bool{},
+	}
+
+	for n, i := range c.Interactions {
+		rp.strict[i.Request.key()] = append(rp.strict[i.Request.key()], i)
+		rp.loose[i.Request.looseKey()] = append(rp.loose[i.Request.looseKey()], i)
+		rp.index[i] = n
+	}
+
+	return rp
+}
+
+// next returns the next recorded interaction for the request, or nil if there is none.
+// A request that matches a recorded interaction except for its body is not answered, and is
+// reported as a divergence from that interaction.
+func (rp *replayer) next(req Request) *Interaction {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	key := req.key()
+
+	if i := rp.dequeue(key); i != nil {
+		rp.last[key] = i
+
+		return i
+	}
+
+	if i, ok := rp.last[key]; ok {
+		return i
+	}
+
+	if i := rp.closest(req); i != nil {
+		rp.diverged = append(rp.diverged, Divergence{Request: req, Index: rp.index[i], Recorded: i.Request})
+
+		return nil
+	}
+
+	rp.unmatched = append(rp.unmatched, req)
+
+	return nil
+}
+
+// dequeue removes and returns the first interaction for the strict key that has not been
+// replayed yet.
+func (rp *replayer) dequeue(key string) *Interaction {
+	q := rp.strict[key]
+
+	for len(q) > 0 {
+		i := q[0]
+		q = q[1:]
+
+		if !rp.used[i] {
+			rp.strict[key] = q
+			rp.used[i] = true
+
+			return i
+		}
+	}
+
+	rp.strict[key] = q
+
+	return nil
+}
+
+// closest returns the recorded interaction the request was most likely meant to match: the
+// first one with the same method, path and query that has not been replayed yet, or else the
+// last one that has.
+func (rp *replayer) closest(req Request) *Interaction {
+	q := rp.loose[req.looseKey()]
+
+	for _, i := range q {
+		if !rp.used[i] {
+			return i
+		}
+	}
+
+	if len(q) > 0 {
+		return q[len(q)-1]
+	}
+
+	return nil
+}
+
+func (rp *replayer) unmatchedRequests() []Request {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	return append([]Request(nil), rp.unmatched...)
+}
+
+func (rp *replayer) divergences() []Divergence {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	return append([]Divergence(nil), rp.diverged...)
+}
+
+// ServeHTTP implements http.Handler.
+func (rp *replayer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	req := newRequest(r, body)
+
+	i := rp.next(req)
+	if i == nil {
+		http.Error(w, fmt.Sprintf("%s: %s %s", ErrNoInteraction, req.Method, req.Path), http.StatusNotImplemented)
+
+		return
+	}
+
+	writeResponse(w, i.Response)
+}
+
+// writeResponse writes a recorded response. Proxmox VE reports error details in the HTTP
+// reason phrase, which net/http cannot customize, so non-standard status lines are
+// written on the raw connection.
+func writeResponse(w http.ResponseWriter, res Response) {
+	if res.Status != "" && res.Status != standardStatus(res.StatusCode) {
+		if writeRawResponse(w, res) {
+			return
+		}
+	}
+
+	if res.ContentType != "" {
+		w.Header().Set("Content-Type", res.ContentType)
+	}
+
+	w.WriteHeader(res.StatusCode)
+	_, _ = w.Write([]byte(res.Body))
+}
+
+func standardStatus(code int) string {
+	return fmt.Sprintf("%d %s", code, http.StatusText(code))
+}
+
+// writeRawResponse writes the response with its recorded status line to the hijacked
+// connection. It returns false if the connection cannot be hijacked.
+func writeRawResponse(w http.ResponseWriter, res Response) bool {
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		return false
+	}
+
+	conn, buf, err := hj.Hijack()
+	if err != nil {
+		return false
+	}
+
+	defer conn.Close() //nolint:errcheck
+
+	_, _ = fmt.Fprintf(buf, "HTTP/1.1 %s\r\n", res.Status)
+
+	if res.ContentType != "" {
+		_, _ = fmt.Fprintf(buf, "Content-Type: %s\r\n", res.ContentType)
+	}
+
+	_, _ = fmt.Fprintf(buf, "Content-Length: %d\r\nConnection: close\r\n\r\n%s", len(res.Body), res.Body)
+	_ = buf.Flush()
+
+	return true
+}
+
+// Start starts a cassette server for the test in the given mode and registers its cleanup.
+// In record mode the recorded interactions are written to path when the test succeeds.
+// In replay mode the cassette is loaded from path, and any request that could not be
+// answered from it, or that diverged from its recording, fails the test.
+func Start(t testing.TB, mode Mode, path, upstream string) *Server {
+	t.Helper()
+
+	var (
+		s   *Server
+		err error
+	)
+
+	switch mode {
+	case ModeRecord:
+		s, err = NewRecordingServer(upstream)
+		if err != nil {
+			t.Fatalf("failed to start recording server: %v", err)
+		}
+
+		t.Cleanup(func() {
+			s.Close()
+
+			if t.Failed() {
+				return
+			}
+
+			if err := s.Cassette().Save(path); err != nil {
+				t.Errorf("failed to save cassette: %v", err)
+			}
+		})
+	case ModeReplay:
+		c, err := Load(path)
+		if err != nil {
+			t.Fatalf("failed to load cassette, record it first with %s=record: %v", EnvMode, err)
+		}
+
+		s = NewReplayServer(c)
+
+		t.Cleanup(func() {
+			s.Close()
+
+			for _, r := range s.Unmatched() {
+				t.Errorf("%s: %s %s?%s %s", ErrNoInteraction, r.Method, r.Path, r.Query, r.Body)
+			}
+
+			for _, d := range s.Diverged() {
+				t.Errorf("%s: %s", ErrNoInteraction, d)
+			}
+		})
+	default:
+		t.Fatalf("unsupported cassette mode %q", mode)
+	}
+
+	return s
+}
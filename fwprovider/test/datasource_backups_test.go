@@ -0,0 +1,171 @@
+//go:build acceptance || all
+
+//testacc:tier=light
+//testacc:resource=backup
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+)
+
+func TestAccDatasourceBackups(t *testing.T) {
+	te := InitEnvironment(t)
+
+	datasourceName := "data.proxmox_backups.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+					data "proxmox_backups" "test" {
+						node_name    = "{{.NodeName}}"
+						datastore_id = "local"
+						vm_id        = 999999998
+						max_age      = "24h"
+					}
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "node_name", te.NodeName),
+					resource.TestCheckResourceAttr(datasourceName, "datastore_id", "local"),
+					resource.TestCheckResourceAttr(datasourceName, "backups.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccDatasourceBackupsOfVM backs up a VM twice and reads the backups, newest first.
+func TestAccDatasourceBackupsOfVM(t *testing.T) {
+	te := InitEnvironment(t)
+
+	vmID := 100000 + rand.Intn(99999)
+
+	te.AddTemplateVars(map[string]any{"VMID": vmID})
+
+	t.Cleanup(func() {
+		backups, err := te.NodeStorageClient().ListDatastoreBackups(context.Background(), &vmID)
+		require.NoError(t, err)
+
+		for _, b := range backups {
+			require.NoError(t, te.NodeStorageClient().DeleteDatastoreFile(context.Background(), b.VolumeID))
+		}
+	})
+
+	backup := func(notes string) {
+		result := te.NodeClient().BackupGuests(context.Background(), &nodes.VzdumpRequestBody{
+			VMID:          strconv.Itoa(vmID),
+			Storage:       new(te.DatastoreID),
+			Mode:          new(nodes.BackupModeStop),
+			Compress:      new(nodes.BackupCompressZstd),
+			NotesTemplate: new(notes),
+		})
+		require.NoError(t, result.Err())
+	}
+
+	vmConfig := `
+		resource "proxmox_virtual_environment_vm" "test" {
+			node_name = "{{.NodeName}}"
+			vm_id     = {{.VMID}}
+			started   = false
+
+			disk {
+				datastore_id = "local-lvm"
+				interface    = "scsi0"
+				size         = 1
+			}
+		}
+	`
+
+	datasourceName := "data.proxmox_backups.test"
+	volumePrefix := regexp.MustCompile(fmt.Sprintf(`^%s:backup/vzdump-qemu-%d-`, regexp.QuoteMeta(te.DatastoreID), vmID))
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(vmConfig),
+			},
+			{
+				PreConfig: func() {
+					backup("first")
+					// the backups are named by the second they were started at
+					time.Sleep(time.Second)
+					backup("second")
+				},
+				Config: te.RenderConfig(vmConfig + `
+					data "proxmox_backups" "test" {
+						node_name    = proxmox_virtual_environment_vm.test.node_name
+						datastore_id = "{{.DatastoreID}}"
+						vm_id        = proxmox_virtual_environment_vm.test.vm_id
+						max_age      = "1h"
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes(datasourceName, map[string]string{
+						"backups.#":           "2",
+						"backups.0.notes":     "second",
+						"backups.0.vm_id":     strconv.Itoa(vmID),
+						"backups.0.subtype":   "qemu",
+						"backups.0.format":    "vma.zst",
+						"backups.0.protected": "false",
+						"backups.0.encrypted": "false",
+						"backups.1.notes":     "first",
+						"backups.1.vm_id":     strconv.Itoa(vmID),
+					}),
+					ResourceAttributesSet(datasourceName, []string{
+						"backups.0.size",
+						"backups.0.creation_time",
+						"backups.1.creation_time",
+					}),
+					resource.TestMatchResourceAttr(datasourceName, "backups.0.id", volumePrefix),
+					resource.TestMatchResourceAttr(datasourceName, "backups.1.id", volumePrefix),
+					func(s *terraform.State) error {
+						attrs := s.RootModule().Resources[datasourceName].Primary.Attributes
+
+						if attrs["backups.0.creation_time"] <= attrs["backups.1.creation_time"] {
+							return fmt.Errorf("backups are not sorted newest first: %s, %s",
+								attrs["backups.0.creation_time"], attrs["backups.1.creation_time"])
+						}
+
+						return nil
+					},
+				),
+			},
+			// the backups are older than the maximum age
+			{
+				PreConfig: func() {
+					time.Sleep(2 * time.Second)
+				},
+				Config: te.RenderConfig(vmConfig + `
+					data "proxmox_backups" "test" {
+						node_name    = proxmox_virtual_environment_vm.test.node_name
+						datastore_id = "{{.DatastoreID}}"
+						vm_id        = proxmox_virtual_environment_vm.test.vm_id
+						max_age      = "1s"
+					}
+				`),
+				Check: ResourceAttributes(datasourceName, map[string]string{"backups.#": "0"}),
+			},
+		},
+	})
+}
@@ -0,0 +1,64 @@
+//go:build acceptance || all
+
+//testacc:tier=light
+//testacc:resource=misc
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatasourceClusterStatus(t *testing.T) {
+	te := InitEnvironment(t)
+
+	datasourceName := "data.proxmox_cluster_status.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+					data "proxmox_cluster_status" "test" {}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes(datasourceName, map[string]string{
+						"quorate": "true",
+					}),
+					ResourceAttributesSet(datasourceName, []string{"local_node", "nodes.#"}),
+					resource.TestCheckTypeSetElemNestedAttrs(datasourceName, "nodes.*", map[string]string{
+						"name":   te.NodeName,
+						"online": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(datasourceName, "nodes.*", map[string]string{
+						"local": "true",
+					}),
+				),
+			},
+			// the quorum can be asserted before any change is made
+			{
+				Config: te.RenderConfig(`
+					data "proxmox_cluster_status" "test" {}
+
+					check "quorum" {
+						assert {
+							condition     = data.proxmox_cluster_status.test.quorate
+							error_message = "the cluster is not quorate"
+						}
+					}
+				`),
+				Check: ResourceAttributes(datasourceName, map[string]string{
+					"quorate": "true",
+				}),
+			},
+		},
+	})
+}
@@ -0,0 +1,75 @@
+//go:build acceptance || all
+
+//testacc:tier=light
+//testacc:resource=ha
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccDatasourceHAStatus reads the HA status of a stopped VM that is managed by the HA manager.
+func TestAccDatasourceHAStatus(t *testing.T) {
+	te := InitEnvironment(t)
+
+	vmID := 100000 + rand.Intn(99999)
+	serviceID := fmt.Sprintf("vm:%d", vmID)
+
+	te.AddTemplateVars(map[string]any{"VMID": vmID})
+
+	datasourceName := "data.proxmox_ha_status.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+					resource "proxmox_virtual_environment_vm" "test" {
+						node_name = "{{.NodeName}}"
+						vm_id     = {{.VMID}}
+						started   = false
+					}
+
+					resource "proxmox_haresource" "test" {
+						resource_id = "vm:${proxmox_virtual_environment_vm.test.vm_id}"
+						state       = "stopped"
+					}
+
+					data "proxmox_ha_status" "test" {
+						depends_on = [proxmox_haresource.test]
+					}
+
+					check "ha_service" {
+						assert {
+							condition     = data.proxmox_ha_status.test.services[proxmox_haresource.test.resource_id].state == "stopped"
+							error_message = "The HA service of the VM is not stopped."
+						}
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes(datasourceName, map[string]string{
+						"quorate": "true",
+						fmt.Sprintf("services.%s.node_name", serviceID): te.NodeName,
+						fmt.Sprintf("services.%s.state", serviceID):     "stopped",
+					}),
+					ResourceAttributesSet(datasourceName, []string{
+						fmt.Sprintf("services.%s.status", serviceID),
+						fmt.Sprintf("nodes.%s.state", te.NodeName),
+						fmt.Sprintf("nodes.%s.status", te.NodeName),
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+//go:build acceptance || all
+
+//testacc:tier=light
+//testacc:resource=vm
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccDatasourceTasks reads the task that created a VM, filtered by the ID of the VM.
+func TestAccDatasourceTasks(t *testing.T) {
+	te := InitEnvironment(t)
+
+	vmID := 100000 + rand.Intn(99999)
+
+	te.AddTemplateVars(map[string]any{"VMID": vmID})
+
+	datasourceName := "data.proxmox_tasks.create"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+					resource "proxmox_virtual_environment_vm" "test" {
+						node_name = "{{.NodeName}}"
+						vm_id     = {{.VMID}}
+						started   = false
+					}
+
+					data "proxmox_tasks" "create" {
+						node_name = proxmox_virtual_environment_vm.test.node_name
+						vm_id     = proxmox_virtual_environment_vm.test.vm_id
+						type      = "qmcreate"
+					}
+
+					data "proxmox_tasks" "failed" {
+						node_name = proxmox_virtual_environment_vm.test.node_name
+						vm_id     = proxmox_virtual_environment_vm.test.vm_id
+						status    = "error"
+					}
+
+					data "proxmox_tasks" "future" {
+						node_name = proxmox_virtual_environment_vm.test.node_name
+						since     = timeadd(plantimestamp(), "24h")
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes(datasourceName, map[string]string{
+						"tasks.#":           "1",
+						"tasks.0.type":      "qmcreate",
+						"tasks.0.id":        fmt.Sprint(vmID),
+						"tasks.0.node_name": te.NodeName,
+						"tasks.0.status":    "OK",
+					}),
+					ResourceAttributesSet(datasourceName, []string{
+						"tasks.0.upid",
+						"tasks.0.user",
+						"tasks.0.start_time",
+						"tasks.0.end_time",
+					}),
+					ResourceAttributes("data.proxmox_tasks.failed", map[string]string{"tasks.#": "0"}),
+					ResourceAttributes("data.proxmox_tasks.future", map[string]string{"tasks.#": "0"}),
+				),
+			},
+		},
+	})
+}
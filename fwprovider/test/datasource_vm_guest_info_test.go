@@ -0,0 +1,115 @@
+//go:build acceptance || all
+
+//testacc:tier=heavy
+//testacc:resource=vm
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatasourceVMGuestInfo(t *testing.T) {
+	te := InitEnvironment(t)
+	imageFileID := te.DownloadCloudImage()
+	te.AddTemplateVars(map[string]any{"ImageFileID": imageFileID})
+
+	datasourceName := "data.proxmox_vm_guest_info.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+					resource "proxmox_virtual_environment_file" "cloud_config" {
+						content_type = "snippets"
+						datastore_id = "local"
+						node_name    = "{{.NodeName}}"
+						overwrite    = true
+						source_raw {
+							data = <<-EOF
+							#cloud-config
+							hostname: acc-guest-info
+							timezone: UTC
+							runcmd:
+							  - apt update
+							  - apt install -y qemu-guest-agent
+							  - systemctl enable qemu-guest-agent
+							  - systemctl start qemu-guest-agent
+							EOF
+							file_name = "{{.TestName}}-cloud-config.yaml"
+						}
+					}
+
+					resource "proxmox_virtual_environment_vm" "test" {
+						node_name       = "{{.NodeName}}"
+						started         = true
+						stop_on_destroy = true
+						agent {
+							enabled = true
+						}
+						cpu {
+							cores = 2
+						}
+						memory {
+							dedicated = 2048
+						}
+						disk {
+							datastore_id = "local-lvm"
+							file_id      = "{{.ImageFileID}}"
+							interface    = "virtio0"
+							size         = 20
+						}
+						initialization {
+							ip_config {
+								ipv4 {
+									address = "dhcp"
+								}
+							}
+							user_data_file_id = proxmox_virtual_environment_file.cloud_config.id
+						}
+						network_device {
+							bridge = "vmbr0"
+						}
+					}
+
+					data "proxmox_vm_guest_info" "test" {
+						node_name = proxmox_virtual_environment_vm.test.node_name
+						vm_id     = proxmox_virtual_environment_vm.test.vm_id
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes(datasourceName, map[string]string{
+						"host_name":     "acc-guest-info",
+						"os.id":         "ubuntu",
+						"os.version_id": "24.04",
+						"os.machine":    "x86_64",
+						"timezone.zone": "UTC",
+						"vcpus.#":       "2",
+					}),
+					ResourceAttributesSet(datasourceName, []string{
+						"os.pretty_name",
+						"os.kernel_release",
+						"timezone.offset",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(datasourceName, "vcpus.*", map[string]string{
+						"logical_id": "0",
+						"online":     "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(datasourceName, "filesystems.*", map[string]string{
+						"mount_point": "/",
+						"type":        "ext4",
+					}),
+				),
+			},
+		},
+	})
+}
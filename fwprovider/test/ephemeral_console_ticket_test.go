@@ -0,0 +1,91 @@
+//go:build acceptance || all
+
+//testacc:tier=light
+//testacc:resource=misc
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"maps"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccEphemeralConsoleTicket opens the consoles of a node shell. The ephemeral values are
+// not stored in the state, so they are passed through the echo provider to be checked.
+func TestAccEphemeralConsoleTicket(t *testing.T) {
+	te := InitEnvironment(t)
+
+	providers := maps.Clone(te.AccProviders)
+	providers["echo"] = echoprovider.NewProviderServer()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providers,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+					ephemeral "proxmox_console_ticket" "vnc" {
+						node_name = "{{.NodeName}}"
+					}
+
+					provider "echo" {
+						data = {
+							port          = ephemeral.proxmox_console_ticket.vnc.port
+							user          = ephemeral.proxmox_console_ticket.vnc.user
+							url           = ephemeral.proxmox_console_ticket.vnc.url
+							has_ticket    = nonsensitive(ephemeral.proxmox_console_ticket.vnc.ticket != "")
+							websocket_url = split("?", nonsensitive(ephemeral.proxmox_console_ticket.vnc.websocket_url))[0]
+						}
+					}
+
+					resource "echo" "vnc" {}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes("echo.vnc", map[string]string{
+						"data.has_ticket": "true",
+					}),
+					ResourceAttributesSet("echo.vnc", []string{"data.port", "data.user"}),
+					resource.TestMatchResourceAttr("echo.vnc", "data.url",
+						regexp.MustCompile(`/\?console=shell&node=`+regexp.QuoteMeta(te.NodeName)+`&novnc=1&resize=scale$`)),
+					resource.TestMatchResourceAttr("echo.vnc", "data.websocket_url",
+						regexp.MustCompile(`^wss?://.+/api2/json/nodes/`+regexp.QuoteMeta(te.NodeName)+`/vncwebsocket$`)),
+				),
+			},
+			{
+				Config: te.RenderConfig(`
+					ephemeral "proxmox_console_ticket" "term" {
+						node_name = "{{.NodeName}}"
+						protocol  = "term"
+					}
+
+					provider "echo" {
+						data = {
+							port = ephemeral.proxmox_console_ticket.term.port
+							url  = ephemeral.proxmox_console_ticket.term.url
+						}
+					}
+
+					resource "echo" "term" {}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributesSet("echo.term", []string{"data.port"}),
+					resource.TestMatchResourceAttr("echo.term", "data.url",
+						regexp.MustCompile(`/\?console=shell&node=`+regexp.QuoteMeta(te.NodeName)+`&xtermjs=1$`)),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,110 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package faketest provides helpers for unit testing framework resources and data sources
+// against the in-memory Proxmox VE API of package fake.
+package faketest
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+)
+
+// NewClient starts a fake Proxmox VE API server with the options, which is closed when the
+// test finishes, and returns it with a client connected to it.
+func NewClient(t testing.TB, opts ...fake.Option) (*fake.Server, proxmox.Client) {
+	t.Helper()
+
+	s := fake.NewServer(opts...)
+	t.Cleanup(s.Close)
+
+	return s, Client(t, s, nil)
+}
+
+// Client returns a client connected to the fake server, which runs SSH commands with the
+// given client. The SSH client may be nil if the test does not use SSH.
+func Client(t testing.TB, s *fake.Server, sshClient ssh.Client) proxmox.Client {
+	t.Helper()
+
+	apiClient, err := s.Client()
+	require.NoError(t, err)
+
+	return proxmox.NewClient(apiClient, sshClient, "")
+}
+
+// ResourceState returns a state of the resource that has only the given root attributes set,
+// for use as the plan or prior state of a request.
+func ResourceState(t testing.TB, r resource.Resource, attrs map[string]any) tfsdk.State {
+	t.Helper()
+
+	ctx := t.Context()
+
+	var schemaResp resource.SchemaResponse
+
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	st := tfsdk.State{
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		Schema: schemaResp.Schema,
+	}
+
+	for name, v := range attrs {
+		require.False(t, st.SetAttribute(ctx, path.Root(name), v).HasError(), name)
+	}
+
+	return st
+}
+
+// ReadDataSource configures the data source with the client and reads it. The configuration
+// is built from cfg, or is empty if cfg is nil. It returns the model read into the state.
+func ReadDataSource[T any](t testing.TB, ds datasource.DataSource, client proxmox.Client, cfg *T) T {
+	t.Helper()
+
+	ctx := t.Context()
+
+	if c, ok := ds.(datasource.DataSourceWithConfigure); ok {
+		var configureResp datasource.ConfigureResponse
+
+		c.Configure(ctx, datasource.ConfigureRequest{ProviderData: config.DataSource{Client: client}}, &configureResp)
+		require.False(t, configureResp.Diagnostics.HasError(), configureResp.Diagnostics)
+	}
+
+	var schemaResp datasource.SchemaResponse
+
+	ds.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	empty := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	// the config is built as a state, which can be set from the model
+	cfgState := tfsdk.State{Raw: empty, Schema: schemaResp.Schema}
+	if cfg != nil {
+		require.False(t, cfgState.Set(ctx, cfg).HasError())
+	}
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Raw: empty, Schema: schemaResp.Schema}}
+
+	ds.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Raw: cfgState.Raw, Schema: schemaResp.Schema}}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var m T
+
+	require.False(t, resp.State.Get(ctx, &m).HasError())
+
+	return m
+}
@@ -0,0 +1,222 @@
+//go:build acceptance || all
+
+//testacc:tier=heavy
+//testacc:resource=container
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+const testAccContainerExecContainer = `
+resource "proxmox_virtual_environment_container" "test_container" {
+	node_name      = "{{.NodeName}}"
+	vm_id          = {{.TestContainerID}}
+	timeout_delete = 300
+	unprivileged   = true
+	started        = true
+	disk {
+		datastore_id = "local-lvm"
+		size         = 4
+	}
+	initialization {
+		hostname = "test-exec"
+		ip_config {
+			ipv4 {
+				address = "dhcp"
+			}
+		}
+	}
+	network_interface {
+		name = "vmbr0"
+	}
+	operating_system {
+		template_file_id = "local:vztmpl/{{.ImageFileName}}"
+		type             = "alpine"
+	}
+}
+`
+
+// testAccContainerExecEnvironment creates the environment of a test that runs commands in a
+// new Alpine container, and returns it with a check of the output of a command in the container.
+func testAccContainerExecEnvironment(t *testing.T) (*Environment, func(command, expected string) resource.TestCheckFunc) {
+	t.Helper()
+
+	te := InitEnvironment(t)
+	imageFileName := fmt.Sprintf("%d-alpine-3.22-default_20250617_amd64.tar.xz", time.Now().UnixMicro())
+	testAccDownloadContainerTemplate(t, te, imageFileName)
+
+	containerID := 100000 + rand.Intn(99999)
+
+	te.AddTemplateVars(map[string]any{
+		"ImageFileName":   imageFileName,
+		"TestContainerID": containerID,
+	})
+
+	checkOutput := func(command, expected string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			out := te.ExecuteNodeCommands([]string{
+				fmt.Sprintf("/usr/sbin/pct exec %d -- /bin/sh -c %q", containerID, command),
+			})
+
+			if actual := strings.TrimSpace(out); actual != expected {
+				return fmt.Errorf("`%s` in container %d returned %q, expected %q", command, containerID, actual, expected)
+			}
+
+			return nil
+		}
+	}
+
+	return te, checkOutput
+}
+
+func TestAccResourceContainerProvisioner(t *testing.T) {
+	te, checkOutput := testAccContainerExecEnvironment(t)
+
+	resourceName := "proxmox_container_provisioner.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(testAccContainerExecContainer+`
+					resource "proxmox_container_provisioner" "test" {
+						node_name    = proxmox_virtual_environment_container.test_container.node_name
+						container_id = proxmox_virtual_environment_container.test_container.vm_id
+
+						files = [
+							{
+								destination = "/root/provisioned"
+								content     = "provisioned\n"
+								mode        = "0640"
+								user        = "nobody"
+							},
+						]
+
+						commands = [
+							"cat /root/provisioned > /root/runs",
+						]
+					}
+				`, WithRootUser()),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributesSet(resourceName, []string{"id"}),
+					checkOutput("stat -c '%a %U %G' /root/provisioned", "640 nobody root"),
+					checkOutput("cat /root/runs", "provisioned"),
+				),
+			},
+			// changing the triggers runs the commands again
+			{
+				Config: te.RenderConfig(testAccContainerExecContainer+`
+					resource "proxmox_container_provisioner" "test" {
+						node_name    = proxmox_virtual_environment_container.test_container.node_name
+						container_id = proxmox_virtual_environment_container.test_container.vm_id
+
+						files = [
+							{
+								destination = "/root/provisioned"
+								content     = "provisioned\n"
+								mode        = "0640"
+								user        = "nobody"
+							},
+						]
+
+						commands = [
+							"cat /root/provisioned >> /root/runs",
+						]
+
+						triggers = {
+							revision = "1"
+						}
+					}
+				`, WithRootUser()),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionReplace),
+					},
+				},
+				Check: checkOutput("wc -l < /root/runs", "2"),
+			},
+			// a failing command fails the apply
+			{
+				Config: te.RenderConfig(testAccContainerExecContainer+`
+					resource "proxmox_container_provisioner" "test" {
+						node_name    = proxmox_virtual_environment_container.test_container.node_name
+						container_id = proxmox_virtual_environment_container.test_container.vm_id
+
+						commands = [
+							"exit 3",
+							"touch /root/not-reached",
+						]
+					}
+				`, WithRootUser()),
+				ExpectError: regexp.MustCompile(`error running command "exit 3"`),
+			},
+		},
+	})
+}
+
+func TestAccActionContainerExec(t *testing.T) {
+	te, checkOutput := testAccContainerExecEnvironment(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(testAccContainerExecContainer+`
+					action "proxmox_container_exec" "test" {
+						config {
+							node_name    = proxmox_virtual_environment_container.test_container.node_name
+							container_id = proxmox_virtual_environment_container.test_container.vm_id
+
+							files = [
+								{
+									destination = "/root/greeting"
+									content     = "hello"
+								},
+							]
+
+							commands = [
+								"tr a-z A-Z < /root/greeting > /root/shout",
+							]
+						}
+					}
+
+					resource "terraform_data" "trigger" {
+						input = proxmox_virtual_environment_container.test_container.vm_id
+
+						lifecycle {
+							action_trigger {
+								events  = [after_create]
+								actions = [action.proxmox_container_exec.test]
+							}
+						}
+					}
+				`, WithRootUser()),
+				Check: resource.ComposeTestCheckFunc(
+					checkOutput("cat /root/greeting", "hello"),
+					checkOutput("cat /root/shout", "HELLO"),
+				),
+			},
+		},
+	})
+}
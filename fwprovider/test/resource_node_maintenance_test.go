@@ -0,0 +1,100 @@
+//go:build acceptance || all
+
+//testacc:tier=medium
+//testacc:resource=ha
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/require"
+
+	hastatus "github.com/bpg/terraform-provider-proxmox/proxmox/cluster/ha/status"
+)
+
+// TestAccResourceNodeMaintenance puts the second node into the HA maintenance mode, which
+// migrates its HA resources away. The HA manager only processes the maintenance commands
+// while the cluster has HA resources, so the test is skipped on clusters without them.
+func TestAccResourceNodeMaintenance(t *testing.T) {
+	te := InitEnvironment(t)
+
+	if te.Node2Name == "" {
+		t.Skip("PROXMOX_VE_ACC_NODE_2_NAME is not set")
+	}
+
+	haStatus := te.ClusterClient().HA().Status()
+
+	status, err := haStatus.GetManagerStatus(context.Background())
+	require.NoError(t, err)
+
+	if err := status.ManagerStatus.CheckNode(te.Node2Name); err != nil {
+		t.Skipf("skipping node maintenance test: %v", err)
+	}
+
+	resourceName := "proxmox_node_maintenance.test"
+
+	checkNodeState := func(state string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			s, err := haStatus.GetManagerStatus(context.Background())
+			if err != nil {
+				return err
+			}
+
+			if actual := s.ManagerStatus.NodeStatus[te.Node2Name]; actual != state {
+				return fmt.Errorf("HA node %s is %q, expected %q", te.Node2Name, actual, state)
+			}
+
+			services := s.ManagerStatus.NodeServices(te.Node2Name)
+			if state == hastatus.NodeStateMaintenance && len(services) > 0 {
+				return fmt.Errorf("HA node %s is not evacuated: %s", te.Node2Name, s.ManagerStatus.DescribeServices(services))
+			}
+
+			return nil
+		}
+	}
+
+	// not parallel, the other tests may use the node that is evacuated
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		CheckDestroy:             checkNodeState(hastatus.NodeStateOnline),
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+					resource "proxmox_node_maintenance" "test" {
+						node_name = "{{.Node2Name}}"
+
+						timeouts = {
+							create = "20m"
+						}
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes(resourceName, map[string]string{
+						"id":                  te.Node2Name,
+						"node_name":           te.Node2Name,
+						"wait_for_evacuation": "true",
+					}),
+					checkNodeState(hastatus.NodeStateMaintenance),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           te.Node2Name,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_evacuation", "timeouts"},
+			},
+		},
+	})
+}
@@ -0,0 +1,97 @@
+//go:build acceptance || all
+
+//testacc:tier=light
+//testacc:resource=misc
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+// TestAccResourceNodeService restarts chrony, which is harmless and running on every node.
+func TestAccResourceNodeService(t *testing.T) {
+	te := InitEnvironment(t)
+
+	resourceName := "proxmox_node_service.chrony"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+					resource "proxmox_node_service" "chrony" {
+						node_name = "{{.NodeName}}"
+						service   = "chrony"
+						state     = "running"
+					}
+
+					data "proxmox_node_services" "test" {
+						node_name = proxmox_node_service.chrony.node_name
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes(resourceName, map[string]string{
+						"id":           te.NodeName + "/chrony",
+						"node_name":    te.NodeName,
+						"service":      "chrony",
+						"state":        "running",
+						"active_state": "active",
+					}),
+					ResourceAttributesSet(resourceName, []string{"description", "unit_state"}),
+					ResourceAttributesSet("data.proxmox_node_services.test", []string{"services.#"}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.proxmox_node_services.test", "services.*", map[string]string{
+						"service":      "chrony",
+						"name":         "chrony",
+						"state":        "running",
+						"active_state": "active",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.proxmox_node_services.test", "services.*", map[string]string{
+						"service": "pveproxy",
+						"state":   "running",
+					}),
+				),
+			},
+			// changing the triggers restarts the service in place
+			{
+				Config: te.RenderConfig(`
+					resource "proxmox_node_service" "chrony" {
+						node_name = "{{.NodeName}}"
+						service   = "chrony"
+						state     = "running"
+
+						triggers = {
+							revision = "1"
+						}
+					}
+				`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: ResourceAttributes(resourceName, map[string]string{
+					"state":             "running",
+					"active_state":      "active",
+					"triggers.revision": "1",
+				}),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           te.NodeName + "/chrony",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"triggers", "enabled"},
+			},
+		},
+	})
+}
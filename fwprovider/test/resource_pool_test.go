@@ -134,3 +134,48 @@ func TestAccResourcePool(t *testing.T) {
 		})
 	}
 }
+
+// TestAccResourcePoolReplay has a recorded cassette in testdata/cassettes, so it runs in CI
+// without a Proxmox VE host, with PROXMOX_VE_ACC_CASSETTE=replay.
+func TestAccResourcePoolReplay(t *testing.T) {
+	if utils.GetAnyStringEnv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled")
+	}
+
+	te := InitEnvironment(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+					resource "proxmox_virtual_environment_pool" "test" {
+						comment = "Managed by Terraform"
+						pool_id = "test-replay"
+					}
+				`),
+				Check: ResourceAttributes("proxmox_virtual_environment_pool.test", map[string]string{
+					"pool_id": "test-replay",
+					"comment": "Managed by Terraform",
+				}),
+			},
+			{
+				Config: te.RenderConfig(`
+					resource "proxmox_virtual_environment_pool" "test" {
+						comment = "Still managed by Terraform"
+						pool_id = "test-replay"
+					}
+				`),
+				Check: ResourceAttributes("proxmox_virtual_environment_pool.test", map[string]string{
+					"pool_id": "test-replay",
+					"comment": "Still managed by Terraform",
+				}),
+			},
+			{
+				ResourceName:      "proxmox_virtual_environment_pool.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
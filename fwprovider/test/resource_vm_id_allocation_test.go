@@ -0,0 +1,168 @@
+//go:build acceptance || all
+
+//testacc:tier=light
+//testacc:resource=misc
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+func TestAccResourceVMIDAllocation(t *testing.T) {
+	te := InitEnvironment(t)
+
+	// a high range, so the claim does not collide with the IDs of other tests
+	start := 900000000 + rand.Intn(90000)*1000
+	end := start + 9
+
+	te.AddTemplateVars(map[string]any{
+		"RangeStart":   start,
+		"RangeEnd":     end,
+		"OverlapStart": start + 5,
+		"OverlapEnd":   end + 5,
+	})
+
+	resourceName := "proxmox_vm_id_allocation.test"
+	rangeID := fmt.Sprintf("%d-%d", start, end)
+
+	checkOwner := func(owner string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			r, err := te.ClusterClient().GetIDRange(context.Background(), start, end)
+			if err != nil {
+				return fmt.Errorf("range %s is not claimed: %w", rangeID, err)
+			}
+
+			if r.Owner != owner {
+				return fmt.Errorf("range %s is claimed by %q, expected %q", rangeID, r.Owner, owner)
+			}
+
+			return nil
+		}
+	}
+
+	checkReleased := func(start, end int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			_, err := te.ClusterClient().GetIDRange(context.Background(), start, end)
+			if err == nil {
+				return fmt.Errorf("range %d-%d is still claimed", start, end)
+			}
+
+			if !errors.Is(err, api.ErrResourceDoesNotExist) {
+				return err
+			}
+
+			return nil
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			checkReleased(start, end),
+			checkReleased(start+5, end+5),
+		),
+		Steps: []resource.TestStep{
+			// allocate
+			{
+				Config: te.RenderConfig(`
+					resource "proxmox_vm_id_allocation" "test" {
+						start = {{.RangeStart}}
+						end   = {{.RangeEnd}}
+						owner = "acc-workspace-a"
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes(resourceName, map[string]string{
+						"id":    rangeID,
+						"start": fmt.Sprint(start),
+						"end":   fmt.Sprint(end),
+						"owner": "acc-workspace-a",
+					}),
+					checkOwner("acc-workspace-a"),
+				),
+			},
+			// renew the claim for another owner, in place
+			{
+				Config: te.RenderConfig(`
+					resource "proxmox_vm_id_allocation" "test" {
+						start = {{.RangeStart}}
+						end   = {{.RangeEnd}}
+						owner = "acc-workspace-b"
+					}
+				`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes(resourceName, map[string]string{
+						"id":    rangeID,
+						"owner": "acc-workspace-b",
+					}),
+					checkOwner("acc-workspace-b"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     rangeID,
+				ImportStateVerify: true,
+			},
+			// an overlapping range cannot be claimed by another workspace
+			{
+				Config: te.RenderConfig(`
+					resource "proxmox_vm_id_allocation" "test" {
+						start = {{.RangeStart}}
+						end   = {{.RangeEnd}}
+						owner = "acc-workspace-b"
+					}
+
+					resource "proxmox_vm_id_allocation" "overlap" {
+						start = {{.OverlapStart}}
+						end   = {{.OverlapEnd}}
+						owner = "acc-workspace-c"
+					}
+				`),
+				ExpectError: regexp.MustCompile(`overlaps range`),
+			},
+			// release
+			{
+				Config: te.RenderConfig(""),
+				Check:  checkReleased(start, end),
+			},
+			// the released IDs can be claimed again
+			{
+				Config: te.RenderConfig(`
+					resource "proxmox_vm_id_allocation" "overlap" {
+						start = {{.OverlapStart}}
+						end   = {{.OverlapEnd}}
+						owner = "acc-workspace-c"
+					}
+				`),
+				Check: ResourceAttributes("proxmox_vm_id_allocation.overlap", map[string]string{
+					"id":    fmt.Sprintf("%d-%d", start+5, end+5),
+					"owner": "acc-workspace-c",
+				}),
+			},
+		},
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"maps"
 	"net/url"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -29,6 +30,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test/cassette"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
@@ -55,6 +57,9 @@ type Environment struct {
 	c                     api.Client
 	CloudImagesServer     string
 	ContainerImagesServer string
+
+	// cassette is the record/replay API server, set when PROXMOX_VE_ACC_CASSETTE is configured.
+	cassette *cassette.Server
 }
 
 // RenderConfigOption is a configuration option for rendering the provider configuration.
@@ -64,6 +69,19 @@ type RenderConfigOption interface {
 
 type renderConfig struct {
 	providerConfig string
+
+	// endpoint overrides the API endpoint from the environment, e.g. with a cassette server.
+	endpoint string
+	replay   bool
+}
+
+// returns the endpoint configuration lines of the provider config, if the endpoint is overridden.
+func (r *renderConfig) endpointConfig() string {
+	if r.endpoint == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("\tendpoint = \"%s\"\n\tinsecure = true\n", r.endpoint)
 }
 
 // returns the ssh configuration section of the provider config.
@@ -85,6 +103,11 @@ func (r *renderConfig) ssh() string {
 		nodeAddress = u.Hostname()
 	}
 
+	if nodeAddress == "" && r.replay {
+		// SSH is not used by replayed tests, but the provider requires a node address
+		nodeAddress = "127.0.0.1"
+	}
+
 	nodePort := utils.GetAnyStringEnv("PROXMOX_VE_ACC_NODE_SSH_PORT")
 	if nodePort == "" {
 		nodePort = "22"
@@ -109,13 +132,20 @@ func WithRootUser() RenderConfigOption {
 type rootUserConfigOption struct{}
 
 func (o *rootUserConfigOption) apply(rc *renderConfig) error {
-	if utils.GetAnyStringEnv("PROXMOX_VE_USERNAME") == "" || utils.GetAnyStringEnv("PROXMOX_VE_PASSWORD") == "" {
+	username := utils.GetAnyStringEnv("PROXMOX_VE_USERNAME")
+	password := utils.GetAnyStringEnv("PROXMOX_VE_PASSWORD")
+
+	if rc.replay && (username == "" || password == "") {
+		// passwords are redacted in cassettes, any credentials will match
+		username, password = "root@pam", "replay"
+	}
+
+	if username == "" || password == "" {
 		return fmt.Errorf("PROXMOX_VE_USERNAME and PROXMOX_VE_PASSWORD must be set")
 	}
 
-	rootUser := fmt.Sprintf("\tusername = \"%s\"\n\tpassword = \"%s\"\n\tapi_token = \"\"",
-		utils.GetAnyStringEnv("PROXMOX_VE_USERNAME"),
-		utils.GetAnyStringEnv("PROXMOX_VE_PASSWORD"),
+	rootUser := fmt.Sprintf("%s\tusername = \"%s\"\n\tpassword = \"%s\"\n\tapi_token = \"\"",
+		rc.endpointConfig(), username, password,
 	)
 
 	rc.providerConfig = fmt.Sprintf("provider \"proxmox\" {\n%s\n%s\n}", rootUser, rc.ssh())
@@ -131,12 +161,17 @@ func WithAPIToken() RenderConfigOption {
 type apiTokenConfigOption struct{}
 
 func (o *apiTokenConfigOption) apply(rc *renderConfig) error {
-	if utils.GetAnyStringEnv("PROXMOX_VE_API_TOKEN") == "" {
+	token := utils.GetAnyStringEnv("PROXMOX_VE_API_TOKEN")
+	if token == "" && rc.replay {
+		token = cassette.ReplayAPIToken
+	}
+
+	if token == "" {
 		return fmt.Errorf("PROXMOX_VE_API_TOKEN must be set")
 	}
 
-	apiToken := fmt.Sprintf("\tapi_token = \"%s\"\n\tusername = \"\"\n\tpassword = \"\"",
-		utils.GetAnyStringEnv("PROXMOX_VE_API_TOKEN"))
+	apiToken := fmt.Sprintf("%s\tapi_token = \"%s\"\n\tusername = \"\"\n\tpassword = \"\"",
+		rc.endpointConfig(), token)
 
 	rc.providerConfig = fmt.Sprintf("provider \"proxmox\" {\n%s\n%s\n}", apiToken, rc.ssh())
 
@@ -169,7 +204,7 @@ func InitEnvironment(t *testing.T) *Environment {
 		containerImagesServer = "http://download.proxmox.com"
 	}
 
-	return &Environment{
+	env := &Environment{
 		t: t,
 		templateVars: map[string]any{
 			"NodeName":              nodeName,
@@ -191,6 +226,43 @@ func InitEnvironment(t *testing.T) *Environment {
 
 		AccProviders: muxProviders(t),
 	}
+
+	env.startCassette()
+
+	return env
+}
+
+// startCassette starts a record/replay API server for the test when PROXMOX_VE_ACC_CASSETTE
+// is set to `record` or `replay`. Cassettes are stored per test in PROXMOX_VE_ACC_CASSETTE_DIR
+// (defaults to `testdata/cassettes` in the test package). SSH operations are not recorded,
+// so only tests that use the API exclusively can be replayed.
+//
+// CI replays every test of this package whose name ends with `Replay`, so such a test must
+// have a committed cassette. To add one, name the test `TestAcc<Name>Replay`, record its
+// cassette against a live cluster with the endpoint and credentials set, and commit
+// `testdata/cassettes/TestAcc<Name>Replay.json`:
+//
+//	PROXMOX_VE_ACC_CASSETTE=record ./testacc TestAcc<Name>Replay
+//	PROXMOX_VE_ACC_CASSETTE=replay ./testacc TestAcc<Name>Replay
+func (e *Environment) startCassette() {
+	mode, err := cassette.ParseMode(utils.GetAnyStringEnv(cassette.EnvMode))
+	require.NoError(e.t, err)
+
+	if mode == cassette.ModeDisabled {
+		return
+	}
+
+	dir := utils.GetAnyStringEnv(cassette.EnvDir)
+	if dir == "" {
+		dir = cassette.DefaultDir
+	}
+
+	e.cassette = cassette.Start(
+		e.t,
+		mode,
+		filepath.Join(dir, cassette.FileName(e.t.Name())),
+		utils.GetAnyStringEnv("PROXMOX_VE_ENDPOINT"),
+	)
 }
 
 var nonAlnum = regexp.MustCompile(`[^a-zA-Z0-9]+`)
@@ -222,6 +294,11 @@ func (e *Environment) RenderConfig(cfg string, opt ...RenderConfigOption) string
 	}
 
 	rc := &renderConfig{}
+	if e.cassette != nil {
+		rc.endpoint = e.cassette.URL
+		rc.replay = e.cassette.Mode() == cassette.ModeReplay
+	}
+
 	for _, o := range opt {
 		err := o.apply(rc)
 		require.NoError(e.t, err, "configuration error")
@@ -254,6 +331,14 @@ func (e *Environment) Client() api.Client {
 				username := utils.GetAnyStringEnv("PROXMOX_VE_USERNAME")
 				password := utils.GetAnyStringEnv("PROXMOX_VE_PASSWORD")
 
+				if e.cassette != nil {
+					endpoint = e.cassette.URL
+
+					if e.cassette.Mode() == cassette.ModeReplay && apiToken == "" {
+						apiToken = cassette.ReplayAPIToken
+					}
+				}
+
 				creds, err := api.NewCredentials(username, password, "", apiToken, authTicket, csrfPreventionToken)
 				if err != nil {
 					panic(err)
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -44,6 +45,25 @@ func IsValidRegularExpression() validator.String {
 	)
 }
 
+// IsValidDuration validates that a string is a positive Go duration, e.g. `90m` or `24h`.
+func IsValidDuration() validator.String {
+	return NewParseValidator(
+		func(s string) (string, error) {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return s, fmt.Errorf("%q is not a valid duration: %w", s, err)
+			}
+
+			if d <= 0 {
+				return s, fmt.Errorf("%q is not a positive duration", s)
+			}
+
+			return s, nil
+		},
+		"must be a positive duration, e.g. `24h`",
+	)
+}
+
 // NonEmptyString returns a new validator to ensure a non-empty string.
 func NonEmptyString() validator.String {
 	return stringvalidator.All(
@@ -20,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/tracing"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/provider"
 )
 
@@ -33,6 +34,10 @@ import (
 // Temporary: while migrating to the TF framework, we need to copy the generated docs to the right place
 // for the resources / data sources that have been migrated.
 //go:generate cp -R ./build/docs-gen/guides/. ./docs/guides/
+//go:generate cp -R ./build/docs-gen/actions/. ./docs/actions/
+//go:generate cp -R ./build/docs-gen/ephemeral-resources/. ./docs/ephemeral-resources/
+//go:generate cp -R ./build/docs-gen/list-resources/. ./docs/list-resources/
+//go:generate cp -R ./build/docs-gen/functions/. ./docs/functions/
 // sorted alphabetically:
 //go:generate cp ./build/docs-gen/data-sources/acme_account.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/acme_accounts.md ./docs/data-sources/
@@ -49,16 +54,20 @@ import (
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_datastores.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/datastores.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/backup_jobs.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/backups.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/ceph_status.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/cluster_status.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_file.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/file.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/files.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/node_config.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/node_services.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/hardware_pci.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/hagroup.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/hagroups.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/haresource.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/haresources.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/ha_status.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_hagroup.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_hagroups.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/hardware_mapping_dir.md ./docs/data-sources/
@@ -106,11 +115,13 @@ import (
 //go:generate cp ./build/docs-gen/data-sources/sdn_zone_vlan.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/sdn_zone_vxlan.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/sdn_zones.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/tasks.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_version.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_vm2.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/resources/acl.md ./docs/resources/
 //go:generate cp ./build/docs-gen/data-sources/version.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/vm.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/vm_guest_info.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/resources/backup_job.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/realm_ldap.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/realm_openid.md ./docs/resources/
@@ -132,6 +143,7 @@ import (
 //go:generate cp ./build/docs-gen/resources/virtual_environment_cloned_vm.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/cloned_vm.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/cluster_options.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/container_provisioner.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_cluster_options.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_download_file.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/download_file.md ./docs/resources/
@@ -157,6 +169,8 @@ import (
 //go:generate cp ./build/docs-gen/resources/node_config.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_node_firewall.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/node_firewall.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/node_maintenance.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/node_service.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_oci_image.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/oci_image.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_pool_membership.md ./docs/resources/
@@ -208,6 +222,7 @@ import (
 //go:generate cp ./build/docs-gen/resources/virtual_environment_vm2.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/pool_membership.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/vm.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/vm_id_allocation.md ./docs/resources/
 
 // these will be set by the goreleaser configuration
 // to appropriate values for the compiled binary.
@@ -221,6 +236,11 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
+	shutdownTracing, err := tracing.Setup(ctx, version)
+	if err != nil {
+		log.Printf("[WARN] failed to set up OpenTelemetry tracing: %s", err)
+	}
+
 	upgradedSdkServer, err := tf5to6server.UpgradeServer(
 		ctx,
 		func() tfprotov5.ProviderServer {
@@ -260,6 +280,12 @@ func main() {
 		muxServer.ProviderServer,
 		serveOpts...,
 	)
+
+	// flush any pending spans before exiting
+	if e := shutdownTracing(ctx); e != nil {
+		log.Printf("[WARN] failed to flush OpenTelemetry traces: %s", e)
+	}
+
 	if err != nil {
 		log.Fatal(err)
 	}
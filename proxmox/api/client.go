@@ -26,6 +26,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 
+	"github.com/bpg/terraform-provider-proxmox/proxmox/tracing"
 	"github.com/bpg/terraform-provider-proxmox/utils"
 )
 
@@ -36,6 +37,7 @@ const (
 // Client is an interface for performing requests against the Proxmox API.
 type Client interface {
 	// DoRequest performs a request against the Proxmox API.
+	// If responseBody is an io.Writer, the raw response body is copied into it instead of being decoded.
 	DoRequest(
 		ctx context.Context,
 		method, path string,
@@ -151,7 +153,15 @@ func (c *client) DoRequest(
 	ctx context.Context,
 	method, path string,
 	requestBody, responseBody any,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "proxmox.api "+method,
+		append(tracing.PathAttributes(path),
+			tracing.AttrHTTPMethod.String(method),
+			tracing.AttrURLPath.String(pathWithoutQuery(path)),
+		)...,
+	)
+	defer func() { tracing.End(span, err) }()
+
 	var reqBodyReader io.Reader
 
 	var reqContentLength *int64
@@ -261,11 +271,27 @@ func (c *client) DoRequest(
 
 	defer utils.CloseOrLogError(ctx)(res.Body)
 
+	span.SetAttributes(tracing.AttrHTTPStatus.Int(res.StatusCode))
+
 	err = validateResponseCode(res)
 	if err != nil {
 		return err
 	}
 
+	if w, ok := responseBody.(io.Writer); ok {
+		_, err = io.Copy(w, res.Body)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to read HTTP %s response body (path: %s) - Reason: %w",
+				method,
+				modifiedPath,
+				err,
+			)
+		}
+
+		return nil
+	}
+
 	//nolint:nestif
 	if responseBody != nil {
 		err = json.NewDecoder(res.Body).Decode(responseBody)
@@ -306,6 +332,16 @@ func (c *client) DoRequest(
 	return nil
 }
 
+// pathWithoutQuery strips the query string from a request path, so that span attributes
+// do not carry request parameters.
+func pathWithoutQuery(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		return path[:i]
+	}
+
+	return path
+}
+
 type dataResponse struct {
 	Data any `json:"data"`
 }
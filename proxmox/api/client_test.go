@@ -16,7 +16,15 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/tracing"
 )
 
 // RoundTripFunc .
@@ -126,3 +134,49 @@ func TestClientDoRequest(t *testing.T) {
 		})
 	}
 }
+
+// TestClientDoRequestTracing is intentionally not parallel: it swaps the global tracer provider.
+func TestClientDoRequestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	c := client{
+		conn: &Connection{
+			endpoint: "http://localhost",
+			httpClient: newTestClient(func(_ *http.Request) *http.Response {
+				return &http.Response{
+					Status:     "500 Internal Server Error",
+					StatusCode: http.StatusInternalServerError,
+					Body:       io.NopCloser(strings.NewReader("")),
+				}
+			}),
+		},
+		auth: dummyAuthenticator{},
+	}
+
+	err := c.DoRequest(t.Context(), http.MethodGet, "nodes/pve/qemu/100/config?current=1", nil, nil)
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, "proxmox.api GET", span.Name())
+	assert.Equal(t, codes.Error, span.Status().Code)
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+
+	assert.Equal(t, "pve", attrs[tracing.AttrNode].AsString())
+	assert.Equal(t, "qemu", attrs[tracing.AttrResourceType].AsString())
+	assert.Equal(t, int64(100), attrs[tracing.AttrVMID].AsInt64())
+	assert.Equal(t, "nodes/pve/qemu/100/config", attrs[tracing.AttrURLPath].AsString())
+	assert.Equal(t, int64(http.StatusInternalServerError), attrs[tracing.AttrHTTPStatus].AsInt64())
+}
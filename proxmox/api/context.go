@@ -0,0 +1,24 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import "context"
+
+type offlineNodeKey struct{}
+
+// WithOfflineNode returns a context for requests that are expected to target an offline node,
+// e.g. to wake it up, so that clients checking the node status do not refuse them.
+func WithOfflineNode(ctx context.Context) context.Context {
+	return context.WithValue(ctx, offlineNodeKey{}, true)
+}
+
+// OfflineNodeAllowed returns true if the requests of the context may target an offline node.
+func OfflineNodeAllowed(ctx context.Context) bool {
+	v, _ := ctx.Value(offlineNodeKey{}).(bool)
+
+	return v
+}
@@ -47,9 +47,12 @@ type ResourcesListResponseData struct {
 	Name       string  `json:"name,omitempty"`
 	NodeName   string  `json:"node,omitempty"`
 	PluginType string  `json:"plugintype,omitempty"`
+	Pool       string  `json:"pool,omitempty"`
 	PoolName   string  `json:"poolname,omitempty"`
 	Status     string  `json:"status,omitempty"`
 	Storage    string  `json:"storage,omitempty"`
+	Tags       string  `json:"tags,omitempty"`
+	Template   int     `json:"template,omitempty"`
 	Uptime     int     `json:"uptime,omitempty"`
 	VMID       int     `json:"vmid,omitempty"`
 }
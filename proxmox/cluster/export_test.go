@@ -0,0 +1,39 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cluster
+
+import "context"
+
+// ErrIDLockLost exposes errIDLockLost to the tests.
+var ErrIDLockLost = errIDLockLost
+
+// IDLock exposes the lock of the VM ID claims to the tests.
+type IDLock struct {
+	lock *idLock
+}
+
+// LockIDClaims acquires the lock of the VM ID claims.
+func (c *Client) LockIDClaims(ctx context.Context, owner string) (*IDLock, error) {
+	l, err := c.lockIDClaims(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDLock{lock: l}, nil
+}
+
+// Renew extends the TTL of the lock regardless of when it was renewed last.
+func (l *IDLock) Renew(ctx context.Context) error {
+	l.lock.renewed = l.lock.renewed.Add(-idLockTTL)
+
+	return l.lock.renew(ctx)
+}
+
+// Release releases the lock.
+func (l *IDLock) Release(ctx context.Context) {
+	l.lock.release(ctx)
+}
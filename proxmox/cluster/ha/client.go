@@ -13,6 +13,7 @@ import (
 	hagroups "github.com/bpg/terraform-provider-proxmox/proxmox/cluster/ha/groups"
 	haresources "github.com/bpg/terraform-provider-proxmox/proxmox/cluster/ha/resources"
 	harules "github.com/bpg/terraform-provider-proxmox/proxmox/cluster/ha/rules"
+	hastatus "github.com/bpg/terraform-provider-proxmox/proxmox/cluster/ha/status"
 )
 
 // Client is an interface for accessing the Proxmox High Availability API.
@@ -39,3 +40,8 @@ func (c *Client) Resources() *haresources.Client {
 func (c *Client) Rules() *harules.Client {
 	return &harules.Client{Client: c.Client}
 }
+
+// Status returns a client for reading the cluster's High Availability status.
+func (c *Client) Status() *hastatus.Client {
+	return &hastatus.Client{Client: c.Client}
+}
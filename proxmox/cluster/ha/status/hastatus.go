@@ -0,0 +1,245 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/retry"
+)
+
+// pollDelay is the delay between the HA manager status checks. The manager itself only acts
+// every 10 seconds.
+const pollDelay = 5 * time.Second
+
+// ErrServiceError is returned when a service blocking the evacuation of a node is in the error
+// state, which the HA manager does not recover from without manual intervention.
+var ErrServiceError = errors.New("service is in error state")
+
+// ErrManagerIdle is returned when no HA manager is active, which is the case while the cluster
+// has no HA resources. An idle manager does not process the node maintenance commands.
+var ErrManagerIdle = errors.New("the HA manager is idle, as the cluster has no HA resources")
+
+// ErrNodeNotManaged is returned when the active HA manager does not know the node.
+var ErrNodeNotManaged = errors.New("the node is not managed by the HA manager")
+
+// GetManagerStatus retrieves the full status of the HA manager.
+func (c *Client) GetManagerStatus(ctx context.Context) (*ManagerStatusResponseData, error) {
+	resBody := &ManagerStatusResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("manager_status"), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving HA manager status: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// ListCurrent retrieves the current status of the HA quorum, manager, nodes and services.
+func (c *Client) ListCurrent(ctx context.Context) ([]*CurrentResponseData, error) {
+	resBody := &CurrentResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("current"), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving HA status: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// WaitForNodeState waits until the HA manager reports the node in the given state,
+// e.g. `online` after the maintenance mode was disabled.
+func (c *Client) WaitForNodeState(ctx context.Context, nodeName, state string) error {
+	unexpectedState := errors.New("unexpected node state")
+
+	var current string
+
+	op := retry.NewPollOperation("HA node state",
+		retry.WithBaseDelay(pollDelay),
+		retry.WithRetryIf(func(err error) bool {
+			return errors.Is(err, unexpectedState)
+		}),
+	)
+
+	err := op.DoPoll(ctx, func() error {
+		data, err := c.GetManagerStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := data.ManagerStatus.CheckNode(nodeName); err != nil {
+			return err
+		}
+
+		if current = data.ManagerStatus.NodeStatus[nodeName]; current != state {
+			return unexpectedState
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timeout while waiting for HA node %s to be %s, it is %s", nodeName, state, describeState(current))
+	}
+
+	if err != nil {
+		return fmt.Errorf("error waiting for HA node %s to be %s: %w", nodeName, state, err)
+	}
+
+	return nil
+}
+
+// WaitForNodeEvacuation waits until the HA manager reports the node in maintenance and has
+// moved all services away from it. It fails early when a remaining service is in error state.
+func (c *Client) WaitForNodeEvacuation(ctx context.Context, nodeName string) error {
+	notEvacuated := errors.New("node not evacuated")
+
+	var (
+		state     string
+		remaining string
+	)
+
+	op := retry.NewPollOperation("HA node evacuation",
+		retry.WithBaseDelay(pollDelay),
+		retry.WithRetryIf(func(err error) bool {
+			return errors.Is(err, notEvacuated)
+		}),
+	)
+
+	err := op.DoPoll(ctx, func() error {
+		data, err := c.GetManagerStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		ms := &data.ManagerStatus
+		if err := ms.CheckNode(nodeName); err != nil {
+			return err
+		}
+
+		services := ms.NodeServices(nodeName)
+
+		state = ms.NodeStatus[nodeName]
+		remaining = ms.DescribeServices(services)
+
+		for _, id := range services {
+			if ms.ServiceStatus[id].State == ServiceStateError {
+				return fmt.Errorf("%w: %s", ErrServiceError, id)
+			}
+		}
+
+		if state != NodeStateMaintenance || len(services) > 0 {
+			return notEvacuated
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		if state != NodeStateMaintenance {
+			return fmt.Errorf("timeout while waiting for HA node %s to enter maintenance, it is %s",
+				nodeName, describeState(state))
+		}
+
+		return fmt.Errorf("timeout while waiting for HA node %s to be evacuated, blocking services: %s",
+			nodeName, remaining)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error waiting for HA node %s to be evacuated: %w", nodeName, err)
+	}
+
+	return nil
+}
+
+// describeState describes a node state for the error messages.
+func describeState(state string) string {
+	if state == "" {
+		return "unknown to the HA manager"
+	}
+
+	return state
+}
+
+// Active returns whether an HA manager is active.
+func (s *ManagerStatus) Active() bool {
+	return s.MasterNode != ""
+}
+
+// CheckNode returns an error when the node state cannot be managed, because the HA manager is
+// idle or does not know the node.
+func (s *ManagerStatus) CheckNode(nodeName string) error {
+	if !s.Active() {
+		return ErrManagerIdle
+	}
+
+	if _, ok := s.NodeStatus[nodeName]; !ok {
+		return fmt.Errorf("%w: %s", ErrNodeNotManaged, nodeName)
+	}
+
+	return nil
+}
+
+// NodeServices returns the IDs of the services that are still assigned to the node, sorted,
+// except for the stopped and ignored services, which do not keep the node busy.
+func (s *ManagerStatus) NodeServices(nodeName string) []string {
+	var ids []string
+
+	for id, svc := range s.ServiceStatus {
+		if svc == nil || svc.Node != nodeName {
+			continue
+		}
+
+		if svc.State == ServiceStateStopped || svc.State == ServiceStateIgnored {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+
+	return ids
+}
+
+// DescribeServices describes the state of the services, e.g. `vm:100 (migrate to pve2)`.
+func (s *ManagerStatus) DescribeServices(ids []string) string {
+	descriptions := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		svc := s.ServiceStatus[id]
+		if svc == nil {
+			descriptions = append(descriptions, id)
+
+			continue
+		}
+
+		state := svc.State
+		if svc.Target != nil && *svc.Target != "" {
+			state = fmt.Sprintf("%s to %s", state, *svc.Target)
+		}
+
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", id, state))
+	}
+
+	return strings.Join(descriptions, ", ")
+}
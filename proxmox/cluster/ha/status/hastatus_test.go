@@ -0,0 +1,99 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedAPIClient returns the HA manager status decoded from the responses in order,
+// repeating the last one.
+type scriptedAPIClient struct {
+	responses []string
+	calls     int
+}
+
+func (c *scriptedAPIClient) DoRequest(_ context.Context, _, _ string, _, responseBody any) error {
+	r := c.responses[min(c.calls, len(c.responses)-1)]
+	c.calls++
+
+	return json.Unmarshal([]byte(`{"data":`+r+`}`), responseBody)
+}
+
+func (c *scriptedAPIClient) ExpandPath(path string) string       { return path }
+func (c *scriptedAPIClient) IsRoot(_ context.Context) bool       { return false }
+func (c *scriptedAPIClient) IsRootTicket(_ context.Context) bool { return false }
+func (c *scriptedAPIClient) HTTP() *http.Client                  { return &http.Client{} }
+
+const (
+	migrating = `{"manager_status":{"master_node":"pve1","node_status":{"pve1":"online","pve2":"maintenance"},
+		"service_status":{
+			"vm:100":{"node":"pve2","state":"migrate","target":"pve1"},
+			"vm:101":{"node":"pve2","state":"stopped"},
+			"ct:102":{"node":"pve1","state":"started"}}}}`
+	evacuated = `{"manager_status":{"master_node":"pve1","node_status":{"pve1":"online","pve2":"maintenance"},
+		"service_status":{
+			"vm:100":{"node":"pve1","state":"started"},
+			"vm:101":{"node":"pve2","state":"stopped"},
+			"ct:102":{"node":"pve1","state":"started"}}}}`
+	failed = `{"manager_status":{"master_node":"pve1","node_status":{"pve1":"online","pve2":"maintenance"},
+		"service_status":{"vm:100":{"node":"pve2","state":"error"}}}}`
+)
+
+func TestNodeServices(t *testing.T) {
+	t.Parallel()
+
+	var data ManagerStatusResponseData
+
+	require.NoError(t, json.Unmarshal([]byte(migrating), &data))
+
+	services := data.ManagerStatus.NodeServices("pve2")
+	assert.Equal(t, []string{"vm:100"}, services)
+	assert.Equal(t, "vm:100 (migrate to pve1)", data.ManagerStatus.DescribeServices(services))
+	assert.Equal(t, []string{"ct:102"}, data.ManagerStatus.NodeServices("pve1"))
+}
+
+func TestWaitForNodeEvacuation(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{Client: &scriptedAPIClient{responses: []string{evacuated}}}
+	require.NoError(t, c.WaitForNodeEvacuation(t.Context(), "pve2"))
+
+	c = &Client{Client: &scriptedAPIClient{responses: []string{failed}}}
+	require.ErrorIs(t, c.WaitForNodeEvacuation(t.Context(), "pve2"), ErrServiceError)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+
+	c = &Client{Client: &scriptedAPIClient{responses: []string{migrating}}}
+	err := c.WaitForNodeEvacuation(ctx, "pve2")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "blocking services: vm:100 (migrate to pve1)")
+
+	c = &Client{Client: &scriptedAPIClient{responses: []string{`{"manager_status":{}}`}}}
+	require.ErrorIs(t, c.WaitForNodeEvacuation(t.Context(), "pve2"), ErrManagerIdle)
+
+	c = &Client{Client: &scriptedAPIClient{responses: []string{evacuated}}}
+	require.ErrorIs(t, c.WaitForNodeEvacuation(t.Context(), "pve3"), ErrNodeNotManaged)
+}
+
+func TestWaitForNodeState(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{Client: &scriptedAPIClient{responses: []string{evacuated}}}
+	require.NoError(t, c.WaitForNodeState(t.Context(), "pve1", NodeStateOnline))
+
+	c = &Client{Client: &scriptedAPIClient{responses: []string{`{"manager_status":{}}`}}}
+	require.ErrorIs(t, c.WaitForNodeState(t.Context(), "pve1", NodeStateOnline), ErrManagerIdle)
+}
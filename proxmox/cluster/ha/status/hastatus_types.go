@@ -0,0 +1,75 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package status
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// Node states reported by the HA manager.
+const (
+	NodeStateOnline      = "online"
+	NodeStateMaintenance = "maintenance"
+)
+
+// Service states reported by the HA manager, which are relevant to the node evacuation.
+const (
+	ServiceStateError   = "error"
+	ServiceStateStopped = "stopped"
+	ServiceStateIgnored = "ignored"
+)
+
+// ManagerStatusResponseBody contains the body from an HA manager status response.
+type ManagerStatusResponseBody struct {
+	Data *ManagerStatusResponseData `json:"data,omitempty"`
+}
+
+// ManagerStatusResponseData contains the full status of the HA manager.
+type ManagerStatusResponseData struct {
+	ManagerStatus ManagerStatus `json:"manager_status"`
+}
+
+// ManagerStatus contains the state of the HA cluster resource manager (CRM). It is empty while
+// no CRM is active, i.e. when there are no HA resources.
+type ManagerStatus struct {
+	MasterNode string `json:"master_node,omitempty"`
+	// NodeStatus maps the node names to their HA states, e.g. `online` or `maintenance`.
+	NodeStatus map[string]string `json:"node_status,omitempty"`
+	// ServiceStatus maps the HA resource IDs, e.g. `vm:100`, to their states.
+	ServiceStatus map[string]*ServiceStatus `json:"service_status,omitempty"`
+	Timestamp     *int64                    `json:"timestamp,omitempty"`
+}
+
+// ServiceStatus contains the HA manager state of a service.
+type ServiceStatus struct {
+	Node   string  `json:"node"`
+	State  string  `json:"state"`
+	Target *string `json:"target,omitempty"`
+}
+
+// CurrentResponseBody contains the body from an HA current status response.
+type CurrentResponseBody struct {
+	Data []*CurrentResponseData `json:"data,omitempty"`
+}
+
+// CurrentResponseData contains an entry of the HA current status, which describes the quorum
+// (type `quorum`), the active manager (type `master`), the local resource manager of a node
+// (type `lrm`) or a service (type `service`).
+type CurrentResponseData struct {
+	ID           string            `json:"id"`
+	Type         string            `json:"type"`
+	Node         string            `json:"node,omitempty"`
+	Status       *string           `json:"status,omitempty"`
+	Quorate      *types.CustomBool `json:"quorate,omitempty"`
+	Timestamp    *int64            `json:"timestamp,omitempty"`
+	SID          *string           `json:"sid,omitempty"`
+	State        *string           `json:"state,omitempty"`
+	CRMState     *string           `json:"crm_state,omitempty"`
+	RequestState *string           `json:"request_state,omitempty"`
+	MaxRelocate  *int64            `json:"max_relocate,omitempty"`
+	MaxRestart   *int64            `json:"max_restart,omitempty"`
+}
@@ -0,0 +1,554 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cluster
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/pools"
+)
+
+// VM ID claims are stored as empty resource pools, because creating a pool is atomic across
+// the cluster and fails if the pool already exists. The pool comment holds the owner of the
+// claim and, for leases and the lock, when it expires.
+const (
+	idClaimPoolPrefix = "terraform-vmid-"
+	idLeasePoolPrefix = "terraform-vmid-lease-"
+	idRangePoolPrefix = "terraform-vmid-range-"
+	idLockPoolID      = "terraform-vmid-lock"
+
+	idLeaseTTL       = 10 * time.Minute
+	idLockTTL        = 30 * time.Second
+	idLockTimeout    = 2 * time.Minute
+	idLockRetryDelay = 500 * time.Millisecond
+
+	idLeaseMaxAttempts = 1000
+)
+
+// errIDLockLost is returned when the lock of the VM ID claims expired and was taken over by
+// another process while it was held.
+var errIDLockLost = errors.New("the VM ID claims lock was broken by another process")
+
+// IsIDClaimPool reports whether the resource pool stores a VM ID claim rather than guests.
+func IsIDClaimPool(poolID string) bool {
+	return strings.HasPrefix(poolID, idClaimPoolPrefix)
+}
+
+// IDRange is a range of VM identifiers claimed by an owner, e.g. a Terraform workspace.
+// The ID generator does not hand out the identifiers of a claimed range.
+type IDRange struct {
+	Start int
+	End   int
+	Owner string
+}
+
+// Contains reports whether the identifier is in the range.
+func (r IDRange) Contains(id int) bool {
+	return id >= r.Start && id <= r.End
+}
+
+func (r IDRange) overlaps(o IDRange) bool {
+	return r.Start <= o.End && o.Start <= r.End
+}
+
+func (r IDRange) poolID() string {
+	return fmt.Sprintf("%s%d-%d", idRangePoolPrefix, r.Start, r.End)
+}
+
+// idClaim is the owner and the expiry of a claim, encoded in the pool comment. The token
+// tells apart the acquisitions of the lock, as an owner may hold it from several processes.
+type idClaim struct {
+	owner   string
+	token   string
+	expires time.Time
+}
+
+func (c idClaim) comment() string {
+	v := url.Values{"owner": []string{c.owner}}
+
+	if c.token != "" {
+		v.Set("token", c.token)
+	}
+
+	if !c.expires.IsZero() {
+		v.Set("expires", strconv.FormatInt(c.expires.Unix(), 10))
+	}
+
+	return v.Encode()
+}
+
+func (c idClaim) expired(now time.Time) bool {
+	return !c.expires.IsZero() && now.After(c.expires)
+}
+
+func parseIDClaim(comment *string) idClaim {
+	if comment == nil {
+		return idClaim{}
+	}
+
+	v, err := url.ParseQuery(*comment)
+	if err != nil {
+		return idClaim{}
+	}
+
+	c := idClaim{owner: v.Get("owner"), token: v.Get("token")}
+
+	if sec, err := strconv.ParseInt(v.Get("expires"), 10, 64); err == nil {
+		c.expires = time.Unix(sec, 0)
+	}
+
+	return c
+}
+
+// idClaims are the claims on VM identifiers in the cluster.
+type idClaims struct {
+	leases map[int]idClaim
+	ranges []IDRange
+}
+
+// taken reports whether the identifier is leased or in a claimed range.
+func (c *idClaims) taken(id int, now time.Time) bool {
+	if l, ok := c.leases[id]; ok && !l.expired(now) {
+		return true
+	}
+
+	return c.claimed(id)
+}
+
+// claimed reports whether the identifier is in a claimed range.
+func (c *idClaims) claimed(id int) bool {
+	for _, r := range c.ranges {
+		if r.Contains(id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Client) pools() *pools.Client {
+	return &pools.Client{Client: c.Client}
+}
+
+func isAlreadyExists(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "already exists")
+}
+
+func isDoesNotExist(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "does not exist")
+}
+
+func (c *Client) createIDClaim(ctx context.Context, poolID string, claim idClaim) error {
+	comment := claim.comment()
+
+	return c.pools().CreatePool(ctx, &pools.PoolCreateRequestBody{ID: poolID, Comment: &comment})
+}
+
+func (c *Client) deleteIDClaim(ctx context.Context, poolID string) error {
+	if err := c.pools().DeletePool(ctx, poolID); err != nil && !isDoesNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) listIDClaims(ctx context.Context) (*idClaims, error) {
+	list, err := c.pools().ListPools(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing VM ID claims: %w", err)
+	}
+
+	claims := &idClaims{leases: map[int]idClaim{}}
+
+	for _, p := range list {
+		switch {
+		case strings.HasPrefix(p.ID, idLeasePoolPrefix):
+			id, err := strconv.Atoi(strings.TrimPrefix(p.ID, idLeasePoolPrefix))
+			if err == nil {
+				claims.leases[id] = parseIDClaim(p.Comment)
+			}
+		case strings.HasPrefix(p.ID, idRangePoolPrefix):
+			if r, ok := parseIDRange(p.ID, p.Comment); ok {
+				claims.ranges = append(claims.ranges, r)
+			}
+		}
+	}
+
+	return claims, nil
+}
+
+func parseIDRange(poolID string, comment *string) (IDRange, bool) {
+	start, end, ok := strings.Cut(strings.TrimPrefix(poolID, idRangePoolPrefix), "-")
+	if !ok {
+		return IDRange{}, false
+	}
+
+	r := IDRange{Owner: parseIDClaim(comment).owner}
+
+	var err error
+
+	if r.Start, err = strconv.Atoi(start); err != nil {
+		return IDRange{}, false
+	}
+
+	if r.End, err = strconv.Atoi(end); err != nil {
+		return IDRange{}, false
+	}
+
+	return r, true
+}
+
+// idLock is a held lock of the VM ID claims.
+type idLock struct {
+	client  *Client
+	claim   idClaim
+	renewed time.Time
+}
+
+// getIDLock reads the current lock of the VM ID claims, or returns nil if there is none.
+func (c *Client) getIDLock(ctx context.Context) (*idClaim, error) {
+	p, err := c.pools().GetPool(ctx, idLockPoolID)
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) || isDoesNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error reading VM ID claims lock: %w", err)
+	}
+
+	return new(parseIDClaim(p.Comment)), nil
+}
+
+// deleteIDLock deletes the lock of the VM ID claims if it is still the acquisition with the
+// token. Pools have no compare-and-delete, so the lock is re-read right before it is deleted.
+func (c *Client) deleteIDLock(ctx context.Context, token string) error {
+	current, err := c.getIDLock(ctx)
+	if err != nil {
+		return err
+	}
+
+	if current == nil || current.token != token {
+		return nil
+	}
+
+	return c.deleteIDClaim(ctx, idLockPoolID)
+}
+
+// lockIDClaims acquires the cluster-wide lock of the VM ID claims. A lock older than its TTL
+// was left behind by a process that did not finish, and is broken.
+func (c *Client) lockIDClaims(ctx context.Context, owner string) (*idLock, error) {
+	lockCtx, cancel := context.WithTimeout(ctx, idLockTimeout)
+	defer cancel()
+
+	holder := ""
+
+	for {
+		now := time.Now()
+		claim := idClaim{owner: owner, token: rand.Text(), expires: now.Add(idLockTTL)}
+
+		err := c.createIDClaim(lockCtx, idLockPoolID, claim)
+		if err == nil {
+			return &idLock{client: c, claim: claim, renewed: now}, nil
+		}
+
+		if !isAlreadyExists(err) {
+			return nil, lockError(lockCtx, holder, fmt.Errorf("error locking VM ID claims: %w", err))
+		}
+
+		current, err := c.getIDLock(lockCtx)
+		if err != nil {
+			return nil, lockError(lockCtx, holder, err)
+		}
+
+		if current != nil {
+			holder = current.owner
+
+			if current.expired(time.Now()) {
+				if err := c.deleteIDLock(lockCtx, current.token); err != nil {
+					return nil, fmt.Errorf("error breaking stale VM ID claims lock of %s: %w", holder, err)
+				}
+
+				continue
+			}
+		}
+
+		select {
+		case <-lockCtx.Done():
+			return nil, lockError(lockCtx, holder, lockCtx.Err())
+		case <-time.After(idLockRetryDelay):
+		}
+	}
+}
+
+// lockError reports a timeout waiting for the lock rather than the error of the request that
+// the timeout interrupted.
+func lockError(lockCtx context.Context, holder string, err error) error {
+	if lockCtx.Err() != nil {
+		return fmt.Errorf("timeout waiting for the VM ID claims lock held by %s: %w", holder, lockCtx.Err())
+	}
+
+	return err
+}
+
+// renew extends the TTL of the lock once a third of it has passed, so that a long search for
+// a free identifier does not let another process break the lock. It fails if the lock is no
+// longer held.
+func (l *idLock) renew(ctx context.Context) error {
+	now := time.Now()
+
+	if now.Sub(l.renewed) < idLockTTL/3 {
+		return nil
+	}
+
+	current, err := l.client.getIDLock(ctx)
+	if err != nil {
+		return err
+	}
+
+	if current == nil || current.token != l.claim.token {
+		return errIDLockLost
+	}
+
+	claim := l.claim
+	claim.expires = now.Add(idLockTTL)
+	comment := claim.comment()
+
+	err = l.client.pools().UpdatePool(ctx, idLockPoolID, &pools.PoolUpdateRequestBody{Comment: &comment})
+	if err != nil {
+		return fmt.Errorf("error renewing VM ID claims lock: %w", err)
+	}
+
+	l.claim = claim
+	l.renewed = now
+
+	return nil
+}
+
+// release deletes the lock unless another process has broken and taken it in the meantime.
+func (l *idLock) release(ctx context.Context) {
+	_ = l.client.deleteIDLock(context.WithoutCancel(ctx), l.claim.token)
+}
+
+// releaseStaleIDLeases deletes the expired leases, and the leases of identifiers that are in
+// use by a guest already.
+func (c *Client) releaseStaleIDLeases(ctx context.Context, claims *idClaims) error {
+	if len(claims.leases) == 0 {
+		return nil
+	}
+
+	guests, err := c.GetClusterResources(ctx, "vm")
+	if err != nil {
+		return err
+	}
+
+	used := map[int]bool{}
+	for _, g := range guests {
+		used[g.VMID] = true
+	}
+
+	now := time.Now()
+
+	for id, l := range claims.leases {
+		if !l.expired(now) && !used[id] {
+			continue
+		}
+
+		if err := c.deleteIDClaim(ctx, fmt.Sprintf("%s%d", idLeasePoolPrefix, id)); err != nil {
+			return fmt.Errorf("error releasing VM ID lease %d: %w", id, err)
+		}
+
+		delete(claims.leases, id)
+	}
+
+	return nil
+}
+
+// ListIDRanges lists the claimed VM ID ranges.
+func (c *Client) ListIDRanges(ctx context.Context) ([]IDRange, error) {
+	claims, err := c.listIDClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims.ranges, nil
+}
+
+// GetIDRange retrieves a claimed VM ID range.
+func (c *Client) GetIDRange(ctx context.Context, start, end int) (*IDRange, error) {
+	ranges, err := c.ListIDRanges(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range ranges {
+		if r.Start == start && r.End == end {
+			return &r, nil
+		}
+	}
+
+	return nil, api.ErrResourceDoesNotExist
+}
+
+// ClaimIDRange claims a range of VM identifiers for an owner. It fails if the range overlaps
+// a range claimed already, or an identifier leased by another owner.
+func (c *Client) ClaimIDRange(ctx context.Context, r IDRange) error {
+	if r.Start > r.End {
+		return fmt.Errorf("invalid VM ID range %d-%d: start is greater than end", r.Start, r.End)
+	}
+
+	lock, err := c.lockIDClaims(ctx, r.Owner)
+	if err != nil {
+		return err
+	}
+
+	defer lock.release(ctx)
+
+	claims, err := c.listIDClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range claims.ranges {
+		if o.overlaps(r) {
+			return fmt.Errorf("VM ID range %d-%d overlaps range %d-%d claimed by %q",
+				r.Start, r.End, o.Start, o.End, o.Owner)
+		}
+	}
+
+	now := time.Now()
+
+	for id, l := range claims.leases {
+		if r.Contains(id) && !l.expired(now) && l.owner != r.Owner {
+			return fmt.Errorf("VM ID %d in range %d-%d is leased by %q", id, r.Start, r.End, l.owner)
+		}
+	}
+
+	if err := c.createIDClaim(ctx, r.poolID(), idClaim{owner: r.Owner}); err != nil {
+		return fmt.Errorf("error claiming VM ID range %d-%d: %w", r.Start, r.End, err)
+	}
+
+	return nil
+}
+
+// UpdateIDRangeOwner changes the owner of a claimed VM ID range.
+func (c *Client) UpdateIDRangeOwner(ctx context.Context, r IDRange) error {
+	comment := idClaim{owner: r.Owner}.comment()
+
+	err := c.pools().UpdatePool(ctx, r.poolID(), &pools.PoolUpdateRequestBody{Comment: &comment})
+	if err != nil {
+		return fmt.Errorf("error updating VM ID range %d-%d: %w", r.Start, r.End, err)
+	}
+
+	return nil
+}
+
+// ReleaseIDRange releases a claimed VM ID range.
+func (c *Client) ReleaseIDRange(ctx context.Context, start, end int) error {
+	if err := c.deleteIDClaim(ctx, IDRange{Start: start, End: end}.poolID()); err != nil {
+		return fmt.Errorf("error releasing VM ID range %d-%d: %w", start, end, err)
+	}
+
+	return nil
+}
+
+// leaseID leases the first free identifier that next returns, starting with candidate. The
+// lease keeps other provider instances from taking the identifier until the guest is created.
+func (c *Client) leaseID(ctx context.Context, candidate int, owner string, next func(int) int) (int, error) {
+	lock, err := c.lockIDClaims(ctx, owner)
+	if err != nil {
+		return -1, err
+	}
+
+	defer lock.release(ctx)
+
+	claims, err := c.listIDClaims(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	if err = c.releaseStaleIDLeases(ctx, claims); err != nil {
+		return -1, err
+	}
+
+	now := time.Now()
+	id := candidate
+
+	var lastErr error
+
+	for range idLeaseMaxAttempts {
+		if err = lock.renew(ctx); err != nil {
+			return -1, err
+		}
+
+		if !claims.taken(id, now) {
+			_, err = c.GetNextID(ctx, &id)
+			if err == nil {
+				lease := idClaim{owner: owner, expires: now.Add(idLeaseTTL)}
+
+				err = c.createIDClaim(ctx, fmt.Sprintf("%s%d", idLeasePoolPrefix, id), lease)
+				if err == nil {
+					return id, nil
+				}
+			}
+
+			if !isAlreadyExists(err) {
+				return -1, fmt.Errorf("error leasing VM ID %d: %w", id, err)
+			}
+
+			lastErr = err
+		}
+
+		id = next(id)
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("all candidates are claimed")
+	}
+
+	return -1, fmt.Errorf("no free VM ID found after %d attempts: %w", idLeaseMaxAttempts, lastErr)
+}
+
+// skipClaimedIDRanges returns the first free identifier that next returns, starting with
+// candidate, that is not in a claimed range. Unlike leaseID, it neither locks nor leases, so it
+// keeps the generated identifiers out of the claimed ranges when cluster leases are disabled.
+func (c *Client) skipClaimedIDRanges(ctx context.Context, candidate int, next func(int) int) (int, error) {
+	claims, err := c.listIDClaims(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	id := candidate
+
+	for range idLeaseMaxAttempts {
+		if !claims.claimed(id) {
+			if id == candidate {
+				return id, nil
+			}
+
+			_, err = c.GetNextID(ctx, &id)
+			if err == nil {
+				return id, nil
+			}
+
+			if !isAlreadyExists(err) {
+				return -1, fmt.Errorf("error checking VM ID %d: %w", id, err)
+			}
+		}
+
+		id = next(id)
+	}
+
+	return -1, fmt.Errorf("no free VM ID outside the claimed ranges found after %d attempts", idLeaseMaxAttempts)
+}
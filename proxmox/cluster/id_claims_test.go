@@ -0,0 +1,254 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/pools"
+)
+
+// newGenerator returns an ID generator with its own sequence file, like a provider instance
+// on another machine.
+func newGenerator(t *testing.T, client *cluster.Client) cluster.IDGenerator {
+	t.Helper()
+
+	t.Setenv("TMPDIR", t.TempDir())
+
+	return cluster.NewIDGenerator(client, cluster.IDGeneratorConfig{ClusterLeases: true})
+}
+
+func claimPools(t *testing.T, client proxmox.Client) []string {
+	t.Helper()
+
+	list, err := client.Pool().ListPools(t.Context())
+	require.NoError(t, err)
+
+	var ids []string
+
+	for _, p := range list {
+		if cluster.IsIDClaimPool(p.ID) {
+			ids = append(ids, p.ID)
+		}
+	}
+
+	return ids
+}
+
+func newClient(t *testing.T) proxmox.Client {
+	t.Helper()
+
+	s := fake.NewServer()
+	t.Cleanup(s.Close)
+
+	apiClient, err := s.Client()
+	require.NoError(t, err)
+
+	return proxmox.NewClient(apiClient, nil, "")
+}
+
+// setIDLock creates or replaces the lock of the VM ID claims, as another process would.
+func setIDLock(t *testing.T, client proxmox.Client, owner, token string, expires time.Time) {
+	t.Helper()
+
+	comment := url.Values{
+		"owner":   []string{owner},
+		"token":   []string{token},
+		"expires": []string{strconv.FormatInt(expires.Unix(), 10)},
+	}.Encode()
+
+	err := client.Pool().CreatePool(t.Context(), &pools.PoolCreateRequestBody{ID: "terraform-vmid-lock", Comment: &comment})
+	if err != nil {
+		require.NoError(t, client.Pool().UpdatePool(t.Context(), "terraform-vmid-lock",
+			&pools.PoolUpdateRequestBody{Comment: &comment}))
+	}
+}
+
+func idLock(t *testing.T, client proxmox.Client) url.Values {
+	t.Helper()
+
+	p, err := client.Pool().GetPool(t.Context(), "terraform-vmid-lock")
+	require.NoError(t, err)
+	require.NotNil(t, p.Comment)
+
+	v, err := url.ParseQuery(*p.Comment)
+	require.NoError(t, err)
+
+	return v
+}
+
+func TestIDGeneratorClusterLeases(t *testing.T) {
+	ctx := t.Context()
+
+	client := newClient(t)
+	c := client.Cluster()
+
+	a := newGenerator(t, c)
+	b := newGenerator(t, c)
+
+	id, err := a.NextID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 100, id)
+
+	id, err = b.NextID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 101, id, "100 is leased by the other generator")
+
+	assert.ElementsMatch(t, []string{"terraform-vmid-lease-100", "terraform-vmid-lease-101"}, claimPools(t, client))
+
+	require.NoError(t, c.ClaimIDRange(ctx, cluster.IDRange{Start: 102, End: 110, Owner: "staging"}))
+
+	err = c.ClaimIDRange(ctx, cluster.IDRange{Start: 105, End: 120, Owner: "production"})
+	require.ErrorContains(t, err, `overlaps range 102-110 claimed by "staging"`)
+
+	err = c.ClaimIDRange(ctx, cluster.IDRange{Start: 100, End: 101, Owner: "production"})
+	require.ErrorContains(t, err, "is leased by")
+
+	r, err := c.GetIDRange(ctx, 102, 110)
+	require.NoError(t, err)
+	assert.Equal(t, "staging", r.Owner)
+
+	require.NoError(t, client.Node(fake.DefaultNode).VM(100).CreateVM(ctx, &vms.CreateRequestBody{VMID: 100}).Err())
+
+	id, err = a.NextID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 111, id, "the claimed range is skipped")
+
+	assert.ElementsMatch(t,
+		[]string{"terraform-vmid-lease-101", "terraform-vmid-lease-111", "terraform-vmid-range-102-110"},
+		claimPools(t, client),
+		"the lease of the created VM is released, and the lock is gone",
+	)
+
+	require.NoError(t, c.ReleaseIDRange(ctx, 102, 110))
+
+	ranges, err := c.ListIDRanges(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, ranges)
+}
+
+func TestIDGeneratorBreaksStaleLock(t *testing.T) {
+	client := newClient(t)
+
+	setIDLock(t, client, "crashed", "stale", time.Now().Add(-time.Minute))
+
+	id, err := newGenerator(t, client.Cluster()).NextID(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 100, id)
+
+	assert.Equal(t, []string{"terraform-vmid-lease-100"}, claimPools(t, client), "the stale lock is gone")
+}
+
+func TestIDGeneratorLockContention(t *testing.T) {
+	ctx := t.Context()
+	client := newClient(t)
+	c := client.Cluster()
+
+	setIDLock(t, client, "other", "held", time.Now().Add(time.Minute))
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	err := c.ClaimIDRange(timeoutCtx, cluster.IDRange{Start: 200, End: 210, Owner: "staging"})
+	require.ErrorContains(t, err, "held by other")
+
+	assert.Equal(t, "held", idLock(t, client).Get("token"), "the lock of the other process is kept")
+
+	require.NoError(t, client.Pool().DeletePool(ctx, "terraform-vmid-lock"))
+
+	generators := make([]cluster.IDGenerator, 5)
+	for i := range generators {
+		generators[i] = newGenerator(t, c)
+	}
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		ids []int
+	)
+
+	for _, g := range generators {
+		wg.Go(func() {
+			id, err := g.NextID(ctx)
+			assert.NoError(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			ids = append(ids, id)
+		})
+	}
+
+	wg.Wait()
+
+	assert.ElementsMatch(t, []int{100, 101, 102, 103, 104}, ids, "each generator leases another ID")
+
+	var leases []string
+	for _, id := range ids {
+		leases = append(leases, fmt.Sprintf("terraform-vmid-lease-%d", id))
+	}
+
+	assert.ElementsMatch(t, leases, claimPools(t, client), "all locks are released")
+}
+
+func TestIDLockRenewal(t *testing.T) {
+	ctx := t.Context()
+	client := newClient(t)
+
+	lock, err := client.Cluster().LockIDClaims(ctx, "staging")
+	require.NoError(t, err)
+
+	expires := idLock(t, client).Get("expires")
+
+	time.Sleep(time.Second)
+
+	require.NoError(t, lock.Renew(ctx))
+
+	renewed := idLock(t, client)
+	assert.Greater(t, renewed.Get("expires"), expires)
+	assert.Equal(t, "staging", renewed.Get("owner"))
+
+	// the lock expired, and another process broke it and locked the claims
+	setIDLock(t, client, "other", "taken", time.Now().Add(time.Minute))
+
+	require.ErrorIs(t, lock.Renew(ctx), cluster.ErrIDLockLost)
+
+	lock.Release(ctx)
+
+	assert.Equal(t, "taken", idLock(t, client).Get("token"), "the lock of the other process is not released")
+}
+
+func TestIDGeneratorSkipsClaimedRangesWithoutLeases(t *testing.T) {
+	ctx := t.Context()
+	client := newClient(t)
+	c := client.Cluster()
+
+	require.NoError(t, c.ClaimIDRange(ctx, cluster.IDRange{Start: 100, End: 104, Owner: "staging"}))
+
+	t.Setenv("TMPDIR", t.TempDir())
+
+	g := cluster.NewIDGenerator(c, cluster.IDGeneratorConfig{})
+
+	id, err := g.NextID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 105, id, "the claimed range is skipped")
+
+	assert.Equal(t, []string{"terraform-vmid-range-100-104"}, claimPools(t, client), "no lease is taken")
+}
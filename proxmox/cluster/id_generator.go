@@ -43,6 +43,10 @@ type IDGeneratorConfig struct {
 	RandomIDStat int
 	RandomIDEnd  int
 
+	// ClusterLeases makes the generator lease each identifier cluster-wide before it is used,
+	// so that provider instances on different machines do not hand out the same identifier.
+	ClusterLeases bool
+
 	lockFName string
 	seqFName  string
 }
@@ -89,6 +93,8 @@ func (g IDGenerator) NextID(ctx context.Context) (int, error) {
 
 	defer unlock()
 
+	leaseCtx := ctx
+
 	ctx, cancel := context.WithTimeout(ctx, idGeneratorContentionWindow+time.Second)
 	defer cancel()
 
@@ -133,6 +139,22 @@ func (g IDGenerator) NextID(ctx context.Context) (int, error) {
 		return -1, fmt.Errorf("unable to retrieve the next available VM identifier: %w", errors.Join(errs...))
 	}
 
+	if g.config.ClusterLeases {
+		leased, err := g.client.leaseID(leaseCtx, *id, idLeaseOwner(), g.nextCandidate)
+		if err != nil {
+			return -1, fmt.Errorf("unable to lease the next available VM identifier: %w", err)
+		}
+
+		id = &leased
+	} else {
+		free, err := g.client.skipClaimedIDRanges(leaseCtx, *id, g.nextCandidate)
+		if err != nil {
+			return -1, fmt.Errorf("unable to skip the claimed VM ID ranges: %w", err)
+		}
+
+		id = &free
+	}
+
 	if !g.config.RandomIDs {
 		var b bytes.Buffer
 
@@ -146,6 +168,26 @@ func (g IDGenerator) NextID(ctx context.Context) (int, error) {
 	return *id, nil
 }
 
+// nextCandidate returns the identifier to try after the given one was taken.
+func (g IDGenerator) nextCandidate(id int) int {
+	if g.config.RandomIDs {
+		//nolint:gosec
+		return rand.Intn(g.config.RandomIDEnd-g.config.RandomIDStat) + g.config.RandomIDStat
+	}
+
+	return id + 1
+}
+
+// idLeaseOwner identifies the provider instance that holds a lease.
+func idLeaseOwner() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
+
 func nextSequentialID(seqFName string) (*int, error) {
 	buf, err := lockedfile.Read(seqFName)
 	if err != nil {
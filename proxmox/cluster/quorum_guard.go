@@ -0,0 +1,190 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+)
+
+// quorumStatusTTL is how long the cluster status is reused for the checks of subsequent requests.
+const quorumStatusTTL = 5 * time.Second
+
+var (
+	// ErrClusterNotQuorate is returned by the quorum guard when the cluster is not quorate.
+	ErrClusterNotQuorate = errors.New("cluster is not quorate")
+
+	// ErrNodeOffline is returned by the quorum guard when the request targets an offline node.
+	ErrNodeOffline = errors.New("node is offline")
+)
+
+// QuorumGuard refuses the mutating requests to the API and the SSH operations on the nodes when
+// the cluster is not quorate, or when they target a node that is offline. Without quorum the
+// cluster file system is read-only, so the operations would otherwise fail halfway through,
+// e.g. with configuration lock errors. The clients returned by API and SSH share the cluster
+// status, which is cached for quorumStatusTTL.
+type QuorumGuard struct {
+	client api.Client
+
+	mu        sync.Mutex
+	status    []*StatusResponseData
+	checkedAt time.Time
+}
+
+// NewQuorumGuard creates a quorum guard that reads the cluster status with the API client.
+func NewQuorumGuard(c api.Client) *QuorumGuard {
+	return &QuorumGuard{client: c}
+}
+
+// API returns the API client of the guard, which checks the cluster status before the mutating
+// requests (anything but GET), including uploads. Read requests are passed through unchanged.
+// A request whose context is marked with api.WithOfflineNode may target an offline node.
+func (g *QuorumGuard) API() api.Client {
+	return &guardedAPIClient{Client: g.client, guard: g}
+}
+
+// SSH returns an SSH client that checks the cluster status before the commands and uploads on
+// a node. The commands cannot be told apart from reads, so all of them are checked.
+func (g *QuorumGuard) SSH(c ssh.Client) ssh.Client {
+	return &guardedSSHClient{Client: c, guard: g}
+}
+
+// guardedAPIClient is an API client that checks the cluster status before mutating requests.
+type guardedAPIClient struct {
+	api.Client
+
+	guard *QuorumGuard
+}
+
+// DoRequest checks the cluster status before a mutating request and then performs it.
+func (c *guardedAPIClient) DoRequest(
+	ctx context.Context,
+	method, path string,
+	requestBody, responseBody any,
+) error {
+	if method != http.MethodGet && method != http.MethodHead {
+		nodeName := pathNodeName(path)
+		if api.OfflineNodeAllowed(ctx) {
+			nodeName = ""
+		}
+
+		if err := c.guard.check(ctx, nodeName); err != nil {
+			return fmt.Errorf("refusing to %s %s: %w", method, strings.SplitN(path, "?", 2)[0], err)
+		}
+	}
+
+	return c.Client.DoRequest(ctx, method, path, requestBody, responseBody)
+}
+
+// guardedSSHClient is an SSH client that checks the cluster status before every operation.
+type guardedSSHClient struct {
+	ssh.Client
+
+	guard *QuorumGuard
+}
+
+// ExecuteNodeCommands checks the cluster status and then executes the commands on the node.
+func (c *guardedSSHClient) ExecuteNodeCommands(ctx context.Context, nodeName string, commands []string) ([]byte, error) {
+	if err := c.guard.check(ctx, nodeName); err != nil {
+		return nil, fmt.Errorf("refusing to run commands on node %s: %w", nodeName, err)
+	}
+
+	return c.Client.ExecuteNodeCommands(ctx, nodeName, commands)
+}
+
+// NodeUpload checks the cluster status and then uploads the file to the node by SFTP.
+func (c *guardedSSHClient) NodeUpload(
+	ctx context.Context,
+	nodeName, remoteFileDir string,
+	fileUploadRequest *api.FileUploadRequest,
+) error {
+	if err := c.guard.check(ctx, nodeName); err != nil {
+		return fmt.Errorf("refusing to upload %s to node %s: %w", fileUploadRequest.FileName, nodeName, err)
+	}
+
+	return c.Client.NodeUpload(ctx, nodeName, remoteFileDir, fileUploadRequest)
+}
+
+// NodeStreamUpload checks the cluster status and then streams the file to the node over SSH.
+func (c *guardedSSHClient) NodeStreamUpload(
+	ctx context.Context,
+	nodeName, remoteFileDir string,
+	fileUploadRequest *api.FileUploadRequest,
+) error {
+	if err := c.guard.check(ctx, nodeName); err != nil {
+		return fmt.Errorf("refusing to upload %s to node %s: %w", fileUploadRequest.FileName, nodeName, err)
+	}
+
+	return c.Client.NodeStreamUpload(ctx, nodeName, remoteFileDir, fileUploadRequest)
+}
+
+// check fails if the cluster is not quorate or if the node, if any, is offline.
+func (g *QuorumGuard) check(ctx context.Context, nodeName string) error {
+	status, err := g.clusterStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to check the cluster quorum: %w", err)
+	}
+
+	for _, s := range status {
+		switch s.Type {
+		case "cluster":
+			if s.Quorate == nil || !bool(*s.Quorate) {
+				return ErrClusterNotQuorate
+			}
+		case "node":
+			if nodeName != "" && s.Name == nodeName && (s.Online == nil || !bool(*s.Online)) {
+				return fmt.Errorf("%w: %s", ErrNodeOffline, nodeName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// clusterStatus returns the cluster status, which is cached for quorumStatusTTL.
+func (g *QuorumGuard) clusterStatus(ctx context.Context) ([]*StatusResponseData, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.status != nil && time.Since(g.checkedAt) < quorumStatusTTL {
+		return g.status, nil
+	}
+
+	status, err := (&Client{Client: g.client}).GetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	g.status = status
+	g.checkedAt = time.Now()
+
+	return status, nil
+}
+
+// pathNodeName returns the node name of a node API path such as `nodes/pve/qemu/100/config`.
+func pathNodeName(path string) string {
+	segments := strings.Split(strings.Trim(strings.SplitN(path, "?", 2)[0], "/"), "/")
+	if len(segments) < 2 || segments[0] != "nodes" {
+		return ""
+	}
+
+	name, err := url.PathUnescape(segments[1])
+	if err != nil {
+		return segments[1]
+	}
+
+	return name
+}
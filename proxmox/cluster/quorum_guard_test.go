@@ -0,0 +1,136 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cluster_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+)
+
+// recordingSSH records the nodes of the SSH operations.
+type recordingSSH struct {
+	nodes []string
+}
+
+func (s *recordingSSH) Username() string {
+	return "root"
+}
+
+func (s *recordingSSH) ExecuteNodeCommands(_ context.Context, nodeName string, _ []string) ([]byte, error) {
+	s.nodes = append(s.nodes, nodeName)
+
+	return nil, nil
+}
+
+func (s *recordingSSH) NodeUpload(_ context.Context, nodeName, _ string, _ *api.FileUploadRequest) error {
+	s.nodes = append(s.nodes, nodeName)
+
+	return nil
+}
+
+func (s *recordingSSH) NodeStreamUpload(_ context.Context, nodeName, _ string, _ *api.FileUploadRequest) error {
+	s.nodes = append(s.nodes, nodeName)
+
+	return nil
+}
+
+func TestQuorumGuard(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	s := fake.NewServer(fake.WithNodes("pve", "pve2", "pve3"))
+	t.Cleanup(s.Close)
+
+	apiClient, err := s.Client()
+	require.NoError(t, err)
+
+	sshClient := &recordingSSH{}
+
+	// every guard caches the cluster status, so each step uses a new one
+	guarded := func() proxmox.Client {
+		g := cluster.NewQuorumGuard(apiClient)
+
+		return proxmox.NewClient(g.API(), g.SSH(sshClient), "")
+	}
+
+	createVM := func(client proxmox.Client, node string, vmid int) error {
+		return client.Node(node).VM(0).CreateVM(ctx, &vms.CreateRequestBody{VMID: vmid}).Err()
+	}
+
+	require.NoError(t, createVM(guarded(), "pve2", 100))
+
+	s.SetNodeOnline("pve2", false)
+
+	client := guarded()
+
+	err = createVM(client, "pve2", 101)
+	require.ErrorIs(t, err, cluster.ErrNodeOffline)
+	assert.Contains(t, err.Error(), "refusing to POST nodes/pve2/qemu")
+
+	require.NoError(t, createVM(client, "pve", 101), "the other nodes are still writable")
+
+	_, err = client.Node("pve2").VM(100).GetVM(ctx)
+	require.NoError(t, err, "read requests are not checked")
+
+	_, err = client.SSH().ExecuteNodeCommands(ctx, "pve2", []string{"true"})
+	require.ErrorIs(t, err, cluster.ErrNodeOffline)
+
+	err = client.SSH().NodeUpload(ctx, "pve2", "/tmp", &api.FileUploadRequest{FileName: "a.txt"})
+	require.ErrorIs(t, err, cluster.ErrNodeOffline)
+	assert.Empty(t, sshClient.nodes, "the SSH operations on the offline node are not run")
+
+	_, err = client.SSH().ExecuteNodeCommands(ctx, "pve", []string{"true"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pve"}, sshClient.nodes)
+
+	err = client.API().DoRequest(ctx, http.MethodPost, "nodes/pve2/wakeonlan", nil, nil)
+	require.ErrorIs(t, err, cluster.ErrNodeOffline, "only the node client marks the request as waking a node")
+
+	_, err = client.Node("pve2").WakeOnLAN(ctx)
+	require.NoError(t, err, "an offline node can be woken")
+	s.SetNodeOnline("pve2", false)
+
+	s.SetNodeOnline("pve3", false)
+
+	err = createVM(guarded(), "pve", 102)
+	require.ErrorIs(t, err, cluster.ErrClusterNotQuorate)
+
+	err = guarded().SSH().NodeStreamUpload(ctx, "pve", "/tmp", &api.FileUploadRequest{FileName: "a.txt"})
+	require.ErrorIs(t, err, cluster.ErrClusterNotQuorate)
+	assert.Equal(t, []string{"pve"}, sshClient.nodes)
+
+	status, err := guarded().Cluster().GetStatus(ctx)
+	require.NoError(t, err)
+	require.Len(t, status, 4)
+	assert.Equal(t, "cluster", status[0].Type)
+	assert.False(t, bool(*status[0].Quorate))
+}
+
+func TestQuorumGuardStandaloneNode(t *testing.T) {
+	t.Parallel()
+
+	s := fake.NewServer()
+	t.Cleanup(s.Close)
+
+	apiClient, err := s.Client()
+	require.NoError(t, err)
+
+	client := proxmox.NewClient(cluster.NewQuorumGuard(apiClient).API(), nil, "")
+
+	require.NoError(t, client.Node(fake.DefaultNode).VM(0).CreateVM(t.Context(), &vms.CreateRequestBody{VMID: 100}).Err())
+}
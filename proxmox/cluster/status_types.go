@@ -0,0 +1,95 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// StatusResponseBody contains the body from a cluster status response.
+type StatusResponseBody struct {
+	Data []*StatusResponseData `json:"data,omitempty"`
+}
+
+// StatusResponseData contains an entry of a cluster status response, which describes either
+// the cluster (type `cluster`) or one of its nodes (type `node`).
+type StatusResponseData struct {
+	Type    string            `json:"type"`
+	ID      string            `json:"id"`
+	Name    string            `json:"name"`
+	IP      *string           `json:"ip,omitempty"`
+	Level   *string           `json:"level,omitempty"`
+	Local   *types.CustomBool `json:"local,omitempty"`
+	NodeID  *int64            `json:"nodeid,omitempty"`
+	Nodes   *int64            `json:"nodes,omitempty"`
+	Online  *types.CustomBool `json:"online,omitempty"`
+	Quorate *types.CustomBool `json:"quorate,omitempty"`
+	Version *int64            `json:"version,omitempty"`
+}
+
+// ConfigNodesResponseBody contains the body from a cluster config nodes response.
+type ConfigNodesResponseBody struct {
+	Data []*ConfigNodeResponseData `json:"data,omitempty"`
+}
+
+// ConfigNodeResponseData contains the corosync configuration of a cluster node.
+type ConfigNodeResponseData struct {
+	Name        string
+	NodeID      *int
+	QuorumVotes *int
+	// Links maps the corosync link numbers to the addresses of the node (`ringX_addr`).
+	Links map[int]string
+}
+
+// UnmarshalJSON unmarshals the corosync configuration of a node, whose link addresses are
+// returned as separate `ring0_addr` to `ring7_addr` properties.
+func (d *ConfigNodeResponseData) UnmarshalJSON(b []byte) error {
+	var raw map[string]any
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return fmt.Errorf("error unmarshalling cluster node configuration: %w", err)
+	}
+
+	d.Links = map[int]string{}
+
+	for k, v := range raw {
+		value := fmt.Sprint(v)
+
+		switch {
+		case k == "name" || (k == "node" && d.Name == ""):
+			d.Name = value
+		case k == "nodeid":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("error parsing node ID %q: %w", value, err)
+			}
+
+			d.NodeID = &n
+		case k == "quorum_votes":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("error parsing quorum votes %q: %w", value, err)
+			}
+
+			d.QuorumVotes = &n
+		case strings.HasPrefix(k, "ring") && strings.HasSuffix(k, "_addr"):
+			link, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(k, "ring"), "_addr"))
+			if err != nil {
+				continue
+			}
+
+			d.Links[link] = value
+		}
+	}
+
+	return nil
+}
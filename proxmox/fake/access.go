@@ -0,0 +1,299 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// userFields are the user properties stored by the server. Passwords are accepted but not stored.
+var userFields = []string{"comment", "email", "enable", "expire", "firstname", "groups", "keys", "lastname"}
+
+// builtinRoles are the roles accepted in ACL entries.
+var builtinRoles = []string{
+	"Administrator", "NoAccess", "PVEAdmin", "PVEAuditor", "PVEDatastoreAdmin", "PVEDatastoreUser",
+	"PVEMappingAdmin", "PVEMappingUser", "PVEPoolAdmin", "PVEPoolUser", "PVESDNAdmin", "PVESDNUser",
+	"PVESysAdmin", "PVETemplateUser", "PVEUserAdmin", "PVEVMAdmin", "PVEVMUser",
+}
+
+func (s *Server) registerAccess(mux *http.ServeMux) {
+	mux.HandleFunc("POST "+basePath+"/access/ticket", s.guard(s.createTicket))
+
+	mux.HandleFunc("GET "+basePath+"/access/users", s.guard(s.listUsers))
+	mux.HandleFunc("POST "+basePath+"/access/users", s.guard(s.createUser))
+	mux.HandleFunc("GET "+basePath+"/access/users/{userid}", s.guard(s.getUser))
+	mux.HandleFunc("PUT "+basePath+"/access/users/{userid}", s.guard(s.updateUser))
+	mux.HandleFunc("DELETE "+basePath+"/access/users/{userid}", s.guard(s.deleteUser))
+	mux.HandleFunc("PUT "+basePath+"/access/password", s.guard(s.changePassword))
+
+	mux.HandleFunc("GET "+basePath+"/access/acl", s.guard(s.getACL))
+	mux.HandleFunc("PUT "+basePath+"/access/acl", s.guard(s.updateACL))
+}
+
+func (s *Server) createTicket(w http.ResponseWriter, r *http.Request) {
+	p := params(r)
+
+	if _, ok := s.state.users[p["username"]]; !ok || p["password"] == "" {
+		writeError(w, http.StatusUnauthorized, "authentication failure")
+
+		return
+	}
+
+	ticket := "PVE:" + p["username"] + ":" + strings.ToUpper(randomHex(16))
+	s.state.tickets[ticket] = true
+
+	writeData(w, map[string]any{
+		"username":            p["username"],
+		"ticket":              ticket,
+		"CSRFPreventionToken": strings.ToUpper(randomHex(8)) + ":" + randomHex(16),
+		"cap":                 map[string]any{},
+	})
+}
+
+func (s *state) user(id string) (*user, *apiError) {
+	u, ok := s.users[id]
+	if !ok {
+		return nil, errorf(http.StatusInternalServerError, "no such user ('%s')", id)
+	}
+
+	return u, nil
+}
+
+func (u *user) data() map[string]any {
+	res := map[string]any{}
+
+	for k, v := range u.fields {
+		switch k {
+		case "groups":
+			if v != "" {
+				res[k] = splitList(v)
+			}
+		case "enable", "expire":
+			res[k] = typedValue(k, v)
+		default:
+			res[k] = v
+		}
+	}
+
+	return res
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, _ *http.Request) {
+	res := []map[string]any{}
+
+	for _, id := range slices.Sorted(maps.Keys(s.state.users)) {
+		data := s.state.users[id].data()
+		data["userid"] = id
+
+		if g, ok := data["groups"].([]string); ok {
+			data["groups"] = strings.Join(g, ",")
+		}
+
+		res = append(res, data)
+	}
+
+	writeData(w, res)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	u, err := s.state.user(r.PathValue("userid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	writeData(w, u.data())
+}
+
+func (u *user) apply(p map[string]string) {
+	for _, k := range userFields {
+		v, ok := p[k]
+		if !ok {
+			continue
+		}
+
+		if k == "groups" && p["append"] == "1" && u.fields[k] != "" {
+			v = u.fields[k] + "," + v
+		}
+
+		u.fields[k] = v
+	}
+
+	for _, k := range splitList(p["delete"]) {
+		delete(u.fields, k)
+	}
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	p := params(r)
+
+	id := p["userid"]
+	if !strings.Contains(id, "@") {
+		writeAPIError(w, paramError("userid", "value '%s' does not look like a valid user name", id))
+
+		return
+	}
+
+	if _, ok := s.state.users[id]; ok {
+		writeAPIError(w, errorf(http.StatusInternalServerError, "create user failed: user '%s' already exists", id))
+
+		return
+	}
+
+	u := &user{id: id, fields: map[string]string{"enable": "1", "expire": "0"}}
+	u.apply(p)
+
+	s.state.users[id] = u
+
+	writeData(w, nil)
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	u, err := s.state.user(r.PathValue("userid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	u.apply(params(r))
+
+	writeData(w, nil)
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("userid")
+	if _, err := s.state.user(id); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	delete(s.state.users, id)
+
+	// ACL entries of the user are removed along with it
+	s.state.acls = slices.DeleteFunc(s.state.acls, func(a *acl) bool {
+		return a.kind == "user" && a.ugid == id
+	})
+
+	writeData(w, nil)
+}
+
+func (s *Server) changePassword(w http.ResponseWriter, r *http.Request) {
+	p := params(r)
+
+	if _, err := s.state.user(p["userid"]); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if len(p["password"]) < 5 {
+		writeAPIError(w, paramError("password", "value may only be 5 characters or longer"))
+
+		return
+	}
+
+	writeData(w, nil)
+}
+
+func (s *Server) getACL(w http.ResponseWriter, _ *http.Request) {
+	res := []map[string]any{}
+
+	for _, a := range s.state.acls {
+		res = append(res, map[string]any{
+			"path":      a.path,
+			"ugid":      a.ugid,
+			"type":      a.kind,
+			"roleid":    a.role,
+			"propagate": boolInt(a.propagate),
+		})
+	}
+
+	writeData(w, res)
+}
+
+func (s *Server) updateACL(w http.ResponseWriter, r *http.Request) {
+	st := s.state
+	p := params(r)
+
+	aclPath := p["path"]
+	if !strings.HasPrefix(aclPath, "/") {
+		writeAPIError(w, paramError("path", "invalid ACL path '%s'", aclPath))
+
+		return
+	}
+
+	roles := splitList(p["roles"])
+	if len(roles) == 0 {
+		writeAPIError(w, paramError("roles", "property is missing and it is not optional"))
+
+		return
+	}
+
+	for _, role := range roles {
+		if !slices.Contains(builtinRoles, role) {
+			writeAPIError(w, errorf(http.StatusInternalServerError, "role '%s' does not exist", role))
+
+			return
+		}
+	}
+
+	type subject struct{ kind, id string }
+
+	var subjects []subject
+
+	for _, u := range splitList(p["users"]) {
+		if _, ok := st.users[u]; !ok {
+			writeAPIError(w, errorf(http.StatusInternalServerError, "user '%s' does not exist", u))
+
+			return
+		}
+
+		subjects = append(subjects, subject{"user", u})
+	}
+
+	for _, g := range splitList(p["groups"]) {
+		subjects = append(subjects, subject{"group", g})
+	}
+
+	for _, t := range splitList(p["tokens"]) {
+		subjects = append(subjects, subject{"token", t})
+	}
+
+	propagate := p["propagate"] != "0"
+
+	for _, sub := range subjects {
+		for _, role := range roles {
+			idx := slices.IndexFunc(st.acls, func(a *acl) bool {
+				return a.path == aclPath && a.kind == sub.kind && a.ugid == sub.id && a.role == role
+			})
+
+			if p["delete"] == "1" {
+				if idx >= 0 {
+					st.acls = slices.Delete(st.acls, idx, idx+1)
+				}
+
+				continue
+			}
+
+			if idx >= 0 {
+				st.acls[idx].propagate = propagate
+
+				continue
+			}
+
+			st.acls = append(st.acls, &acl{path: aclPath, ugid: sub.id, kind: sub.kind, role: role, propagate: propagate})
+		}
+	}
+
+	writeData(w, nil)
+}
@@ -0,0 +1,211 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// SetAgentResult sets the result the QEMU guest agent of the VM returns for a read-only command,
+// e.g. `get-osinfo`, replacing the default result derived from the VM configuration.
+func (s *Server) SetAgentResult(vmid int, command string, result any) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	if s.state.agentResults[vmid] == nil {
+		s.state.agentResults[vmid] = map[string]any{}
+	}
+
+	s.state.agentResults[vmid][command] = result
+}
+
+// GuestPassword returns the password last set with the QEMU guest agent for a user of the VM.
+func (s *Server) GuestPassword(vmid int, username string) (string, bool) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	g, ok := s.state.guests[vmid]
+	if !ok {
+		return "", false
+	}
+
+	password, ok := g.passwords[username]
+
+	return password, ok
+}
+
+func (s *Server) registerAgent(mux *http.ServeMux) {
+	base := basePath + "/nodes/{node}/qemu/{vmid}/agent"
+
+	mux.HandleFunc("GET "+base+"/{command}", s.guard(s.getAgentResult))
+	mux.HandleFunc("POST "+base+"/ping", s.guard(s.pingAgent))
+	mux.HandleFunc("POST "+base+"/{command}", s.guard(s.runAgentCommand))
+}
+
+// frozenAgentCommands are the commands the guest agent accepts while the filesystems are frozen.
+var frozenAgentCommands = []string{"fsfreeze-status", "fsfreeze-thaw", "ping"}
+
+// agentEnabled reports whether the QEMU guest agent is enabled in the VM configuration.
+func (g *guest) agentEnabled() bool {
+	enabled, _, _ := strings.Cut(g.config["agent"], ",")
+
+	return enabled == "1" || enabled == "enabled=1"
+}
+
+// agentGuest returns the VM of the request if its guest agent is running and accepts the command.
+func (s *Server) agentGuest(r *http.Request) (*guest, *apiError) {
+	g, err := s.state.guest(r.PathValue("node"), guestQEMU, r.PathValue("vmid"))
+	if err != nil {
+		return nil, err
+	}
+
+	if !g.agentEnabled() {
+		return nil, errorf(http.StatusInternalServerError, "No QEMU guest agent configured")
+	}
+
+	if g.status != "running" {
+		return nil, errorf(http.StatusInternalServerError, "VM %d is not running", g.vmid)
+	}
+
+	command := r.PathValue("command")
+	if command == "" {
+		command = "ping"
+	}
+
+	if g.frozen && !slices.Contains(frozenAgentCommands, command) {
+		return nil, errorf(http.StatusInternalServerError,
+			"Agent error: Command guest-%s has been disabled: the agent is in frozen state", command)
+	}
+
+	return g, nil
+}
+
+func (s *Server) pingAgent(w http.ResponseWriter, r *http.Request) {
+	_, err := s.agentGuest(r)
+
+	respond(w, map[string]any{"result": map[string]any{}}, err)
+}
+
+// getAgentResult serves the read-only agent commands. Results set with SetAgentResult take
+// precedence over the defaults derived from the VM configuration.
+func (s *Server) getAgentResult(w http.ResponseWriter, r *http.Request) {
+	g, err := s.agentGuest(r)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	command := r.PathValue("command")
+
+	if result, ok := s.state.agentResults[g.vmid][command]; ok {
+		writeData(w, map[string]any{"result": result})
+
+		return
+	}
+
+	var result any
+
+	switch command {
+	case "get-host-name":
+		result = map[string]any{"host-name": g.name()}
+	case "get-osinfo":
+		result = map[string]any{
+			"id":             "debian",
+			"name":           "Debian GNU/Linux",
+			"pretty-name":    "Debian GNU/Linux 12 (bookworm)",
+			"version":        "12 (bookworm)",
+			"version-id":     "12",
+			"kernel-release": "6.1.0-28-amd64",
+			"kernel-version": "#1 SMP PREEMPT_DYNAMIC Debian 6.1.119-1 (2024-11-22)",
+			"machine":        "x86_64",
+		}
+	case "get-timezone":
+		result = map[string]any{"zone": "UTC", "offset": 0}
+	case "get-vcpus":
+		vcpus := []map[string]any{}
+		for i := range g.cpus() {
+			vcpus = append(vcpus, map[string]any{"logical-id": i, "online": true, "can-offline": i > 0})
+		}
+
+		result = vcpus
+	case "get-users", "get-fsinfo":
+		result = []any{}
+	default:
+		writeAPIError(w, paramError("command", "value '%s' does not have a value in the enumeration", command))
+
+		return
+	}
+
+	writeData(w, map[string]any{"result": result})
+}
+
+// runAgentCommand serves the agent commands that change the guest, i.e. filesystem freeze and trim,
+// and user passwords.
+func (s *Server) runAgentCommand(w http.ResponseWriter, r *http.Request) {
+	g, err := s.agentGuest(r)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	var result any
+
+	switch command := r.PathValue("command"); command {
+	case "fsfreeze-freeze":
+		g.frozen = true
+		result = 1
+	case "fsfreeze-thaw":
+		result = 0
+		if g.frozen {
+			result = 1
+		}
+
+		g.frozen = false
+	case "fsfreeze-status":
+		result = "thawed"
+		if g.frozen {
+			result = "frozen"
+		}
+	case "set-user-password":
+		p := params(r)
+
+		switch {
+		case p["username"] == "":
+			writeAPIError(w, paramError("username", "property is missing and it is not optional"))
+
+			return
+		case len(p["password"]) < 5:
+			writeAPIError(w, paramError("password", "value must have at least 5 characters"))
+
+			return
+		}
+
+		if g.passwords == nil {
+			g.passwords = map[string]string{}
+		}
+
+		g.passwords[p["username"]] = p["password"]
+		result = map[string]any{}
+	case "fstrim":
+		result = map[string]any{
+			"paths": []map[string]any{{"path": "/", "trimmed": 1 << 20, "minimum": 0}},
+		}
+		if res, ok := s.state.agentResults[g.vmid][command]; ok {
+			result = res
+		}
+	default:
+		writeAPIError(w, paramError("command", "value '%s' does not have a value in the enumeration", command))
+
+		return
+	}
+
+	writeData(w, map[string]any{"result": result})
+}
@@ -0,0 +1,475 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Backup describes a backup added with Server.AddBackup.
+type Backup struct {
+	// Container marks the backup as a container backup; backups are VM backups by default.
+	Container bool
+	Time      time.Time
+	Size      int64
+	Notes     string
+	Protected bool
+	// Verification is the state of the last verification, e.g. `ok` or `failed`; empty means not verified.
+	Verification string
+	// Encrypted is the fingerprint of the encryption key; empty means not encrypted.
+	Encrypted string
+	// Files maps paths inside the backup, e.g. `/root.pxar.didx/etc/hostname`, to their content.
+	// They are served by the file-restore API of `pbs` storages.
+	Files map[string]string
+	// Config is the guest configuration recorded in the backup, which restores start from.
+	Config map[string]string
+}
+
+// AddBackup adds a backup of the guest to a storage on a node and returns its volume ID.
+// Volume IDs follow the naming of the storage type: PBS snapshots on `pbs` storages,
+// vzdump archives otherwise.
+func (s *Server) AddBackup(nodeName, storageID string, vmid int, b Backup) (string, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	if _, err := s.state.node(nodeName); err != nil {
+		return "", err
+	}
+
+	st, err := s.state.storageOnNode(nodeName, storageID)
+	if err != nil {
+		return "", err
+	}
+
+	if !slices.Contains(st.content, "backup") {
+		return "", errorf(http.StatusInternalServerError,
+			"storage '%s' does not support content-type 'backup'", st.id)
+	}
+
+	return s.state.addBackup(st, nodeName, vmid, b).volid, nil
+}
+
+func (s *state) addBackup(st *storage, nodeName string, vmid int, b Backup) *volume {
+	if b.Time.IsZero() {
+		b.Time = time.Now()
+	}
+
+	b.Files = maps.Clone(b.Files)
+	b.Config = maps.Clone(b.Config)
+
+	v := &volume{
+		storage: st.id,
+		node:    volumeNode(st, nodeName),
+		content: "backup",
+		size:    b.Size,
+		vmid:    vmid,
+		ctime:   b.Time.Unix(),
+		backup:  &b,
+	}
+
+	if st.kind == "pbs" {
+		v.volid = fmt.Sprintf("%s:backup/%s/%d/%s", st.id, b.group(), vmid, b.Time.UTC().Format(time.RFC3339))
+		v.format = "pbs-" + b.group()
+	} else {
+		v.volid = fmt.Sprintf("%s:backup/vzdump-%s-%d-%s.%s.zst",
+			st.id, b.subtype(), vmid, b.Time.UTC().Format("2006_01_02-15_04_05"), b.archive())
+		v.format = b.archive() + ".zst"
+	}
+
+	s.volumes[s.volumeKey(st, nodeName, v.volid)] = v
+
+	return v
+}
+
+func (b *Backup) subtype() string {
+	if b.Container {
+		return "lxc"
+	}
+
+	return "qemu"
+}
+
+func (b *Backup) group() string {
+	if b.Container {
+		return "ct"
+	}
+
+	return "vm"
+}
+
+func (b *Backup) archive() string {
+	if b.Container {
+		return "tar"
+	}
+
+	return "vma"
+}
+
+// listItem adds the backup metadata to a storage content list item.
+func (b *Backup) listItem(item map[string]any) {
+	item["subtype"] = b.subtype()
+
+	if b.Notes != "" {
+		item["notes"] = b.Notes
+	}
+
+	if b.Protected {
+		item["protected"] = 1
+	}
+
+	if b.Verification != "" {
+		item["verification"] = map[string]any{
+			"state": b.Verification,
+			"upid":  "UPID:pbs:00000000:00000000:00000000:verify:fake:root@pam:",
+		}
+	}
+
+	if b.Encrypted != "" {
+		item["encrypted"] = b.Encrypted
+	}
+}
+
+// restoreEntry is a file or directory inside a backup, as listed by the file-restore API.
+type restoreEntry struct {
+	path string
+	dir  bool
+	size int64
+}
+
+// entries lists the direct children of the directory inside the backup.
+func (b *Backup) entries(dir string) []restoreEntry {
+	prefix := strings.TrimSuffix(dir, "/") + "/"
+	children := map[string]restoreEntry{}
+
+	for p, content := range b.Files {
+		rest, ok := strings.CutPrefix(p, prefix)
+		if !ok || rest == "" {
+			continue
+		}
+
+		name, _, nested := strings.Cut(rest, "/")
+		if nested {
+			children[name] = restoreEntry{path: prefix + name, dir: true}
+		} else {
+			children[name] = restoreEntry{path: prefix + name, size: int64(len(content))}
+		}
+	}
+
+	return slices.SortedFunc(maps.Values(children), func(a, b restoreEntry) int {
+		return strings.Compare(a.path, b.path)
+	})
+}
+
+func (s *Server) lookupRestoreVolume(r *http.Request) (*volume, string, *apiError) {
+	nodeName := r.PathValue("node")
+	if _, err := s.state.node(nodeName); err != nil {
+		return nil, "", err
+	}
+
+	st, err := s.state.storageOnNode(nodeName, r.PathValue("storage"))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if st.kind != "pbs" {
+		return nil, "", errorf(http.StatusBadRequest, "not a PBS storage")
+	}
+
+	p := params(r)
+
+	v, ok := s.state.volumes[s.state.volumeKey(st, nodeName, p["volume"])]
+	if !ok || v.backup == nil {
+		return nil, "", errorf(http.StatusInternalServerError, "volume '%s' does not exist", p["volume"])
+	}
+
+	filePath, decodeErr := base64.StdEncoding.DecodeString(p["filepath"])
+	if decodeErr != nil {
+		return nil, "", paramError("filepath", "invalid base64 encoding")
+	}
+
+	return v, string(filePath), nil
+}
+
+func (s *Server) listRestoreFiles(w http.ResponseWriter, r *http.Request) {
+	v, dir, err := s.lookupRestoreVolume(r)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	res := []map[string]any{}
+
+	for _, e := range v.backup.entries(dir) {
+		item := map[string]any{
+			"filepath": base64.StdEncoding.EncodeToString([]byte(e.path)),
+			"text":     path.Base(e.path),
+			"type":     "f",
+			"leaf":     1,
+			"mtime":    v.ctime,
+		}
+
+		if e.dir {
+			item["type"] = "d"
+			item["leaf"] = 0
+		} else {
+			item["size"] = e.size
+		}
+
+		res = append(res, item)
+	}
+
+	writeData(w, res)
+}
+
+// downloadRestoreFile serves a file from a backup, or a directory as a zip archive. With `tar` set,
+// directories are served as a plain tar archive; PVE compresses it with zstd.
+func (s *Server) downloadRestoreFile(w http.ResponseWriter, r *http.Request) {
+	v, filePath, err := s.lookupRestoreVolume(r)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if content, ok := v.backup.Files[filePath]; ok {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = io.WriteString(w, content)
+
+		return
+	}
+
+	prefix := strings.TrimSuffix(filePath, "/") + "/"
+
+	var paths []string
+
+	for p := range v.backup.Files {
+		if strings.HasPrefix(p, prefix) {
+			paths = append(paths, p)
+		}
+	}
+
+	if len(paths) == 0 {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("'%s' does not exist in backup", filePath))
+
+		return
+	}
+
+	slices.Sort(paths)
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+
+	if params(r)["tar"] == "1" {
+		tw := tar.NewWriter(w)
+
+		for _, p := range paths {
+			content := v.backup.Files[p]
+			_ = tw.WriteHeader(&tar.Header{Name: strings.TrimPrefix(p, prefix), Mode: 0o644, Size: int64(len(content))})
+			_, _ = io.WriteString(tw, content)
+		}
+
+		_ = tw.Close()
+
+		return
+	}
+
+	zw := zip.NewWriter(w)
+
+	for _, p := range paths {
+		fw, _ := zw.Create(strings.TrimPrefix(p, prefix))
+		_, _ = io.WriteString(fw, v.backup.Files[p])
+	}
+
+	_ = zw.Close()
+}
+
+// vzdump backs up the guests on the node. The backups are added to the storage when the
+// task finishes; notes templates support the `{{guestname}}`, `{{node}}` and `{{vmid}}` variables.
+func (s *Server) vzdump(w http.ResponseWriter, r *http.Request) {
+	st := s.state
+	nodeName := r.PathValue("node")
+
+	if _, err := st.node(nodeName); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	p := params(r)
+
+	storageID := p["storage"]
+	if storageID == "" {
+		storageID = "local"
+	}
+
+	sto, err := st.storageOnNode(nodeName, storageID)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if !slices.Contains(sto.content, "backup") {
+		writeAPIError(w, paramError("storage", "storage '%s' does not support backups", sto.id))
+
+		return
+	}
+
+	if m := p["mode"]; m != "" && m != "snapshot" && m != "suspend" && m != "stop" {
+		writeAPIError(w, paramError("mode", "value '%s' does not have a value in the enumeration "+
+			"'snapshot, suspend, stop'", m))
+
+		return
+	}
+
+	var guests []*guest
+
+	for id := range strings.SplitSeq(p["vmid"], ",") {
+		vmid, convErr := strconv.Atoi(strings.TrimSpace(id))
+		if convErr != nil {
+			writeAPIError(w, paramError("vmid", "value does not match the regex pattern"))
+
+			return
+		}
+
+		g, ok := st.guests[vmid]
+		if !ok || g.node != nodeName {
+			writeAPIError(w, errorf(http.StatusInternalServerError, "guest %d not found on node '%s'", vmid, nodeName))
+
+			return
+		}
+
+		guests = append(guests, g)
+	}
+
+	taskID := ""
+	if len(guests) == 1 {
+		taskID = strconv.Itoa(guests[0].vmid)
+	}
+
+	t := st.startTask(nodeName, "vzdump", taskID, nil, func() {
+		for _, g := range guests {
+			name := g.config["name"]
+			if g.kind == guestLXC {
+				name = g.config["hostname"]
+			}
+
+			notes := strings.NewReplacer(
+				"{{guestname}}", name,
+				"{{node}}", nodeName,
+				"{{vmid}}", strconv.Itoa(g.vmid),
+			).Replace(p["notes-template"])
+
+			st.addBackup(sto, nodeName, g.vmid, Backup{
+				Container: g.kind == guestLXC,
+				Size:      1 << 20,
+				Notes:     notes,
+				Protected: p["protected"] == "1",
+				Config:    backupConfig(g.config),
+			})
+		}
+	})
+
+	writeData(w, t.upid)
+}
+
+// backupConfig returns the config of a guest as recorded in its backups.
+func backupConfig(cfg map[string]string) map[string]string {
+	res := maps.Clone(cfg)
+	delete(res, "lock")
+
+	return res
+}
+
+// backupVolume returns the backup with the volume ID on the node.
+func (s *state) backupVolume(nodeName, volid string) (*volume, *apiError) {
+	storageID, _, _ := strings.Cut(volid, ":")
+
+	st, err := s.storageOnNode(nodeName, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	v, ok := s.volumes[s.volumeKey(st, nodeName, volid)]
+	if !ok || v.backup == nil {
+		return nil, errorf(http.StatusInternalServerError, "volume '%s' does not exist", volid)
+	}
+
+	return v, nil
+}
+
+// restoreConfig returns the config of a guest restored from the backup. The disks are allocated
+// anew with their recorded sizes, on the storage if set, otherwise on their original storages.
+func restoreConfig(g *guest, b *Backup, storageID string) map[string]string {
+	cfg := maps.Clone(b.Config)
+	if cfg == nil {
+		cfg = map[string]string{}
+	}
+
+	for k, value := range cfg {
+		if !g.isDiskKey(k) || strings.Contains(value, "media=cdrom") {
+			continue
+		}
+
+		volid, opts := splitDiskValue(value)
+
+		source, _, ok := strings.Cut(volid, ":")
+		if !ok {
+			continue
+		}
+
+		target := storageID
+		if target == "" {
+			target = source
+		}
+
+		size := max(diskSize(value), 1<<30)
+
+		var kept []string
+
+		for o := range strings.SplitSeq(opts, ",") {
+			if o != "" && !strings.HasPrefix(o, "size=") {
+				kept = append(kept, o)
+			}
+		}
+
+		cfg[k] = strings.Join(append([]string{
+			target + ":" + strconv.FormatFloat(float64(size)/(1<<30), 'f', -1, 64),
+		}, kept...), ",")
+	}
+
+	return cfg
+}
+
+// extractBackupConfig returns the guest config recorded in a backup, in the config file format.
+func (s *Server) extractBackupConfig(w http.ResponseWriter, r *http.Request) {
+	v, err := s.state.backupVolume(r.PathValue("node"), params(r)["volume"])
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	var sb strings.Builder
+
+	for _, k := range slices.Sorted(maps.Keys(v.backup.Config)) {
+		fmt.Fprintf(&sb, "%s: %s\n", k, v.backup.Config[k])
+	}
+
+	writeData(w, sb.String())
+}
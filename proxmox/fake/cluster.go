@@ -0,0 +1,199 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func (s *Server) registerCluster(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+basePath+"/cluster/resources", s.guard(s.getClusterResources))
+	mux.HandleFunc("GET "+basePath+"/cluster/nextid", s.guard(s.getNextID))
+	mux.HandleFunc("GET "+basePath+"/cluster/status", s.guard(s.getClusterStatus))
+	mux.HandleFunc("GET "+basePath+"/cluster/config/nodes", s.guard(s.listClusterConfigNodes))
+}
+
+func (s *Server) getClusterResources(w http.ResponseWriter, r *http.Request) {
+	st := s.state
+	kind := params(r)["type"]
+
+	res := []map[string]any{}
+
+	if kind == "" || kind == "node" {
+		for _, name := range slices.Sorted(maps.Keys(st.nodes)) {
+			res = append(res, map[string]any{
+				"id":          "node/" + name,
+				"type":        "node",
+				"node":        name,
+				"status":      st.nodes[name].status(),
+				"maxcpu":      nodeCPUs,
+				"maxmem":      nodeMemory,
+				"mem":         st.nodeMemoryUsed(name),
+				"uptime":      3600,
+				"level":       "",
+				"cgroup-mode": 2,
+			})
+		}
+	}
+
+	if kind == "" || kind == "vm" {
+		for _, vmid := range slices.Sorted(maps.Keys(st.guests)) {
+			g := st.guests[vmid]
+
+			item := map[string]any{
+				"id":       fmt.Sprintf("%s/%d", g.kind, vmid),
+				"type":     string(g.kind),
+				"vmid":     vmid,
+				"node":     g.node,
+				"name":     g.name(),
+				"status":   g.status,
+				"maxcpu":   g.cpus(),
+				"maxmem":   g.maxMem(),
+				"maxdisk":  g.maxDisk(),
+				"uptime":   g.uptime(),
+				"template": boolInt(g.isTemplate()),
+			}
+
+			if pool := st.poolOf(vmid); pool != "" {
+				item["pool"] = pool
+			}
+
+			if tags := g.config["tags"]; tags != "" {
+				item["tags"] = tags
+			}
+
+			if lock := g.config["lock"]; lock != "" {
+				item["lock"] = lock
+			}
+
+			res = append(res, item)
+		}
+	}
+
+	if kind == "" || kind == "storage" {
+		for _, name := range slices.Sorted(maps.Keys(st.nodes)) {
+			for _, id := range slices.Sorted(maps.Keys(st.storages)) {
+				sto := st.storages[id]
+				if len(sto.nodes) > 0 && !slices.Contains(sto.nodes, name) {
+					continue
+				}
+
+				res = append(res, map[string]any{
+					"id":         "storage/" + name + "/" + id,
+					"type":       "storage",
+					"node":       name,
+					"storage":    id,
+					"plugintype": sto.kind,
+					"status":     "available",
+					"shared":     boolInt(sto.shared),
+					"maxdisk":    sto.total,
+					"disk":       sto.used(st),
+					"content":    strings.Join(sto.content, ","),
+				})
+			}
+		}
+	}
+
+	if kind == "" || kind == "pool" {
+		for _, id := range slices.Sorted(maps.Keys(st.pools)) {
+			res = append(res, map[string]any{
+				"id":     "/pool/" + id,
+				"type":   "pool",
+				"pool":   id,
+				"status": "online",
+			})
+		}
+	}
+
+	writeData(w, res)
+}
+
+func (s *Server) getNextID(w http.ResponseWriter, r *http.Request) {
+	if v := params(r)["vmid"]; v != "" {
+		vmid, err := strconv.Atoi(v)
+		if err != nil || vmid < 100 {
+			writeAPIError(w, paramError("vmid", "invalid format - value does not look like a valid VM ID"))
+
+			return
+		}
+
+		if _, ok := s.state.guests[vmid]; ok {
+			writeAPIError(w, errorf(http.StatusBadRequest, "VM %d already exists", vmid))
+
+			return
+		}
+
+		writeData(w, strconv.Itoa(vmid))
+
+		return
+	}
+
+	writeData(w, strconv.Itoa(s.state.nextVMID()))
+}
+
+func (s *Server) getClusterStatus(w http.ResponseWriter, _ *http.Request) {
+	names := slices.Sorted(maps.Keys(s.state.nodes))
+
+	res := []map[string]any{}
+
+	if len(names) > 1 {
+		res = append(res, map[string]any{
+			"id":      "cluster",
+			"type":    "cluster",
+			"name":    "fake",
+			"nodes":   len(names),
+			"quorate": boolInt(s.state.quorate()),
+			"version": len(names),
+		})
+	}
+
+	for i, name := range names {
+		res = append(res, map[string]any{
+			"id":     "node/" + name,
+			"type":   "node",
+			"name":   name,
+			"nodeid": i + 1,
+			"ip":     fmt.Sprintf("192.0.2.%d", i+1),
+			"online": boolInt(!s.state.nodes[name].offline),
+			"local":  boolInt(i == 0),
+			"level":  "",
+		})
+	}
+
+	writeData(w, res)
+}
+
+func (s *Server) listClusterConfigNodes(w http.ResponseWriter, _ *http.Request) {
+	names := slices.Sorted(maps.Keys(s.state.nodes))
+
+	if len(names) < 2 {
+		writeError(w, http.StatusInternalServerError,
+			"Corosync config '/etc/pve/corosync.conf' does not exist - is this node part of a cluster?")
+
+		return
+	}
+
+	res := []map[string]any{}
+
+	for i, name := range names {
+		res = append(res, map[string]any{
+			"name":         name,
+			"node":         name,
+			"nodeid":       strconv.Itoa(i + 1),
+			"quorum_votes": "1",
+			"ring0_addr":   fmt.Sprintf("192.0.2.%d", i+1),
+			"ring1_addr":   fmt.Sprintf("198.51.100.%d", i+1),
+		})
+	}
+
+	writeData(w, res)
+}
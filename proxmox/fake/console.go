@@ -0,0 +1,150 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// consolePortBase is the first port of the console proxies, like the VNC port range of PVE.
+const consolePortBase = 5900
+
+func (s *Server) registerConsole(mux *http.ServeMux) {
+	for _, kind := range []guestType{guestQEMU, guestLXC} {
+		base := basePath + "/nodes/{node}/" + string(kind) + "/{vmid}"
+
+		for _, proxy := range []string{"vncproxy", "termproxy"} {
+			mux.HandleFunc("POST "+base+"/"+proxy, s.guard(func(w http.ResponseWriter, r *http.Request) {
+				s.createGuestConsoleProxy(w, r, kind, proxy)
+			}))
+		}
+
+		mux.HandleFunc("POST "+base+"/spiceproxy", s.guard(func(w http.ResponseWriter, r *http.Request) {
+			s.createGuestSPICEProxy(w, r, kind)
+		}))
+	}
+
+	base := basePath + "/nodes/{node}"
+
+	for _, proxy := range []string{"vncshell", "termproxy"} {
+		mux.HandleFunc("POST "+base+"/"+proxy, s.guard(func(w http.ResponseWriter, r *http.Request) {
+			s.createShellProxy(w, r, proxy)
+		}))
+	}
+
+	mux.HandleFunc("POST "+base+"/spiceshell", s.guard(s.createSPICEShell))
+}
+
+// consoleProxy starts the task of a console proxy and returns the proxy ticket and port.
+func (s *state) consoleProxy(nodeName, kind, id string) map[string]any {
+	t := s.startTask(nodeName, kind, id, nil, nil)
+
+	return map[string]any{
+		"port":   strconv.Itoa(consolePortBase + t.pid%100),
+		"ticket": "PVEVNC:" + strings.ToUpper(randomHex(4)) + "::" + randomHex(32),
+		"upid":   t.upid,
+		"user":   t.user,
+		"cert":   "-----BEGIN CERTIFICATE-----\nfake\n-----END CERTIFICATE-----\n",
+	}
+}
+
+// spiceConfig returns the remote viewer settings of a SPICE proxy.
+func spiceConfig(r *http.Request, nodeName, title string) map[string]any {
+	proxy := params(r)["proxy"]
+	if proxy == "" {
+		proxy = nodeName
+	}
+
+	return map[string]any{
+		"type":              "spice",
+		"title":             title,
+		"host":              "pvespiceproxy:" + randomHex(16),
+		"proxy":             "http://" + proxy + ":3128",
+		"password":          randomHex(16),
+		"tls-port":          61000,
+		"host-subject":      "OU=PVE Cluster Node,O=Proxmox Virtual Environment,CN=" + nodeName,
+		"ca":                "-----BEGIN CERTIFICATE-----\\nfake\\n-----END CERTIFICATE-----\\n",
+		"delete-this-file":  1,
+		"release-cursor":    "Ctrl+Alt+R",
+		"secure-attention":  "Ctrl+Alt+Ins",
+		"toggle-fullscreen": "Shift+F11",
+	}
+}
+
+// spiceDisplay reports whether the VM has a SPICE display, i.e. a `qxl` VGA type.
+func (g *guest) spiceDisplay() bool {
+	vgaType, _, _ := strings.Cut(g.config["vga"], ",")
+
+	return strings.HasPrefix(strings.TrimPrefix(vgaType, "type="), "qxl")
+}
+
+// runningGuest returns the guest of the request if it is running.
+func (s *Server) runningGuest(r *http.Request, kind guestType) (*guest, *apiError) {
+	g, err := s.state.guest(r.PathValue("node"), kind, r.PathValue("vmid"))
+	if err != nil {
+		return nil, err
+	}
+
+	if g.status != "running" {
+		return nil, errorf(http.StatusInternalServerError, "%s %d not running", g.label(), g.vmid)
+	}
+
+	return g, nil
+}
+
+func (s *Server) createGuestConsoleProxy(w http.ResponseWriter, r *http.Request, kind guestType, proxy string) {
+	g, err := s.runningGuest(r, kind)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	writeData(w, s.state.consoleProxy(g.node, kind.taskPrefix()+proxy, strconv.Itoa(g.vmid)))
+}
+
+func (s *Server) createGuestSPICEProxy(w http.ResponseWriter, r *http.Request, kind guestType) {
+	g, err := s.runningGuest(r, kind)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if kind == guestQEMU && !g.spiceDisplay() {
+		writeAPIError(w, errorf(http.StatusInternalServerError, "no spice port"))
+
+		return
+	}
+
+	writeData(w, spiceConfig(r, g.node, fmt.Sprintf("%s %d - %s", g.label(), g.vmid, g.name())))
+}
+
+func (s *Server) createShellProxy(w http.ResponseWriter, r *http.Request, proxy string) {
+	n, err := s.state.node(r.PathValue("node"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	writeData(w, s.state.consoleProxy(n.name, proxy, ""))
+}
+
+func (s *Server) createSPICEShell(w http.ResponseWriter, r *http.Request) {
+	n, err := s.state.node(r.PathValue("node"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	writeData(w, spiceConfig(r, n.name, "Shell on '"+n.name+"'"))
+}
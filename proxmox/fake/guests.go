@@ -0,0 +1,1260 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// qemuDiskKey matches QEMU config keys that hold disks.
+	qemuDiskKey = regexp.MustCompile(`^(ide|sata|scsi|virtio)\d+$|^efidisk0$|^tpmstate0$`)
+
+	// lxcDiskKey matches LXC config keys that hold volumes.
+	lxcDiskKey = regexp.MustCompile(`^rootfs$|^mp\d+$`)
+
+	// newVolumeSpec matches a `<storage>:<size in GiB>` disk allocation request.
+	newVolumeSpec = regexp.MustCompile(`^([^:,]+):(\d+(?:\.\d+)?)$`)
+)
+
+// createOnlyParams are create/clone parameters that are not stored in the guest config.
+var createOnlyParams = []string{
+	"archive", "force", "live-restore", "ostemplate", "password", "pool", "restore",
+	"ssh-public-keys", "start", "storage", "unique", "vmid",
+}
+
+// stringKeys are config keys whose values are always rendered as JSON strings,
+// even if they look like numbers.
+var stringKeys = []string{
+	"affinity", "args", "cipassword", "ciuser", "comment", "description", "digest", "hookscript",
+	"hostname", "hugepages", "lock", "name", "nameserver", "searchdomain", "startdate", "tags",
+	"vmgenid",
+}
+
+// typedValue renders a config value the way the Proxmox VE API does: numeric values as JSON
+// numbers, everything else as strings.
+func typedValue(key, value string) any {
+	if slices.Contains(stringKeys, key) {
+		return value
+	}
+
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+
+	if f, err := strconv.ParseFloat(value, 64); err == nil && strings.Contains(value, ".") {
+		return f
+	}
+
+	return value
+}
+
+func typedConfig(cfg map[string]string) map[string]any {
+	res := make(map[string]any, len(cfg))
+
+	for k, v := range cfg {
+		res[k] = typedValue(k, v)
+	}
+
+	return res
+}
+
+// configDigest returns the SHA1 digest of a guest config, used for optimistic concurrency.
+func configDigest(cfg map[string]string) string {
+	h := sha1.New() //nolint:gosec
+
+	for _, k := range slices.Sorted(maps.Keys(cfg)) {
+		_, _ = fmt.Fprintf(h, "%s: %s\n", k, cfg[k])
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (g *guest) label() string {
+	if g.kind == guestLXC {
+		return "CT"
+	}
+
+	return "VM"
+}
+
+func (g *guest) isDiskKey(key string) bool {
+	if g.kind == guestLXC {
+		return lxcDiskKey.MatchString(key)
+	}
+
+	return qemuDiskKey.MatchString(key)
+}
+
+func (g *guest) name() string {
+	if g.kind == guestLXC {
+		return g.config["hostname"]
+	}
+
+	return g.config["name"]
+}
+
+func (g *guest) isTemplate() bool {
+	return g.config["template"] == "1"
+}
+
+// cpus returns the number of virtual CPUs of the guest.
+func (g *guest) cpus() int {
+	cores, err := strconv.Atoi(g.config["cores"])
+	if err != nil || cores < 1 {
+		cores = 1
+	}
+
+	if g.kind == guestLXC {
+		return cores
+	}
+
+	sockets, err := strconv.Atoi(g.config["sockets"])
+	if err != nil || sockets < 1 {
+		sockets = 1
+	}
+
+	return cores * sockets
+}
+
+// maxMem returns the configured memory of the guest in bytes.
+func (g *guest) maxMem() int64 {
+	mem, err := strconv.ParseInt(strings.SplitN(g.config["memory"], ",", 2)[0], 10, 64)
+	if err != nil || mem < 1 {
+		mem = 512
+		if g.kind == guestQEMU {
+			mem = 2048
+		}
+	}
+
+	return mem << 20
+}
+
+// maxDisk returns the size of the boot disk (QEMU) or root filesystem (LXC) in bytes.
+func (g *guest) maxDisk() int64 {
+	var total int64
+
+	for k, v := range g.config {
+		if g.isDiskKey(k) && (k == "rootfs" || (g.kind == guestQEMU && !strings.Contains(v, "media=cdrom"))) {
+			total = max(total, diskSize(v))
+		}
+	}
+
+	return total
+}
+
+func (g *guest) uptime() int64 {
+	if g.status != "running" {
+		return 0
+	}
+
+	return int64(time.Since(g.started).Seconds())
+}
+
+// diskSize returns the `size=` option of a disk config value in bytes.
+func diskSize(value string) int64 {
+	for opt := range strings.SplitSeq(value, ",") {
+		if v, ok := strings.CutPrefix(opt, "size="); ok {
+			size, err := parseSize(v, 1)
+			if err == nil {
+				return size
+			}
+		}
+	}
+
+	return 0
+}
+
+// setDiskSize replaces or adds the `size=` option of a disk config value.
+func setDiskSize(value string, size int64) string {
+	opts := strings.Split(value, ",")
+
+	for i, opt := range opts {
+		if strings.HasPrefix(opt, "size=") {
+			opts[i] = "size=" + formatSize(size)
+
+			return strings.Join(opts, ",")
+		}
+	}
+
+	return strings.Join(append(opts, "size="+formatSize(size)), ",")
+}
+
+// parseSize parses a disk size like `8G` or `512M`, or a number of the given unit in bytes.
+func parseSize(s string, unit int64) (int64, error) {
+	units := map[byte]int64{'K': 1 << 10, 'M': 1 << 20, 'G': 1 << 30, 'T': 1 << 40}
+
+	mult := unit
+	if s != "" {
+		if u, ok := units[s[len(s)-1]]; ok {
+			mult = u
+			s = s[:len(s)-1]
+		}
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", s, err)
+	}
+
+	return int64(f * float64(mult)), nil
+}
+
+func formatSize(size int64) string {
+	for _, u := range []struct {
+		suffix string
+		bytes  int64
+	}{{"T", 1 << 40}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}} {
+		if size >= u.bytes && size%u.bytes == 0 {
+			return fmt.Sprintf("%d%s", size/u.bytes, u.suffix)
+		}
+	}
+
+	return strconv.FormatInt(size, 10)
+}
+
+// splitDiskValue splits a disk config value into the volume and the other options. The volume
+// is the default key of a disk, it may be given explicitly as `file=` or `volume=` as well.
+func splitDiskValue(value string) (string, string) {
+	var (
+		volume string
+		opts   []string
+	)
+
+	for o := range strings.SplitSeq(value, ",") {
+		k, v, ok := strings.Cut(o, "=")
+
+		switch {
+		case !ok && volume == "":
+			volume = o
+		case ok && (k == "file" || k == "volume"):
+			volume = v
+		default:
+			opts = append(opts, o)
+		}
+	}
+
+	return volume, strings.Join(opts, ",")
+}
+
+// allocateVolumes replaces `<storage>:<size>` disk specs in the config with newly allocated volumes.
+func (s *state) allocateVolumes(g *guest, cfg map[string]string) *apiError {
+	for _, k := range slices.Sorted(maps.Keys(cfg)) {
+		if !g.isDiskKey(k) {
+			continue
+		}
+
+		file, opts := splitDiskValue(cfg[k])
+
+		m := newVolumeSpec.FindStringSubmatch(file)
+		if m == nil {
+			continue
+		}
+
+		st, err := s.storageOnNode(g.node, m[1])
+		if err != nil {
+			return err
+		}
+
+		size, perr := parseSize(m[2], 1<<30)
+		if perr != nil {
+			return paramError(k, "invalid disk size '%s'", m[2])
+		}
+
+		opts, importSize, err := s.importFrom(g.node, k, opts)
+		if err != nil {
+			return err
+		}
+
+		if importSize > 0 {
+			size = importSize
+		}
+
+		content := "images"
+		if g.kind == guestLXC {
+			content = "rootdir"
+		}
+
+		if !slices.Contains(st.content, content) {
+			return errorf(http.StatusInternalServerError,
+				"storage '%s' does not support content-type '%s'", st.id, content)
+		}
+
+		// efidisk and tpmstate have a fixed size
+		switch {
+		case strings.HasPrefix(k, "efidisk"):
+			size = 1 << 20
+		case strings.HasPrefix(k, "tpmstate"):
+			size = 4 << 20
+		}
+
+		volid := s.allocateDisk(st, g.node, g.vmid, size, content)
+
+		value := volid
+		if opts != "" {
+			value += "," + opts
+		}
+
+		cfg[k] = setDiskSize(value, size)
+	}
+
+	return nil
+}
+
+// importFrom resolves the `import-from` option of a new disk: the source volume must be a
+// disk image or an importable file, and its size becomes the size of the disk. The option
+// itself is not kept in the config.
+func (s *state) importFrom(nodeName, key, opts string) (string, int64, *apiError) {
+	var (
+		kept []string
+		size int64
+	)
+
+	for _, o := range splitList(opts) {
+		volid, ok := strings.CutPrefix(o, "import-from=")
+		if !ok {
+			kept = append(kept, o)
+
+			continue
+		}
+
+		storageID, _, _ := strings.Cut(volid, ":")
+
+		st, err := s.storageOnNode(nodeName, storageID)
+		if err != nil {
+			return "", 0, err
+		}
+
+		v, ok := s.volumes[s.volumeKey(st, nodeName, volid)]
+		if !ok {
+			return "", 0, errorf(http.StatusInternalServerError, "volume '%s' does not exist", volid)
+		}
+
+		if v.content != "images" && v.content != "import" {
+			return "", 0, paramError(key, "'import-from' has wrong type '%s' - needs to be 'images' or 'import'",
+				v.content)
+		}
+
+		size = v.size
+	}
+
+	return strings.Join(kept, ","), size, nil
+}
+
+func (s *Server) registerGuests(mux *http.ServeMux) {
+	for _, kind := range []guestType{guestQEMU, guestLXC} {
+		base := basePath + "/nodes/{node}/" + string(kind)
+
+		mux.HandleFunc("GET "+base, s.guard(func(w http.ResponseWriter, r *http.Request) {
+			s.listGuests(w, r, kind)
+		}))
+		mux.HandleFunc("POST "+base, s.guard(func(w http.ResponseWriter, r *http.Request) {
+			s.createGuest(w, r, kind)
+		}))
+		mux.HandleFunc("DELETE "+base+"/{vmid}", s.guard(func(w http.ResponseWriter, r *http.Request) {
+			s.deleteGuest(w, r, kind)
+		}))
+		mux.HandleFunc("GET "+base+"/{vmid}/config", s.guard(func(w http.ResponseWriter, r *http.Request) {
+			s.getGuestConfig(w, r, kind)
+		}))
+		mux.HandleFunc("PUT "+base+"/{vmid}/config", s.guard(func(w http.ResponseWriter, r *http.Request) {
+			s.updateGuestConfig(w, r, kind, false)
+		}))
+		mux.HandleFunc("GET "+base+"/{vmid}/status/current", s.guard(func(w http.ResponseWriter, r *http.Request) {
+			s.getGuestStatus(w, r, kind)
+		}))
+		mux.HandleFunc("POST "+base+"/{vmid}/status/{action}", s.guard(func(w http.ResponseWriter, r *http.Request) {
+			s.changeGuestStatus(w, r, kind)
+		}))
+		mux.HandleFunc("POST "+base+"/{vmid}/clone", s.guard(func(w http.ResponseWriter, r *http.Request) {
+			s.cloneGuest(w, r, kind)
+		}))
+		mux.HandleFunc("POST "+base+"/{vmid}/migrate", s.guard(func(w http.ResponseWriter, r *http.Request) {
+			s.migrateGuest(w, r, kind)
+		}))
+		mux.HandleFunc("PUT "+base+"/{vmid}/resize", s.guard(func(w http.ResponseWriter, r *http.Request) {
+			s.resizeGuestDisk(w, r, kind)
+		}))
+		mux.HandleFunc("POST "+base+"/{vmid}/template", s.guard(func(w http.ResponseWriter, r *http.Request) {
+			s.convertToTemplate(w, r, kind)
+		}))
+	}
+
+	// only QEMU supports asynchronous config updates
+	mux.HandleFunc("POST "+basePath+"/nodes/{node}/qemu/{vmid}/config", s.guard(func(w http.ResponseWriter, r *http.Request) {
+		s.updateGuestConfig(w, r, guestQEMU, true)
+	}))
+	mux.HandleFunc("POST "+basePath+"/nodes/{node}/qemu/{vmid}/move_disk", s.guard(s.moveGuestDisk))
+}
+
+func (s *Server) listGuests(w http.ResponseWriter, r *http.Request, kind guestType) {
+	nodeName := r.PathValue("node")
+	if _, err := s.state.node(nodeName); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	res := []map[string]any{}
+
+	for _, id := range slices.Sorted(maps.Keys(s.state.guests)) {
+		g := s.state.guests[id]
+		if g.node != nodeName || g.kind != kind {
+			continue
+		}
+
+		item := map[string]any{
+			"vmid":    g.vmid,
+			"name":    g.name(),
+			"status":  g.status,
+			"cpus":    g.cpus(),
+			"maxmem":  g.maxMem(),
+			"maxdisk": g.maxDisk(),
+			"uptime":  g.uptime(),
+		}
+
+		if kind == guestLXC {
+			item["type"] = "lxc"
+		}
+
+		if g.isTemplate() {
+			item["template"] = 1
+		}
+
+		if tags := g.config["tags"]; tags != "" {
+			item["tags"] = tags
+		}
+
+		if lock := g.config["lock"]; lock != "" {
+			item["lock"] = lock
+		}
+
+		res = append(res, item)
+	}
+
+	writeData(w, res)
+}
+
+func (s *Server) createGuest(w http.ResponseWriter, r *http.Request, kind guestType) {
+	st := s.state
+	p := params(r)
+	nodeName := r.PathValue("node")
+
+	if _, err := st.node(nodeName); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	vmid, err := strconv.Atoi(p["vmid"])
+	if err != nil || vmid < 100 {
+		writeAPIError(w, paramError("vmid", "property is missing and it is not optional"))
+
+		return
+	}
+
+	if existing, ok := st.guests[vmid]; ok {
+		writeAPIError(w, errorf(http.StatusInternalServerError,
+			"unable to create %s %d - %s %d already exists on node '%s'",
+			existing.label(), vmid, existing.label(), vmid, existing.node))
+
+		return
+	}
+
+	poolID := p["pool"]
+	if poolID != "" {
+		if _, ok := st.pools[poolID]; !ok {
+			writeAPIError(w, errorf(http.StatusInternalServerError, "pool '%s' does not exist", poolID))
+
+			return
+		}
+	}
+
+	if kind == guestLXC && p["ostemplate"] == "" && p["archive"] == "" {
+		writeAPIError(w, paramError("ostemplate", "property is missing and it is not optional"))
+
+		return
+	}
+
+	g := &guest{vmid: vmid, node: nodeName, kind: kind, status: "stopped", config: map[string]string{}}
+
+	archive := p["archive"]
+	if kind == guestLXC && p["restore"] == "1" {
+		archive = p["ostemplate"]
+	}
+
+	if archive != "" {
+		v, aerr := st.backupVolume(nodeName, archive)
+		if aerr != nil {
+			writeAPIError(w, aerr)
+
+			return
+		}
+
+		if p["live-restore"] == "1" && (kind != guestQEMU || st.storages[v.storage].kind != "pbs") {
+			writeAPIError(w, paramError("live-restore",
+				"live-restore is only compatible with backup images from a Proxmox Backup Server"))
+
+			return
+		}
+
+		recorded := *v.backup
+
+		// QEMU restores take the disks from the backup, while container restores may place the
+		// volumes anew, which replaces all volumes recorded in the backup
+		for k := range p {
+			if !g.isDiskKey(k) {
+				continue
+			}
+
+			if kind == guestQEMU {
+				writeAPIError(w, paramError(k, "option conflicts with option 'archive'"))
+
+				return
+			}
+
+			recorded.Config = maps.Clone(recorded.Config)
+			maps.DeleteFunc(recorded.Config, func(key, _ string) bool { return g.isDiskKey(key) })
+		}
+
+		g.config = restoreConfig(g, &recorded, p["storage"])
+	}
+
+	for k, v := range p {
+		if !slices.Contains(createOnlyParams, k) {
+			g.config[k] = v
+		}
+	}
+
+	switch kind {
+	case guestQEMU:
+		if _, ok := g.config["smbios1"]; !ok {
+			g.config["smbios1"] = "uuid=" + uuid()
+		}
+
+		if _, ok := g.config["vmgenid"]; !ok {
+			g.config["vmgenid"] = uuid()
+		}
+	case guestLXC:
+		if _, ok := g.config["hostname"]; !ok {
+			g.config["hostname"] = fmt.Sprintf("CT%d", vmid)
+		}
+
+		if _, ok := g.config["arch"]; !ok {
+			g.config["arch"] = "amd64"
+		}
+
+		if _, ok := g.config["rootfs"]; !ok {
+			g.config["rootfs"] = "local-lvm:4"
+		}
+	}
+
+	if aerr := st.allocateVolumes(g, g.config); aerr != nil {
+		writeAPIError(w, aerr)
+
+		return
+	}
+
+	st.guests[vmid] = g
+
+	if poolID != "" {
+		st.pools[poolID].vms[vmid] = true
+	}
+
+	// a live restore starts the VM right away and restores the disks in the background
+	start := p["start"] == "1" || p["live-restore"] == "1"
+
+	t := st.startTask(nodeName, kind.taskPrefix()+"create", strconv.Itoa(vmid), g, func() {
+		if start {
+			g.status = "running"
+			g.started = time.Now()
+		}
+	})
+	t.onFail = func() { st.removeGuest(g) }
+
+	writeData(w, t.upid)
+}
+
+func (s *Server) deleteGuest(w http.ResponseWriter, r *http.Request, kind guestType) {
+	st := s.state
+	p := params(r)
+
+	g, err := st.guest(r.PathValue("node"), kind, r.PathValue("vmid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if g.status == "running" {
+		writeAPIError(w, errorf(http.StatusInternalServerError,
+			"%s %d is running - destroy failed", g.label(), g.vmid))
+
+		return
+	}
+
+	if err = st.checkUnlocked(g); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	purge := p["purge"] == "1"
+
+	t := st.startTask(g.node, kind.taskPrefix()+"destroy", strconv.Itoa(g.vmid), g, func() {
+		st.removeGuest(g)
+
+		if purge {
+			st.removeGuestReferences(g.vmid)
+		}
+	})
+
+	writeData(w, t.upid)
+}
+
+func (s *Server) getGuestConfig(w http.ResponseWriter, r *http.Request, kind guestType) {
+	g, err := s.state.guest(r.PathValue("node"), kind, r.PathValue("vmid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	cfg := typedConfig(g.config)
+	cfg["digest"] = configDigest(g.config)
+
+	writeData(w, cfg)
+}
+
+func (s *Server) updateGuestConfig(w http.ResponseWriter, r *http.Request, kind guestType, async bool) {
+	st := s.state
+	p := params(r)
+
+	g, err := st.guest(r.PathValue("node"), kind, r.PathValue("vmid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if digest := p["digest"]; digest != "" && digest != configDigest(g.config) {
+		writeError(w, http.StatusInternalServerError,
+			"detected modified configuration - file changed by other user? Try again.")
+
+		return
+	}
+
+	if p["skiplock"] != "1" {
+		if err = st.checkUnlocked(g); err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+	}
+
+	updated := maps.Clone(g.config)
+
+	deleted := splitList(p["delete"])
+
+	for _, k := range deleted {
+		if g.isDiskKey(k) {
+			// detached disks are kept as unused volumes, like PVE does
+			if volid, _, _ := strings.Cut(updated[k], ","); volid != "" && volid != "none" &&
+				!strings.Contains(updated[k], "media=cdrom") {
+				updated[nextUnusedKey(updated)] = volid
+			}
+		}
+
+		delete(updated, k)
+	}
+
+	for k, v := range p {
+		switch k {
+		case "delete", "digest", "skiplock", "revert", "background_delay":
+			continue
+		}
+
+		if slices.Contains(deleted, k) {
+			writeAPIError(w, paramError(k, "unable to delete '%s' - option is also set", k))
+
+			return
+		}
+
+		updated[k] = v
+	}
+
+	if err = st.allocateVolumes(g, updated); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if !async {
+		g.config = updated
+
+		writeData(w, nil)
+
+		return
+	}
+
+	t := st.startTask(g.node, "qmconfig", strconv.Itoa(g.vmid), g, func() {
+		g.config = updated
+	})
+
+	writeData(w, t.upid)
+}
+
+func nextUnusedKey(cfg map[string]string) string {
+	for i := 0; ; i++ {
+		k := fmt.Sprintf("unused%d", i)
+		if _, ok := cfg[k]; !ok {
+			return k
+		}
+	}
+}
+
+func (s *Server) getGuestStatus(w http.ResponseWriter, r *http.Request, kind guestType) {
+	g, err := s.state.guest(r.PathValue("node"), kind, r.PathValue("vmid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	res := map[string]any{
+		"vmid":    g.vmid,
+		"name":    g.name(),
+		"status":  g.status,
+		"cpus":    g.cpus(),
+		"maxmem":  g.maxMem(),
+		"maxdisk": g.maxDisk(),
+		"uptime":  g.uptime(),
+		"ha":      map[string]any{"managed": 0},
+	}
+
+	if kind == guestQEMU {
+		res["qmpstatus"] = g.status
+		if g.config["agent"] != "" {
+			res["agent"] = 1
+		}
+	} else {
+		res["type"] = "lxc"
+	}
+
+	if g.status == "running" {
+		res["pid"] = 10000 + g.vmid
+	}
+
+	if lock := g.config["lock"]; lock != "" {
+		res["lock"] = lock
+	}
+
+	if g.isTemplate() {
+		res["template"] = 1
+	}
+
+	writeData(w, res)
+}
+
+func (s *Server) changeGuestStatus(w http.ResponseWriter, r *http.Request, kind guestType) {
+	st := s.state
+
+	g, err := st.guest(r.PathValue("node"), kind, r.PathValue("vmid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	action := r.PathValue("action")
+
+	var target string
+
+	switch action {
+	case "start":
+		if g.isTemplate() {
+			writeError(w, http.StatusInternalServerError, "you can't start a vm if it's a template")
+
+			return
+		}
+
+		if g.status == "running" {
+			writeAPIError(w, errorf(http.StatusInternalServerError, "%s %d already running", g.label(), g.vmid))
+
+			return
+		}
+
+		target = "running"
+	case "stop":
+		target = "stopped"
+	case "shutdown", "reboot", "suspend", "resume":
+		if g.status != "running" {
+			writeAPIError(w, errorf(http.StatusInternalServerError, "%s %d not running", g.label(), g.vmid))
+
+			return
+		}
+
+		target = "stopped"
+		if action != "shutdown" {
+			target = "running"
+		}
+	default:
+		writeError(w, http.StatusNotImplemented, fmt.Sprintf("Method 'POST /nodes/{node}/%s/{vmid}/status/%s' not implemented",
+			kind, action))
+
+		return
+	}
+
+	// stop is allowed to preempt other tasks, like PVE does with `qm stop --overrule-shutdown`
+	if action != "stop" {
+		if err = st.checkUnlocked(g); err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+	} else if lock := g.config["lock"]; lock != "" && params(r)["skiplock"] != "1" {
+		writeAPIError(w, errorf(http.StatusInternalServerError, "VM is locked (%s)", lock))
+
+		return
+	}
+
+	t := st.startTask(g.node, kind.taskPrefix()+action, strconv.Itoa(g.vmid), g, func() {
+		if g.status != "running" && target == "running" || action == "reboot" {
+			g.started = time.Now()
+		}
+
+		// a filesystem freeze does not survive the guest stopping
+		if target == "stopped" {
+			g.frozen = false
+		}
+
+		g.status = target
+	})
+
+	writeData(w, t.upid)
+}
+
+func (s *Server) cloneGuest(w http.ResponseWriter, r *http.Request, kind guestType) {
+	st := s.state
+	p := params(r)
+
+	src, err := st.guest(r.PathValue("node"), kind, r.PathValue("vmid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	newID, cerr := strconv.Atoi(p["newid"])
+	if cerr != nil || newID < 100 {
+		writeAPIError(w, paramError("newid", "property is missing and it is not optional"))
+
+		return
+	}
+
+	if existing, ok := st.guests[newID]; ok {
+		writeAPIError(w, errorf(http.StatusInternalServerError,
+			"unable to create %s %d - %s %d already exists on node '%s'",
+			existing.label(), newID, existing.label(), newID, existing.node))
+
+		return
+	}
+
+	if lock := src.config["lock"]; lock != "" {
+		writeAPIError(w, errorf(http.StatusInternalServerError, "VM is locked (%s)", lock))
+
+		return
+	}
+
+	targetNode := src.node
+	if t := p["target"]; t != "" {
+		if _, err = st.node(t); err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		targetNode = t
+	}
+
+	poolID := p["pool"]
+	if poolID != "" {
+		if _, ok := st.pools[poolID]; !ok {
+			writeAPIError(w, errorf(http.StatusInternalServerError, "pool '%s' does not exist", poolID))
+
+			return
+		}
+	}
+
+	cfg := maps.Clone(src.config)
+	delete(cfg, "template")
+
+	for k := range cfg {
+		if strings.HasPrefix(k, "unused") {
+			delete(cfg, k)
+		}
+	}
+
+	if kind == guestQEMU {
+		cfg["vmgenid"] = uuid()
+		cfg["smbios1"] = "uuid=" + uuid()
+
+		if name := p["name"]; name != "" {
+			cfg["name"] = name
+		} else {
+			cfg["name"] = "Copy-of-VM-" + src.name()
+		}
+	} else if hostname := p["hostname"]; hostname != "" {
+		cfg["hostname"] = hostname
+	}
+
+	if desc, ok := p["description"]; ok {
+		cfg["description"] = desc
+	}
+
+	dst := &guest{vmid: newID, node: targetNode, kind: kind, status: "stopped", config: cfg}
+
+	// clone every disk into a new volume of the same size, on the requested storage if any
+	for k, v := range cfg {
+		if !dst.isDiskKey(k) || strings.Contains(v, "media=cdrom") || v == "none" {
+			continue
+		}
+
+		storageID, _, _ := strings.Cut(v, ":")
+		if p["storage"] != "" {
+			storageID = p["storage"]
+		}
+
+		_, opts, _ := strings.Cut(v, ",")
+		cfg[k] = fmt.Sprintf("%s:%s", storageID, strconv.FormatFloat(float64(diskSize(v))/(1<<30), 'f', -1, 64))
+
+		if opts != "" {
+			cfg[k] += "," + opts
+		}
+	}
+
+	if err = st.allocateVolumes(dst, cfg); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	cfg["lock"] = "clone"
+	st.guests[newID] = dst
+
+	if poolID != "" {
+		st.pools[poolID].vms[newID] = true
+	}
+
+	t := st.startTask(src.node, kind.taskPrefix()+"clone", strconv.Itoa(src.vmid), src, func() {
+		delete(dst.config, "lock")
+	})
+	t.onFail = func() { st.removeGuest(dst) }
+
+	writeData(w, t.upid)
+}
+
+func (s *Server) migrateGuest(w http.ResponseWriter, r *http.Request, kind guestType) {
+	st := s.state
+	p := params(r)
+
+	g, err := st.guest(r.PathValue("node"), kind, r.PathValue("vmid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	target := p["target"]
+	if target == "" {
+		writeAPIError(w, paramError("target", "property is missing and it is not optional"))
+
+		return
+	}
+
+	if _, err = st.node(target); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if target == g.node {
+		writeAPIError(w, errorf(http.StatusInternalServerError, "target is local node."))
+
+		return
+	}
+
+	if g.status == "running" && p["online"] != "1" && p["restart"] != "1" {
+		writeAPIError(w, errorf(http.StatusInternalServerError,
+			"can't migrate running %s without --online", g.label()))
+
+		return
+	}
+
+	if err = st.checkUnlocked(g); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	targetStorage := p["targetstorage"]
+	if kind == guestLXC {
+		targetStorage = p["target-storage"]
+	}
+
+	// target storages are either a single storage ID or a list of `source:target` mappings
+	for m := range strings.SplitSeq(targetStorage, ",") {
+		if m == "" || m == "1" {
+			continue
+		}
+
+		_, dst, found := strings.Cut(m, ":")
+		if !found {
+			dst = m
+		}
+
+		if _, err = st.storageOnNode(target, dst); err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+	}
+
+	source := g.node
+
+	// live migration of local disks must be requested explicitly
+	if kind == guestQEMU && g.status == "running" && p["with-local-disks"] != "1" {
+		for _, v := range st.volumes {
+			if v.vmid == g.vmid && v.node == source && v.content == "images" {
+				writeAPIError(w, errorf(http.StatusInternalServerError,
+					"can't live migrate attached local disks without with-local-disks option"))
+
+				return
+			}
+		}
+	}
+
+	g.config["lock"] = "migrate"
+
+	taskType := "qmigrate"
+	if kind == guestLXC {
+		taskType = "vzmigrate"
+	}
+
+	var t *task
+
+	t = st.startTask(source, taskType, strconv.Itoa(g.vmid), g, func() {
+		delete(g.config, "lock")
+
+		st.moveGuest(g, target)
+		t.log = append(t.log, "migration finished successfully")
+	})
+
+	t.log = append(t.log, fmt.Sprintf("starting migration of %s %d to node '%s'", g.label(), g.vmid, target))
+
+	writeData(w, t.upid)
+}
+
+// moveGuest moves the guest to the target node. Local volumes move along with the guest,
+// shared ones stay where they are.
+func (s *state) moveGuest(g *guest, target string) {
+	for k, v := range s.volumes {
+		if v.vmid == g.vmid && v.node == g.node {
+			delete(s.volumes, k)
+
+			v.node = target
+			s.volumes[v.node+"/"+v.volid] = v
+		}
+	}
+
+	g.node = target
+}
+
+func (s *Server) resizeGuestDisk(w http.ResponseWriter, r *http.Request, kind guestType) {
+	st := s.state
+	p := params(r)
+
+	g, err := st.guest(r.PathValue("node"), kind, r.PathValue("vmid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	disk := p["disk"]
+
+	value, ok := g.config[disk]
+	if !ok || !g.isDiskKey(disk) {
+		writeAPIError(w, errorf(http.StatusInternalServerError, "disk '%s' does not exist", disk))
+
+		return
+	}
+
+	if err = st.checkUnlocked(g); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	current := diskSize(value)
+
+	sizeParam, grow := strings.CutPrefix(p["size"], "+")
+
+	size, perr := parseSize(sizeParam, 1)
+	if perr != nil {
+		writeAPIError(w, paramError("size", "value does not match the regex pattern"))
+
+		return
+	}
+
+	if grow {
+		size += current
+	}
+
+	if size < current {
+		writeError(w, http.StatusInternalServerError, "shrinking disks is not supported")
+
+		return
+	}
+
+	t := st.startTask(g.node, "resize", strconv.Itoa(g.vmid), g, func() {
+		g.config[disk] = setDiskSize(g.config[disk], size)
+
+		volid, _, _ := strings.Cut(value, ",")
+		for _, v := range st.volumes {
+			if v.volid == volid {
+				v.size = size
+			}
+		}
+	})
+
+	writeData(w, t.upid)
+}
+
+// moveGuestDisk moves a VM disk to another storage. The source volume is deleted with `delete`,
+// otherwise it is kept as an unused disk.
+func (s *Server) moveGuestDisk(w http.ResponseWriter, r *http.Request) {
+	st := s.state
+	p := params(r)
+
+	g, err := st.guest(r.PathValue("node"), guestQEMU, r.PathValue("vmid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	disk := p["disk"]
+
+	value, ok := g.config[disk]
+	if !ok || !g.isDiskKey(disk) {
+		writeAPIError(w, errorf(http.StatusInternalServerError, "disk '%s' does not exist", disk))
+
+		return
+	}
+
+	target, err := st.storageOnNode(g.node, p["storage"])
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if !slices.Contains(target.content, "images") {
+		writeAPIError(w, errorf(http.StatusInternalServerError,
+			"storage '%s' does not support content-type 'images'", target.id))
+
+		return
+	}
+
+	volid, opts := splitDiskValue(value)
+	if storageID, _, _ := strings.Cut(volid, ":"); storageID == target.id {
+		writeError(w, http.StatusInternalServerError, "you can't move to the same storage with same format")
+
+		return
+	}
+
+	if err = st.checkUnlocked(g); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	t := st.startTask(g.node, "qmmove", strconv.Itoa(g.vmid), g, func() {
+		moved := st.allocateDisk(target, g.node, g.vmid, diskSize(value), "images")
+		if opts != "" {
+			moved += "," + opts
+		}
+
+		g.config[disk] = moved
+
+		if p["delete"] == "1" {
+			for k, v := range st.volumes {
+				if v.volid == volid && v.vmid == g.vmid {
+					delete(st.volumes, k)
+				}
+			}
+		} else {
+			g.config[nextUnusedKey(g.config)] = volid
+		}
+	})
+
+	writeData(w, t.upid)
+}
+
+func (s *Server) convertToTemplate(w http.ResponseWriter, r *http.Request, kind guestType) {
+	st := s.state
+
+	g, err := st.guest(r.PathValue("node"), kind, r.PathValue("vmid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if g.status == "running" {
+		writeError(w, http.StatusInternalServerError, "you can't convert a running VM to a template")
+
+		return
+	}
+
+	if err = st.checkUnlocked(g); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if kind == guestLXC {
+		// LXC template conversion is synchronous
+		g.config["template"] = "1"
+
+		writeData(w, nil)
+
+		return
+	}
+
+	t := st.startTask(g.node, "qmtemplate", strconv.Itoa(g.vmid), g, func() {
+		g.config["template"] = "1"
+	})
+
+	writeData(w, t.upid)
+}
+
+func uuid() string {
+	h := randomHex(16)
+
+	return fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:32])
+}
@@ -0,0 +1,125 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// WithHAManager activates the HA cluster resource manager (CRM), as if the cluster had HA
+// resources. Without it, the manager is idle and reports an empty status.
+func WithHAManager() Option {
+	return func(s *Server) {
+		s.state.haActive = true
+	}
+}
+
+func (s *Server) registerHA(mux *http.ServeMux) {
+	base := basePath + "/cluster/ha/status"
+
+	mux.HandleFunc("GET "+base+"/manager_status", s.guard(s.getHAManagerStatus))
+	mux.HandleFunc("GET "+base+"/current", s.guard(s.listHACurrent))
+}
+
+// SetNodeMaintenance enables or disables the HA maintenance mode of a node, like
+// `ha-manager crm-command node-maintenance` does. It has no effect while the HA manager is idle.
+func (s *Server) SetNodeMaintenance(nodeName string, enable bool) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	if n, ok := s.state.nodes[nodeName]; ok && s.state.haActive {
+		n.maintenance = enable
+	}
+}
+
+// haNodeState returns the state of the node reported by the HA manager.
+func (n *node) haNodeState() string {
+	switch {
+	case n.maintenance:
+		return "maintenance"
+	case n.offline:
+		return "unknown"
+	default:
+		return "online"
+	}
+}
+
+func (s *Server) getHAManagerStatus(w http.ResponseWriter, _ *http.Request) {
+	st := s.state
+	names := slices.Sorted(maps.Keys(st.nodes))
+
+	status := map[string]any{}
+
+	if st.haActive {
+		nodeStatus := map[string]string{}
+		for _, name := range names {
+			nodeStatus[name] = st.nodes[name].haNodeState()
+		}
+
+		status = map[string]any{
+			"master_node":    names[0],
+			"node_status":    nodeStatus,
+			"service_status": map[string]any{},
+			"timestamp":      time.Now().Unix(),
+		}
+	}
+
+	writeData(w, map[string]any{
+		"manager_status": status,
+		"quorum":         map[string]any{"node": names[0], "quorate": boolInt(st.quorate())},
+	})
+}
+
+func (s *Server) listHACurrent(w http.ResponseWriter, _ *http.Request) {
+	st := s.state
+	names := slices.Sorted(maps.Keys(st.nodes))
+	now := time.Now()
+	stamp := now.UTC().Format(time.ANSIC)
+
+	res := []map[string]any{{
+		"id":      "quorum",
+		"type":    "quorum",
+		"node":    names[0],
+		"quorate": boolInt(st.quorate()),
+		"status":  "OK",
+	}}
+
+	if st.haActive {
+		res = append(res, map[string]any{
+			"id":        "master",
+			"type":      "master",
+			"node":      names[0],
+			"status":    fmt.Sprintf("%s (active, %s)", names[0], stamp),
+			"timestamp": now.Unix(),
+		})
+	}
+
+	for _, name := range names {
+		mode := "idle"
+
+		switch {
+		case st.nodes[name].maintenance:
+			mode = "maintenance mode"
+		case st.haActive:
+			mode = "active"
+		}
+
+		res = append(res, map[string]any{
+			"id":        "lrm:" + name,
+			"type":      "lrm",
+			"node":      name,
+			"status":    fmt.Sprintf("%s (%s, %s)", name, mode, stamp),
+			"timestamp": now.Unix(),
+		})
+	}
+
+	writeData(w, res)
+}
@@ -0,0 +1,153 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+)
+
+const (
+	nodeCPUs   = 8
+	nodeMemory = int64(32 << 30)
+)
+
+func (s *Server) registerNodes(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+basePath+"/nodes", s.guard(s.listNodes))
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/status", s.guard(s.getNodeStatus))
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/version", s.guard(s.getNodeVersion))
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/time", s.guard(s.getNodeTime))
+	mux.HandleFunc("POST "+basePath+"/nodes/{node}/vzdump", s.guard(s.vzdump))
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/vzdump/extractconfig", s.guard(s.extractBackupConfig))
+	mux.HandleFunc("POST "+basePath+"/nodes/{node}/status", s.guard(s.nodePower))
+	mux.HandleFunc("POST "+basePath+"/nodes/{node}/wakeonlan", s.guard(s.wakeNode))
+	mux.HandleFunc("POST "+basePath+"/nodes/{node}/startall", s.guard(s.startAllGuests))
+	mux.HandleFunc("POST "+basePath+"/nodes/{node}/stopall", s.guard(s.stopAllGuests))
+	mux.HandleFunc("POST "+basePath+"/nodes/{node}/migrateall", s.guard(s.migrateAllGuests))
+}
+
+// SetNodeOnline sets whether the node is reported as online by the node lists and the cluster
+// status. The cluster loses its quorum when half of the nodes or more are offline.
+func (s *Server) SetNodeOnline(nodeName string, online bool) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	if n, ok := s.state.nodes[nodeName]; ok {
+		n.offline = !online
+	}
+}
+
+// nodeMemoryUsed returns the memory used by the running guests of the node.
+func (s *state) nodeMemoryUsed(nodeName string) int64 {
+	var used int64
+
+	for _, g := range s.guests {
+		if g.node == nodeName && g.status == "running" {
+			used += g.maxMem()
+		}
+	}
+
+	return used
+}
+
+func (s *Server) listNodes(w http.ResponseWriter, _ *http.Request) {
+	res := []map[string]any{}
+
+	for _, name := range slices.Sorted(maps.Keys(s.state.nodes)) {
+		res = append(res, map[string]any{
+			"node":    name,
+			"id":      "node/" + name,
+			"type":    "node",
+			"status":  s.state.nodes[name].status(),
+			"level":   "",
+			"maxcpu":  nodeCPUs,
+			"cpu":     0.01,
+			"maxmem":  nodeMemory,
+			"mem":     s.state.nodeMemoryUsed(name),
+			"maxdisk": int64(100 << 30),
+			"disk":    int64(10 << 30),
+			"uptime":  s.state.nodes[name].uptime(),
+		})
+	}
+
+	writeData(w, res)
+}
+
+func (s *Server) getNodeStatus(w http.ResponseWriter, r *http.Request) {
+	nodeName := r.PathValue("node")
+
+	n, err := s.state.node(nodeName)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if n.offline {
+		writeAPIError(w, errNodeUnreachable(nodeName))
+
+		return
+	}
+
+	used := s.state.nodeMemoryUsed(nodeName)
+
+	writeData(w, map[string]any{
+		"uptime":     n.uptime(),
+		"cpu":        0.01,
+		"loadavg":    []string{"0.01", "0.02", "0.00"},
+		"pveversion": "pve-manager/" + s.state.version,
+		"kversion":   "Linux 6.14.8-2-pve",
+		"cpuinfo": map[string]any{
+			"cpus":    nodeCPUs,
+			"cores":   nodeCPUs,
+			"sockets": 1,
+			"model":   "Fake CPU",
+		},
+		"memory": map[string]any{
+			"total": nodeMemory,
+			"used":  used,
+			"free":  nodeMemory - used,
+		},
+		"rootfs": map[string]any{
+			"total": int64(100 << 30),
+			"used":  int64(10 << 30),
+			"avail": int64(90 << 30),
+		},
+	})
+}
+
+func (s *Server) getNodeVersion(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.state.node(r.PathValue("node")); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	writeData(w, map[string]any{
+		"release": s.state.version,
+		"repoid":  "fake",
+		"version": s.state.version,
+	})
+}
+
+func (s *Server) getNodeTime(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.state.node(r.PathValue("node")); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	now := time.Now()
+
+	writeData(w, map[string]any{
+		"localtime": now.Unix(),
+		"time":      now.Unix(),
+		"timezone":  "UTC",
+	})
+}
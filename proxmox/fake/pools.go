@@ -0,0 +1,241 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+func (s *Server) registerPools(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+basePath+"/pools", s.guard(s.listPools))
+	mux.HandleFunc("POST "+basePath+"/pools", s.guard(s.createPool))
+	mux.HandleFunc("PUT "+basePath+"/pools", s.guard(s.updatePool))
+	mux.HandleFunc("DELETE "+basePath+"/pools", s.guard(s.deletePool))
+
+	// pre PVE 8.1 endpoints, with the pool ID in the path
+	mux.HandleFunc("GET "+basePath+"/pools/{poolid}", s.guard(s.getPoolLegacy))
+	mux.HandleFunc("PUT "+basePath+"/pools/{poolid}", s.guard(s.updatePool))
+	mux.HandleFunc("DELETE "+basePath+"/pools/{poolid}", s.guard(s.deletePool))
+}
+
+// poolID returns the pool ID from the path or the `poolid` parameter.
+func poolID(r *http.Request) string {
+	if id := r.PathValue("poolid"); id != "" {
+		return id
+	}
+
+	return params(r)["poolid"]
+}
+
+func (s *state) pool(id string) (*pool, *apiError) {
+	p, ok := s.pools[id]
+	if !ok {
+		return nil, errorf(http.StatusInternalServerError, "pool '%s' does not exist", id)
+	}
+
+	return p, nil
+}
+
+func (s *state) poolMembers(p *pool) []map[string]any {
+	members := []map[string]any{}
+
+	for _, vmid := range slices.Sorted(maps.Keys(p.vms)) {
+		g, ok := s.guests[vmid]
+		if !ok {
+			continue
+		}
+
+		members = append(members, map[string]any{
+			"id":     string(g.kind) + "/" + strconv.Itoa(vmid),
+			"node":   g.node,
+			"type":   string(g.kind),
+			"vmid":   vmid,
+			"name":   g.name(),
+			"status": g.status,
+		})
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(p.storages)) {
+		for _, n := range slices.Sorted(maps.Keys(s.nodes)) {
+			st, ok := s.storages[id]
+			if !ok || (len(st.nodes) > 0 && !slices.Contains(st.nodes, n)) {
+				continue
+			}
+
+			members = append(members, map[string]any{
+				"id":      "storage/" + n + "/" + id,
+				"node":    n,
+				"type":    "storage",
+				"storage": id,
+			})
+		}
+	}
+
+	return members
+}
+
+func (s *Server) listPools(w http.ResponseWriter, r *http.Request) {
+	if id := params(r)["poolid"]; id != "" {
+		p, err := s.state.pool(id)
+		if err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		writeData(w, []map[string]any{{
+			"poolid":  p.id,
+			"comment": p.comment,
+			"members": s.state.poolMembers(p),
+		}})
+
+		return
+	}
+
+	res := []map[string]any{}
+
+	for _, id := range slices.Sorted(maps.Keys(s.state.pools)) {
+		res = append(res, map[string]any{"poolid": id, "comment": s.state.pools[id].comment})
+	}
+
+	writeData(w, res)
+}
+
+func (s *Server) getPoolLegacy(w http.ResponseWriter, r *http.Request) {
+	p, err := s.state.pool(r.PathValue("poolid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	writeData(w, map[string]any{
+		"comment": p.comment,
+		"members": s.state.poolMembers(p),
+	})
+}
+
+func (s *Server) createPool(w http.ResponseWriter, r *http.Request) {
+	p := params(r)
+
+	id := p["poolid"]
+	if id == "" {
+		writeAPIError(w, paramError("poolid", "property is missing and it is not optional"))
+
+		return
+	}
+
+	if _, ok := s.state.pools[id]; ok {
+		writeAPIError(w, errorf(http.StatusInternalServerError, "create failed - pool '%s' already exists", id))
+
+		return
+	}
+
+	s.state.pools[id] = &pool{
+		id:       id,
+		comment:  p["comment"],
+		vms:      map[int]bool{},
+		storages: map[string]bool{},
+	}
+
+	writeData(w, nil)
+}
+
+func (s *Server) updatePool(w http.ResponseWriter, r *http.Request) {
+	st := s.state
+
+	pl, err := st.pool(poolID(r))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	p := params(r)
+	remove := p["delete"] == "1"
+
+	vmids := []int{}
+
+	for _, v := range splitList(p["vms"]) {
+		vmid, cerr := strconv.Atoi(v)
+
+		g, ok := st.guests[vmid]
+		if cerr != nil || !ok {
+			writeAPIError(w, errorf(http.StatusInternalServerError, "no such VMID '%s'", v))
+
+			return
+		}
+
+		if other := st.poolOf(vmid); !remove && other != "" && other != pl.id && p["allow-move"] != "1" {
+			writeAPIError(w, errorf(http.StatusInternalServerError,
+				"%s %d belongs already to pool '%s' and allow-move is not set", g.label(), vmid, other))
+
+			return
+		}
+
+		vmids = append(vmids, vmid)
+	}
+
+	storages := splitList(p["storage"])
+	for _, id := range storages {
+		if _, ok := st.storages[id]; !ok {
+			writeAPIError(w, errorf(http.StatusInternalServerError, "storage '%s' does not exist", id))
+
+			return
+		}
+	}
+
+	if c, ok := p["comment"]; ok {
+		pl.comment = c
+	}
+
+	for _, vmid := range vmids {
+		if remove {
+			delete(pl.vms, vmid)
+
+			continue
+		}
+
+		for _, other := range st.pools {
+			delete(other.vms, vmid)
+		}
+
+		pl.vms[vmid] = true
+	}
+
+	for _, id := range storages {
+		if remove {
+			delete(pl.storages, id)
+		} else {
+			pl.storages[id] = true
+		}
+	}
+
+	writeData(w, nil)
+}
+
+func (s *Server) deletePool(w http.ResponseWriter, r *http.Request) {
+	pl, err := s.state.pool(poolID(r))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if len(pl.vms) > 0 || len(pl.storages) > 0 {
+		writeAPIError(w, errorf(http.StatusInternalServerError, "delete pool failed - pool '%s' is not empty", pl.id))
+
+		return
+	}
+
+	delete(s.state.pools, pl.id)
+
+	writeData(w, nil)
+}
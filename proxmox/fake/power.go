@@ -0,0 +1,237 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// errNodeUnreachable is the error of the requests proxied to an offline node.
+func errNodeUnreachable(nodeName string) *apiError {
+	return errorf(595, "Connection refused - node '%s' is unreachable", nodeName)
+}
+
+// onlineNode returns the node if it is online, as requests to an offline node cannot be proxied.
+func (s *state) onlineNode(name string) (*node, *apiError) {
+	n, err := s.node(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.offline {
+		return nil, errNodeUnreachable(name)
+	}
+
+	return n, nil
+}
+
+// nodeGuests returns the guests of the node, filtered by a comma-separated list of IDs.
+func (s *state) nodeGuests(nodeName, vms string) []*guest {
+	ids := splitList(vms)
+
+	var res []*guest
+
+	for _, g := range s.guests {
+		if g.node == nodeName && (len(ids) == 0 || slices.Contains(ids, strconv.Itoa(g.vmid))) {
+			res = append(res, g)
+		}
+	}
+
+	slices.SortFunc(res, func(a, b *guest) int { return a.vmid - b.vmid })
+
+	return res
+}
+
+// nodeMAC returns a stable MAC address for the Wake-on-LAN of the node.
+func nodeMAC(nodeName string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(nodeName))
+	sum := h.Sum32()
+
+	return fmt.Sprintf("52:54:00:%02x:%02x:%02x", byte(sum>>16), byte(sum>>8), byte(sum))
+}
+
+// nodePower reboots or shuts down the node. Both happen immediately: a rebooted node is back
+// online with its `onboot` guests started, a node that was shut down is offline.
+func (s *Server) nodePower(w http.ResponseWriter, r *http.Request) {
+	st := s.state
+	nodeName := r.PathValue("node")
+
+	n, err := st.onlineNode(nodeName)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	command := params(r)["command"]
+	if command != "reboot" && command != "shutdown" {
+		writeAPIError(w, paramError("command", "value '%s' does not have a value in the enumeration "+
+			"'reboot, shutdown'", command))
+
+		return
+	}
+
+	for _, g := range st.nodeGuests(nodeName, "") {
+		g.status = "stopped"
+		g.frozen = false
+
+		if command == "reboot" && g.config["onboot"] == "1" {
+			g.status = "running"
+			g.started = time.Now()
+		}
+	}
+
+	if command == "shutdown" {
+		n.offline = true
+	} else {
+		n.booted = time.Now()
+	}
+
+	writeData(w, nil)
+}
+
+// wakeNode boots an offline node immediately.
+func (s *Server) wakeNode(w http.ResponseWriter, r *http.Request) {
+	nodeName := r.PathValue("node")
+
+	n, err := s.state.node(nodeName)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if n.offline {
+		n.offline = false
+		n.booted = time.Now()
+	}
+
+	writeData(w, nodeMAC(nodeName))
+}
+
+// startAllGuests starts the stopped guests of the node that are started on boot, or all of them
+// with `force`.
+func (s *Server) startAllGuests(w http.ResponseWriter, r *http.Request) {
+	st := s.state
+	nodeName := r.PathValue("node")
+
+	if _, err := st.onlineNode(nodeName); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	p := params(r)
+
+	var guests []*guest
+
+	for _, g := range st.nodeGuests(nodeName, p["vms"]) {
+		if g.status != "running" && (p["force"] == "1" || g.config["onboot"] == "1") {
+			guests = append(guests, g)
+		}
+	}
+
+	var t *task
+
+	t = st.startTask(nodeName, "startall", "", nil, func() {
+		for _, g := range guests {
+			g.status = "running"
+			g.started = time.Now()
+			t.log = append(t.log, fmt.Sprintf("Starting %s %d", g.label(), g.vmid))
+		}
+	})
+
+	writeData(w, t.upid)
+}
+
+// stopAllGuests stops the running guests of the node.
+func (s *Server) stopAllGuests(w http.ResponseWriter, r *http.Request) {
+	st := s.state
+	nodeName := r.PathValue("node")
+
+	if _, err := st.onlineNode(nodeName); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	guests := st.nodeGuests(nodeName, params(r)["vms"])
+
+	var t *task
+
+	t = st.startTask(nodeName, "stopall", "", nil, func() {
+		for _, g := range guests {
+			if g.status == "running" {
+				g.status = "stopped"
+				g.frozen = false
+				t.log = append(t.log, fmt.Sprintf("Stopping %s %d", g.label(), g.vmid))
+			}
+		}
+	})
+
+	writeData(w, t.upid)
+}
+
+// migrateAllGuests migrates the guests of the node to the target node.
+func (s *Server) migrateAllGuests(w http.ResponseWriter, r *http.Request) {
+	st := s.state
+	nodeName := r.PathValue("node")
+
+	if _, err := st.onlineNode(nodeName); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	p := params(r)
+
+	target := p["target"]
+	if target == "" {
+		writeAPIError(w, paramError("target", "property is missing and it is not optional"))
+
+		return
+	}
+
+	if _, err := st.onlineNode(target); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if target == nodeName {
+		writeAPIError(w, errorf(http.StatusInternalServerError, "target is local node."))
+
+		return
+	}
+
+	if m := p["maxworkers"]; m != "" {
+		if n, convErr := strconv.Atoi(m); convErr != nil || n < 1 {
+			writeAPIError(w, paramError("maxworkers", "value must have a minimum value of 1"))
+
+			return
+		}
+	}
+
+	guests := st.nodeGuests(nodeName, p["vms"])
+
+	var t *task
+
+	t = st.startTask(nodeName, "migrateall", "", nil, func() {
+		for _, g := range guests {
+			st.moveGuest(g, target)
+			t.log = append(t.log, fmt.Sprintf("Migrating %s %d to node '%s'", g.label(), g.vmid, target))
+		}
+	})
+
+	writeData(w, t.upid)
+}
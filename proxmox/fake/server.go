@@ -0,0 +1,291 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package fake implements a stateful, in-memory Proxmox VE API server for unit tests.
+//
+// The server covers the commonly used endpoints: version, nodes, QEMU and LXC guests
+// (config, status, lifecycle), storage and storage content, tasks, pools, users, ACLs and
+// cluster resources. Asynchronous operations return UPIDs and run as tasks that hold the
+// guest lock until they finish, so conflicting operations fail like they do on a real node.
+//
+// It is intended as an executable definition of the API contract the provider relies on,
+// not as a complete emulation of Proxmox VE.
+package fake
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+const (
+	// DefaultNode is the name of the node the server starts with.
+	DefaultNode = "pve"
+
+	// DefaultVersion is the Proxmox VE version reported by the server.
+	DefaultVersion = "9.0.3"
+
+	// APIToken is an API token accepted by the server.
+	APIToken = "root@pam!fake=00000000-0000-0000-0000-000000000000"
+
+	basePath = "/api2/json"
+)
+
+// Option configures a Server.
+type Option func(s *Server)
+
+// WithNodes replaces the default node with the given nodes.
+func WithNodes(names ...string) Option {
+	return func(s *Server) {
+		s.state.nodes = map[string]*node{}
+
+		for _, n := range names {
+			s.state.addNode(n)
+		}
+	}
+}
+
+// WithVersion sets the Proxmox VE version reported by the server.
+func WithVersion(version string) Option {
+	return func(s *Server) {
+		s.state.version = version
+	}
+}
+
+// WithStorage adds a storage of the given type (e.g. `pbs` or `nfs`) that is available on all nodes.
+func WithStorage(id, kind string, shared bool, content ...string) Option {
+	return func(s *Server) {
+		s.state.storages[id] = &storage{
+			id:      id,
+			kind:    kind,
+			content: slices.Sorted(slices.Values(content)),
+			shared:  shared,
+			total:   100 << 30,
+		}
+	}
+}
+
+// WithTaskDuration sets how long tasks keep running before they finish. Guests stay
+// locked while their tasks are running. Defaults to zero, i.e. tasks finish immediately.
+func WithTaskDuration(d time.Duration) Option {
+	return func(s *Server) {
+		s.state.taskDuration = d
+	}
+}
+
+// Server is an in-memory Proxmox VE API server.
+type Server struct {
+	*httptest.Server
+
+	state *state
+}
+
+// NewServer starts a new fake Proxmox VE API server over TLS with a default node, storages
+// and the `root@pam` user. The caller must Close it.
+func NewServer(opts ...Option) *Server {
+	s := &Server{state: newState()}
+
+	for _, o := range opts {
+		o(s)
+	}
+
+	s.Server = httptest.NewTLSServer(s.Handler())
+
+	return s
+}
+
+// Handler returns the HTTP handler of the fake API, e.g. to serve it on a custom listener.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	s.registerAccess(mux)
+	s.registerCluster(mux)
+	s.registerHA(mux)
+	s.registerNodes(mux)
+	s.registerServices(mux)
+	s.registerGuests(mux)
+	s.registerAgent(mux)
+	s.registerConsole(mux)
+	s.registerStorage(mux)
+	s.registerPools(mux)
+	s.registerTasks(mux)
+
+	mux.HandleFunc("GET "+basePath+"/version", s.guard(s.getVersion))
+
+	return s.authenticate(trimTrailingSlash(mux))
+}
+
+// Client returns an API client for the server, authenticated with APIToken.
+func (s *Server) Client() (api.Client, error) {
+	creds, err := api.NewCredentials("", "", "", APIToken, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credentials: %w", err)
+	}
+
+	conn, err := api.NewConnection(s.URL, true, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection: %w", err)
+	}
+
+	client, err := api.NewClient(creds, conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return client, nil
+}
+
+// trimTrailingSlash drops a trailing slash from the request path, which Proxmox VE ignores
+// but the mux patterns do not.
+func trimTrailingSlash(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.URL.Path) > 1 && strings.HasSuffix(r.URL.Path, "/") {
+			r.URL.Path = strings.TrimRight(r.URL.Path, "/")
+			r.URL.RawPath = strings.TrimRight(r.URL.RawPath, "/")
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authenticate rejects requests without an API token or ticket, except for ticket creation.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == basePath+"/access/ticket" {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if strings.HasPrefix(r.Header.Get("Authorization"), "PVEAPIToken=") {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if c, err := r.Cookie("PVEAuthCookie"); err == nil && s.state.validTicket(c.Value) {
+			if r.Method != http.MethodGet && r.Header.Get("CSRFPreventionToken") == "" {
+				writeError(w, http.StatusUnauthorized, "permission denied - invalid csrf token")
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		writeError(w, http.StatusUnauthorized, "authentication failure")
+	})
+}
+
+func (s *Server) getVersion(w http.ResponseWriter, _ *http.Request) {
+	parts := strings.SplitN(s.state.version, ".", 3)
+
+	release := parts[0]
+	if len(parts) > 1 {
+		release += "." + parts[1]
+	}
+
+	writeData(w, map[string]any{
+		"release": release,
+		"repoid":  "fake",
+		"version": s.state.version,
+	})
+}
+
+// apiError is an error with an HTTP status code, returned by state operations.
+type apiError struct {
+	code    int
+	message string
+	params  map[string]string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func errorf(code int, format string, args ...any) *apiError {
+	return &apiError{code: code, message: fmt.Sprintf(format, args...)}
+}
+
+func paramError(param, format string, args ...any) *apiError {
+	return &apiError{
+		code:    http.StatusBadRequest,
+		message: "Parameter verification failed.",
+		params:  map[string]string{param: fmt.Sprintf(format, args...)},
+	}
+}
+
+func writeData(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeAPIError(w, &apiError{code: code, message: message})
+}
+
+func writeAPIError(w http.ResponseWriter, err *apiError) {
+	body := map[string]any{"data": nil, "message": err.message + "\n"}
+	if err.params != nil {
+		body["errors"] = err.params
+	}
+
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(err.code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// respond writes data, or the error if it is not nil.
+func respond(w http.ResponseWriter, data any, err *apiError) {
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	writeData(w, data)
+}
+
+// params returns the request parameters, from the query string and form-encoded body.
+func params(r *http.Request) map[string]string {
+	_ = r.ParseForm()
+
+	p := make(map[string]string, len(r.Form))
+	for k, v := range r.Form {
+		p[k] = strings.Join(v, ",")
+	}
+
+	return p
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// guard serializes access to the state and finishes due tasks before a handler runs.
+func (s *Server) guard(h func(w http.ResponseWriter, r *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.state.mu.Lock()
+		defer s.state.mu.Unlock()
+
+		s.state.finishTasks(time.Now())
+
+		h(w, r)
+	}
+}
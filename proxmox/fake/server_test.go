@@ -0,0 +1,344 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/containers"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/pools"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+func newClient(t *testing.T, opts ...Option) (*Server, proxmox.Client) {
+	t.Helper()
+
+	s := NewServer(opts...)
+	t.Cleanup(s.Close)
+
+	c, err := s.Client()
+	require.NoError(t, err)
+
+	return s, proxmox.NewClient(c, nil, "")
+}
+
+func TestVersion(t *testing.T) {
+	t.Parallel()
+
+	_, c := newClient(t, WithVersion("8.4.1"))
+
+	v, err := c.Version().Version(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "8.4.1", v.Version.String())
+}
+
+func TestAuthentication(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	res, err := s.Server.Client().Do(func() *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, s.URL+basePath+"/version", nil)
+
+		return req
+	}())
+	require.NoError(t, err)
+
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
+
+func TestTicketAuthentication(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	conn, err := api.NewConnection(s.URL, true, "")
+	require.NoError(t, err)
+
+	creds, err := api.NewCredentials("root@pam", "password", "", "", "", "")
+	require.NoError(t, err)
+
+	c, err := api.NewClient(creds, conn)
+	require.NoError(t, err)
+
+	pc := proxmox.NewClient(c, nil, "")
+
+	require.NoError(t, pc.Pool().CreatePool(context.Background(), &pools.PoolCreateRequestBody{ID: "test"}))
+
+	list, err := pc.Pool().ListPools(context.Background())
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "test", list[0].ID)
+}
+
+func TestVMLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, c := newClient(t)
+
+	vm := c.Node(DefaultNode).VM(100)
+
+	require.NoError(t, vm.CreateVM(ctx, &vms.CreateRequestBody{
+		VMID:     100,
+		Name:     new("test"),
+		CPUCores: new(int64(2)),
+	}).Err())
+
+	_, err := vm.CreateVMAsync(ctx, &vms.CreateRequestBody{VMID: 100})
+	require.ErrorIs(t, err, api.ErrResourceAlreadyExists)
+
+	cfg, err := vm.GetVM(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, cfg.Name)
+	assert.Equal(t, "test", *cfg.Name)
+	require.NotNil(t, cfg.CPUCores)
+	assert.EqualValues(t, 2, *cfg.CPUCores)
+
+	require.NoError(t, vm.UpdateVM(ctx, &vms.UpdateRequestBody{Description: new("updated")}))
+
+	cfg, err = vm.GetVM(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, cfg.Description)
+	assert.Equal(t, "updated", *cfg.Description)
+
+	require.NoError(t, vm.StartVM(ctx, 30).Err())
+
+	status, err := vm.GetVMStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "running", status.Status)
+
+	require.NoError(t, vm.StopVM(ctx).Err())
+
+	status, err = vm.GetVMStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "stopped", status.Status)
+
+	require.NoError(t, vm.DeleteVM(ctx, true, true).Err())
+
+	_, err = vm.GetVM(ctx)
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+}
+
+func TestGuestLockedByRunningTask(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, c := newClient(t, WithTaskDuration(time.Hour))
+
+	raw, err := s.Client()
+	require.NoError(t, err)
+
+	vm := c.Node(DefaultNode).VM(100)
+
+	upid, err := vm.CreateVMAsync(ctx, &vms.CreateRequestBody{VMID: 100})
+	require.NoError(t, err)
+	require.NotNil(t, upid)
+
+	err = raw.DoRequest(ctx, http.MethodPut, "nodes/pve/qemu/100/config", &vms.UpdateRequestBody{
+		Description: new("locked"),
+	}, nil)
+	require.ErrorContains(t, err, "got timeout")
+
+	status, err := c.Node(DefaultNode).Tasks().GetTaskStatus(ctx, *upid)
+	require.NoError(t, err)
+	assert.Equal(t, "running", status.Status)
+
+	// stopping the task discards its effects
+	require.NoError(t, c.Node(DefaultNode).Tasks().DeleteTask(ctx, *upid))
+
+	_, err = vm.GetVM(ctx)
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+}
+
+func TestTaskFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, c := newClient(t)
+
+	vm := c.Node(DefaultNode).VM(100)
+	require.NoError(t, vm.CreateVM(ctx, &vms.CreateRequestBody{VMID: 100}).Err())
+
+	s.SetTaskResult("qmstart", "start failed: QEMU exited with code 1", "kvm: failed to initialize KVM")
+
+	err := vm.StartVM(ctx, 30).Err()
+	require.ErrorContains(t, err, "start failed")
+	require.ErrorContains(t, err, "failed to initialize KVM")
+
+	status, err := vm.GetVMStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "stopped", status.Status)
+
+	s.SetTaskResult("qmstart", "WARNINGS: 1", "WARN: something odd")
+
+	res := vm.StartVM(ctx, 30)
+	require.NoError(t, res.Err())
+	assert.True(t, res.HasWarnings())
+}
+
+func TestContainerLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, c := newClient(t)
+
+	tmpl, err := s.AddVolume(DefaultNode, "local", "vztmpl", "debian-12-standard_12.7-1_amd64.tar.zst", 1<<20)
+	require.NoError(t, err)
+
+	ct := c.Node(DefaultNode).Container(200)
+
+	require.NoError(t, ct.CreateContainer(ctx, &containers.CreateRequestBody{
+		VMID:                 new(200),
+		Hostname:             new("ct"),
+		OSTemplateFileVolume: &tmpl,
+	}).Err())
+
+	cfg, err := ct.GetContainer(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, cfg.Hostname)
+	assert.Equal(t, "ct", *cfg.Hostname)
+
+	require.NoError(t, ct.StartContainer(ctx).Err())
+
+	err = ct.DeleteContainer(ctx).Err()
+	require.ErrorContains(t, err, "is running")
+
+	require.NoError(t, ct.StopContainer(ctx))
+	require.NoError(t, ct.WaitForContainerStatus(ctx, "stopped"))
+	require.NoError(t, ct.DeleteContainer(ctx).Err())
+
+	_, err = ct.GetContainer(ctx)
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+}
+
+func TestStorageContent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, c := newClient(t)
+
+	iso, err := s.AddVolume(DefaultNode, "local", "iso", "test.iso", 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "local:iso/test.iso", iso)
+
+	st := c.Node(DefaultNode).Storage("local")
+
+	files, err := st.ListDatastoreFiles(ctx, new("iso"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, iso, files[0].VolumeID)
+	assert.EqualValues(t, 1024, files[0].FileSize)
+
+	require.NoError(t, st.DeleteDatastoreFile(ctx, iso))
+
+	files, err = st.ListDatastoreFiles(ctx, new("iso"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestPools(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, c := newClient(t)
+
+	require.NoError(t, c.Pool().CreatePool(ctx, &pools.PoolCreateRequestBody{ID: "prod", Comment: new("production")}))
+	require.ErrorIs(t, c.Pool().CreatePool(ctx, &pools.PoolCreateRequestBody{ID: "prod"}), api.ErrResourceAlreadyExists)
+
+	require.NoError(t, c.Node(DefaultNode).VM(100).CreateVM(ctx, &vms.CreateRequestBody{VMID: 100}).Err())
+
+	vmList := types.CustomCommaSeparatedList{"100"}
+	require.NoError(t, c.Pool().UpdatePool(ctx, "prod", &pools.PoolUpdateRequestBody{VMs: &vmList}))
+
+	p, err := c.Pool().GetPool(ctx, "prod")
+	require.NoError(t, err)
+	require.Len(t, p.Members, 1)
+	assert.Equal(t, "qemu/100", p.Members[0].ID)
+
+	require.ErrorContains(t, c.Pool().DeletePool(ctx, "prod"), "not empty")
+
+	_, err = c.Pool().GetPool(ctx, "missing")
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+}
+
+func TestUsersAndACL(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, c := newClient(t)
+
+	require.NoError(t, c.Access().CreateUser(ctx, &access.UserCreateRequestBody{
+		ID:      "alice@pve",
+		Comment: new("test user"),
+		Enabled: new(types.CustomBool(true)),
+	}))
+
+	u, err := c.Access().GetUser(ctx, "alice@pve")
+	require.NoError(t, err)
+	require.NotNil(t, u.Comment)
+	assert.Equal(t, "test user", *u.Comment)
+	require.NotNil(t, u.Enabled)
+	assert.True(t, bool(*u.Enabled))
+
+	require.NoError(t, c.Access().UpdateACL(ctx, &access.ACLUpdateRequestBody{
+		Path:  "/vms",
+		Roles: []string{"PVEVMUser"},
+		Users: []string{"alice@pve"},
+	}))
+
+	acl, err := c.Access().GetACL(ctx)
+	require.NoError(t, err)
+	require.Len(t, acl, 1)
+	assert.Equal(t, "alice@pve", acl[0].UserOrGroupID)
+	require.NotNil(t, acl[0].Propagate)
+	assert.True(t, bool(*acl[0].Propagate))
+
+	require.NoError(t, c.Access().DeleteUser(ctx, "alice@pve"))
+
+	acl, err = c.Access().GetACL(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, acl)
+}
+
+func TestClusterResources(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, c := newClient(t, WithNodes("pve1", "pve2"))
+
+	require.NoError(t, c.Node("pve2").VM(100).CreateVM(ctx, &vms.CreateRequestBody{VMID: 100}).Err())
+
+	node, err := c.Cluster().GetVMNodeName(ctx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, "pve2", *node)
+
+	id, err := c.Cluster().GetNextID(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 101, *id)
+
+	_, err = c.Cluster().GetNextID(ctx, new(100))
+	require.Error(t, err)
+
+	res, err := c.Cluster().GetClusterResources(ctx, "node")
+	require.NoError(t, err)
+	assert.Len(t, res, 2)
+}
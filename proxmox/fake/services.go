@@ -0,0 +1,159 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// service is a systemd service of a node that can be managed with the API.
+type service struct {
+	id      string
+	name    string
+	desc    string
+	running bool
+	enabled bool
+}
+
+// defaultServices returns the services of a new node. The IDs are the ones of the API, which
+// differ from the unit names for some services.
+func defaultServices() map[string]*service {
+	res := map[string]*service{}
+
+	for _, s := range []service{
+		{id: "chrony", name: "chrony", desc: "chrony, an NTP client/server", running: true, enabled: true},
+		{id: "corosync", name: "corosync", desc: "Corosync Cluster Engine", running: true, enabled: true},
+		{id: "cron", name: "cron", desc: "Regular background program processing daemon", running: true, enabled: true},
+		{id: "ksmtuned", name: "ksmtuned", desc: "Kernel Samepage Merging (KSM) Tuning Daemon"},
+		{id: "postfix", name: "postfix", desc: "Postfix Mail Transport Agent", running: true, enabled: true},
+		{id: "pve-cluster", name: "pve-cluster", desc: "The Proxmox VE cluster filesystem", running: true, enabled: true},
+		{id: "pve-firewall", name: "pve-firewall", desc: "Proxmox VE firewall", running: true, enabled: true},
+		{id: "pve-ha-crm", name: "pve-ha-crm", desc: "PVE Cluster HA Resource Manager Daemon", running: true, enabled: true},
+		{id: "pve-ha-lrm", name: "pve-ha-lrm", desc: "PVE Local HA Resource Manager Daemon", running: true, enabled: true},
+		{id: "pvedaemon", name: "pvedaemon", desc: "PVE API Daemon", running: true, enabled: true},
+		{id: "pveproxy", name: "pveproxy", desc: "PVE API Proxy Server", running: true, enabled: true},
+		{id: "pvestatd", name: "pvestatd", desc: "PVE Status Daemon", running: true, enabled: true},
+		{id: "spiceproxy", name: "spiceproxy", desc: "PVE SPICE Proxy Server", running: true, enabled: true},
+		{id: "sshd", name: "ssh", desc: "OpenBSD Secure Shell server", running: true, enabled: true},
+		{id: "syslog", name: "rsyslog", desc: "System Logging Service", running: true, enabled: true},
+	} {
+		res[s.id] = &s
+	}
+
+	return res
+}
+
+func (s *Server) registerServices(mux *http.ServeMux) {
+	base := basePath + "/nodes/{node}/services"
+
+	mux.HandleFunc("GET "+base, s.guard(s.listServices))
+	mux.HandleFunc("GET "+base+"/{service}/state", s.guard(s.getServiceState))
+
+	for _, command := range []string{"start", "stop", "restart", "reload"} {
+		mux.HandleFunc("POST "+base+"/{service}/"+command, s.guard(func(w http.ResponseWriter, r *http.Request) {
+			s.runServiceCommand(w, r, command)
+		}))
+	}
+}
+
+// SetServiceState sets whether a service of the node is running and enabled, e.g. to simulate
+// a crashed service.
+func (s *Server) SetServiceState(nodeName, serviceID string, running, enabled bool) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	if n, ok := s.state.nodes[nodeName]; ok {
+		if svc, ok := n.services[serviceID]; ok {
+			svc.running = running
+			svc.enabled = enabled
+		}
+	}
+}
+
+func (svc *service) state() map[string]any {
+	state, activeState, unitState := "stopped", "inactive", "disabled"
+	if svc.running {
+		state, activeState = "running", "active"
+	}
+
+	if svc.enabled {
+		unitState = "enabled"
+	}
+
+	return map[string]any{
+		"service":      svc.id,
+		"name":         svc.name,
+		"desc":         svc.desc,
+		"state":        state,
+		"active-state": activeState,
+		"unit-state":   unitState,
+	}
+}
+
+// nodeService returns the service of an online node.
+func (s *state) nodeService(nodeName, serviceID string) (*service, *apiError) {
+	n, err := s.onlineNode(nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, ok := n.services[serviceID]
+	if !ok {
+		return nil, paramError("service", "value '%s' does not have a value in the enumeration '%s'",
+			serviceID, strings.Join(slices.Sorted(maps.Keys(n.services)), ", "))
+	}
+
+	return svc, nil
+}
+
+func (s *Server) listServices(w http.ResponseWriter, r *http.Request) {
+	n, err := s.state.onlineNode(r.PathValue("node"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	res := []map[string]any{}
+
+	for _, id := range slices.Sorted(maps.Keys(n.services)) {
+		res = append(res, n.services[id].state())
+	}
+
+	writeData(w, res)
+}
+
+func (s *Server) getServiceState(w http.ResponseWriter, r *http.Request) {
+	svc, err := s.state.nodeService(r.PathValue("node"), r.PathValue("service"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	writeData(w, svc.state())
+}
+
+func (s *Server) runServiceCommand(w http.ResponseWriter, r *http.Request, command string) {
+	nodeName := r.PathValue("node")
+
+	svc, err := s.state.nodeService(nodeName, r.PathValue("service"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	t := s.state.startTask(nodeName, "srv"+command, svc.id, nil, func() {
+		svc.running = command != "stop"
+	})
+
+	writeData(w, t.upid)
+}
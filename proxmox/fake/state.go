@@ -0,0 +1,503 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// guestType is the API path segment of a guest type.
+type guestType string
+
+const (
+	guestQEMU guestType = "qemu"
+	guestLXC  guestType = "lxc"
+)
+
+// configDir returns the directory of the guest config files, used in error messages.
+func (t guestType) configDir() string {
+	if t == guestLXC {
+		return "lxc"
+	}
+
+	return "qemu-server"
+}
+
+// taskPrefix returns the task type prefix of the guest type, e.g. `qm` for `qmstart`.
+func (t guestType) taskPrefix() string {
+	if t == guestLXC {
+		return "vz"
+	}
+
+	return "qm"
+}
+
+type node struct {
+	name string
+	// offline is set for the nodes that were taken offline with Server.SetNodeOnline.
+	offline bool
+	// booted is the boot time of the node, reset by a reboot.
+	booted time.Time
+	// services are the systemd services of the node, by service ID.
+	services map[string]*service
+	// nextPID is used to generate task UPIDs.
+	nextPID int
+	// maintenance is set while the node is in the HA maintenance mode, see Server.SetNodeMaintenance.
+	maintenance bool
+}
+
+type guest struct {
+	vmid   int
+	node   string
+	kind   guestType
+	config map[string]string
+	status string
+	// started is the start time of a running guest.
+	started time.Time
+	// task is the UPID of the running task that holds the guest's file lock.
+	task string
+	// frozen is set while the guest agent keeps the filesystems frozen.
+	frozen bool
+	// passwords holds the user passwords set with the guest agent.
+	passwords map[string]string
+}
+
+type storage struct {
+	id      string
+	kind    string
+	content []string
+	shared  bool
+	// nodes restricts the storage to the given nodes; empty means all nodes.
+	nodes []string
+	total int64
+}
+
+type volume struct {
+	volid   string
+	storage string
+	node    string
+	content string
+	format  string
+	size    int64
+	vmid    int
+	ctime   int64
+	// backup holds the metadata of backup volumes.
+	backup *Backup
+}
+
+type pool struct {
+	id       string
+	comment  string
+	vms      map[int]bool
+	storages map[string]bool
+}
+
+type user struct {
+	id     string
+	fields map[string]string
+}
+
+type acl struct {
+	path      string
+	ugid      string
+	kind      string
+	role      string
+	propagate bool
+}
+
+type task struct {
+	upid     string
+	node     string
+	kind     string
+	id       string
+	user     string
+	pid      int
+	start    time.Time
+	duration time.Duration
+	exit     string
+	done     bool
+	log      []string
+	onFinish func()
+	// onFail undoes partial effects of a failed task, e.g. removes a guest that failed to create.
+	onFail    func()
+	startTime int64
+	// guest is the guest whose file lock the task holds, if any.
+	guest *guest
+}
+
+// release releases the guest held by the task.
+func (t *task) release() {
+	if t.guest != nil && t.guest.task == t.upid {
+		t.guest.task = ""
+	}
+}
+
+type state struct {
+	mu sync.Mutex
+
+	version      string
+	taskDuration time.Duration
+
+	nodes    map[string]*node
+	guests   map[int]*guest
+	storages map[string]*storage
+	volumes  map[string]*volume
+	pools    map[string]*pool
+	users    map[string]*user
+	acls     []*acl
+	tasks    map[string]*task
+	tickets  map[string]bool
+
+	// haActive is set when the HA manager is active, see WithHAManager.
+	haActive bool
+
+	// taskResults are injected outcomes for upcoming tasks, see Server.SetTaskResult.
+	taskResults []taskResult
+	// agentResults are injected guest agent command results by VM ID, see Server.SetAgentResult.
+	agentResults map[int]map[string]any
+}
+
+func newState() *state {
+	s := &state{
+		version:  DefaultVersion,
+		nodes:    map[string]*node{},
+		guests:   map[int]*guest{},
+		storages: map[string]*storage{},
+		volumes:  map[string]*volume{},
+		pools:    map[string]*pool{},
+		users:    map[string]*user{},
+		tasks:    map[string]*task{},
+		tickets:  map[string]bool{},
+
+		agentResults: map[int]map[string]any{},
+	}
+
+	s.addNode(DefaultNode)
+
+	s.storages["local"] = &storage{
+		id:      "local",
+		kind:    "dir",
+		content: []string{"backup", "import", "iso", "snippets", "vztmpl"},
+		total:   100 << 30,
+	}
+	s.storages["local-lvm"] = &storage{
+		id:      "local-lvm",
+		kind:    "lvmthin",
+		content: []string{"images", "rootdir"},
+		total:   400 << 30,
+	}
+
+	s.users["root@pam"] = &user{
+		id:     "root@pam",
+		fields: map[string]string{"enable": "1", "expire": "0", "comment": "Built-in Superuser"},
+	}
+
+	return s
+}
+
+func (s *state) addNode(name string) {
+	s.nodes[name] = &node{name: name, booted: time.Now().Add(-time.Hour), services: defaultServices(), nextPID: 1000}
+}
+
+func (s *state) node(name string) (*node, *apiError) {
+	n, ok := s.nodes[name]
+	if !ok {
+		return nil, errorf(http.StatusInternalServerError,
+			"hostname lookup '%s' failed - failed to get address info for: %s: Name or service not known", name, name)
+	}
+
+	return n, nil
+}
+
+// status returns the status of the node as reported by the node lists.
+func (n *node) status() string {
+	if n.offline {
+		return "offline"
+	}
+
+	return "online"
+}
+
+// uptime returns the uptime of the node in seconds.
+func (n *node) uptime() int64 {
+	return int64(time.Since(n.booted).Seconds())
+}
+
+// quorate reports whether more than half of the nodes are online.
+func (s *state) quorate() bool {
+	online := 0
+
+	for _, n := range s.nodes {
+		if !n.offline {
+			online++
+		}
+	}
+
+	return online*2 > len(s.nodes)
+}
+
+func (s *state) validTicket(ticket string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.tickets[ticket]
+}
+
+// guest returns the guest of the given type on the given node, failing like PVE does when the
+// config file does not exist on that node.
+func (s *state) guest(nodeName string, kind guestType, vmid string) (*guest, *apiError) {
+	if _, err := s.node(nodeName); err != nil {
+		return nil, err
+	}
+
+	id, err := strconv.Atoi(vmid)
+	if err != nil || id < 100 {
+		return nil, paramError("vmid", "invalid format - value does not look like a valid VM ID")
+	}
+
+	g, ok := s.guests[id]
+	if !ok || g.node != nodeName || g.kind != kind {
+		return nil, errorf(http.StatusInternalServerError,
+			"Configuration file 'nodes/%s/%s/%d.conf' does not exist", nodeName, kind.configDir(), id)
+	}
+
+	return g, nil
+}
+
+// checkUnlocked fails if the guest has a config lock or a running task.
+func (s *state) checkUnlocked(g *guest) *apiError {
+	if lock := g.config["lock"]; lock != "" {
+		return errorf(http.StatusInternalServerError, "VM is locked (%s)", lock)
+	}
+
+	if g.task != "" {
+		return errorf(http.StatusInternalServerError,
+			"can't lock file '/var/lock/%s/lock-%d.conf' - got timeout", g.kind.configDir(), g.vmid)
+	}
+
+	return nil
+}
+
+// startTask starts a task on the node. The task finishes once the configured task duration
+// has passed, at the earliest with the next request, and onFinish is then called to apply its
+// effects. If the task is associated with a guest, the guest is busy until the task finishes.
+func (s *state) startTask(nodeName, kind, id string, g *guest, onFinish func()) *task {
+	n := s.nodes[nodeName]
+	n.nextPID++
+
+	now := time.Now()
+
+	t := &task{
+		node:      nodeName,
+		kind:      kind,
+		id:        id,
+		user:      "root@pam",
+		pid:       n.nextPID,
+		start:     now,
+		startTime: now.Unix(),
+		duration:  s.taskDuration,
+		exit:      "OK",
+		onFinish:  onFinish,
+		guest:     g,
+	}
+
+	t.upid = fmt.Sprintf("UPID:%s:%08X:%08X:%08X:%s:%s:%s:",
+		nodeName, t.pid, t.pid*100, t.startTime, kind, id, t.user)
+
+	if g != nil {
+		g.task = t.upid
+	}
+
+	s.tasks[t.upid] = t
+	t.log = append(t.log, fmt.Sprintf("starting task %s", t.upid))
+
+	return t
+}
+
+func (s *state) finishTask(t *task) {
+	if t.done {
+		return
+	}
+
+	t.done = true
+	t.release()
+
+	for i, r := range s.taskResults {
+		if r.taskType == t.kind {
+			t.exit = r.exit
+			t.log = append(t.log, r.log...)
+			s.taskResults = slices.Delete(s.taskResults, i, i+1)
+
+			break
+		}
+	}
+
+	// failed tasks have no effect, tasks with warnings do
+	switch {
+	case t.exit == "OK" || strings.HasPrefix(t.exit, "WARNINGS: "):
+		if t.onFinish != nil {
+			t.onFinish()
+		}
+
+		t.log = append(t.log, "TASK "+t.exit)
+	default:
+		if t.onFail != nil {
+			t.onFail()
+		}
+
+		t.log = append(t.log, "TASK ERROR: "+t.exit)
+	}
+}
+
+// finishTasks finishes all running tasks that are due.
+func (s *state) finishTasks(now time.Time) {
+	upids := slices.Sorted(maps.Keys(s.tasks))
+
+	for _, upid := range upids {
+		t := s.tasks[upid]
+		if !t.done && !now.Before(t.start.Add(t.duration)) {
+			s.finishTask(t)
+		}
+	}
+}
+
+// nextVMID returns the lowest free VM ID starting at 100.
+func (s *state) nextVMID() int {
+	for id := 100; ; id++ {
+		if _, ok := s.guests[id]; !ok {
+			return id
+		}
+	}
+}
+
+// poolOf returns the pool the guest is a member of, if any.
+func (s *state) poolOf(vmid int) string {
+	for _, p := range s.pools {
+		if p.vms[vmid] {
+			return p.id
+		}
+	}
+
+	return ""
+}
+
+// storageOnNode returns the storage if it is available on the node.
+func (s *state) storageOnNode(nodeName, id string) (*storage, *apiError) {
+	st, ok := s.storages[id]
+	if !ok || (len(st.nodes) > 0 && !slices.Contains(st.nodes, nodeName)) {
+		return nil, errorf(http.StatusInternalServerError, "storage '%s' does not exist", id)
+	}
+
+	return st, nil
+}
+
+// volumeNode returns the node key used for volumes on the storage: shared storages keep a
+// single list of volumes for all nodes.
+func volumeNode(st *storage, nodeName string) string {
+	if st.shared {
+		return ""
+	}
+
+	return nodeName
+}
+
+func (s *state) volumesOn(st *storage, nodeName string) []*volume {
+	nodeKey := volumeNode(st, nodeName)
+
+	var res []*volume
+
+	for _, v := range s.volumes {
+		if v.storage == st.id && v.node == nodeKey {
+			res = append(res, v)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].volid < res[j].volid })
+
+	return res
+}
+
+func (s *state) volumeKey(st *storage, nodeName, volid string) string {
+	return volumeNode(st, nodeName) + "/" + volid
+}
+
+// allocateDisk creates a new disk volume for the guest on the storage and returns its volume ID.
+func (s *state) allocateDisk(st *storage, nodeName string, vmid int, size int64, content string) string {
+	format := "raw"
+	if st.kind == "dir" || st.kind == "nfs" || st.kind == "cifs" {
+		format = "qcow2"
+	}
+
+	prefix := "vm"
+	if content == "rootdir" {
+		prefix = "subvol"
+		if st.kind == "lvmthin" || st.kind == "lvm" {
+			prefix = "vm"
+		}
+	}
+
+	for i := 0; ; i++ {
+		name := fmt.Sprintf("%s-%d-disk-%d", prefix, vmid, i)
+		if format == "qcow2" {
+			name = fmt.Sprintf("%d/%s.qcow2", vmid, name)
+		}
+
+		volid := st.id + ":" + name
+		if _, ok := s.volumes[s.volumeKey(st, nodeName, volid)]; ok {
+			continue
+		}
+
+		s.volumes[s.volumeKey(st, nodeName, volid)] = &volume{
+			volid:   volid,
+			storage: st.id,
+			node:    volumeNode(st, nodeName),
+			content: content,
+			format:  format,
+			size:    size,
+			vmid:    vmid,
+			ctime:   time.Now().Unix(),
+		}
+
+		return volid
+	}
+}
+
+// removeGuest deletes the guest, its disk volumes and pool membership.
+func (s *state) removeGuest(g *guest) {
+	delete(s.guests, g.vmid)
+
+	for k, v := range s.volumes {
+		if v.vmid == g.vmid && (v.content == "images" || v.content == "rootdir") {
+			delete(s.volumes, k)
+		}
+	}
+
+	for _, p := range s.pools {
+		delete(p.vms, g.vmid)
+	}
+}
+
+// removeGuestReferences removes the guest from ACLs, as `purge` does.
+func (s *state) removeGuestReferences(vmid int) {
+	path := fmt.Sprintf("/vms/%d", vmid)
+	s.acls = slices.DeleteFunc(s.acls, func(a *acl) bool { return a.path == path })
+}
+
+// splitList splits a comma, semicolon or whitespace separated list.
+func splitList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\t' || r == '\n'
+	})
+}
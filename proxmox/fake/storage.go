@@ -0,0 +1,679 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// contentDirs maps content types to their directory in file based storages.
+var contentDirs = map[string]string{
+	"backup":   "dump",
+	"import":   "import",
+	"iso":      "template/iso",
+	"snippets": "snippets",
+	"vztmpl":   "template/cache",
+}
+
+// volumeDirs maps content types to the directory prefix in volume IDs.
+var volumeDirs = map[string]string{
+	"backup":   "backup",
+	"import":   "import",
+	"iso":      "iso",
+	"snippets": "snippets",
+	"vztmpl":   "vztmpl",
+}
+
+// AddVolume adds a file volume (e.g. an ISO image or container template) to a storage on a
+// node and returns its volume ID.
+func (s *Server) AddVolume(nodeName, storageID, content, fileName string, size int64) (string, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	if _, err := s.state.node(nodeName); err != nil {
+		return "", err
+	}
+
+	st, err := s.state.storageOnNode(nodeName, storageID)
+	if err != nil {
+		return "", err
+	}
+
+	v, err := s.state.addFileVolume(st, nodeName, content, fileName, size)
+	if err != nil {
+		return "", err
+	}
+
+	return v.volid, nil
+}
+
+func (s *state) addFileVolume(st *storage, nodeName, content, fileName string, size int64) (*volume, *apiError) {
+	dir, ok := volumeDirs[content]
+	if !ok {
+		return nil, paramError("content", "value '%s' does not have a value in the enumeration", content)
+	}
+
+	if !slices.Contains(st.content, content) {
+		return nil, errorf(http.StatusInternalServerError,
+			"storage '%s' does not support content-type '%s'", st.id, content)
+	}
+
+	if fileName == "" || strings.Contains(fileName, "/") {
+		return nil, paramError("filename", "invalid filename")
+	}
+
+	v := &volume{
+		volid:   fmt.Sprintf("%s:%s/%s", st.id, dir, fileName),
+		storage: st.id,
+		node:    volumeNode(st, nodeName),
+		content: content,
+		format:  fileFormat(content, fileName),
+		size:    size,
+		ctime:   time.Now().Unix(),
+	}
+
+	s.volumes[s.volumeKey(st, nodeName, v.volid)] = v
+
+	return v, nil
+}
+
+// fileFormat derives the volume format from the file name, like PVE does.
+func fileFormat(content, fileName string) string {
+	switch content {
+	case "iso":
+		return "iso"
+	case "vztmpl":
+		return strings.TrimPrefix(path.Ext(fileName), ".")
+	case "snippets":
+		return "snippet"
+	default:
+		ext := strings.TrimPrefix(path.Ext(fileName), ".")
+		if ext == "" {
+			return "raw"
+		}
+
+		return ext
+	}
+}
+
+func (st *storage) used(s *state) int64 {
+	var used int64
+
+	for _, v := range s.volumes {
+		if v.storage == st.id {
+			used += v.size
+		}
+	}
+
+	return used
+}
+
+func (st *storage) config() map[string]any {
+	res := map[string]any{
+		"storage": st.id,
+		"type":    st.kind,
+		"content": strings.Join(st.content, ","),
+		"digest":  "fake",
+	}
+
+	if st.shared {
+		res["shared"] = 1
+	}
+
+	if len(st.nodes) > 0 {
+		res["nodes"] = strings.Join(st.nodes, ",")
+	}
+
+	if st.kind == "dir" {
+		res["path"] = "/var/lib/vz"
+	}
+
+	return res
+}
+
+func (s *Server) registerStorage(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+basePath+"/storage", s.guard(s.listStorages))
+	mux.HandleFunc("POST "+basePath+"/storage", s.guard(s.createStorage))
+	mux.HandleFunc("GET "+basePath+"/storage/{storage}", s.guard(s.getStorage))
+	mux.HandleFunc("PUT "+basePath+"/storage/{storage}", s.guard(s.updateStorage))
+	mux.HandleFunc("DELETE "+basePath+"/storage/{storage}", s.guard(s.deleteStorage))
+
+	nodeStorage := basePath + "/nodes/{node}/storage"
+	mux.HandleFunc("GET "+nodeStorage, s.guard(s.listNodeStorages))
+	mux.HandleFunc("GET "+nodeStorage+"/{storage}/status", s.guard(s.getNodeStorageStatus))
+	mux.HandleFunc("GET "+nodeStorage+"/{storage}/content", s.guard(s.listContent))
+	mux.HandleFunc("POST "+nodeStorage+"/{storage}/content", s.guard(s.allocateContent))
+	mux.HandleFunc("GET "+nodeStorage+"/{storage}/content/{volume}", s.guard(s.getContent))
+	mux.HandleFunc("DELETE "+nodeStorage+"/{storage}/content/{volume}", s.guard(s.deleteContent))
+	mux.HandleFunc("POST "+nodeStorage+"/{storage}/upload", s.guard(s.uploadContent))
+	mux.HandleFunc("POST "+nodeStorage+"/{storage}/download-url", s.guard(s.downloadURL))
+	mux.HandleFunc("GET "+nodeStorage+"/{storage}/file-restore/list", s.guard(s.listRestoreFiles))
+	mux.HandleFunc("GET "+nodeStorage+"/{storage}/file-restore/download", s.guard(s.downloadRestoreFile))
+}
+
+func (s *Server) listStorages(w http.ResponseWriter, r *http.Request) {
+	kind := params(r)["type"]
+
+	res := []map[string]any{}
+
+	for _, id := range slices.Sorted(maps.Keys(s.state.storages)) {
+		st := s.state.storages[id]
+		if kind == "" || st.kind == kind {
+			res = append(res, st.config())
+		}
+	}
+
+	writeData(w, res)
+}
+
+func (s *Server) getStorage(w http.ResponseWriter, r *http.Request) {
+	st, ok := s.state.storages[r.PathValue("storage")]
+	if !ok {
+		writeAPIError(w, errorf(http.StatusInternalServerError, "storage '%s' does not exist", r.PathValue("storage")))
+
+		return
+	}
+
+	writeData(w, st.config())
+}
+
+func (s *Server) createStorage(w http.ResponseWriter, r *http.Request) {
+	p := params(r)
+
+	id := p["storage"]
+	if id == "" {
+		writeAPIError(w, paramError("storage", "property is missing and it is not optional"))
+
+		return
+	}
+
+	if p["type"] == "" {
+		writeAPIError(w, paramError("type", "property is missing and it is not optional"))
+
+		return
+	}
+
+	if _, ok := s.state.storages[id]; ok {
+		writeAPIError(w, errorf(http.StatusInternalServerError,
+			"create storage failed: storage ID '%s' already defined", id))
+
+		return
+	}
+
+	st := &storage{id: id, kind: p["type"], total: 100 << 30}
+	st.apply(p)
+
+	s.state.storages[id] = st
+
+	writeData(w, map[string]any{"storage": id, "type": st.kind})
+}
+
+func (st *storage) apply(p map[string]string) {
+	if c, ok := p["content"]; ok {
+		st.content = splitList(c)
+		slices.Sort(st.content)
+	}
+
+	if n, ok := p["nodes"]; ok {
+		st.nodes = splitList(n)
+	}
+
+	if sh, ok := p["shared"]; ok {
+		st.shared = sh == "1"
+	}
+
+	// network storages are always shared
+	switch st.kind {
+	case "nfs", "cifs", "pbs", "cephfs", "rbd", "iscsi", "glusterfs":
+		st.shared = true
+	}
+
+	for _, k := range splitList(p["delete"]) {
+		switch k {
+		case "nodes":
+			st.nodes = nil
+		case "content":
+			st.content = nil
+		}
+	}
+}
+
+func (s *Server) updateStorage(w http.ResponseWriter, r *http.Request) {
+	st, ok := s.state.storages[r.PathValue("storage")]
+	if !ok {
+		writeAPIError(w, errorf(http.StatusInternalServerError, "storage '%s' does not exist", r.PathValue("storage")))
+
+		return
+	}
+
+	st.apply(params(r))
+
+	writeData(w, map[string]any{"storage": st.id, "type": st.kind})
+}
+
+func (s *Server) deleteStorage(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("storage")
+	if _, ok := s.state.storages[id]; !ok {
+		writeAPIError(w, errorf(http.StatusInternalServerError, "storage '%s' does not exist", id))
+
+		return
+	}
+
+	delete(s.state.storages, id)
+
+	for k, v := range s.state.volumes {
+		if v.storage == id {
+			delete(s.state.volumes, k)
+		}
+	}
+
+	for _, pl := range s.state.pools {
+		delete(pl.storages, id)
+	}
+
+	writeData(w, nil)
+}
+
+func (s *Server) nodeStorageStatus(st *storage) map[string]any {
+	used := st.used(s.state)
+
+	return map[string]any{
+		"storage":       st.id,
+		"type":          st.kind,
+		"content":       strings.Join(st.content, ","),
+		"active":        1,
+		"enabled":       1,
+		"shared":        boolInt(st.shared),
+		"total":         st.total,
+		"used":          used,
+		"avail":         st.total - used,
+		"used_fraction": float64(used) / float64(st.total),
+	}
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+func (s *Server) listNodeStorages(w http.ResponseWriter, r *http.Request) {
+	nodeName := r.PathValue("node")
+	if _, err := s.state.node(nodeName); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	p := params(r)
+
+	res := []map[string]any{}
+
+	for _, id := range slices.Sorted(maps.Keys(s.state.storages)) {
+		st := s.state.storages[id]
+
+		if len(st.nodes) > 0 && !slices.Contains(st.nodes, nodeName) {
+			continue
+		}
+
+		if c := p["content"]; c != "" && !slices.ContainsFunc(splitList(c), func(c string) bool {
+			return slices.Contains(st.content, c)
+		}) {
+			continue
+		}
+
+		if sid := p["storage"]; sid != "" && sid != id {
+			continue
+		}
+
+		res = append(res, s.nodeStorageStatus(st))
+	}
+
+	writeData(w, res)
+}
+
+func (s *Server) getNodeStorageStatus(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.state.node(r.PathValue("node")); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	st, err := s.state.storageOnNode(r.PathValue("node"), r.PathValue("storage"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	writeData(w, s.nodeStorageStatus(st))
+}
+
+func (v *volume) listItem() map[string]any {
+	item := map[string]any{
+		"volid":   v.volid,
+		"content": v.content,
+		"format":  v.format,
+		"size":    v.size,
+		"used":    v.size,
+		"ctime":   v.ctime,
+	}
+
+	if v.vmid != 0 {
+		item["vmid"] = v.vmid
+	}
+
+	if v.backup != nil {
+		v.backup.listItem(item)
+	}
+
+	return item
+}
+
+func (s *Server) listContent(w http.ResponseWriter, r *http.Request) {
+	nodeName := r.PathValue("node")
+	if _, err := s.state.node(nodeName); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	st, err := s.state.storageOnNode(nodeName, r.PathValue("storage"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	p := params(r)
+
+	res := []map[string]any{}
+
+	for _, v := range s.state.volumesOn(st, nodeName) {
+		if c := p["content"]; c != "" && c != v.content {
+			continue
+		}
+
+		if id := p["vmid"]; id != "" && id != strconv.Itoa(v.vmid) {
+			continue
+		}
+
+		res = append(res, v.listItem())
+	}
+
+	writeData(w, res)
+}
+
+// lookupVolume finds a volume by its full volume ID or by its name within the storage.
+func (s *Server) lookupVolume(r *http.Request) (*storage, *volume, *apiError) {
+	nodeName := r.PathValue("node")
+	if _, err := s.state.node(nodeName); err != nil {
+		return nil, nil, err
+	}
+
+	st, err := s.state.storageOnNode(nodeName, r.PathValue("storage"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	volid := r.PathValue("volume")
+	if !strings.HasPrefix(volid, st.id+":") {
+		volid = st.id + ":" + volid
+	}
+
+	v, ok := s.state.volumes[s.state.volumeKey(st, nodeName, volid)]
+	if !ok {
+		return nil, nil, errorf(http.StatusInternalServerError, "volume '%s' does not exist", volid)
+	}
+
+	return st, v, nil
+}
+
+func (s *Server) getContent(w http.ResponseWriter, r *http.Request) {
+	st, v, err := s.lookupVolume(r)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	_, name, _ := strings.Cut(v.volid, ":")
+
+	filePath := "/dev/" + st.id + "/" + name
+	if dir, ok := contentDirs[v.content]; ok {
+		filePath = "/var/lib/vz/" + dir + "/" + path.Base(name)
+	} else if st.kind == "dir" {
+		filePath = "/var/lib/vz/images/" + name
+	}
+
+	writeData(w, map[string]any{
+		"path":   filePath,
+		"format": v.format,
+		"size":   v.size,
+		"used":   v.size,
+	})
+}
+
+func (s *Server) deleteContent(w http.ResponseWriter, r *http.Request) {
+	st, v, err := s.lookupVolume(r)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	// volumes still referenced by a guest config cannot be deleted
+	if g, ok := s.state.guests[v.vmid]; ok {
+		for k, value := range g.config {
+			if volid, _, _ := strings.Cut(value, ","); volid == v.volid && !strings.HasPrefix(k, "unused") {
+				writeAPIError(w, errorf(http.StatusInternalServerError,
+					"unable to delete '%s' - volume is still in use (referenced in %s %d)", v.volid, g.label(), g.vmid))
+
+				return
+			}
+		}
+	}
+
+	nodeName := r.PathValue("node")
+
+	t := s.state.startTask(nodeName, "imgdel", v.volid, nil, func() {
+		delete(s.state.volumes, s.state.volumeKey(st, nodeName, v.volid))
+
+		if g, ok := s.state.guests[v.vmid]; ok {
+			for k, value := range g.config {
+				if strings.HasPrefix(k, "unused") && value == v.volid {
+					delete(g.config, k)
+				}
+			}
+		}
+	})
+
+	writeData(w, t.upid)
+}
+
+// allocateContent allocates a new disk image for a guest.
+func (s *Server) allocateContent(w http.ResponseWriter, r *http.Request) {
+	nodeName := r.PathValue("node")
+	if _, err := s.state.node(nodeName); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	st, err := s.state.storageOnNode(nodeName, r.PathValue("storage"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	p := params(r)
+
+	vmid, cerr := strconv.Atoi(p["vmid"])
+	if cerr != nil || vmid < 100 {
+		writeAPIError(w, paramError("vmid", "property is missing and it is not optional"))
+
+		return
+	}
+
+	// the size is in KiB unless a unit is given
+	size, perr := parseSize(p["size"], 1<<10)
+	if perr != nil || size <= 0 {
+		writeAPIError(w, paramError("size", "value does not match the regex pattern"))
+
+		return
+	}
+
+	if !slices.Contains(st.content, "images") {
+		writeAPIError(w, errorf(http.StatusInternalServerError,
+			"storage '%s' does not support content-type 'images'", st.id))
+
+		return
+	}
+
+	writeData(w, s.state.allocateDisk(st, nodeName, vmid, size, "images"))
+}
+
+func (s *Server) uploadContent(w http.ResponseWriter, r *http.Request) {
+	nodeName := r.PathValue("node")
+	if _, err := s.state.node(nodeName); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	st, err := s.state.storageOnNode(nodeName, r.PathValue("storage"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	mr, merr := r.MultipartReader()
+	if merr != nil {
+		writeAPIError(w, paramError("filename", "multipart upload expected"))
+
+		return
+	}
+
+	var (
+		content  string
+		fileName string
+		size     int64
+	)
+
+	for {
+		part, perr := mr.NextPart()
+		if perr == io.EOF {
+			break
+		}
+
+		if perr != nil {
+			writeAPIError(w, errorf(http.StatusBadRequest, "failed to read upload: %s", perr))
+
+			return
+		}
+
+		switch part.FormName() {
+		case "content":
+			b, _ := io.ReadAll(part)
+			content = string(b)
+		case "filename":
+			if part.FileName() == "" {
+				b, _ := io.ReadAll(part)
+				fileName = string(b)
+
+				continue
+			}
+
+			fileName = part.FileName()
+			size, _ = io.Copy(io.Discard, part)
+		default:
+			_, _ = io.Copy(io.Discard, part)
+		}
+	}
+
+	v, aerr := s.state.addFileVolume(st, nodeName, content, fileName, size)
+	if aerr != nil {
+		writeAPIError(w, aerr)
+
+		return
+	}
+
+	t := s.state.startTask(nodeName, "imgcopy", "", nil, nil)
+	t.log = append(t.log, fmt.Sprintf("target file: %s", v.volid))
+
+	writeData(w, t.upid)
+}
+
+// downloadURL simulates downloading a file into the storage. The file size is taken from
+// the `size` query parameter of the URL, if present, and defaults to 1 MiB.
+func (s *Server) downloadURL(w http.ResponseWriter, r *http.Request) {
+	nodeName := r.PathValue("node")
+	if _, err := s.state.node(nodeName); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	st, err := s.state.storageOnNode(nodeName, r.PathValue("storage"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	p := params(r)
+
+	if p["url"] == "" {
+		writeAPIError(w, paramError("url", "property is missing and it is not optional"))
+
+		return
+	}
+
+	volid := fmt.Sprintf("%s:%s/%s", st.id, volumeDirs[p["content"]], p["filename"])
+	if _, ok := s.state.volumes[s.state.volumeKey(st, nodeName, volid)]; ok {
+		writeAPIError(w, errorf(http.StatusInternalServerError,
+			"refusing to override existing file '%s'", p["filename"]))
+
+		return
+	}
+
+	v, aerr := s.state.addFileVolume(st, nodeName, p["content"], p["filename"], 0)
+	if aerr != nil {
+		writeAPIError(w, aerr)
+
+		return
+	}
+
+	// the file only appears once the download is complete
+	delete(s.state.volumes, s.state.volumeKey(st, nodeName, v.volid))
+
+	v.size = 1 << 20
+	if i := strings.Index(p["url"], "size="); i >= 0 {
+		if n, perr := strconv.ParseInt(strings.SplitN(p["url"][i+5:], "&", 2)[0], 10, 64); perr == nil {
+			v.size = n
+		}
+	}
+
+	t := s.state.startTask(nodeName, "download", "", nil, func() {
+		s.state.volumes[s.state.volumeKey(st, nodeName, v.volid)] = v
+	})
+	t.log = append(t.log, fmt.Sprintf("downloading %s to %s", p["url"], v.volid))
+
+	writeData(w, t.upid)
+}
@@ -0,0 +1,228 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (s *Server) registerTasks(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/tasks", s.guard(s.listTasks))
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/tasks/{upid}/status", s.guard(s.getTaskStatus))
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/tasks/{upid}/log", s.guard(s.getTaskLog))
+	mux.HandleFunc("DELETE "+basePath+"/nodes/{node}/tasks/{upid}", s.guard(s.stopTask))
+}
+
+// SetTaskResult makes the next finished task of the given type (e.g. `qmstart`) end with the
+// given exit status (e.g. `WARNINGS: 1` or an error message) and append the log lines.
+// It is used to test how clients handle failed tasks.
+func (s *Server) SetTaskResult(taskType, exitStatus string, log ...string) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	s.state.taskResults = append(s.state.taskResults, taskResult{
+		taskType: taskType,
+		exit:     exitStatus,
+		log:      log,
+	})
+}
+
+// taskResult is an injected task outcome, see Server.SetTaskResult.
+type taskResult struct {
+	taskType string
+	exit     string
+	log      []string
+}
+
+// task returns the task with the UPID on the node, failing like PVE does for unknown tasks.
+func (s *state) task(nodeName, upid string) (*task, *apiError) {
+	if _, err := s.node(nodeName); err != nil {
+		return nil, err
+	}
+
+	t, ok := s.tasks[upid]
+	if !ok || t.node != nodeName {
+		return nil, paramError("upid", "unable to parse worker upid '%s'", upid)
+	}
+
+	return t, nil
+}
+
+func (t *task) status() map[string]any {
+	res := map[string]any{
+		"upid":      t.upid,
+		"node":      t.node,
+		"pid":       t.pid,
+		"pstart":    t.pid * 100,
+		"starttime": t.startTime,
+		"type":      t.kind,
+		"id":        t.id,
+		"user":      t.user,
+		"status":    "running",
+	}
+
+	if t.done {
+		res["status"] = "stopped"
+		res["exitstatus"] = t.exit
+	}
+
+	return res
+}
+
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	nodeName := r.PathValue("node")
+	if _, err := s.state.node(nodeName); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	p := params(r)
+
+	since, _ := strconv.ParseInt(p["since"], 10, 64)
+	until, _ := strconv.ParseInt(p["until"], 10, 64)
+
+	tasks := []*task{}
+
+	for _, t := range s.state.tasks {
+		// like PVE, the finished tasks are listed by default, and the running ones with source=active
+		switch p["source"] {
+		case "active":
+			if t.done {
+				continue
+			}
+		case "all":
+		default:
+			if !t.done {
+				continue
+			}
+		}
+
+		if t.node != nodeName ||
+			(p["vmid"] != "" && p["vmid"] != t.id) ||
+			(p["typefilter"] != "" && p["typefilter"] != t.kind) ||
+			(p["userfilter"] != "" && !strings.Contains(t.user, p["userfilter"])) ||
+			(since > 0 && t.startTime < since) ||
+			(until > 0 && t.startTime > until) ||
+			(p["statusfilter"] != "" && (!t.done || !slices.Contains(strings.Split(p["statusfilter"], ","), taskStatusClass(t.exit)))) {
+			continue
+		}
+
+		tasks = append(tasks, t)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].startTime != tasks[j].startTime {
+			return tasks[i].startTime > tasks[j].startTime
+		}
+
+		return tasks[i].upid > tasks[j].upid
+	})
+
+	start, _ := strconv.Atoi(p["start"])
+
+	limit, cerr := strconv.Atoi(p["limit"])
+	if cerr != nil || limit <= 0 {
+		limit = 50
+	}
+
+	res := []map[string]any{}
+
+	for i := start; i < len(tasks) && i < start+limit; i++ {
+		t := tasks[i]
+
+		item := t.status()
+		if t.done {
+			item["endtime"] = t.start.Add(t.duration).Unix()
+			item["status"] = t.exit
+		} else {
+			delete(item, "status")
+		}
+
+		res = append(res, item)
+	}
+
+	writeData(w, res)
+}
+
+// taskStatusClass returns the class of the exit status of a task, as matched by the status filter of the task list.
+func taskStatusClass(exit string) string {
+	switch {
+	case exit == "OK":
+		return "ok"
+	case strings.HasPrefix(exit, "WARNINGS: "):
+		return "warning"
+	case exit == "unknown" || exit == "":
+		return "unknown"
+	default:
+		return "error"
+	}
+}
+
+func (s *Server) getTaskStatus(w http.ResponseWriter, r *http.Request) {
+	t, err := s.state.task(r.PathValue("node"), r.PathValue("upid"))
+
+	var data any
+	if t != nil {
+		data = t.status()
+	}
+
+	respond(w, data, err)
+}
+
+func (s *Server) getTaskLog(w http.ResponseWriter, r *http.Request) {
+	t, err := s.state.task(r.PathValue("node"), r.PathValue("upid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	p := params(r)
+
+	start, _ := strconv.Atoi(p["start"])
+
+	limit, cerr := strconv.Atoi(p["limit"])
+	if cerr != nil || limit <= 0 {
+		limit = 50
+	}
+
+	res := []map[string]any{}
+
+	for i := start; i < len(t.log) && i < start+limit; i++ {
+		res = append(res, map[string]any{"n": i + 1, "t": t.log[i]})
+	}
+
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": res, "total": len(t.log)})
+}
+
+func (s *Server) stopTask(w http.ResponseWriter, r *http.Request) {
+	t, err := s.state.task(r.PathValue("node"), r.PathValue("upid"))
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if !t.done {
+		// the effects of a stopped task are not applied
+		t.onFinish = nil
+		t.exit = "unexpected status"
+		t.duration = time.Since(t.start)
+		t.log = append(t.log, "received interrupt")
+		s.state.finishTask(t)
+	}
+
+	writeData(w, nil)
+}
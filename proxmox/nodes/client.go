@@ -18,6 +18,8 @@ import (
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/disks"
 	nodefirewall "github.com/bpg/terraform-provider-proxmox/proxmox/nodes/firewall"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/hardware"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/replication"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/services"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/storage"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/tasks"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
@@ -73,6 +75,21 @@ func (c *Client) Storage(storageName string) *storage.Client {
 	}
 }
 
+// Replication returns a client for the state of a replication job on the node.
+func (c *Client) Replication(id string) *replication.Client {
+	return &replication.Client{
+		Client: c,
+		ID:     id,
+	}
+}
+
+// Services returns a client for managing the services of the node.
+func (c *Client) Services() *services.Client {
+	return &services.Client{
+		Client: c,
+	}
+}
+
 // Tasks returns a client for managing VM tasks.
 func (c *Client) Tasks() *tasks.Client {
 	return &tasks.Client{
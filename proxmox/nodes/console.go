@@ -0,0 +1,57 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// CreateVNCShell creates a VNC proxy to a shell on the node and returns its ticket and port.
+func (c *Client) CreateVNCShell(ctx context.Context, d *VNCShellRequestBody) (*ConsoleProxyResponseData, error) {
+	return createConsoleProxy(ctx, c, "vncshell", d)
+}
+
+// CreateTermProxy creates a terminal proxy to a shell on the node and returns its ticket and port.
+func (c *Client) CreateTermProxy(ctx context.Context, d *TermProxyRequestBody) (*ConsoleProxyResponseData, error) {
+	return createConsoleProxy(ctx, c, "termproxy", d)
+}
+
+// CreateSPICEShell creates a SPICE proxy to a shell on the node and returns the connection
+// settings of the remote viewer, i.e. the entries of the `[virt-viewer]` section of a `.vv` file.
+func (c *Client) CreateSPICEShell(ctx context.Context, d *SPICEShellRequestBody) (map[string]any, error) {
+	resBody := &SPICEShellResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("spiceshell"), d, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error creating SPICE shell on node %q: %w", c.NodeName, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+func createConsoleProxy(ctx context.Context, c *Client, kind string, d any) (*ConsoleProxyResponseData, error) {
+	resBody := &ConsoleProxyResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath(kind), d, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s on node %q: %w", kind, c.NodeName, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
@@ -0,0 +1,54 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+
+// Commands of the node shell, the default is a login shell.
+const (
+	ShellCommandCephInstall = "ceph_install"
+	ShellCommandLogin       = "login"
+	ShellCommandUpgrade     = "upgrade"
+)
+
+// VNCShellRequestBody contains the body for a VNC shell request.
+type VNCShellRequestBody struct {
+	Command   *string           `json:"cmd,omitempty"       url:"cmd,omitempty"`
+	Height    *int              `json:"height,omitempty"    url:"height,omitempty"`
+	WebSocket *types.CustomBool `json:"websocket,omitempty" url:"websocket,omitempty,int"`
+	Width     *int              `json:"width,omitempty"     url:"width,omitempty"`
+}
+
+// TermProxyRequestBody contains the body for a terminal proxy request.
+type TermProxyRequestBody struct {
+	Command *string `json:"cmd,omitempty" url:"cmd,omitempty"`
+}
+
+// SPICEShellRequestBody contains the body for a SPICE shell request.
+type SPICEShellRequestBody struct {
+	Command *string `json:"cmd,omitempty"   url:"cmd,omitempty"`
+	Proxy   *string `json:"proxy,omitempty" url:"proxy,omitempty"`
+}
+
+// ConsoleProxyResponseBody contains the body from a VNC shell or terminal proxy response.
+type ConsoleProxyResponseBody struct {
+	Data *ConsoleProxyResponseData `json:"data,omitempty"`
+}
+
+// ConsoleProxyResponseData contains the data from a VNC shell or terminal proxy response.
+type ConsoleProxyResponseData struct {
+	Cert   *string         `json:"cert,omitempty"`
+	Port   types.CustomInt `json:"port"`
+	Ticket string          `json:"ticket"`
+	UPID   string          `json:"upid"`
+	User   string          `json:"user"`
+}
+
+// SPICEShellResponseBody contains the body from a SPICE shell response.
+type SPICEShellResponseBody struct {
+	Data map[string]any `json:"data,omitempty"`
+}
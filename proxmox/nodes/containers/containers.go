@@ -81,6 +81,19 @@ func (c *Client) CreateContainerAsync(ctx context.Context, d *CreateRequestBody)
 	return resBody.Data, nil
 }
 
+// RestoreContainer creates a container from the backup archive set as the OS template of the
+// request body, see CreateRequestBody.Restore.
+func (c *Client) RestoreContainer(ctx context.Context, d *CreateRequestBody) tasks.TaskResult {
+	op := retry.NewTaskOperation("container restore",
+		retry.WithRetryIf(retry.IsTransientAPIError),
+		retry.WithAlreadyDoneCheck(retry.ErrorContains("already exists")),
+	)
+
+	return c.Tasks().DoTask(ctx, op,
+		func() (*string, error) { return c.CreateContainerAsync(ctx, d) },
+	)
+}
+
 // DeleteContainer deletes a container.
 func (c *Client) DeleteContainer(ctx context.Context) tasks.TaskResult {
 	op := retry.NewTaskOperation("container delete",
@@ -272,6 +285,38 @@ func (c *Client) checkIPAddresses(
 	return hasIPv4, hasIPv6
 }
 
+// MigrateContainer migrates a container. Running containers are migrated in restart mode when
+// Restart is set: they are shut down, moved and started on the target node. The wait options,
+// e.g. tasks.WithLogHandler, apply to the migration task.
+func (c *Client) MigrateContainer(
+	ctx context.Context,
+	d *MigrateRequestBody,
+	opts ...tasks.TaskWaitOption,
+) tasks.TaskResult {
+	taskID, err := c.MigrateContainerAsync(ctx, d)
+	if err != nil {
+		return tasks.TaskFailed(err)
+	}
+
+	return c.Tasks().WaitForTask(ctx, *taskID, opts...)
+}
+
+// MigrateContainerAsync migrates a container asynchronously.
+func (c *Client) MigrateContainerAsync(ctx context.Context, d *MigrateRequestBody) (*string, error) {
+	resBody := &MigrateResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("migrate"), d, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error migrating container: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
 // RebootContainer reboots a container.
 func (c *Client) RebootContainer(ctx context.Context, d *RebootRequestBody) tasks.TaskResult {
 	taskID, err := c.RebootContainerAsync(ctx, d)
@@ -300,6 +300,19 @@ type StartResponseBody struct {
 	Data *string `json:"data,omitempty"`
 }
 
+// MigrateRequestBody contains the body for a container migration request.
+type MigrateRequestBody struct {
+	BandwidthLimit *int              `json:"bwlimit,omitempty"        url:"bwlimit,omitempty"`
+	Restart        *types.CustomBool `json:"restart,omitempty"        url:"restart,omitempty,int"`
+	TargetNode     string            `json:"target"                   url:"target"`
+	TargetStorage  *string           `json:"target-storage,omitempty" url:"target-storage,omitempty"`
+}
+
+// MigrateResponseBody contains the body from a container migrate response.
+type MigrateResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
+
 // RebootRequestBody contains the body for a container reboot request.
 type RebootRequestBody struct {
 	Timeout *int `json:"timeout,omitempty" url:"timeout,omitempty"`
@@ -0,0 +1,182 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/tasks"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/retry"
+)
+
+// powerPollDelay is the delay between the checks of the node status while it reboots, shuts
+// down or boots.
+const powerPollDelay = 5 * time.Second
+
+// ExecutePowerCommand reboots or shuts down the node. It returns as soon as the node has
+// accepted the command.
+func (c *Client) ExecutePowerCommand(ctx context.Context, command string) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("status"), &PowerRequestBody{Command: command}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to %s node \"%s\": %w", command, c.NodeName, err)
+	}
+
+	return nil
+}
+
+// WakeOnLAN sends a Wake-on-LAN packet to the node, from the node the request is sent to. The
+// MAC address of the node must be set in its `wakeonlan` option. It returns the MAC address.
+func (c *Client) WakeOnLAN(ctx context.Context) (string, error) {
+	resBody := &WakeOnLANResponseBody{}
+
+	// the node is expected to be offline, the packet is sent by the node serving the API
+	err := c.DoRequest(api.WithOfflineNode(ctx), http.MethodPost, c.ExpandPath("wakeonlan"), nil, resBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to wake node \"%s\": %w", c.NodeName, err)
+	}
+
+	if resBody.Data == nil {
+		return "", api.ErrNoDataObjectInResponse
+	}
+
+	return *resBody.Data, nil
+}
+
+// WaitForStatus waits until the node list reports the node with the given status, e.g.
+// `offline` after a shutdown. Errors are retried, as the API may be served by the node itself.
+func (c *Client) WaitForStatus(ctx context.Context, status string) error {
+	unexpectedStatus := errors.New("unexpected node status")
+
+	op := retry.NewPollOperation("node status", retry.WithBaseDelay(powerPollDelay))
+
+	err := op.DoPoll(ctx, func() error {
+		list, err := c.ListNodes(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, n := range list {
+			if n.Name == c.NodeName && n.Status != nil && *n.Status == status {
+				return nil
+			}
+		}
+
+		return unexpectedStatus
+	})
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timeout while waiting for node \"%s\" to be %s", c.NodeName, status)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error waiting for node \"%s\" to be %s: %w", c.NodeName, status, err)
+	}
+
+	return nil
+}
+
+// WaitForReboot waits until the node reports that it has booted after the given time.
+// Errors are retried, as the node is unreachable while it reboots.
+func (c *Client) WaitForReboot(ctx context.Context, since time.Time) error {
+	notRebooted := errors.New("node not rebooted")
+
+	op := retry.NewPollOperation("node reboot", retry.WithBaseDelay(powerPollDelay))
+
+	err := op.DoPoll(ctx, func() error {
+		info, err := c.GetInfo(ctx)
+		if err != nil {
+			return err
+		}
+
+		if info.Uptime == nil || time.Now().Add(-time.Duration(*info.Uptime)*time.Second).Before(since) {
+			return notRebooted
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timeout while waiting for node \"%s\" to reboot", c.NodeName)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error waiting for node \"%s\" to reboot: %w", c.NodeName, err)
+	}
+
+	return nil
+}
+
+// StartAllGuestsAsync starts the guests of the node and returns the task ID.
+func (c *Client) StartAllGuestsAsync(ctx context.Context, d *StartAllRequestBody) (*string, error) {
+	return c.bulkActionAsync(ctx, "startall", d)
+}
+
+// StartAllGuests starts the guests of the node and waits for them to be started.
+func (c *Client) StartAllGuests(ctx context.Context, d *StartAllRequestBody) tasks.TaskResult {
+	op := retry.NewTaskOperation("start all guests",
+		retry.WithRetryIf(retry.IsTransientAPIError),
+	)
+
+	return c.Tasks().DoTask(ctx, op,
+		func() (*string, error) { return c.StartAllGuestsAsync(ctx, d) },
+	)
+}
+
+// StopAllGuestsAsync stops the guests of the node and returns the task ID.
+func (c *Client) StopAllGuestsAsync(ctx context.Context, d *StopAllRequestBody) (*string, error) {
+	return c.bulkActionAsync(ctx, "stopall", d)
+}
+
+// StopAllGuests stops the guests of the node and waits for them to be stopped.
+func (c *Client) StopAllGuests(ctx context.Context, d *StopAllRequestBody) tasks.TaskResult {
+	op := retry.NewTaskOperation("stop all guests",
+		retry.WithRetryIf(retry.IsTransientAPIError),
+	)
+
+	return c.Tasks().DoTask(ctx, op,
+		func() (*string, error) { return c.StopAllGuestsAsync(ctx, d) },
+	)
+}
+
+// MigrateAllGuestsAsync migrates the guests of the node to the target node and returns the
+// task ID.
+func (c *Client) MigrateAllGuestsAsync(ctx context.Context, d *MigrateAllRequestBody) (*string, error) {
+	return c.bulkActionAsync(ctx, "migrateall", d)
+}
+
+// MigrateAllGuests migrates the guests of the node to the target node and waits for the
+// migrations to finish.
+func (c *Client) MigrateAllGuests(ctx context.Context, d *MigrateAllRequestBody) tasks.TaskResult {
+	op := retry.NewTaskOperation("migrate all guests",
+		retry.WithRetryIf(retry.IsTransientAPIError),
+	)
+
+	return c.Tasks().DoTask(ctx, op,
+		func() (*string, error) { return c.MigrateAllGuestsAsync(ctx, d) },
+	)
+}
+
+// bulkActionAsync starts a bulk guest action of the node and returns the task ID.
+func (c *Client) bulkActionAsync(ctx context.Context, action string, d any) (*string, error) {
+	resBody := &BulkActionResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath(action), d, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run %s on node \"%s\": %w", action, c.NodeName, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
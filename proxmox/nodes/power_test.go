@@ -0,0 +1,113 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+func newFakeClient(t *testing.T) proxmox.Client {
+	t.Helper()
+
+	s := fake.NewServer(fake.WithNodes("pve", "pve2", "pve3"))
+	t.Cleanup(s.Close)
+
+	apiClient, err := s.Client()
+	require.NoError(t, err)
+
+	return proxmox.NewClient(apiClient, nil, "")
+}
+
+func TestBulkGuestActions(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	client := newFakeClient(t)
+	node := client.Node("pve")
+
+	require.NoError(t, node.VM(0).CreateVM(ctx, &vms.CreateRequestBody{VMID: 100, StartOnBoot: new(types.CustomBool(true))}).Err())
+	require.NoError(t, node.VM(0).CreateVM(ctx, &vms.CreateRequestBody{VMID: 101}).Err())
+
+	vmStatus := func(vmid int) string {
+		nodeName, err := client.Cluster().GetVMNodeName(ctx, vmid)
+		require.NoError(t, err)
+
+		status, err := client.Node(*nodeName).VM(vmid).GetVMStatus(ctx)
+		require.NoError(t, err)
+
+		return status.Status
+	}
+
+	require.NoError(t, node.StartAllGuests(ctx, &nodes.StartAllRequestBody{}).Err())
+	assert.Equal(t, "running", vmStatus(100))
+	assert.Equal(t, "stopped", vmStatus(101), "only the guests started on boot are started without force")
+
+	require.NoError(t, node.StartAllGuests(ctx, &nodes.StartAllRequestBody{Force: new(types.CustomBool(true))}).Err())
+	assert.Equal(t, "running", vmStatus(101))
+
+	require.NoError(t, node.StopAllGuests(ctx, &nodes.StopAllRequestBody{VMs: new("101")}).Err())
+	assert.Equal(t, "running", vmStatus(100))
+	assert.Equal(t, "stopped", vmStatus(101))
+
+	result := node.MigrateAllGuests(ctx, &nodes.MigrateAllRequestBody{Target: "pve2", MaxWorkers: new(int64(2))})
+	require.NoError(t, result.Err())
+
+	for _, vmid := range []int{100, 101} {
+		nodeName, err := client.Cluster().GetVMNodeName(ctx, vmid)
+		require.NoError(t, err)
+		assert.Equal(t, "pve2", *nodeName)
+	}
+
+	err := node.MigrateAllGuests(ctx, &nodes.MigrateAllRequestBody{Target: "pve"}).Err()
+	require.ErrorContains(t, err, "target is local node")
+}
+
+func TestPowerCommands(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	node := newFakeClient(t).Node("pve2")
+
+	since := time.Now()
+
+	require.NoError(t, node.ExecutePowerCommand(ctx, nodes.PowerCommandReboot))
+	require.NoError(t, node.WaitForReboot(ctx, since))
+
+	require.NoError(t, node.ExecutePowerCommand(ctx, nodes.PowerCommandShutdown))
+	require.NoError(t, node.WaitForStatus(ctx, nodes.NodeStatusOffline))
+
+	_, err := node.GetInfo(ctx)
+	require.Error(t, err, "an offline node is unreachable")
+
+	mac, err := node.WakeOnLAN(ctx)
+	require.NoError(t, err)
+	assert.Regexp(t, `^52:54:00(:[0-9a-f]{2}){3}$`, mac)
+	require.NoError(t, node.WaitForStatus(ctx, nodes.NodeStatusOnline))
+}
+
+func TestWaitForRebootTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+
+	err := newFakeClient(t).Node("pve").WaitForReboot(ctx, time.Now())
+	require.ErrorContains(t, err, "timeout while waiting for node \"pve\" to reboot")
+}
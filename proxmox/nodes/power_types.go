@@ -0,0 +1,67 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+
+// Power commands supported by the node status API.
+const (
+	PowerCommandReboot   = "reboot"
+	PowerCommandShutdown = "shutdown"
+)
+
+// Node statuses reported by the node list.
+const (
+	NodeStatusOnline  = "online"
+	NodeStatusOffline = "offline"
+)
+
+// PowerRequestBody contains the body for a request to reboot or shut down a node.
+type PowerRequestBody struct {
+	Command string `url:"command"`
+}
+
+// WakeOnLANResponseBody contains the body from a Wake-on-LAN response, which is the MAC
+// address the packet was sent to.
+type WakeOnLANResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
+
+// StartAllRequestBody contains the body for a request to start the guests of a node.
+type StartAllRequestBody struct {
+	// Force starts the guests regardless of their `onboot` setting.
+	Force *types.CustomBool `url:"force,omitempty,int"`
+	// VMs is a comma-separated list of the IDs of the guests to start, all if empty.
+	VMs *string `url:"vms,omitempty"`
+}
+
+// StopAllRequestBody contains the body for a request to stop the guests of a node.
+type StopAllRequestBody struct {
+	// ForceStop hard stops the guests that did not shut down within the timeout.
+	ForceStop *types.CustomBool `url:"force-stop,omitempty,int"`
+	// Timeout is the time in seconds the guests are given to shut down.
+	Timeout *int64 `url:"timeout,omitempty"`
+	// VMs is a comma-separated list of the IDs of the guests to stop, all if empty.
+	VMs *string `url:"vms,omitempty"`
+}
+
+// MigrateAllRequestBody contains the body for a request to migrate the guests of a node.
+type MigrateAllRequestBody struct {
+	Target string `url:"target"`
+	// MaxWorkers is the maximum number of parallel migrations, which defaults to the
+	// `max_workers` datacenter option.
+	MaxWorkers *int64 `url:"maxworkers,omitempty"`
+	// VMs is a comma-separated list of the IDs of the guests to migrate, all if empty.
+	VMs            *string           `url:"vms,omitempty"`
+	WithLocalDisks *types.CustomBool `url:"with-local-disks,omitempty,int"`
+}
+
+// BulkActionResponseBody contains the body from a response to a bulk guest action, which is
+// the task ID.
+type BulkActionResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
@@ -0,0 +1,31 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// Client is an interface for accessing the replication jobs of a node.
+type Client struct {
+	api.Client
+
+	ID string
+}
+
+// ExpandPath expands a relative path to a full replication job path.
+func (c *Client) ExpandPath(path string) string {
+	p := fmt.Sprintf("replication/%s", url.PathEscape(c.ID))
+	if path != "" {
+		p = fmt.Sprintf("%s/%s", p, path)
+	}
+
+	return c.Client.ExpandPath(p)
+}
@@ -0,0 +1,95 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/retry"
+)
+
+// pollDelay is the delay between the job status checks. The scheduler only starts due jobs
+// once per minute.
+const pollDelay = 5 * time.Second
+
+// ErrJobFailed is returned when a run of a replication job failed.
+var ErrJobFailed = errors.New("replication job failed")
+
+// GetStatus retrieves the state of the replication job on the node.
+func (c *Client) GetStatus(ctx context.Context) (*StatusResponseData, error) {
+	resBody := &StatusResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("status"), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving status of replication job %s: %w", c.ID, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// ScheduleNow schedules the replication job to run as soon as possible.
+func (c *Client) ScheduleNow(ctx context.Context) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("schedule_now"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error scheduling replication job %s: %w", c.ID, err)
+	}
+
+	return nil
+}
+
+// WaitForRun waits until a run of the replication job that started after the given Unix
+// timestamp has finished, and returns the state of the job after it.
+func (c *Client) WaitForRun(ctx context.Context, since int64) (*StatusResponseData, error) {
+	notFinished := errors.New("replication job run not finished")
+
+	var data *StatusResponseData
+
+	op := retry.NewPollOperation("replication job run",
+		retry.WithBaseDelay(pollDelay),
+		retry.WithRetryIf(func(err error) bool {
+			return errors.Is(err, notFinished)
+		}),
+	)
+
+	err := op.DoPoll(ctx, func() error {
+		var err error
+
+		data, err = c.GetStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		if data.Running() || data.LastTryTime() <= since {
+			return notFinished
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return data, fmt.Errorf("timeout while waiting for replication job %s to run", c.ID)
+	}
+
+	if err != nil {
+		return data, fmt.Errorf("error waiting for replication job %s to run: %w", c.ID, err)
+	}
+
+	if data.Error != nil && *data.Error != "" {
+		return data, fmt.Errorf("%w: %s", ErrJobFailed, *data.Error)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,84 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedAPIClient returns the job status decoded from the responses in order,
+// repeating the last one.
+type scriptedAPIClient struct {
+	responses []string
+	calls     int
+}
+
+func (c *scriptedAPIClient) DoRequest(_ context.Context, _, _ string, _, responseBody any) error {
+	r := c.responses[min(c.calls, len(c.responses)-1)]
+	c.calls++
+
+	return json.Unmarshal([]byte(`{"data":`+r+`}`), responseBody)
+}
+
+func (c *scriptedAPIClient) ExpandPath(path string) string       { return path }
+func (c *scriptedAPIClient) IsRoot(_ context.Context) bool       { return false }
+func (c *scriptedAPIClient) IsRootTicket(_ context.Context) bool { return false }
+func (c *scriptedAPIClient) HTTP() *http.Client                  { return &http.Client{} }
+
+const (
+	synced = `{"id":"100-0","guest":"100","target":"pve2","last_sync":1700000600,"last_try":1700000600,
+		"next_sync":1700001500,"duration":3.2,"fail_count":0}`
+	running = `{"id":"100-0","guest":"100","target":"pve2","last_sync":1700000000,"last_try":1700000600,
+		"fail_count":0,"pid":4242}`
+	broken = `{"id":"100-0","guest":"100","target":"pve2","last_sync":1700000000,"last_try":1700000600,
+		"duration":0.5,"fail_count":2,"error":"storage 'local-zfs' is not available on node 'pve2'"}`
+)
+
+func TestClientExpandPath(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{Client: &scriptedAPIClient{}, ID: "100-0"}
+	assert.Equal(t, "replication/100-0/status", c.ExpandPath("status"))
+}
+
+func TestWaitForRun(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{Client: &scriptedAPIClient{responses: []string{synced}}, ID: "100-0"}
+	data, err := c.WaitForRun(t.Context(), 1700000000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000600), *data.LastSync)
+	assert.InDelta(t, 3.2, float64(*data.Duration), 0.001)
+
+	c = &Client{Client: &scriptedAPIClient{responses: []string{broken}}, ID: "100-0"}
+	data, err = c.WaitForRun(t.Context(), 1700000000)
+	require.ErrorIs(t, err, ErrJobFailed)
+	require.ErrorContains(t, err, "local-zfs")
+	assert.Equal(t, int64(2), *data.FailCount)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+
+	c = &Client{Client: &scriptedAPIClient{responses: []string{running}}, ID: "100-0"}
+	_, err = c.WaitForRun(ctx, 1700000000)
+	require.ErrorContains(t, err, "timeout while waiting for replication job 100-0")
+
+	ctx, cancel = context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+
+	// a run that finished before the job was scheduled is not the awaited one
+	c = &Client{Client: &scriptedAPIClient{responses: []string{synced}}, ID: "100-0"}
+	_, err = c.WaitForRun(ctx, 1700000600)
+	require.Error(t, err)
+}
@@ -0,0 +1,43 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+
+// StatusResponseBody contains the body from a replication job status response.
+type StatusResponseBody struct {
+	Data *StatusResponseData `json:"data,omitempty"`
+}
+
+// StatusResponseData contains the state of a replication job on its source node.
+// The timestamps are Unix timestamps, and are 0 while the job has not run yet.
+type StatusResponseData struct {
+	ID        string               `json:"id"`
+	Guest     types.CustomInt64    `json:"guest"`
+	Target    string               `json:"target"`
+	LastSync  *int64               `json:"last_sync,omitempty"`
+	LastTry   *int64               `json:"last_try,omitempty"`
+	NextSync  *int64               `json:"next_sync,omitempty"`
+	Duration  *types.CustomFloat64 `json:"duration,omitempty"`
+	FailCount *int64               `json:"fail_count,omitempty"`
+	Error     *string              `json:"error,omitempty"`
+	PID       *int64               `json:"pid,omitempty"`
+}
+
+// Running returns whether the job is running.
+func (d *StatusResponseData) Running() bool {
+	return d.PID != nil && *d.PID != 0
+}
+
+// LastTryTime returns the time of the last run of the job, or 0 if it has not run yet.
+func (d *StatusResponseData) LastTryTime() int64 {
+	if d.LastTry == nil {
+		return 0
+	}
+
+	return *d.LastTry
+}
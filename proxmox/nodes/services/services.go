@@ -0,0 +1,83 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/tasks"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/retry"
+)
+
+// ListServices retrieves the state of the services of the node, sorted by service ID.
+func (c *Client) ListServices(ctx context.Context) ([]*StateResponseData, error) {
+	resBody := &ListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(""), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing services: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	sort.Slice(resBody.Data, func(i, j int) bool {
+		return resBody.Data[i].Service < resBody.Data[j].Service
+	})
+
+	return resBody.Data, nil
+}
+
+// GetServiceState retrieves the state of a service.
+func (c *Client) GetServiceState(ctx context.Context, service string) (*StateResponseData, error) {
+	resBody := &StateResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(url.PathEscape(service)+"/state"), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving state of service %s: %w", service, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// ExecuteServiceCommandAsync starts, stops, restarts or reloads a service and returns the task ID.
+func (c *Client) ExecuteServiceCommandAsync(ctx context.Context, service, command string) (*string, error) {
+	resBody := &CommandResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath(url.PathEscape(service)+"/"+command), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error running %s of service %s: %w", command, service, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// ExecuteServiceCommand starts, stops, restarts or reloads a service and waits for the command
+// to finish.
+func (c *Client) ExecuteServiceCommand(ctx context.Context, service, command string) tasks.TaskResult {
+	op := retry.NewTaskOperation("service "+command,
+		retry.WithRetryIf(retry.IsTransientAPIError),
+	)
+
+	return c.Tasks().DoTask(ctx, op,
+		func() (*string, error) { return c.ExecuteServiceCommandAsync(ctx, service, command) },
+	)
+}
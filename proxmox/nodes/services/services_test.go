@@ -0,0 +1,59 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package services_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/services"
+)
+
+func TestServices(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	s := fake.NewServer()
+	t.Cleanup(s.Close)
+
+	apiClient, err := s.Client()
+	require.NoError(t, err)
+
+	c := proxmox.NewClient(apiClient, nil, "").Node(fake.DefaultNode).Services()
+
+	list, err := c.ListServices(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+	assert.Equal(t, "chrony", list[0].Service)
+
+	state, err := c.GetServiceState(ctx, "sshd")
+	require.NoError(t, err)
+	assert.Equal(t, "ssh", *state.Name)
+	assert.Equal(t, services.StateRunning, *state.State)
+	assert.Equal(t, services.UnitStateEnabled, *state.UnitState)
+
+	require.NoError(t, c.ExecuteServiceCommand(ctx, "postfix", services.CommandStop).Err())
+
+	state, err = c.GetServiceState(ctx, "postfix")
+	require.NoError(t, err)
+	assert.Equal(t, services.StateStopped, *state.State)
+	assert.Equal(t, "inactive", *state.ActiveState)
+
+	require.NoError(t, c.ExecuteServiceCommand(ctx, "postfix", services.CommandRestart).Err())
+
+	state, err = c.GetServiceState(ctx, "postfix")
+	require.NoError(t, err)
+	assert.Equal(t, services.StateRunning, *state.State)
+
+	_, err = c.GetServiceState(ctx, "nginx")
+	require.ErrorContains(t, err, "does not have a value in the enumeration")
+}
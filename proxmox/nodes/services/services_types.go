@@ -0,0 +1,55 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package services
+
+// Service states reported by the node services API.
+const (
+	StateRunning = "running"
+	StateStopped = "stopped"
+)
+
+// Service commands supported by the node services API.
+const (
+	CommandStart   = "start"
+	CommandStop    = "stop"
+	CommandRestart = "restart"
+	CommandReload  = "reload"
+)
+
+// Unit states of the systemd units of the services.
+const (
+	UnitStateEnabled  = "enabled"
+	UnitStateDisabled = "disabled"
+)
+
+// ListResponseBody contains the body from a service list response.
+type ListResponseBody struct {
+	Data []*StateResponseData `json:"data,omitempty"`
+}
+
+// StateResponseBody contains the body from a service state response.
+type StateResponseBody struct {
+	Data *StateResponseData `json:"data,omitempty"`
+}
+
+// StateResponseData contains the state of a service.
+type StateResponseData struct {
+	Service     string  `json:"service"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"desc,omitempty"`
+	// State is `running`, `stopped` or `unknown`.
+	State *string `json:"state,omitempty"`
+	// ActiveState is the systemd active state of the unit, e.g. `active` or `failed`.
+	ActiveState *string `json:"active-state,omitempty"`
+	// UnitState is the systemd unit file state, e.g. `enabled`, `disabled` or `masked`.
+	UnitState *string `json:"unit-state,omitempty"`
+}
+
+// CommandResponseBody contains the body from a service command response, which is the task ID.
+type CommandResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
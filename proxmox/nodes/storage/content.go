@@ -56,10 +56,28 @@ func (c *Client) ListDatastoreFiles(
 	ctx context.Context,
 	contentType *string,
 ) ([]*DatastoreFileListResponseData, error) {
-	resBody := &DatastoreFileListResponseBody{}
-	reqBody := &DatastoreFileListRequestBody{
+	return c.listDatastoreFiles(ctx, &DatastoreFileListRequestBody{
 		ContentType: contentType,
-	}
+	})
+}
+
+// ListDatastoreBackups retrieves a list of the backups in a datastore, including their metadata.
+// vmID is optional and filters the results by the guest the backups belong to.
+func (c *Client) ListDatastoreBackups(
+	ctx context.Context,
+	vmID *int,
+) ([]*DatastoreFileListResponseData, error) {
+	return c.listDatastoreFiles(ctx, &DatastoreFileListRequestBody{
+		ContentType: new(ContentTypeBackup),
+		VMID:        vmID,
+	})
+}
+
+func (c *Client) listDatastoreFiles(
+	ctx context.Context,
+	reqBody *DatastoreFileListRequestBody,
+) ([]*DatastoreFileListResponseData, error) {
+	resBody := &DatastoreFileListResponseBody{}
 
 	err := retry.New(
 		retry.Context(ctx),
@@ -6,6 +6,8 @@
 
 package storage
 
+import "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+
 // Content type constants for Proxmox VE datastores.
 const (
 	ContentTypeBackup   = "backup"   // VM backups
@@ -33,6 +35,7 @@ func ValidContentTypes() []string {
 // DatastoreFileListRequestBody contains the request parameters for listing datastore files.
 type DatastoreFileListRequestBody struct {
 	ContentType *string `json:"content,omitempty" url:"content,omitempty"`
+	VMID        *int    `json:"vmid,omitempty"    url:"vmid,omitempty"`
 }
 
 // DatastoreFileListResponseBody contains the body from a datastore content list response.
@@ -49,6 +52,26 @@ type DatastoreFileListResponseData struct {
 	SpaceUsed      *int    `json:"used,omitempty"`
 	VMID           *int    `json:"vmid,omitempty"`
 	VolumeID       string  `json:"volid"`
+
+	// Backup metadata, only reported for the `backup` content type.
+	CreationTime *int64                     `json:"ctime,omitempty"`
+	Encrypted    *string                    `json:"encrypted,omitempty"`
+	Notes        *string                    `json:"notes,omitempty"`
+	Protected    *types.CustomBool          `json:"protected,omitempty"`
+	Subtype      *string                    `json:"subtype,omitempty"`
+	Verification *DatastoreFileVerification `json:"verification,omitempty"`
+}
+
+// DatastoreFileVerification contains the state of the last verification of a backup.
+type DatastoreFileVerification struct {
+	State string `json:"state"`
+	UPID  string `json:"upid"`
+}
+
+// IsEncrypted returns true if the backup is encrypted. PBS reports the key fingerprint, or `1`
+// when the fingerprint is unknown.
+func (d *DatastoreFileListResponseData) IsEncrypted() bool {
+	return d.Encrypted != nil && *d.Encrypted != "" && *d.Encrypted != "0"
 }
 
 // DatastoreFileGetRequestData contains the body from a datastore content get request.
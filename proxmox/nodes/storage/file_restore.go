@@ -0,0 +1,69 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// ListRestoreFiles lists the entries of a directory inside a backup stored on a Proxmox Backup Server
+// datastore. Use "/" as filePath to list the archives of the backup.
+func (c *Client) ListRestoreFiles(
+	ctx context.Context,
+	volumeID string,
+	filePath string,
+) ([]*FileRestoreListResponseData, error) {
+	resBody := &FileRestoreListResponseBody{}
+	reqBody := &FileRestoreListRequestBody{
+		FilePath: base64.StdEncoding.EncodeToString([]byte(filePath)),
+		Volume:   volumeID,
+	}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("file-restore/list"), reqBody, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing %s in backup %s: %w", filePath, volumeID, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// DownloadRestoreFile streams a file from a backup stored on a Proxmox Backup Server datastore into w.
+// Directories are returned as a zip archive, or as a zstd compressed tar archive if tar is set.
+func (c *Client) DownloadRestoreFile(
+	ctx context.Context,
+	volumeID string,
+	filePath string,
+	tar bool,
+	w io.Writer,
+) error {
+	reqBody := &FileRestoreDownloadRequestBody{
+		FilePath: base64.StdEncoding.EncodeToString([]byte(filePath)),
+		Volume:   volumeID,
+	}
+
+	if tar {
+		reqBody.Tar = new(types.CustomBool(true))
+	}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("file-restore/download"), reqBody, w)
+	if err != nil {
+		return fmt.Errorf("error downloading %s from backup %s: %w", filePath, volumeID, err)
+	}
+
+	return nil
+}
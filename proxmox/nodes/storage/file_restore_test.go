@@ -0,0 +1,143 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+)
+
+func newFakeStorageClient(t *testing.T, s *fake.Server, storageName string) *Client {
+	t.Helper()
+
+	apiClient, err := s.Client()
+	require.NoError(t, err)
+
+	return &Client{
+		Client: &nodeClient{Client: apiClient, nodeName: fake.DefaultNode},
+
+		StorageName: storageName,
+	}
+}
+
+// nodeClient expands paths relative to a node, like nodes.Client does.
+type nodeClient struct {
+	api.Client
+
+	nodeName string
+}
+
+func (c *nodeClient) ExpandPath(path string) string {
+	return "nodes/" + c.nodeName + "/" + path
+}
+
+func TestListDatastoreBackups(t *testing.T) {
+	t.Parallel()
+
+	s := fake.NewServer(fake.WithStorage("pbs", "pbs", true, "backup"))
+	t.Cleanup(s.Close)
+
+	backupTime := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	vmBackup, err := s.AddBackup(fake.DefaultNode, "pbs", 100, fake.Backup{
+		Time:         backupTime,
+		Size:         1 << 30,
+		Notes:        "before upgrade",
+		Protected:    true,
+		Verification: "ok",
+		Encrypted:    "aa:bb:cc",
+	})
+	require.NoError(t, err)
+
+	_, err = s.AddBackup(fake.DefaultNode, "pbs", 101, fake.Backup{Container: true, Time: backupTime})
+	require.NoError(t, err)
+
+	c := newFakeStorageClient(t, s, "pbs")
+
+	backups, err := c.ListDatastoreBackups(t.Context(), new(100))
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	b := backups[0]
+	assert.Equal(t, vmBackup, b.VolumeID)
+	assert.Equal(t, "pbs:backup/vm/100/2025-03-01T12:00:00Z", b.VolumeID)
+	assert.Equal(t, backupTime.Unix(), *b.CreationTime)
+	assert.Equal(t, "before upgrade", *b.Notes)
+	assert.True(t, bool(*b.Protected))
+	assert.Equal(t, "ok", b.Verification.State)
+	assert.True(t, b.IsEncrypted())
+	assert.Equal(t, "qemu", *b.Subtype)
+
+	all, err := c.ListDatastoreBackups(t.Context(), nil)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.False(t, all[0].IsEncrypted())
+	assert.Equal(t, "lxc", *all[0].Subtype)
+	assert.Nil(t, all[0].Verification)
+}
+
+func TestFileRestore(t *testing.T) {
+	t.Parallel()
+
+	s := fake.NewServer(fake.WithStorage("pbs", "pbs", true, "backup"))
+	t.Cleanup(s.Close)
+
+	volumeID, err := s.AddBackup(fake.DefaultNode, "pbs", 101, fake.Backup{
+		Container: true,
+		Files: map[string]string{
+			"/root.pxar.didx/etc/hostname": "ct101\n",
+			"/root.pxar.didx/etc/hosts":    "127.0.0.1 localhost\n",
+		},
+	})
+	require.NoError(t, err)
+
+	c := newFakeStorageClient(t, s, "pbs")
+
+	entries, err := c.ListRestoreFiles(t.Context(), volumeID, "/root.pxar.didx/etc")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "hostname", entries[0].Text)
+	assert.Equal(t, FileRestoreTypeFile, entries[0].Type)
+	assert.True(t, bool(entries[0].Leaf))
+
+	filePath, err := base64.StdEncoding.DecodeString(entries[0].FilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "/root.pxar.didx/etc/hostname", string(filePath))
+
+	var file bytes.Buffer
+
+	require.NoError(t, c.DownloadRestoreFile(t.Context(), volumeID, "/root.pxar.didx/etc/hostname", false, &file))
+	assert.Equal(t, "ct101\n", file.String())
+
+	var dir bytes.Buffer
+
+	require.NoError(t, c.DownloadRestoreFile(t.Context(), volumeID, "/root.pxar.didx/etc", false, &dir))
+
+	zr, err := zip.NewReader(bytes.NewReader(dir.Bytes()), int64(dir.Len()))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 2)
+
+	f, err := zr.File[1].Open()
+	require.NoError(t, err)
+
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1 localhost\n", string(content))
+
+	err = c.DownloadRestoreFile(t.Context(), volumeID, "/root.pxar.didx/missing", false, io.Discard)
+	require.Error(t, err)
+}
@@ -0,0 +1,48 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+
+// File restore entry types, as reported by the PBS catalog.
+const (
+	FileRestoreTypeDirectory = "d"
+	FileRestoreTypeFile      = "f"
+	FileRestoreTypeSymlink   = "l"
+	FileRestoreTypeVirtual   = "v"
+)
+
+// FileRestoreListRequestBody contains the request parameters for listing files in a backup.
+type FileRestoreListRequestBody struct {
+	// FilePath is the base64 encoded path of the directory to list.
+	FilePath string `url:"filepath"`
+	Volume   string `url:"volume"`
+}
+
+// FileRestoreListResponseBody contains the body from a file restore list response.
+type FileRestoreListResponseBody struct {
+	Data []*FileRestoreListResponseData `json:"data,omitempty"`
+}
+
+// FileRestoreListResponseData contains a single entry of a file restore list response.
+type FileRestoreListResponseData struct {
+	// FilePath is the base64 encoded path of the entry.
+	FilePath string           `json:"filepath"`
+	Leaf     types.CustomBool `json:"leaf"`
+	MTime    *int64           `json:"mtime,omitempty"`
+	Size     *int64           `json:"size,omitempty"`
+	Text     string           `json:"text"`
+	Type     string           `json:"type"`
+}
+
+// FileRestoreDownloadRequestBody contains the request parameters for downloading a file from a backup.
+type FileRestoreDownloadRequestBody struct {
+	// FilePath is the base64 encoded path of the file or directory to download.
+	FilePath string            `url:"filepath"`
+	Volume   string            `url:"volume"`
+	Tar      *types.CustomBool `url:"tar,omitempty,int"`
+}
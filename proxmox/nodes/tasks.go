@@ -0,0 +1,31 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// ListTasks lists the tasks of the node, newest first.
+func (c *Client) ListTasks(ctx context.Context, d *ListTasksRequestBody) ([]*ListTasksResponseData, error) {
+	resBody := &ListTasksResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("tasks"), d, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing tasks of node %q: %w", c.NodeName, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
@@ -61,6 +61,7 @@ type DiagnosticAccumulator interface {
 
 // AddDiags adds the TaskResult's error and warnings to a DiagnosticAccumulator.
 // The summary is used as the diagnostic summary for both errors and warnings.
+// The error of a task that failed carries the last lines of the task log, which end up in the error detail.
 // Returns true if the result contains an error.
 func (r TaskResult) AddDiags(diags DiagnosticAccumulator, summary string) bool {
 	if r.err != nil {
@@ -11,14 +11,17 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	retrylib "github.com/avast/retry-go/v5"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/retry"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/tracing"
 )
 
 // GetTaskStatus retrieves the status of a task.
@@ -71,6 +74,77 @@ func (c *Client) GetTaskLog(ctx context.Context, upid string) ([]string, error)
 	return lines, nil
 }
 
+// GetTaskLogLines retrieves up to limit lines of the task log, starting after the first start lines.
+func (c *Client) GetTaskLogLines(ctx context.Context, upid string, start, limit int) ([]*GetTaskLogResponseData, error) {
+	resBody, err := c.getTaskLogPage(ctx, upid, start, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody.Data, nil
+}
+
+// GetTaskLogTail retrieves the last lines of the task log, together with the number of lines before them.
+func (c *Client) GetTaskLogTail(ctx context.Context, upid string, lines int) ([]string, int, error) {
+	var tail []string
+
+	start := 0
+
+	for {
+		resBody, err := c.getTaskLogPage(ctx, upid, start, taskLogPageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for _, line := range resBody.Data {
+			tail = append(tail, line.LineText)
+		}
+
+		start += len(resBody.Data)
+
+		if len(tail) > lines {
+			tail = tail[len(tail)-lines:]
+		}
+
+		if len(resBody.Data) < taskLogPageSize {
+			return tail, start - len(tail), nil
+		}
+
+		// skip to the last page when the API reports the length of the log
+		if resBody.Total != nil && *resBody.Total-lines > start {
+			start = *resBody.Total - lines
+			tail = nil
+		}
+	}
+}
+
+func (c *Client) getTaskLogPage(ctx context.Context, upid string, start, limit int) (*GetTaskLogResponseBody, error) {
+	resBody := &GetTaskLogResponseBody{}
+
+	path, err := c.BuildPath(upid, "log")
+	if err != nil {
+		return nil, fmt.Errorf("error building path for task log: %w", err)
+	}
+
+	reqBody := &GetTaskLogRequestBody{Start: &start, Limit: &limit}
+
+	err = c.DoRequest(ctx, http.MethodGet, path, reqBody, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving task log: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	// an empty log is reported as a single placeholder line
+	if len(resBody.Data) == 1 && resBody.Data[0].LineText == taskLogNoContent {
+		resBody.Data = resBody.Data[:0]
+	}
+
+	return resBody, nil
+}
+
 // DeleteTask deletes specific task.
 func (c *Client) DeleteTask(ctx context.Context, upid string) error {
 	path, err := c.baseTaskPath(upid)
@@ -90,9 +164,19 @@ func (c *Client) DeleteTask(ctx context.Context, upid string) error {
 	return nil
 }
 
+// taskLogPageSize is the number of task log lines fetched per request when streaming the log.
+const taskLogPageSize = 500
+
+// taskLogNoContent is the placeholder line the API returns for a task log without lines (yet).
+const taskLogNoContent = "no content"
+
+// taskLogTailLines is the number of the last task log lines included in the error of a failed task.
+const taskLogTailLines = 50
+
 type taskWaitOptions struct {
 	failOnWarnings   bool
 	ignoreStatusCode int
+	logHandler       func(line string)
 }
 
 // TaskWaitOption is an option for waiting for a task to complete.
@@ -125,6 +209,21 @@ func (w withIgnoreStatus) apply(opts *taskWaitOptions) {
 	opts.ignoreStatusCode = w.statusCode
 }
 
+type withLogHandler struct {
+	handler func(line string)
+}
+
+// WithLogHandler streams the task log while waiting for the task to complete: the handler is
+// called once for every log line, in order, as the task produces them. Failures to fetch the
+// log are logged and do not affect the result.
+func WithLogHandler(handler func(line string)) TaskWaitOption {
+	return withLogHandler{handler: handler}
+}
+
+func (w withLogHandler) apply(opts *taskWaitOptions) {
+	opts.logHandler = w.handler
+}
+
 // DoTask dispatches an async PVE task with retry and waits for its result.
 // On dispatch failure, the returned TaskResult wraps the dispatch error.
 // On success or task failure, the TaskResult comes from WaitForTask. When the task
@@ -150,7 +249,10 @@ func (c *Client) DoTask(
 
 // WaitForTask waits for a specific task to complete and returns a TaskResult
 // that carries the outcome (error and/or warnings extracted from the task log).
-func (c *Client) WaitForTask(ctx context.Context, upid string, opts ...TaskWaitOption) TaskResult {
+func (c *Client) WaitForTask(ctx context.Context, upid string, opts ...TaskWaitOption) (result TaskResult) {
+	ctx, span := tracing.Start(ctx, "proxmox.task.wait", taskSpanAttributes(upid)...)
+	defer func() { tracing.End(span, result.Err()) }()
+
 	errStillRunning := errors.New("still running")
 
 	options := &taskWaitOptions{}
@@ -159,6 +261,8 @@ func (c *Client) WaitForTask(ctx context.Context, upid string, opts ...TaskWaitO
 		opt.apply(options)
 	}
 
+	logStream := &taskLogStream{client: c, upid: upid, handler: options.logHandler}
+
 	status, err := retrylib.NewWithData[*GetTaskStatusResponseData](
 		retrylib.Context(ctx),
 		retrylib.RetryIf(func(err error) bool {
@@ -189,6 +293,9 @@ func (c *Client) WaitForTask(ctx context.Context, upid string, opts ...TaskWaitO
 				return nil, err
 			}
 
+			// the log is read after the status, so it is complete once the task has stopped
+			logStream.poll(ctx)
+
 			if status.Status == "running" {
 				return nil, errStillRunning
 			}
@@ -204,6 +311,8 @@ func (c *Client) WaitForTask(ctx context.Context, upid string, opts ...TaskWaitO
 		return TaskFailed(fmt.Errorf("error while waiting for task %q to complete: %w", upid, err))
 	}
 
+	span.SetAttributes(tracing.AttrTaskExit.String(status.ExitCode))
+
 	if status.ExitCode != "OK" {
 		if !options.failOnWarnings &&
 			strings.HasPrefix(status.ExitCode, "WARNINGS: ") && !strings.Contains(status.ExitCode, "ERROR") {
@@ -221,6 +330,74 @@ func (c *Client) WaitForTask(ctx context.Context, upid string, opts ...TaskWaitO
 	return TaskOK()
 }
 
+// taskLogStream passes new task log lines to a handler, see WithLogHandler.
+type taskLogStream struct {
+	client  *Client
+	upid    string
+	handler func(line string)
+	offset  int
+}
+
+// poll passes the log lines produced since the previous poll to the handler.
+func (s *taskLogStream) poll(ctx context.Context) {
+	if s.handler == nil {
+		return
+	}
+
+	for {
+		lines, err := s.client.GetTaskLogLines(ctx, s.upid, s.offset, taskLogPageSize)
+		if err != nil {
+			tflog.Debug(ctx, "failed to fetch task log for streaming", map[string]any{
+				"task_id": s.upid,
+				"error":   err.Error(),
+			})
+
+			return
+		}
+
+		for _, line := range lines {
+			n := line.LineNumber
+			if n == 0 {
+				n = s.offset + 1
+			}
+
+			// the offset follows the line numbers, so lines are never skipped or passed twice
+			if n <= s.offset {
+				continue
+			}
+
+			s.handler(line.LineText)
+			s.offset = n
+		}
+
+		if len(lines) < taskLogPageSize {
+			return
+		}
+	}
+}
+
+// taskSpanAttributes returns the span attributes describing a task. Components that cannot
+// be parsed from the UPID are omitted.
+func taskSpanAttributes(upid string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{tracing.AttrUPID.String(upid)}
+
+	tid, err := ParseTaskID(upid)
+	if err != nil {
+		return attrs
+	}
+
+	attrs = append(attrs,
+		tracing.AttrNode.String(tid.NodeName),
+		tracing.AttrTaskType.String(tid.Type),
+	)
+
+	if vmid, err := strconv.Atoi(tid.ID); err == nil {
+		attrs = append(attrs, tracing.AttrVMID.Int(vmid))
+	}
+
+	return attrs
+}
+
 // filterWarnings extracts warning lines from a task log.
 func filterWarnings(lines []string) []string {
 	var warnings []string
@@ -252,10 +429,11 @@ func (c *Client) getTaskWarnings(ctx context.Context, upid string) []string {
 	return filterWarnings(lines)
 }
 
-// taskFailedResult fetches the task log and returns a TaskResult that includes both the exit code and
-// the log output, so users can see what went wrong without checking the Proxmox task history.
+// taskFailedResult fetches the tail of the task log and returns a TaskResult that includes both the exit code and
+// the last log lines, so users can see what went wrong without checking the Proxmox task history. The reason of
+// a failure is usually at the end of the log, which can be long, e.g. for a backup of many guests.
 func (c *Client) taskFailedResult(ctx context.Context, upid string, exitCode string) TaskResult {
-	lines, err := c.GetTaskLog(ctx, upid)
+	lines, skipped, err := c.GetTaskLogTail(ctx, upid, taskLogTailLines)
 	if err != nil {
 		tflog.Warn(ctx, "failed to fetch task log for error details", map[string]any{
 			"task_id": upid,
@@ -265,37 +443,33 @@ func (c *Client) taskFailedResult(ctx context.Context, upid string, exitCode str
 		return TaskFailed(fmt.Errorf("task %q failed to complete with exit code: %s", upid, exitCode))
 	}
 
-	if len(lines) == 0 {
-		return TaskFailed(fmt.Errorf("task %q failed to complete with exit code: %s", upid, exitCode))
+	// Exclude WARN: lines from the error detail since they are surfaced as separate warning diagnostics.
+	warnings := filterWarnings(lines)
+	nonWarningLines := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		if !strings.Contains(line, "WARN:") {
+			nonWarningLines = append(nonWarningLines, line)
+		}
 	}
 
-	if warnings := filterWarnings(lines); len(warnings) > 0 {
-		// Exclude WARN: lines from the error detail since they are surfaced as separate warning diagnostics.
-		nonWarningLines := make([]string, 0, len(lines))
+	taskErr := fmt.Errorf("task %q failed to complete with exit code: %s", upid, exitCode)
 
-		for _, line := range lines {
-			if !strings.Contains(line, "WARN:") {
-				nonWarningLines = append(nonWarningLines, line)
-			}
+	if len(nonWarningLines) > 0 {
+		heading := "task log:"
+		if skipped > 0 {
+			heading = fmt.Sprintf("task log (%d earlier lines omitted):", skipped)
 		}
 
-		var taskErr error
-		if len(nonWarningLines) > 0 {
-			taskErr = fmt.Errorf(
-				"task %q failed to complete with exit code: %s\ntask log:\n  %s",
-				upid, exitCode, strings.Join(nonWarningLines, "\n  "),
-			)
-		} else {
-			taskErr = fmt.Errorf("task %q failed to complete with exit code: %s", upid, exitCode)
-		}
+		taskErr = fmt.Errorf(
+			"task %q failed to complete with exit code: %s\n%s\n  %s",
+			upid, exitCode, heading, strings.Join(nonWarningLines, "\n  "),
+		)
+	}
 
+	if len(warnings) > 0 {
 		return TaskFailedWithWarnings(taskErr, warnings)
 	}
 
-	taskErr := fmt.Errorf(
-		"task %q failed to complete with exit code: %s\ntask log:\n  %s",
-		upid, exitCode, strings.Join(lines, "\n  "),
-	)
-
 	return TaskFailed(taskErr)
 }
@@ -8,14 +8,19 @@ package tasks
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/tracing"
 )
 
 // testUPID is a valid Proxmox UPID for use in tests.
@@ -117,6 +122,66 @@ func TestWaitForTask_FailedTaskWithLogFetchError(t *testing.T) {
 	assert.Contains(t, result.Err().Error(), "failed to complete with exit code: ERROR")
 }
 
+// TestWaitForTask_FailedTaskIncludesLogTail verifies that the error of a task with a long log
+// includes the end of the log, where the reason of the failure is, rather than its beginning.
+func TestWaitForTask_FailedTaskIncludesLogTail(t *testing.T) {
+	t.Parallel()
+
+	log := make([]string, 0, 1200)
+	for i := range 1199 {
+		log = append(log, fmt.Sprintf("backing up guest %d", i+1))
+	}
+
+	log = append(log, "TASK ERROR: job errors")
+
+	var requests int
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api2/json/nodes/pve/tasks/"+testUPID+"/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		writeJSON(w, map[string]any{
+			"data": map[string]any{
+				"status":     "stopped",
+				"exitstatus": "job errors",
+			},
+		})
+	})
+
+	mux.HandleFunc("GET /api2/json/nodes/pve/tasks/"+testUPID+"/log", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		start, err := strconv.Atoi(r.URL.Query().Get("start"))
+		assert.NoError(t, err)
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		assert.NoError(t, err)
+
+		data := []map[string]any{}
+		for i := start; i < len(log) && i < start+limit; i++ {
+			data = append(data, map[string]any{"n": i + 1, "t": log[i]})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		writeJSON(w, map[string]any{"data": data, "total": len(log)})
+	})
+
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	result := client.WaitForTask(t.Context(), testUPID)
+	require.Error(t, result.Err())
+
+	msg := result.Err().Error()
+	assert.Contains(t, msg, "TASK ERROR: job errors")
+	assert.Contains(t, msg, "backing up guest 1199")
+	assert.Contains(t, msg, "(1150 earlier lines omitted)")
+	assert.NotContains(t, msg, "backing up guest 1150\n")
+	assert.Equal(t, 2, requests, "the log should be read from its first page and its last page only")
+}
+
 // TestWaitForTask_WarningsAreNonFatalByDefault verifies that when a task completes
 // with warnings, WaitForTask succeeds and the warning text is available.
 func TestWaitForTask_WarningsAreNonFatalByDefault(t *testing.T) {
@@ -256,3 +321,88 @@ func TestWaitForTask_FailedTaskWithWarningsDoesNotDuplicateWarnLines(t *testing.
 	require.Len(t, result.Warnings(), 1)
 	assert.Contains(t, result.Warnings()[0], "disk is nearly full")
 }
+
+// TestWaitForTask_WithLogHandlerStreamsLog verifies that the log handler receives every
+// task log line exactly once, in order, while the task is running and after it finishes.
+func TestWaitForTask_WithLogHandlerStreamsLog(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		polls  int
+		logged []string
+	)
+
+	mux := http.NewServeMux()
+
+	// Task status: running on the first poll, each poll adds a line to the log.
+	mux.HandleFunc("GET /api2/json/nodes/pve/tasks/"+testUPID+"/status", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		polls++
+
+		// the log is still empty on the first poll
+		if polls > 1 {
+			logged = append(logged, fmt.Sprintf("line %d", len(logged)+1))
+		}
+
+		status := map[string]any{"status": "running"}
+		if polls > 2 {
+			logged = append(logged, "TASK OK")
+			status = map[string]any{"status": "stopped", "exitstatus": "OK"}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		writeJSON(w, map[string]any{"data": status})
+	})
+
+	mux.HandleFunc("GET /api2/json/nodes/pve/tasks/"+testUPID+"/log", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		start, err := strconv.Atoi(r.URL.Query().Get("start"))
+		assert.NoError(t, err)
+
+		data := []map[string]any{}
+		for i := start; i < len(logged); i++ {
+			data = append(data, map[string]any{"n": i + 1, "t": logged[i]})
+		}
+
+		if len(data) == 0 {
+			// the API returns a placeholder line for an empty log
+			data = append(data, map[string]any{"n": 1, "t": "no content"})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		writeJSON(w, map[string]any{"data": data})
+	})
+
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	var lines []string
+
+	result := client.WaitForTask(t.Context(), testUPID, WithLogHandler(func(line string) {
+		lines = append(lines, line)
+	}))
+	require.NoError(t, result.Err())
+
+	assert.Equal(t, []string{"line 1", "line 2", "TASK OK"}, lines)
+}
+
+func TestTaskSpanAttributes(t *testing.T) {
+	t.Parallel()
+
+	attrs := taskSpanAttributes(testUPID)
+	assert.Equal(t, []attribute.KeyValue{
+		tracing.AttrUPID.String(testUPID),
+		tracing.AttrNode.String("pve"),
+		tracing.AttrTaskType.String("vzcreate"),
+		tracing.AttrVMID.Int(100),
+	}, attrs)
+
+	assert.Equal(t, []attribute.KeyValue{tracing.AttrUPID.String("invalid")}, taskSpanAttributes("invalid"))
+}
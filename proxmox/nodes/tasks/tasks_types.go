@@ -25,9 +25,16 @@ type GetTaskStatusResponseData struct {
 	ExitCode string `json:"exitstatus,omitempty"`
 }
 
+// GetTaskLogRequestBody contains the query parameters for a node get task log request.
+type GetTaskLogRequestBody struct {
+	Start *int `url:"start,omitempty"`
+	Limit *int `url:"limit,omitempty"`
+}
+
 // GetTaskLogResponseBody contains the body from a node get task log response.
 type GetTaskLogResponseBody struct {
-	Data []*GetTaskLogResponseData `json:"data,omitempty"`
+	Data  []*GetTaskLogResponseData `json:"data,omitempty"`
+	Total *int                      `json:"total,omitempty"`
 }
 
 // GetTaskLogResponseData contains the data from a node get task log response.
@@ -0,0 +1,36 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+// ListTasksRequestBody contains the query parameters for a node list tasks request.
+type ListTasksRequestBody struct {
+	Limit        *int    `url:"limit,omitempty"`
+	Since        *int64  `url:"since,omitempty"`
+	Source       *string `url:"source,omitempty"`
+	StatusFilter *string `url:"statusfilter,omitempty"`
+	TypeFilter   *string `url:"typefilter,omitempty"`
+	Until        *int64  `url:"until,omitempty"`
+	UserFilter   *string `url:"userfilter,omitempty"`
+	VMID         *int    `url:"vmid,omitempty"`
+}
+
+// ListTasksResponseBody contains the body from a node list tasks response.
+type ListTasksResponseBody struct {
+	Data []*ListTasksResponseData `json:"data,omitempty"`
+}
+
+// ListTasksResponseData contains the data from a node list tasks response.
+type ListTasksResponseData struct {
+	UPID      string  `json:"upid"`
+	NodeName  string  `json:"node"`
+	Type      string  `json:"type"`
+	ID        string  `json:"id,omitempty"`
+	User      string  `json:"user"`
+	StartTime int64   `json:"starttime"`
+	EndTime   *int64  `json:"endtime,omitempty"`
+	Status    *string `json:"status,omitempty"`
+}
@@ -104,6 +104,18 @@ func (c *Client) CreateVMAsync(ctx context.Context, d *CreateRequestBody) (*stri
 	return resBody.TaskID, nil
 }
 
+// RestoreVM creates a virtual machine from the backup archive set in the request body.
+func (c *Client) RestoreVM(ctx context.Context, d *CreateRequestBody) tasks.TaskResult {
+	op := retry.NewTaskOperation("VM restore",
+		retry.WithRetryIf(retry.IsTransientAPIError),
+		retry.WithAlreadyDoneCheck(retry.ErrorContains("already exists")),
+	)
+
+	return c.Tasks().DoTask(ctx, op,
+		func() (*string, error) { return c.CreateVMAsync(ctx, d) },
+	)
+}
+
 // DeleteVM deletes a virtual machine.
 func (c *Client) DeleteVM(ctx context.Context, purge bool, destroyUnreferencedDisks bool) tasks.TaskResult {
 	purgeValue := 0
@@ -187,14 +199,14 @@ func (c *Client) GetVMStatus(ctx context.Context) (*GetStatusResponseData, error
 	return resBody.Data, nil
 }
 
-// MigrateVM migrates a virtual machine.
-func (c *Client) MigrateVM(ctx context.Context, d *MigrateRequestBody) tasks.TaskResult {
+// MigrateVM migrates a virtual machine. The wait options, e.g. tasks.WithLogHandler, apply to the migration task.
+func (c *Client) MigrateVM(ctx context.Context, d *MigrateRequestBody, opts ...tasks.TaskWaitOption) tasks.TaskResult {
 	taskID, err := c.MigrateVMAsync(ctx, d)
 	if err != nil {
 		return tasks.TaskFailed(err)
 	}
 
-	return c.Tasks().WaitForTask(ctx, *taskID)
+	return c.Tasks().WaitForTask(ctx, *taskID, opts...)
 }
 
 // MigrateVMAsync migrates a virtual machine asynchronously.
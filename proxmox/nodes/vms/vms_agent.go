@@ -0,0 +1,132 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// GetAgentOSInfo retrieves the operating system information from the QEMU agent.
+func (c *Client) GetAgentOSInfo(ctx context.Context) (*AgentOSInfo, error) {
+	return runAgentCommand[AgentOSInfo](ctx, c, http.MethodGet, "get-osinfo", nil)
+}
+
+// GetAgentFSInfo retrieves the mounted filesystems from the QEMU agent.
+func (c *Client) GetAgentFSInfo(ctx context.Context) ([]AgentFSInfo, error) {
+	res, err := runAgentCommand[[]AgentFSInfo](ctx, c, http.MethodGet, "get-fsinfo", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return *res, nil
+}
+
+// GetAgentUsers retrieves the users logged in to the guest from the QEMU agent.
+func (c *Client) GetAgentUsers(ctx context.Context) ([]AgentUser, error) {
+	res, err := runAgentCommand[[]AgentUser](ctx, c, http.MethodGet, "get-users", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return *res, nil
+}
+
+// GetAgentHostName retrieves the host name of the guest from the QEMU agent.
+func (c *Client) GetAgentHostName(ctx context.Context) (*AgentHostName, error) {
+	return runAgentCommand[AgentHostName](ctx, c, http.MethodGet, "get-host-name", nil)
+}
+
+// GetAgentTimezone retrieves the timezone of the guest from the QEMU agent.
+func (c *Client) GetAgentTimezone(ctx context.Context) (*AgentTimezone, error) {
+	return runAgentCommand[AgentTimezone](ctx, c, http.MethodGet, "get-timezone", nil)
+}
+
+// GetAgentVCPUs retrieves the virtual CPUs of the guest from the QEMU agent.
+func (c *Client) GetAgentVCPUs(ctx context.Context) ([]AgentVCPU, error) {
+	res, err := runAgentCommand[[]AgentVCPU](ctx, c, http.MethodGet, "get-vcpus", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return *res, nil
+}
+
+// FreezeFilesystems freezes the filesystems of the guest with the QEMU agent and returns the
+// number of frozen filesystems. The guest stays frozen until ThawFilesystems is called.
+func (c *Client) FreezeFilesystems(ctx context.Context) (int, error) {
+	res, err := runAgentCommand[int](ctx, c, http.MethodPost, "fsfreeze-freeze", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return *res, nil
+}
+
+// ThawFilesystems thaws the filesystems of the guest with the QEMU agent and returns the
+// number of thawed filesystems.
+func (c *Client) ThawFilesystems(ctx context.Context) (int, error) {
+	res, err := runAgentCommand[int](ctx, c, http.MethodPost, "fsfreeze-thaw", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return *res, nil
+}
+
+// GetFilesystemFreezeStatus retrieves the filesystem freeze status of the guest from the QEMU agent,
+// i.e. AgentFSFreezeStatusFrozen or AgentFSFreezeStatusThawed.
+func (c *Client) GetFilesystemFreezeStatus(ctx context.Context) (string, error) {
+	res, err := runAgentCommand[string](ctx, c, http.MethodPost, "fsfreeze-status", nil)
+	if err != nil {
+		return "", err
+	}
+
+	return *res, nil
+}
+
+// TrimFilesystems discards the unused blocks of the mounted filesystems of the guest with the QEMU agent.
+func (c *Client) TrimFilesystems(ctx context.Context) (*AgentFSTrimResult, error) {
+	return runAgentCommand[AgentFSTrimResult](ctx, c, http.MethodPost, "fstrim", nil)
+}
+
+// SetUserPassword sets the password of a user in the guest with the QEMU agent. The password is
+// passed in plain text, unless crypted is set and it is already encrypted with crypt(3).
+func (c *Client) SetUserPassword(ctx context.Context, username, password string, crypted bool) error {
+	reqBody := &AgentSetUserPasswordRequestBody{
+		Password: password,
+		Username: username,
+	}
+
+	if crypted {
+		reqBody.Crypted = types.CustomBool(true).Pointer()
+	}
+
+	_, err := runAgentCommand[map[string]any](ctx, c, http.MethodPost, "set-user-password", reqBody)
+
+	return err
+}
+
+// runAgentCommand runs a QEMU agent command and returns its result.
+func runAgentCommand[T any](ctx context.Context, c *Client, method, command string, reqBody any) (*T, error) {
+	resBody := &AgentResponseBody[T]{}
+
+	err := c.DoRequest(ctx, method, c.ExpandPath("agent/"+command), reqBody, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error running QEMU agent command %q on VM %d: %w", command, c.VMID, err)
+	}
+
+	if resBody.Data == nil || resBody.Data.Result == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data.Result, nil
+}
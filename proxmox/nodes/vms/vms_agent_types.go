@@ -0,0 +1,101 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vms
+
+import "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+
+const (
+	// AgentFSFreezeStatusFrozen is the freeze status of a guest with frozen filesystems.
+	AgentFSFreezeStatusFrozen = "frozen"
+	// AgentFSFreezeStatusThawed is the freeze status of a guest with thawed filesystems.
+	AgentFSFreezeStatusThawed = "thawed"
+)
+
+// AgentResponseBody contains the body from a QEMU agent command response.
+// PVE wraps the result of the guest agent command in the `result` field.
+type AgentResponseBody[T any] struct {
+	Data *struct {
+		Result *T `json:"result,omitempty"`
+	} `json:"data,omitempty"`
+}
+
+// AgentOSInfo contains the operating system information reported by the QEMU agent.
+type AgentOSInfo struct {
+	ID            *string `json:"id,omitempty"`
+	KernelRelease *string `json:"kernel-release,omitempty"`
+	KernelVersion *string `json:"kernel-version,omitempty"`
+	Machine       *string `json:"machine,omitempty"`
+	Name          *string `json:"name,omitempty"`
+	PrettyName    *string `json:"pretty-name,omitempty"`
+	Variant       *string `json:"variant,omitempty"`
+	VariantID     *string `json:"variant-id,omitempty"`
+	Version       *string `json:"version,omitempty"`
+	VersionID     *string `json:"version-id,omitempty"`
+}
+
+// AgentFSInfo contains a mounted filesystem reported by the QEMU agent.
+type AgentFSInfo struct {
+	Disks      []AgentFSInfoDisk `json:"disk"`
+	MountPoint string            `json:"mountpoint"`
+	Name       string            `json:"name"`
+	TotalBytes *int64            `json:"total-bytes,omitempty"`
+	Type       string            `json:"type"`
+	UsedBytes  *int64            `json:"used-bytes,omitempty"`
+}
+
+// AgentFSInfoDisk contains a disk backing a filesystem reported by the QEMU agent.
+type AgentFSInfoDisk struct {
+	BusType string  `json:"bus-type"`
+	Dev     *string `json:"dev,omitempty"`
+	Serial  *string `json:"serial,omitempty"`
+}
+
+// AgentUser contains a user logged in to the guest, as reported by the QEMU agent.
+type AgentUser struct {
+	Domain    *string `json:"domain,omitempty"`
+	LoginTime float64 `json:"login-time"`
+	User      string  `json:"user"`
+}
+
+// AgentHostName contains the host name reported by the QEMU agent.
+type AgentHostName struct {
+	HostName string `json:"host-name"`
+}
+
+// AgentTimezone contains the timezone reported by the QEMU agent.
+type AgentTimezone struct {
+	// Offset is the offset to UTC in seconds, negative numbers for time zones west of GMT.
+	Offset int     `json:"offset"`
+	Zone   *string `json:"zone,omitempty"`
+}
+
+// AgentVCPU contains a virtual CPU reported by the QEMU agent.
+type AgentVCPU struct {
+	CanOffline *bool `json:"can-offline,omitempty"`
+	LogicalID  int   `json:"logical-id"`
+	Online     bool  `json:"online"`
+}
+
+// AgentFSTrimResult contains the result of a QEMU agent fstrim command.
+type AgentFSTrimResult struct {
+	Paths []AgentFSTrimPath `json:"paths"`
+}
+
+// AgentFSTrimPath contains the fstrim result of a mounted filesystem.
+type AgentFSTrimPath struct {
+	Error   *string `json:"error,omitempty"`
+	Minimum *int64  `json:"minimum,omitempty"`
+	Path    string  `json:"path"`
+	Trimmed *int64  `json:"trimmed,omitempty"`
+}
+
+// AgentSetUserPasswordRequestBody contains the body for a QEMU agent set-user-password request.
+type AgentSetUserPasswordRequestBody struct {
+	Crypted  *types.CustomBool `json:"crypted,omitempty" url:"crypted,omitempty,int"`
+	Password string            `json:"password"          url:"password"`
+	Username string            `json:"username"          url:"username"`
+}
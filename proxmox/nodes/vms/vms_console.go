@@ -0,0 +1,57 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// CreateVNCProxy creates a VNC proxy to the console of the VM and returns its ticket and port.
+func (c *Client) CreateVNCProxy(ctx context.Context, d *VNCProxyRequestBody) (*ConsoleProxyResponseData, error) {
+	return createConsoleProxy(ctx, c, "vncproxy", d)
+}
+
+// CreateTermProxy creates a terminal proxy to the serial console of the VM and returns its ticket and port.
+func (c *Client) CreateTermProxy(ctx context.Context, d *TermProxyRequestBody) (*ConsoleProxyResponseData, error) {
+	return createConsoleProxy(ctx, c, "termproxy", d)
+}
+
+// CreateSPICEProxy creates a SPICE proxy to the VM and returns the connection settings
+// of the remote viewer, i.e. the entries of the `[virt-viewer]` section of a `.vv` file.
+func (c *Client) CreateSPICEProxy(ctx context.Context, d *SPICEProxyRequestBody) (map[string]any, error) {
+	resBody := &SPICEProxyResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("spiceproxy"), d, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error creating SPICE proxy for VM %d: %w", c.VMID, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+func createConsoleProxy(ctx context.Context, c *Client, kind string, d any) (*ConsoleProxyResponseData, error) {
+	resBody := &ConsoleProxyResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath(kind), d, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s for VM %d: %w", kind, c.VMID, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
@@ -0,0 +1,45 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vms
+
+import "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+
+// VNCProxyRequestBody contains the body for a VNC proxy request.
+type VNCProxyRequestBody struct {
+	GeneratePassword *types.CustomBool `json:"generate-password,omitempty" url:"generate-password,omitempty,int"`
+	WebSocket        *types.CustomBool `json:"websocket,omitempty"         url:"websocket,omitempty,int"`
+}
+
+// TermProxyRequestBody contains the body for a terminal proxy request.
+type TermProxyRequestBody struct {
+	Serial *string `json:"serial,omitempty" url:"serial,omitempty"`
+}
+
+// SPICEProxyRequestBody contains the body for a SPICE proxy request.
+type SPICEProxyRequestBody struct {
+	Proxy *string `json:"proxy,omitempty" url:"proxy,omitempty"`
+}
+
+// ConsoleProxyResponseBody contains the body from a VNC or terminal proxy response.
+type ConsoleProxyResponseBody struct {
+	Data *ConsoleProxyResponseData `json:"data,omitempty"`
+}
+
+// ConsoleProxyResponseData contains the data from a VNC or terminal proxy response.
+type ConsoleProxyResponseData struct {
+	Cert     *string         `json:"cert,omitempty"`
+	Password *string         `json:"password,omitempty"`
+	Port     types.CustomInt `json:"port"`
+	Ticket   string          `json:"ticket"`
+	UPID     string          `json:"upid"`
+	User     string          `json:"user"`
+}
+
+// SPICEProxyResponseBody contains the body from a SPICE proxy response.
+type SPICEProxyResponseBody struct {
+	Data map[string]any `json:"data,omitempty"`
+}
@@ -91,6 +91,7 @@ type CreateRequestBody struct {
 	KVMArguments         *string                        `json:"args,omitempty"               url:"args,omitempty,space"`
 	KVMEnabled           *types.CustomBool              `json:"kvm,omitempty"                url:"kvm,omitempty,int"`
 	LocalTime            *types.CustomBool              `json:"localtime,omitempty"          url:"localtime,omitempty,int"`
+	LiveRestore          *types.CustomBool              `json:"live-restore,omitempty"       url:"live-restore,omitempty,int"`
 	Lock                 *string                        `json:"lock,omitempty"               url:"lock,omitempty"`
 	Machine              *string                        `json:"machine,omitempty"            url:"machine,omitempty"`
 	MigrateDowntime      *float64                       `json:"migrate_downtime,omitempty"   url:"migrate_downtime,omitempty"`
@@ -112,6 +113,7 @@ type CreateRequestBody struct {
 	SMBIOS               *CustomSMBIOS                  `json:"smbios1,omitempty"            url:"smbios1,omitempty"`
 	SpiceEnhancements    *CustomSpiceEnhancements       `json:"spice_enhancements,omitempty" url:"spice_enhancements,omitempty"`
 	StartDate            *string                        `json:"startdate,omitempty"          url:"startdate,omitempty"`
+	Storage              *string                        `json:"storage,omitempty"            url:"storage,omitempty"`
 	StartOnBoot          *types.CustomBool              `json:"onboot,omitempty"             url:"onboot,omitempty,int"`
 	StartupOrder         *CustomStartupOrder            `json:"startup,omitempty"            url:"startup,omitempty"`
 	TabletDeviceEnabled  *types.CustomBool              `json:"tablet,omitempty"             url:"tablet,omitempty,int"`
@@ -119,6 +121,7 @@ type CreateRequestBody struct {
 	Template             *types.CustomBool              `json:"template,omitempty"           url:"template,omitempty,int"`
 	TimeDriftFixEnabled  *types.CustomBool              `json:"tdf,omitempty"                url:"tdf,omitempty,int"`
 	TPMState             *CustomTPMState                `json:"tpmstate0,omitempty"          url:"tpmstate0,omitempty"`
+	Unique               *types.CustomBool              `json:"unique,omitempty"             url:"unique,omitempty,int"`
 	USBDevices           CustomUSBDevices               `json:"usb,omitempty"                url:"usb,omitempty"`
 	VGADevice            *CustomVGADevice               `json:"vga,omitempty"                url:"vga,omitempty"`
 	VirtualCPUCount      *int64                         `json:"vcpus,omitempty"              url:"vcpus,omitempty"`
@@ -329,10 +332,13 @@ type ListResponseData struct {
 
 // MigrateRequestBody contains the body for a VM migration request.
 type MigrateRequestBody struct {
-	OnlineMigration *types.CustomBool `json:"online,omitempty"           url:"online,omitempty,int"`
-	TargetNode      string            `json:"target"                     url:"target"`
-	TargetStorage   *string           `json:"targetstorage,omitempty"    url:"targetstorage,omitempty"`
-	WithLocalDisks  *types.CustomBool `json:"with-local-disks,omitempty" url:"with-local-disks,omitempty,int"`
+	BandwidthLimit   *int              `json:"bwlimit,omitempty"           url:"bwlimit,omitempty"`
+	MigrationNetwork *string           `json:"migration_network,omitempty" url:"migration_network,omitempty"`
+	MigrationType    *string           `json:"migration_type,omitempty"    url:"migration_type,omitempty"`
+	OnlineMigration  *types.CustomBool `json:"online,omitempty"            url:"online,omitempty,int"`
+	TargetNode       string            `json:"target"                      url:"target"`
+	TargetStorage    *string           `json:"targetstorage,omitempty"     url:"targetstorage,omitempty"`
+	WithLocalDisks   *types.CustomBool `json:"with-local-disks,omitempty"  url:"with-local-disks,omitempty,int"`
 }
 
 // MigrateResponseBody contains the body from a VM migrate response.
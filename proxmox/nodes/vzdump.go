@@ -0,0 +1,79 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/tasks"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/retry"
+)
+
+// BackupGuestsAsync starts a vzdump backup of the guests on the node and returns the task ID.
+func (c *Client) BackupGuestsAsync(ctx context.Context, d *VzdumpRequestBody) (*string, error) {
+	resBody := &VzdumpResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("vzdump"), d, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error starting backup of guests %s: %w", d.VMID, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// BackupGuests backs up the guests on the node with vzdump and waits for the backup to finish.
+// The backup is started only once: after a timeout or a server error, the request may have
+// started the backup already, and a retry would back up the guests twice.
+func (c *Client) BackupGuests(ctx context.Context, d *VzdumpRequestBody) tasks.TaskResult {
+	op := retry.NewTaskOperation("guest backup",
+		retry.WithAttempts(1),
+	)
+
+	return c.Tasks().DoTask(ctx, op,
+		func() (*string, error) { return c.BackupGuestsAsync(ctx, d) },
+	)
+}
+
+// ExtractBackupConfig retrieves the guest configuration recorded in a backup, by config key.
+// Snapshot sections and comments are skipped.
+func (c *Client) ExtractBackupConfig(ctx context.Context, volumeID string) (map[string]string, error) {
+	resBody := &VzdumpExtractConfigResponseBody{}
+	reqBody := &VzdumpExtractConfigRequestBody{Volume: volumeID}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("vzdump/extractconfig"), reqBody, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error extracting the configuration of backup %s: %w", volumeID, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	config := map[string]string{}
+
+	for line := range strings.Lines(*resBody.Data) {
+		line = strings.TrimSpace(line)
+
+		if strings.HasPrefix(line, "[") {
+			break
+		}
+
+		if key, value, ok := strings.Cut(line, ": "); ok && !strings.HasPrefix(line, "#") {
+			config[key] = value
+		}
+	}
+
+	return config, nil
+}
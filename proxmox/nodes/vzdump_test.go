@@ -0,0 +1,50 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+)
+
+// TestBackupGuestsNotRetried tests that a failed backup start is not retried, as the failed
+// request may have started the backup already.
+func TestBackupGuestsNotRetried(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	t.Cleanup(s.Close)
+
+	creds, err := api.NewCredentials("", "", "", fake.APIToken, "", "")
+	require.NoError(t, err)
+
+	conn, err := api.NewConnection(s.URL, true, "")
+	require.NoError(t, err)
+
+	apiClient, err := api.NewClient(creds, conn)
+	require.NoError(t, err)
+
+	err = proxmox.NewClient(apiClient, nil, "").Node("pve").
+		BackupGuests(t.Context(), &nodes.VzdumpRequestBody{VMID: "100"}).Err()
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,50 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+
+// Backup modes supported by vzdump.
+const (
+	BackupModeSnapshot = "snapshot"
+	BackupModeSuspend  = "suspend"
+	BackupModeStop     = "stop"
+)
+
+// Backup compression algorithms supported by vzdump. `0` disables compression.
+const (
+	BackupCompressNone = "0"
+	BackupCompressGzip = "gzip"
+	BackupCompressLZO  = "lzo"
+	BackupCompressZstd = "zstd"
+)
+
+// VzdumpRequestBody contains the body for a request to back up guests on a node.
+type VzdumpRequestBody struct {
+	// VMID is a comma-separated list of the IDs of the guests to back up.
+	VMID          string            `url:"vmid"`
+	Storage       *string           `url:"storage,omitempty"`
+	Mode          *string           `url:"mode,omitempty"`
+	Compress      *string           `url:"compress,omitempty"`
+	NotesTemplate *string           `url:"notes-template,omitempty"`
+	Protected     *types.CustomBool `url:"protected,omitempty,int"`
+}
+
+// VzdumpResponseBody contains the body from a vzdump response.
+type VzdumpResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
+
+// VzdumpExtractConfigRequestBody contains the parameters for extracting the config of a backup.
+type VzdumpExtractConfigRequestBody struct {
+	Volume string `url:"volume"`
+}
+
+// VzdumpExtractConfigResponseBody contains the body from a vzdump extract config response.
+type VzdumpExtractConfigResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
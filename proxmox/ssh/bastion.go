@@ -0,0 +1,160 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// Bastion is an SSH jump host the node connections are tunnelled through, like `ssh -J`.
+type Bastion struct {
+	Address    string
+	Port       int32
+	Username   string
+	Password   string
+	PrivateKey string
+	Agent      bool
+}
+
+// WithBastion routes all node connections through the bastion host. If a SOCKS5 proxy is
+// configured as well, the bastion is reached through the proxy.
+func WithBastion(b *Bastion) ClientOption {
+	return func(c *client) {
+		c.bastion = b
+	}
+}
+
+// hostPort formats the address and port for dialing, bracketing IPv6 addresses.
+func hostPort(address string, port int32) string {
+	if strings.Contains(address, ":") {
+		// IPv6
+		return fmt.Sprintf("[%s]:%d", address, port)
+	}
+
+	// IPv4
+	return fmt.Sprintf("%s:%d", address, port)
+}
+
+// bastionConn is a connection to a node tunnelled through the bastion. Closing it closes the
+// bastion connection and its SSH agent connection as well.
+type bastionConn struct {
+	net.Conn
+
+	bastion *ssh.Client
+	agent   net.Conn
+}
+
+func (c *bastionConn) Close() error {
+	return errors.Join(c.Conn.Close(), c.bastion.Close(), closeAgent(c.agent))
+}
+
+// closeAgent closes the SSH agent connection of the bastion, if there is one.
+func closeAgent(conn net.Conn) error {
+	if conn == nil {
+		return nil
+	}
+
+	return conn.Close()
+}
+
+// dialBastion connects to the bastion and opens a tunnel to the node.
+func (c *client) dialBastion(ctx context.Context, sshHost string) (net.Conn, error) {
+	b := c.bastion
+	bastionHost := hostPort(b.Address, b.Port)
+
+	hk, err := c.hostKeyVerifier(ctx, ProxmoxNode{Address: b.Address, Port: b.Port}, bastionHost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify bastion host key: %w", err)
+	}
+
+	auth, agentConn, err := c.bastionAuthMethods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sshConfig := &ssh.ClientConfig{
+		User:              b.Username,
+		Auth:              auth,
+		HostKeyCallback:   hk.callback,
+		HostKeyAlgorithms: hk.algorithms,
+	}
+
+	tflog.Debug(ctx, "connecting to SSH bastion", map[string]any{
+		"bastion": bastionHost,
+		"user":    b.Username,
+		"host":    sshHost,
+	})
+
+	bastionClient, err := c.connectWith(ctx, bastionHost, sshConfig, c.dialDirect)
+	if err != nil {
+		_ = closeAgent(agentConn)
+
+		return nil, fmt.Errorf("failed to connect to SSH bastion %s: %w", bastionHost, err)
+	}
+
+	conn, err := bastionClient.DialContext(ctx, "tcp", sshHost)
+	if err != nil {
+		_ = bastionClient.Close()
+		_ = closeAgent(agentConn)
+
+		return nil, fmt.Errorf("failed to dial %s via SSH bastion %s: %w", sshHost, bastionHost, err)
+	}
+
+	return &bastionConn{Conn: conn, bastion: bastionClient, agent: agentConn}, nil
+}
+
+// bastionAuthMethods returns the authentication methods for the bastion, in the same order
+// of preference as for the nodes: agent, private key, password. The returned SSH agent
+// connection, if any, must be closed by the caller when the bastion connection is closed.
+func (c *client) bastionAuthMethods(ctx context.Context) ([]ssh.AuthMethod, net.Conn, error) {
+	b := c.bastion
+
+	var (
+		auth      []ssh.AuthMethod
+		agentConn net.Conn
+	)
+
+	if b.Agent {
+		conn, err := dialSocket(ctx, c.agentSocket)
+		if err != nil {
+			tflog.Error(ctx, "Failed to connect to SSH agent for the bastion", map[string]any{"error": err})
+		} else {
+			agentConn = conn
+			auth = append(auth, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+
+	if b.PrivateKey != "" {
+		key, err := ssh.ParsePrivateKey([]byte(b.PrivateKey))
+		if err != nil {
+			_ = closeAgent(agentConn)
+
+			return nil, nil, fmt.Errorf("failed to parse bastion private key: %w", err)
+		}
+
+		auth = append(auth, ssh.PublicKeys(key))
+	}
+
+	if b.Password != "" {
+		auth = append(auth, ssh.Password(b.Password))
+	}
+
+	if len(auth) == 0 {
+		return nil, nil, errors.New("no authentication method configured for the SSH bastion: " +
+			"set its password or private key, or enable the SSH agent")
+	}
+
+	return auth, agentConn, nil
+}
@@ -0,0 +1,323 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// listen opens a TCP listener on localhost and serves each connection with the handler.
+func listen(t *testing.T, handle func(conn net.Conn)) *net.TCPAddr {
+	t.Helper()
+
+	var lc net.ListenConfig
+
+	ln, err := lc.Listen(context.Background(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, aerr := ln.Accept()
+			if aerr != nil {
+				return
+			}
+
+			go handle(conn)
+		}
+	}()
+
+	addr, ok := ln.Addr().(*net.TCPAddr)
+	require.True(t, ok)
+
+	return addr
+}
+
+// bastionServer starts an SSH server accepting the password that forwards `direct-tcpip` channels.
+func bastionServer(t *testing.T, password string) (*net.TCPAddr, ssh.PublicKey) {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+
+	cfg := &ssh.ServerConfig{
+		PasswordCallback: func(_ ssh.ConnMetadata, p []byte) (*ssh.Permissions, error) {
+			if string(p) != password {
+				return nil, errors.New("wrong password")
+			}
+
+			return &ssh.Permissions{}, nil
+		},
+	}
+	cfg.AddHostKey(signer)
+
+	addr := listen(t, func(conn net.Conn) {
+		_, chans, reqs, serr := ssh.NewServerConn(conn, cfg)
+		if serr != nil {
+			return
+		}
+
+		go ssh.DiscardRequests(reqs)
+
+		for nc := range chans {
+			if nc.ChannelType() != "direct-tcpip" {
+				_ = nc.Reject(ssh.UnknownChannelType, "unsupported")
+
+				continue
+			}
+
+			var target struct {
+				Host       string
+				Port       uint32
+				OriginHost string
+				OriginPort uint32
+			}
+
+			if uerr := ssh.Unmarshal(nc.ExtraData(), &target); uerr != nil {
+				_ = nc.Reject(ssh.ConnectionFailed, uerr.Error())
+
+				continue
+			}
+
+			var d net.Dialer
+
+			tc, derr := d.DialContext(context.Background(), "tcp",
+				net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+			if derr != nil {
+				_ = nc.Reject(ssh.ConnectionFailed, derr.Error())
+
+				continue
+			}
+
+			ch, creqs, aerr := nc.Accept()
+			if aerr != nil {
+				_ = tc.Close()
+
+				continue
+			}
+
+			go ssh.DiscardRequests(creqs)
+
+			go func() {
+				_, _ = io.Copy(ch, tc)
+				_ = ch.Close()
+			}()
+
+			go func() {
+				_, _ = io.Copy(tc, ch)
+				_ = tc.Close()
+			}()
+		}
+	})
+
+	return addr, signer.PublicKey()
+}
+
+func TestDialThroughBastion(t *testing.T) {
+	t.Parallel()
+
+	echo := listen(t, func(conn net.Conn) {
+		defer conn.Close()
+
+		_, _ = io.Copy(conn, conn)
+	})
+
+	bastionAddr, hostKey := bastionServer(t, "secret")
+
+	khPath := filepath.Join(t.TempDir(), "known_hosts")
+
+	c, err := NewClient("root", "", false, "", false, "", "", "", "", &staticResolver{},
+		WithKnownHostsFile(khPath),
+		WithBastion(&Bastion{
+			Address:  bastionAddr.IP.String(),
+			Port:     int32(bastionAddr.Port),
+			Username: "jump",
+			Password: "secret",
+		}),
+	)
+	require.NoError(t, err)
+
+	conn, err := c.(*client).dial(context.Background(), echo.String())
+	require.NoError(t, err)
+
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf))
+
+	bc, ok := conn.(*bastionConn)
+	require.True(t, ok)
+	assert.Equal(t, "jump", bc.bastion.User())
+
+	// the bastion host key is verified like a node's, here trusted on first use
+	known, err := os.ReadFile(khPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(known), strings.TrimSpace(string(ssh.MarshalAuthorizedKey(hostKey))))
+
+	require.NoError(t, conn.Close())
+
+	// closing the tunnel closes the bastion connection as well
+	_, _, err = bc.bastion.SendRequest("keepalive@openssh.com", true, nil)
+	require.Error(t, err)
+}
+
+func TestDialThroughBastionClosesAgent(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the SSH agent is a named pipe on Windows")
+	}
+
+	echo := listen(t, func(conn net.Conn) {
+		defer conn.Close()
+
+		_, _ = io.Copy(conn, conn)
+	})
+
+	bastionAddr, _ := bastionServer(t, "secret")
+
+	socket := filepath.Join(t.TempDir(), "agent.sock")
+
+	var lc net.ListenConfig
+
+	ln, err := lc.Listen(context.Background(), "unix", socket)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = ln.Close() })
+
+	closed := make(chan struct{})
+
+	go func() {
+		conn, aerr := ln.Accept()
+		if aerr != nil {
+			return
+		}
+
+		// serves the empty keyring until the client closes the connection
+		_ = agent.ServeAgent(agent.NewKeyring(), conn)
+
+		close(closed)
+	}()
+
+	c := &client{
+		agentSocket:    socket,
+		knownHostsFile: filepath.Join(t.TempDir(), "known_hosts"),
+		bastion: &Bastion{
+			Address:  bastionAddr.IP.String(),
+			Port:     int32(bastionAddr.Port),
+			Username: "jump",
+			Password: "secret",
+			Agent:    true,
+		},
+	}
+
+	conn, err := c.dial(context.Background(), echo.String())
+	require.NoError(t, err)
+
+	require.NoError(t, conn.Close())
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the SSH agent connection was not closed with the bastion connection")
+	}
+}
+
+func TestDialThroughBastionStrictHostKey(t *testing.T) {
+	t.Parallel()
+
+	bastionAddr, _ := bastionServer(t, "secret")
+
+	khPath := filepath.Join(t.TempDir(), "known_hosts")
+
+	c := &client{
+		username:              "root",
+		knownHostsFile:        khPath,
+		strictHostKeyChecking: true,
+		bastion: &Bastion{
+			Address:  bastionAddr.IP.String(),
+			Port:     int32(bastionAddr.Port),
+			Username: "jump",
+			Password: "secret",
+		},
+	}
+
+	_, err := c.dial(context.Background(), "127.0.0.1:22")
+	require.ErrorContains(t, err, "failed to verify bastion host key")
+}
+
+func TestDialThroughBastionAuthFailure(t *testing.T) {
+	t.Parallel()
+
+	bastionAddr, _ := bastionServer(t, "secret")
+
+	c := &client{
+		knownHostsFile: filepath.Join(t.TempDir(), "known_hosts"),
+		bastion: &Bastion{
+			Address:  bastionAddr.IP.String(),
+			Port:     int32(bastionAddr.Port),
+			Username: "jump",
+			Password: "wrong",
+		},
+	}
+
+	_, err := c.dial(context.Background(), "127.0.0.1:22")
+	require.ErrorContains(t, err, "failed to connect to SSH bastion")
+
+	c.bastion.Password = ""
+
+	_, err = c.dial(context.Background(), "127.0.0.1:22")
+	require.ErrorContains(t, err, "no authentication method configured")
+}
+
+func TestNewClientBastionDefaults(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewClient("root", "", false, "", false, "", "", "", "", &staticResolver{},
+		WithBastion(&Bastion{Address: "jump.example.com"}),
+	)
+	require.NoError(t, err)
+
+	b := c.(*client).bastion
+	assert.Equal(t, int32(22), b.Port)
+	assert.Equal(t, "root", b.Username)
+
+	_, err = NewClient("root", "", false, "", false, "", "", "", "", &staticResolver{},
+		WithBastion(&Bastion{}),
+	)
+	require.ErrorContains(t, err, "bastion address is required")
+}
+
+type staticResolver struct{}
+
+func (r *staticResolver) Resolve(_ context.Context, _ string) (ProxmoxNode, error) {
+	return ProxmoxNode{Address: "127.0.0.1", Port: 22}, nil
+}
@@ -13,7 +13,6 @@ import (
 	"io"
 	"net"
 	"os"
-	"path"
 	"path/filepath"
 	"runtime"
 	"strconv"
@@ -23,14 +22,14 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/pkg/sftp"
-	"github.com/skeema/knownhosts"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/agent"
 	"golang.org/x/net/proxy"
 	"golang.org/x/sync/singleflight"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
-	"github.com/bpg/terraform-provider-proxmox/utils"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/tracing"
 )
 
 const (
@@ -79,19 +78,22 @@ type Client interface {
 }
 
 type client struct {
-	username        string
-	password        string
-	agent           bool
-	agentSocket     string
-	agentForwarding bool
-	privateKey      string
-	socks5Server    string
-	socks5Username  string
-	socks5Password  string
-	nodeResolver    NodeResolver
-	sudoCache       map[string]bool
-	sudoCacheMu     sync.RWMutex
-	sudoProbe       singleflight.Group
+	username              string
+	password              string
+	agent                 bool
+	agentSocket           string
+	agentForwarding       bool
+	privateKey            string
+	socks5Server          string
+	socks5Username        string
+	socks5Password        string
+	nodeResolver          NodeResolver
+	knownHostsFile        string
+	strictHostKeyChecking bool
+	bastion               *Bastion
+	sudoCache             map[string]bool
+	sudoCacheMu           sync.RWMutex
+	sudoProbe             singleflight.Group
 }
 
 // NewClient creates a new SSH client.
@@ -101,6 +103,7 @@ func NewClient(
 	privateKey string,
 	socks5Server string, socks5Username string, socks5Password string,
 	nodeResolver NodeResolver,
+	opts ...ClientOption,
 ) (Client, error) {
 	if agent &&
 		runtime.GOOS != "linux" &&
@@ -121,7 +124,7 @@ func NewClient(
 		return nil, errors.New("node resolver is required")
 	}
 
-	return &client{
+	c := &client{
 		username:        username,
 		password:        password,
 		agent:           agent,
@@ -134,7 +137,27 @@ func NewClient(
 		nodeResolver:    nodeResolver,
 		sudoCache:       make(map[string]bool),
 		sudoCacheMu:     sync.RWMutex{},
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.bastion != nil {
+		if c.bastion.Address == "" {
+			return nil, errors.New("the SSH bastion address is required")
+		}
+
+		if c.bastion.Port == 0 {
+			c.bastion.Port = 22
+		}
+
+		if c.bastion.Username == "" {
+			c.bastion.Username = username
+		}
+	}
+
+	return c, nil
 }
 
 func (c *client) Username() string {
@@ -207,7 +230,13 @@ func (c *client) probeSudo(ctx context.Context, nodeName string) (bool, error) {
 }
 
 // ExecuteNodeCommands executes commands on a given node.
-func (c *client) ExecuteNodeCommands(ctx context.Context, nodeName string, commands []string) ([]byte, error) {
+func (c *client) ExecuteNodeCommands(ctx context.Context, nodeName string, commands []string) (_ []byte, err error) {
+	ctx, span := tracing.Start(ctx, "proxmox.ssh.exec",
+		tracing.AttrNode.String(nodeName),
+		tracing.AttrSSHCommands.Int(len(commands)),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	commandsStr := strings.Join(commands, "; ")
 	needsSudoCheck := strings.Contains(commandsStr, "try_sudo")
 
@@ -326,7 +355,10 @@ func (c *client) NodeUpload(
 	nodeName string,
 	remoteFileDir string,
 	d *api.FileUploadRequest,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "proxmox.ssh.upload", uploadSpanAttributes(nodeName, remoteFileDir, d)...)
+	defer func() { tracing.End(span, err) }()
+
 	ip, err := c.nodeResolver.Resolve(ctx, nodeName)
 	if err != nil {
 		return fmt.Errorf("failed to find node endpoint: %w", err)
@@ -446,7 +478,10 @@ func (c *client) NodeStreamUpload(
 	nodeName string,
 	remoteFileDir string,
 	d *api.FileUploadRequest,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "proxmox.ssh.stream_upload", uploadSpanAttributes(nodeName, remoteFileDir, d)...)
+	defer func() { tracing.End(span, err) }()
+
 	ip, err := c.nodeResolver.Resolve(ctx, nodeName)
 	if err != nil {
 		return fmt.Errorf("failed to find node endpoint: %w", err)
@@ -515,6 +550,23 @@ func (c *client) NodeStreamUpload(
 	return nil
 }
 
+// uploadSpanAttributes returns the span attributes describing a file upload.
+func uploadSpanAttributes(nodeName string, remoteFileDir string, d *api.FileUploadRequest) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		tracing.AttrNode.String(nodeName),
+		tracing.AttrFileName.String(d.FileName),
+		tracing.AttrFileDir.String(remoteFileDir),
+	}
+
+	if d.File != nil {
+		if fi, err := d.File.Stat(); err == nil {
+			attrs = append(attrs, tracing.AttrFileSize.Int64(fi.Size()))
+		}
+	}
+
+	return attrs
+}
+
 func (c *client) uploadFile(
 	ctx context.Context,
 	sshClient *ssh.Client,
@@ -638,76 +690,18 @@ func (c *client) changeModeUploadedFile(
 
 // openNodeShell establishes a new SSH connection to a node.
 func (c *client) openNodeShell(ctx context.Context, node ProxmoxNode) (*ssh.Client, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return nil, fmt.Errorf("failed to determine the home directory: %w", err)
-	}
-
-	var sshHost string
-	if strings.Contains(node.Address, ":") {
-		// IPv6
-		sshHost = fmt.Sprintf("[%s]:%d", node.Address, node.Port)
-	} else {
-		// IPv4
-		sshHost = fmt.Sprintf("%s:%d", node.Address, node.Port)
-	}
-
-	sshPath := path.Join(homeDir, ".ssh")
-	if _, err = os.Stat(sshPath); os.IsNotExist(err) {
-		e := os.Mkdir(sshPath, 0o700)
-		if e != nil && !os.IsExist(e) {
-			return nil, fmt.Errorf("failed to create %s: %w", sshPath, e)
-		}
-	}
-
-	khPath := path.Join(sshPath, "known_hosts")
-	if _, err = os.Stat(khPath); os.IsNotExist(err) {
-		e := os.WriteFile(khPath, []byte{}, 0o600)
-		if e != nil {
-			return nil, fmt.Errorf("failed to create %s: %w", khPath, e)
-		}
-	}
+	sshHost := hostPort(node.Address, node.Port)
 
-	kh, err := knownhosts.NewDB(khPath)
+	hk, err := c.hostKeyVerifier(ctx, node, sshHost)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read %s: %w", khPath, err)
+		return nil, err
 	}
 
-	// Create a custom permissive host key callback which still errors on hosts
-	// with changed keys, but allows unknown hosts and adds them to known_hosts
-	cb := ssh.HostKeyCallback(func(hostname string, remote net.Addr, key ssh.PublicKey) error {
-		khErr := kh.HostKeyCallback()(hostname, remote, key)
-		if knownhosts.IsHostKeyChanged(khErr) {
-			return fmt.Errorf("REMOTE HOST IDENTIFICATION HAS CHANGED for host %s! This may indicate a MitM attack", hostname)
-		}
-
-		if knownhosts.IsHostUnknown(khErr) {
-			f, fErr := os.OpenFile(khPath, os.O_APPEND|os.O_WRONLY, 0o600)
-			if fErr == nil {
-				defer utils.CloseOrLogError(ctx)(f)
-
-				fErr = knownhosts.WriteKnownHost(f, hostname, remote, key)
-			}
-
-			if fErr == nil {
-				tflog.Info(ctx, fmt.Sprintf("Added host %s to known_hosts", hostname))
-			} else {
-				tflog.Error(ctx, fmt.Sprintf("Failed to add host %s to known_hosts", hostname), map[string]any{
-					"error": khErr,
-				})
-			}
-
-			return nil
-		}
-
-		return khErr
-	})
-
 	tflog.Info(ctx, fmt.Sprintf("agent is set to %t", c.agent))
 
 	var sshClient *ssh.Client
 	if c.agent {
-		sshClient, err = c.createSSHClientAgent(ctx, cb, kh, sshHost)
+		sshClient, err = c.createSSHClientAgent(ctx, hk, sshHost)
 		if err == nil {
 			return sshClient, nil
 		}
@@ -719,7 +713,7 @@ func (c *client) openNodeShell(ctx context.Context, node ProxmoxNode) (*ssh.Clie
 	}
 
 	if c.privateKey != "" {
-		sshClient, err = c.createSSHClientWithPrivateKey(ctx, cb, kh, sshHost)
+		sshClient, err = c.createSSHClientWithPrivateKey(ctx, hk, sshHost)
 		if err == nil {
 			return sshClient, nil
 		}
@@ -732,7 +726,7 @@ func (c *client) openNodeShell(ctx context.Context, node ProxmoxNode) (*ssh.Clie
 
 	tflog.Info(ctx, "Falling back to password authentication for SSH connection")
 
-	sshClient, err = c.createSSHClient(ctx, cb, kh, sshHost)
+	sshClient, err = c.createSSHClient(ctx, hk, sshHost)
 	if err != nil {
 		return nil, fmt.Errorf("unable to authenticate user %q over SSH to %q. Please verify that ssh-agent is "+
 			"correctly loaded with an authorized key via 'ssh-add -L' (NOTE: configurations in ~/.ssh/config are "+
@@ -744,8 +738,7 @@ func (c *client) openNodeShell(ctx context.Context, node ProxmoxNode) (*ssh.Clie
 
 func (c *client) createSSHClient(
 	ctx context.Context,
-	cb ssh.HostKeyCallback,
-	kh *knownhosts.HostKeyDB,
+	hk *hostKeyVerifier,
 	sshHost string,
 ) (*ssh.Client, error) {
 	if c.password == "" {
@@ -755,8 +748,8 @@ func (c *client) createSSHClient(
 	sshConfig := &ssh.ClientConfig{
 		User:              c.username,
 		Auth:              []ssh.AuthMethod{ssh.Password(c.password)},
-		HostKeyCallback:   cb,
-		HostKeyAlgorithms: kh.HostKeyAlgorithms(sshHost),
+		HostKeyCallback:   hk.callback,
+		HostKeyAlgorithms: hk.algorithms,
 	}
 
 	return c.connect(ctx, sshHost, sshConfig)
@@ -765,8 +758,7 @@ func (c *client) createSSHClient(
 // createSSHClientAgent establishes an ssh connection through the agent authentication mechanism.
 func (c *client) createSSHClientAgent(
 	ctx context.Context,
-	cb ssh.HostKeyCallback,
-	kh *knownhosts.HostKeyDB,
+	hk *hostKeyVerifier,
 	sshHost string,
 ) (*ssh.Client, error) {
 	conn, err := dialSocket(ctx, c.agentSocket)
@@ -779,8 +771,8 @@ func (c *client) createSSHClientAgent(
 	sshConfig := &ssh.ClientConfig{
 		User:              c.username,
 		Auth:              []ssh.AuthMethod{ssh.PublicKeysCallback(ag.Signers), ssh.Password(c.password)},
-		HostKeyCallback:   cb,
-		HostKeyAlgorithms: kh.HostKeyAlgorithms(sshHost),
+		HostKeyCallback:   hk.callback,
+		HostKeyAlgorithms: hk.algorithms,
 	}
 
 	return c.connect(ctx, sshHost, sshConfig)
@@ -788,8 +780,7 @@ func (c *client) createSSHClientAgent(
 
 func (c *client) createSSHClientWithPrivateKey(
 	ctx context.Context,
-	cb ssh.HostKeyCallback,
-	kh *knownhosts.HostKeyDB,
+	hk *hostKeyVerifier,
 	sshHost string,
 ) (*ssh.Client, error) {
 	privateKey, err := ssh.ParsePrivateKey([]byte(c.privateKey))
@@ -800,14 +791,24 @@ func (c *client) createSSHClientWithPrivateKey(
 	sshConfig := &ssh.ClientConfig{
 		User:              c.username,
 		Auth:              []ssh.AuthMethod{ssh.PublicKeys(privateKey)},
-		HostKeyCallback:   cb,
-		HostKeyAlgorithms: kh.HostKeyAlgorithms(sshHost),
+		HostKeyCallback:   hk.callback,
+		HostKeyAlgorithms: hk.algorithms,
 	}
 
 	return c.connect(ctx, sshHost, sshConfig)
 }
 
 func (c *client) connect(ctx context.Context, sshHost string, sshConfig *ssh.ClientConfig) (*ssh.Client, error) {
+	return c.connectWith(ctx, sshHost, sshConfig, c.dial)
+}
+
+// connectWith establishes an SSH connection over a connection opened by the dial function.
+func (c *client) connectWith(
+	ctx context.Context,
+	sshHost string,
+	sshConfig *ssh.ClientConfig,
+	dial func(ctx context.Context, sshHost string) (net.Conn, error),
+) (*ssh.Client, error) {
 	dialCtx, cancel, timeout := dialContext(ctx)
 	defer cancel()
 
@@ -816,7 +817,7 @@ func (c *client) connect(ctx context.Context, sshHost string, sshConfig *ssh.Cli
 		"dial_timeout": timeout.String(),
 	})
 
-	conn, err := c.dial(dialCtx, sshHost)
+	conn, err := dial(dialCtx, sshHost)
 	if err != nil {
 		return nil, err
 	}
@@ -860,7 +861,7 @@ func (c *client) connect(ctx context.Context, sshHost string, sshConfig *ssh.Cli
 
 		tflog.Debug(ctx, "SSH connection established", map[string]any{
 			"host":          sshHost,
-			"user":          c.username,
+			"user":          sshConfig.User,
 			"socks5_server": c.socks5Server,
 		})
 
@@ -885,7 +886,17 @@ func dialContext(ctx context.Context) (context.Context, context.CancelFunc, time
 	return dialCtx, cancel, timeout
 }
 
+// dial opens a connection to the host, through the bastion if one is configured.
 func (c *client) dial(ctx context.Context, sshHost string) (net.Conn, error) {
+	if c.bastion != nil {
+		return c.dialBastion(ctx, sshHost)
+	}
+
+	return c.dialDirect(ctx, sshHost)
+}
+
+// dialDirect opens a TCP connection to the host, through the SOCKS5 proxy if one is configured.
+func (c *client) dialDirect(ctx context.Context, sshHost string) (net.Conn, error) {
 	if c.socks5Server == "" {
 		var dialer net.Dialer
 
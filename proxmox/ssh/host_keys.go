@@ -0,0 +1,209 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/skeema/knownhosts"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/bpg/terraform-provider-proxmox/utils"
+)
+
+// ClientOption configures optional behaviour of the SSH client.
+type ClientOption func(c *client)
+
+// WithKnownHostsFile sets the known_hosts file used to verify node host keys.
+// Defaults to `~/.ssh/known_hosts`.
+func WithKnownHostsFile(path string) ClientOption {
+	return func(c *client) {
+		c.knownHostsFile = path
+	}
+}
+
+// WithStrictHostKeyChecking rejects nodes whose host key is neither pinned nor present in the
+// known_hosts file, instead of adding them to the file on first use.
+func WithStrictHostKeyChecking(strict bool) ClientOption {
+	return func(c *client) {
+		c.strictHostKeyChecking = strict
+	}
+}
+
+// ParseHostKeys parses host keys in the `authorized_keys` format, e.g. `ssh-ed25519 AAAA...`.
+func ParseHostKeys(keys []string) ([]ssh.PublicKey, error) {
+	res := make([]ssh.PublicKey, 0, len(keys))
+
+	for _, k := range keys {
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(k))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse host key %q: %w", k, err)
+		}
+
+		res = append(res, key)
+	}
+
+	return res, nil
+}
+
+// hostKeyVerifier is the host key callback and the host key algorithms to offer for a node.
+type hostKeyVerifier struct {
+	callback   ssh.HostKeyCallback
+	algorithms []string
+}
+
+// hostKeyVerifier returns the host key verification for the node: its pinned keys if any,
+// otherwise the known_hosts file, adding unknown hosts to it unless strict checking is enabled.
+func (c *client) hostKeyVerifier(ctx context.Context, node ProxmoxNode, sshHost string) (*hostKeyVerifier, error) {
+	if len(node.HostKeys) > 0 {
+		tflog.Debug(ctx, "verifying SSH host key against pinned keys", map[string]any{
+			"host": sshHost,
+			"keys": len(node.HostKeys),
+		})
+
+		return &hostKeyVerifier{
+			callback:   pinnedHostKeyCallback(node.HostKeys),
+			algorithms: pinnedHostKeyAlgorithms(node.HostKeys),
+		}, nil
+	}
+
+	khPath, err := c.knownHostsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	kh, err := knownhosts.NewDB(khPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", khPath, err)
+	}
+
+	cb := ssh.HostKeyCallback(func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		khErr := kh.HostKeyCallback()(hostname, remote, key)
+		if knownhosts.IsHostKeyChanged(khErr) {
+			return fmt.Errorf("REMOTE HOST IDENTIFICATION HAS CHANGED for host %s! This may indicate a MitM attack", hostname)
+		}
+
+		if !knownhosts.IsHostUnknown(khErr) {
+			return khErr
+		}
+
+		if c.strictHostKeyChecking {
+			return fmt.Errorf("host key verification failed: %s key %s of host %s is not in %s and strict "+
+				"host key checking is enabled. Add the key to the file or pin it in the provider `node` block",
+				key.Type(), ssh.FingerprintSHA256(key), hostname, khPath)
+		}
+
+		// trust on first use: allow unknown hosts and add them to known_hosts
+		f, fErr := os.OpenFile(khPath, os.O_APPEND|os.O_WRONLY, 0o600)
+		if fErr == nil {
+			defer utils.CloseOrLogError(ctx)(f)
+
+			fErr = knownhosts.WriteKnownHost(f, hostname, remote, key)
+		}
+
+		if fErr == nil {
+			tflog.Info(ctx, fmt.Sprintf("Added host %s to %s", hostname, khPath))
+		} else {
+			tflog.Error(ctx, fmt.Sprintf("Failed to add host %s to %s", hostname, khPath), map[string]any{
+				"error": fErr,
+			})
+		}
+
+		return nil
+	})
+
+	return &hostKeyVerifier{
+		callback:   cb,
+		algorithms: kh.HostKeyAlgorithms(sshHost),
+	}, nil
+}
+
+// knownHostsPath returns the known_hosts file to use. Unless strict host key checking is
+// enabled, a missing file (and the `~/.ssh` directory) is created.
+func (c *client) knownHostsPath() (string, error) {
+	khPath := c.knownHostsFile
+
+	if khPath == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to determine the home directory: %w", err)
+		}
+
+		khPath = filepath.Join(homeDir, ".ssh", "known_hosts")
+	}
+
+	_, err := os.Stat(khPath)
+
+	switch {
+	case err == nil:
+		return khPath, nil
+	case !errors.Is(err, os.ErrNotExist):
+		return "", fmt.Errorf("failed to access %s: %w", khPath, err)
+	case c.strictHostKeyChecking:
+		return "", fmt.Errorf("known_hosts file %s does not exist and strict host key checking is enabled", khPath)
+	}
+
+	if e := os.MkdirAll(filepath.Dir(khPath), 0o700); e != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(khPath), e)
+	}
+
+	if e := os.WriteFile(khPath, []byte{}, 0o600); e != nil {
+		return "", fmt.Errorf("failed to create %s: %w", khPath, e)
+	}
+
+	return khPath, nil
+}
+
+// pinnedHostKeyCallback accepts only the given host keys.
+func pinnedHostKeyCallback(keys []ssh.PublicKey) ssh.HostKeyCallback {
+	return func(hostname string, _ net.Addr, key ssh.PublicKey) error {
+		for _, k := range keys {
+			if bytes.Equal(k.Marshal(), key.Marshal()) {
+				return nil
+			}
+		}
+
+		pinned := make([]string, 0, len(keys))
+		for _, k := range keys {
+			pinned = append(pinned, ssh.FingerprintSHA256(k))
+		}
+
+		return fmt.Errorf("host key verification failed: %s key %s of host %s does not match "+
+			"any of the pinned host keys (%s). This may indicate a MitM attack",
+			key.Type(), ssh.FingerprintSHA256(key), hostname, strings.Join(pinned, ", "))
+	}
+}
+
+// pinnedHostKeyAlgorithms returns the host key algorithms matching the pinned keys, so the
+// server presents a key that can be verified.
+func pinnedHostKeyAlgorithms(keys []ssh.PublicKey) []string {
+	var algorithms []string
+
+	for _, k := range keys {
+		algos := []string{k.Type()}
+		if k.Type() == ssh.KeyAlgoRSA {
+			algos = []string{ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA}
+		}
+
+		for _, a := range algos {
+			if !slices.Contains(algorithms, a) {
+				algorithms = append(algorithms, a)
+			}
+		}
+	}
+
+	return algorithms
+}
@@ -0,0 +1,138 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skeema/knownhosts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+const testHost = "192.0.2.10:22"
+
+var testAddr = &net.TCPAddr{IP: net.ParseIP("192.0.2.10"), Port: 22}
+
+func ed25519HostKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	key, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+
+	return key
+}
+
+func TestParseHostKeys(t *testing.T) {
+	t.Parallel()
+
+	key := ed25519HostKey(t)
+
+	keys, err := ParseHostKeys([]string{strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))) + " root@pve"})
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, key.Marshal(), keys[0].Marshal())
+
+	_, err = ParseHostKeys([]string{"not a key"})
+	require.Error(t, err)
+}
+
+func TestHostKeyVerifierPinned(t *testing.T) {
+	t.Parallel()
+
+	pinned := ed25519HostKey(t)
+
+	// a missing known_hosts file is irrelevant for pinned nodes, even in strict mode
+	c := &client{knownHostsFile: filepath.Join(t.TempDir(), "missing"), strictHostKeyChecking: true}
+
+	hk, err := c.hostKeyVerifier(context.Background(), ProxmoxNode{HostKeys: []ssh.PublicKey{pinned}}, testHost)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ssh.KeyAlgoED25519}, hk.algorithms)
+
+	require.NoError(t, hk.callback(testHost, testAddr, pinned))
+
+	err = hk.callback(testHost, testAddr, ed25519HostKey(t))
+	require.ErrorContains(t, err, "does not match any of the pinned host keys")
+	require.ErrorContains(t, err, ssh.FingerprintSHA256(pinned))
+}
+
+func TestPinnedHostKeyAlgorithmsRSA(t *testing.T) {
+	t.Parallel()
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	rsaKey, err := ssh.NewPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]string{ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA, ssh.KeyAlgoED25519},
+		pinnedHostKeyAlgorithms([]ssh.PublicKey{rsaKey, ed25519HostKey(t), rsaKey}),
+	)
+}
+
+func TestHostKeyVerifierTrustOnFirstUse(t *testing.T) {
+	t.Parallel()
+
+	khPath := filepath.Join(t.TempDir(), "ssh", "known_hosts")
+	c := &client{knownHostsFile: khPath}
+	key := ed25519HostKey(t)
+
+	hk, err := c.hostKeyVerifier(context.Background(), ProxmoxNode{}, testHost)
+	require.NoError(t, err)
+	require.FileExists(t, khPath)
+
+	require.NoError(t, hk.callback(testHost, testAddr, key))
+
+	// the key has been recorded, a different one is rejected
+	hk, err = c.hostKeyVerifier(context.Background(), ProxmoxNode{}, testHost)
+	require.NoError(t, err)
+
+	require.NoError(t, hk.callback(testHost, testAddr, key))
+	require.ErrorContains(t, hk.callback(testHost, testAddr, ed25519HostKey(t)), "REMOTE HOST IDENTIFICATION HAS CHANGED")
+}
+
+func TestHostKeyVerifierStrict(t *testing.T) {
+	t.Parallel()
+
+	khPath := filepath.Join(t.TempDir(), "known_hosts")
+	c := &client{knownHostsFile: khPath, strictHostKeyChecking: true}
+
+	_, err := c.hostKeyVerifier(context.Background(), ProxmoxNode{}, testHost)
+	require.ErrorContains(t, err, "does not exist")
+	require.NoFileExists(t, khPath)
+
+	known := ed25519HostKey(t)
+	line := knownhosts.Line([]string{knownhosts.Normalize(testHost)}, known)
+	require.NoError(t, os.WriteFile(khPath, []byte(line+"\n"), 0o600))
+
+	hk, err := c.hostKeyVerifier(context.Background(), ProxmoxNode{}, testHost)
+	require.NoError(t, err)
+
+	require.NoError(t, hk.callback(testHost, testAddr, known))
+
+	const otherHost = "192.0.2.11:22"
+
+	err = hk.callback(otherHost, &net.TCPAddr{IP: net.ParseIP("192.0.2.11"), Port: 22}, ed25519HostKey(t))
+	require.ErrorContains(t, err, "strict host key checking is enabled")
+
+	data, err := os.ReadFile(khPath)
+	require.NoError(t, err)
+	assert.Equal(t, line+"\n", string(data), "strict mode must not modify known_hosts")
+}
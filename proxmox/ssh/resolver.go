@@ -8,12 +8,16 @@ package ssh
 
 import (
 	"context"
+
+	"golang.org/x/crypto/ssh"
 )
 
 // ProxmoxNode represents node address and port for SSH connection.
 type ProxmoxNode struct {
 	Address string
 	Port    int32
+	// HostKeys are the pinned host keys of the node. If set, the known_hosts file is not used.
+	HostKeys []ssh.PublicKey
 }
 
 // NodeResolver is an interface for resolving node names to IP addresses to use for SSH connection.
@@ -0,0 +1,43 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VolumeID is the identifier of a file stored in a datastore.
+type VolumeID struct {
+	DatastoreID string
+	ContentType string
+	FileName    string
+}
+
+// String returns the volume ID in the format datastore_id:content_type/file_name.
+func (v VolumeID) String() string {
+	return fmt.Sprintf("%s:%s/%s", v.DatastoreID, v.ContentType, v.FileName)
+}
+
+// ParseVolumeID parses a volume ID in the format datastore_id:content_type/file_name.
+func ParseVolumeID(id string) (VolumeID, error) {
+	datastoreID, path, ok := strings.Cut(id, ":")
+	if !ok || datastoreID == "" || path == "" {
+		return VolumeID{}, fmt.Errorf("unexpected format of ID (%s), expected datastore_id:content_type/file_name", id)
+	}
+
+	contentType, fileName, ok := strings.Cut(path, "/")
+	if !ok || contentType == "" || fileName == "" {
+		return VolumeID{}, fmt.Errorf("unexpected format of ID (%s), expected datastore_id:content_type/file_name", id)
+	}
+
+	return VolumeID{
+		DatastoreID: datastoreID,
+		ContentType: contentType,
+		FileName:    fileName,
+	}, nil
+}
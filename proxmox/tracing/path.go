@@ -0,0 +1,75 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package tracing
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// PathAttributes derives span attributes (node, resource type, VMID, UPID) from a relative
+// API path such as `nodes/pve/qemu/100/config` or `cluster/ha/resources`.
+// Query strings are ignored. Segments that cannot be identified are skipped.
+func PathAttributes(path string) []attribute.KeyValue {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		if u, err := url.PathUnescape(s); err == nil {
+			segments[i] = u
+		}
+	}
+
+	if len(segments) == 0 || segments[0] == "" {
+		return nil
+	}
+
+	attrs := make([]attribute.KeyValue, 0, 4)
+
+	switch segments[0] {
+	case "nodes":
+		if len(segments) < 2 {
+			return append(attrs, AttrResourceType.String("nodes"))
+		}
+
+		attrs = append(attrs, AttrNode.String(segments[1]))
+
+		if len(segments) < 3 {
+			return append(attrs, AttrResourceType.String("node"))
+		}
+
+		attrs = append(attrs, AttrResourceType.String(segments[2]))
+
+		if len(segments) < 4 {
+			return attrs
+		}
+
+		switch segments[2] {
+		case "qemu", "lxc":
+			if vmid, err := strconv.Atoi(segments[3]); err == nil {
+				attrs = append(attrs, AttrVMID.Int(vmid))
+			}
+		case "tasks":
+			attrs = append(attrs, AttrUPID.String(segments[3]))
+		}
+	case "cluster":
+		if len(segments) < 2 {
+			return append(attrs, AttrResourceType.String("cluster"))
+		}
+
+		attrs = append(attrs, AttrResourceType.String("cluster/"+segments[1]))
+	default:
+		attrs = append(attrs, AttrResourceType.String(segments[0]))
+	}
+
+	return attrs
+}
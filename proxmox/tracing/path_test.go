@@ -0,0 +1,62 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestPathAttributes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want []attribute.KeyValue
+	}{
+		{"empty", "", nil},
+		{"version", "version", []attribute.KeyValue{AttrResourceType.String("version")}},
+		{"nodes list", "nodes", []attribute.KeyValue{AttrResourceType.String("nodes")}},
+		{"node", "nodes/pve", []attribute.KeyValue{
+			AttrNode.String("pve"),
+			AttrResourceType.String("node"),
+		}},
+		{"vm config", "nodes/pve/qemu/100/config", []attribute.KeyValue{
+			AttrNode.String("pve"),
+			AttrResourceType.String("qemu"),
+			AttrVMID.Int(100),
+		}},
+		{"container status with query", "/nodes/pve-2/lxc/101/status/current?foo=bar", []attribute.KeyValue{
+			AttrNode.String("pve-2"),
+			AttrResourceType.String("lxc"),
+			AttrVMID.Int(101),
+		}},
+		{"vm list", "nodes/pve/qemu", []attribute.KeyValue{
+			AttrNode.String("pve"),
+			AttrResourceType.String("qemu"),
+		}},
+		{"task status", "nodes/pve/tasks/UPID%3Apve%3A00001234%3A00005678%3AAABBCCDD%3Aqmstart%3A100%3Aroot%40pam%3A/status",
+			[]attribute.KeyValue{
+				AttrNode.String("pve"),
+				AttrResourceType.String("tasks"),
+				AttrUPID.String("UPID:pve:00001234:00005678:AABBCCDD:qmstart:100:root@pam:"),
+			}},
+		{"cluster ha", "cluster/ha/resources/vm:100", []attribute.KeyValue{AttrResourceType.String("cluster/ha")}},
+		{"cluster", "cluster", []attribute.KeyValue{AttrResourceType.String("cluster")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, PathAttributes(tt.path))
+		})
+	}
+}
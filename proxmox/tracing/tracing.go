@@ -0,0 +1,152 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/bpg/terraform-provider-proxmox/utils"
+)
+
+const (
+	// instrumentationName is the name of the tracer used for all provider spans.
+	instrumentationName = "github.com/bpg/terraform-provider-proxmox"
+
+	// serviceName is reported as `service.name` unless overridden by OTEL_SERVICE_NAME.
+	serviceName = "terraform-provider-proxmox"
+)
+
+// Span attribute keys shared by the API, task and SSH instrumentation.
+const (
+	AttrNode         = attribute.Key("proxmox.node")
+	AttrResourceType = attribute.Key("proxmox.resource_type")
+	AttrVMID         = attribute.Key("proxmox.vmid")
+	AttrUPID         = attribute.Key("proxmox.upid")
+	AttrTaskType     = attribute.Key("proxmox.task.type")
+	AttrTaskExit     = attribute.Key("proxmox.task.exit_status")
+	AttrHTTPMethod   = attribute.Key("http.request.method")
+	AttrHTTPStatus   = attribute.Key("http.response.status_code")
+	AttrURLPath      = attribute.Key("url.path")
+	AttrSSHCommands  = attribute.Key("proxmox.ssh.command_count")
+	AttrFileName     = attribute.Key("proxmox.file.name")
+	AttrFileSize     = attribute.Key("proxmox.file.size")
+	AttrFileDir      = attribute.Key("proxmox.file.directory")
+)
+
+// Tracer returns the tracer used for provider spans. When tracing has not been
+// enabled via Setup, the global no-op tracer provider makes all spans free.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start starts a new span with the provider tracer.
+func Start(
+	ctx context.Context,
+	name string,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on the span (if any) and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// Enabled returns true if any of the standard OTLP exporter endpoint variables is set.
+func Enabled() bool {
+	return utils.GetAnyStringEnv(
+		"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+		"OTEL_EXPORTER_OTLP_ENDPOINT",
+	) != ""
+}
+
+// Setup installs a global OTLP trace exporter when the standard `OTEL_EXPORTER_OTLP_*`
+// environment variables are set. The exporter reads its endpoint, headers, TLS and timeout
+// settings from the same variables. The returned function flushes pending spans and must be
+// called before the process exits; it is a no-op when tracing is not enabled.
+func Setup(ctx context.Context, version string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	if !Enabled() {
+		return noop, nil
+	}
+
+	exporter, err := newExporter(ctx)
+	if err != nil {
+		return noop, err
+	}
+
+	// detectors are applied in order, so OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES
+	// take precedence over the defaults
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", version),
+		),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return noop, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return tp.Shutdown, nil
+}
+
+// newExporter creates an OTLP exporter using the protocol selected by
+// `OTEL_EXPORTER_OTLP_TRACES_PROTOCOL` or `OTEL_EXPORTER_OTLP_PROTOCOL`. Defaults to `http/protobuf`.
+func newExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	protocol := utils.GetAnyStringEnv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "OTEL_EXPORTER_OTLP_PROTOCOL")
+
+	switch strings.ToLower(strings.TrimSpace(protocol)) {
+	case "grpc":
+		exporter, err := otlptracegrpc.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP gRPC trace exporter: %w", err)
+		}
+
+		return exporter, nil
+	case "", "http/protobuf":
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP HTTP trace exporter: %w", err)
+		}
+
+		return exporter, nil
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q, must be one of: grpc, http/protobuf", protocol)
+	}
+}
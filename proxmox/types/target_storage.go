@@ -0,0 +1,37 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package types
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// TargetStorageWildcard is the source storage of a target storage mapping that applies to all
+// storages without an explicit mapping.
+const TargetStorageWildcard = "*"
+
+// TargetStorageMapping maps the source storages of a guest to the storages on the target node
+// of a migration.
+type TargetStorageMapping map[string]string
+
+// String encodes the mapping in the `targetstorage` / `target-storage` format of the API, i.e. a
+// list of `source:target` pairs with an optional default target storage.
+func (m TargetStorageMapping) String() string {
+	pairs := make([]string, 0, len(m))
+
+	for _, source := range slices.Sorted(maps.Keys(m)) {
+		if source == TargetStorageWildcard {
+			pairs = append(pairs, m[source])
+		} else {
+			pairs = append(pairs, source+":"+m[source])
+		}
+	}
+
+	return strings.Join(pairs, ",")
+}
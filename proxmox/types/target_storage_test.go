@@ -0,0 +1,39 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTargetStorageMappingString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mapping TargetStorageMapping
+		want    string
+	}{
+		{"wildcard only", TargetStorageMapping{TargetStorageWildcard: "local-lvm"}, "local-lvm"},
+		{"pairs are sorted", TargetStorageMapping{"local": "local-zfs", "ceph": "ceph"}, "ceph:ceph,local:local-zfs"},
+		{
+			"wildcard with pairs",
+			TargetStorageMapping{"local-lvm": "fast", TargetStorageWildcard: "slow", "ceph": "ceph"},
+			"slow,ceph:ceph,local-lvm:fast",
+		},
+		{"empty", TargetStorageMapping{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.mapping.String())
+		})
+	}
+}
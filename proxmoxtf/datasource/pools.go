@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf"
 )
 
@@ -47,10 +48,15 @@ func poolsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnost
 		return diag.FromErr(err)
 	}
 
-	poolIDs := make([]interface{}, len(list))
+	poolIDs := make([]interface{}, 0, len(list))
 
-	for i, v := range list {
-		poolIDs[i] = v.ID
+	for _, v := range list {
+		// the pools that store VM ID claims hold no guests
+		if cluster.IsIDClaimPool(v.ID) {
+			continue
+		}
+
+		poolIDs = append(poolIDs, v.ID)
 	}
 
 	d.SetId("pools")
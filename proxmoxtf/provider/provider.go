@@ -7,9 +7,11 @@
 package provider
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -111,6 +113,13 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (any, diag.D
 		return nil, diag.Errorf("error creating virtual environment client: %s", err)
 	}
 
+	var quorumGuard *cluster.QuorumGuard
+
+	if v, ok := d.GetOk(mkProviderRequireQuorum); ok && v.(bool) {
+		quorumGuard = cluster.NewQuorumGuard(apiClient)
+		apiClient = quorumGuard.API()
+	}
+
 	// ////////////////////////////////////////////////////////////////////////////////////
 
 	sshConf := map[string]any{}
@@ -129,6 +138,8 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (any, diag.D
 	sshSocks5Server := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_SERVER")
 	sshSocks5Username := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_USERNAME")
 	sshSocks5Password := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_PASSWORD")
+	sshKnownHostsFile := utils.GetAnyStringEnv("PROXMOX_VE_SSH_KNOWN_HOSTS_FILE")
+	sshStrictHostKeyChecking := utils.GetAnyBoolEnv("PROXMOX_VE_SSH_STRICT_HOST_KEY_CHECKING")
 
 	if v, ok := sshConf[mkProviderSSHUsername]; !ok || v.(string) == "" {
 		switch {
@@ -180,15 +191,38 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (any, diag.D
 		sshConf[mkProviderSSHSocks5Password] = sshSocks5Password
 	}
 
+	if v, ok := sshConf[mkProviderSSHKnownHostsFile]; !ok || v.(string) == "" {
+		sshConf[mkProviderSSHKnownHostsFile] = sshKnownHostsFile
+	}
+
+	if _, ok := sshConf[mkProviderSSHStrictHostKey]; !ok {
+		sshConf[mkProviderSSHStrictHostKey] = sshStrictHostKeyChecking
+	}
+
 	nodeOverrides := map[string]ssh.ProxmoxNode{}
 
 	if ns, ok := sshConf[mkProviderSSHNode]; ok {
 		for _, n := range ns.([]any) {
 			node := n.(map[string]any)
+
+			var hostKeys []string
+
+			if keys, ok := node[mkProviderSSHNodeHostKeys].([]any); ok {
+				for _, k := range keys {
+					hostKeys = append(hostKeys, k.(string))
+				}
+			}
+
+			pinnedKeys, err := ssh.ParseHostKeys(hostKeys)
+			if err != nil {
+				return nil, diag.Errorf("invalid SSH host key for node %q: %s", node[mkProviderSSHNodeName], err)
+			}
+
 			nodeOverrides[node[mkProviderSSHNodeName].(string)] = ssh.ProxmoxNode{
 				Address: node[mkProviderSSHNodeAddress].(string),
 
-				Port: int32(node[mkProviderSSHNodePort].(int)),
+				Port:     int32(node[mkProviderSSHNodePort].(int)),
+				HostKeys: pinnedKeys,
 			}
 		}
 	}
@@ -213,6 +247,20 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (any, diag.D
 		}
 	}
 
+	sshOptions := []ssh.ClientOption{
+		ssh.WithKnownHostsFile(sshConf[mkProviderSSHKnownHostsFile].(string)),
+		ssh.WithStrictHostKeyChecking(sshConf[mkProviderSSHStrictHostKey].(bool)),
+	}
+
+	bastion, err := sshBastion(sshConf)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	if bastion != nil {
+		sshOptions = append(sshOptions, ssh.WithBastion(bastion))
+	}
+
 	sshClient, err = ssh.NewClient(
 		sshConf[mkProviderSSHUsername].(string),
 		sshConf[mkProviderSSHPassword].(string),
@@ -224,11 +272,16 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (any, diag.D
 		sshConf[mkProviderSSHSocks5Username].(string),
 		sshConf[mkProviderSSHSocks5Password].(string),
 		nodeResolver,
+		sshOptions...,
 	)
 	if err != nil {
 		return nil, diag.Errorf("error creating SSH client: %s", err)
 	}
 
+	if quorumGuard != nil {
+		sshClient = quorumGuard.SSH(sshClient)
+	}
+
 	// Intentionally use 'PROXMOX_VE_TMPDIR' with 'TMP' instead of 'TEMP', to match os.TempDir's use of $TMPDIR
 	tmpDirOverride := utils.GetAnyStringEnv("PROXMOX_VE_TMPDIR", "PM_VE_TMPDIR")
 
@@ -250,6 +303,10 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (any, diag.D
 		idCfg.RandomIDEnd = v.(int)
 	}
 
+	if v, ok := d.GetOk(mkProviderVMIDLeases); ok {
+		idCfg.ClusterLeases = v.(bool)
+	}
+
 	config, err := proxmoxtf.NewProviderConfiguration(apiClient, sshClient, tmpDirOverride, idCfg)
 	if err != nil {
 		return nil, diag.Errorf("error creating provider's configuration: %s", err)
@@ -361,6 +418,47 @@ func (r *dnsResolver) Resolve(ctx context.Context, nodeName string) (ssh.Proxmox
 	return ssh.ProxmoxNode{}, fmt.Errorf("DNS lookup for node %q returned no addresses", nodeName)
 }
 
+// sshBastion returns the SSH bastion from the `bastion` block or the environment, or nil if none
+// is configured. Credentials not set for the bastion are inherited from the SSH configuration.
+func sshBastion(sshConf map[string]any) (*ssh.Bastion, error) {
+	bastion := &ssh.Bastion{
+		Address:  utils.GetAnyStringEnv("PROXMOX_VE_SSH_BASTION_ADDRESS"),
+		Username: utils.GetAnyStringEnv("PROXMOX_VE_SSH_BASTION_USERNAME"),
+	}
+
+	if p := utils.GetAnyStringEnv("PROXMOX_VE_SSH_BASTION_PORT"); p != "" {
+		port, err := strconv.ParseUint(p, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SSH bastion port %q: %w", p, err)
+		}
+
+		bastion.Port = int32(port)
+	}
+
+	if bs, ok := sshConf[mkProviderSSHBastion].([]any); ok && len(bs) > 0 && bs[0] != nil {
+		b := bs[0].(map[string]any)
+
+		bastion.Address = b[mkProviderSSHBastionAddress].(string)
+		bastion.Port = int32(b[mkProviderSSHBastionPort].(int))
+		bastion.Password = b[mkProviderSSHBastionPassword].(string)
+		bastion.PrivateKey = b[mkProviderSSHBastionPrivateKey].(string)
+
+		if v := b[mkProviderSSHBastionUsername].(string); v != "" {
+			bastion.Username = v
+		}
+	}
+
+	if bastion.Address == "" {
+		return nil, nil //nolint:nilnil // no bastion configured
+	}
+
+	bastion.Password = cmp.Or(bastion.Password, sshConf[mkProviderSSHPassword].(string))
+	bastion.PrivateKey = cmp.Or(bastion.PrivateKey, sshConf[mkProviderSSHPrivateKey].(string))
+	bastion.Agent = sshConf[mkProviderSSHAgent].(bool)
+
+	return bastion, nil
+}
+
 type resolverWithOverrides struct {
 	inner     ssh.NodeResolver
 	overrides map[string]ssh.ProxmoxNode
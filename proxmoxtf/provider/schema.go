@@ -11,6 +11,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/bpg/terraform-provider-proxmox/utils"
 )
 
 const (
@@ -27,6 +29,8 @@ const (
 	mkProviderRandomVMIDs          = "random_vm_ids"
 	mkProviderRandomVMIDStart      = "random_vm_id_start"
 	mkProviderRandomVMIDEnd        = "random_vm_id_end"
+	mkProviderRequireQuorum        = "require_quorum"
+	mkProviderVMIDLeases           = "vm_id_leases"
 	mkProviderSSH                  = "ssh"
 	mkProviderSSHUsername          = "username"
 	mkProviderSSHPassword          = "password"
@@ -38,11 +42,21 @@ const (
 	mkProviderSSHSocks5Username    = "socks5_username"
 	mkProviderSSHSocks5Password    = "socks5_password"
 	mkProviderSSHNodeAddressSource = "node_address_source"
+	mkProviderSSHKnownHostsFile    = "known_hosts_file"
+	mkProviderSSHStrictHostKey     = "strict_host_key_checking"
+
+	mkProviderSSHBastion           = "bastion"
+	mkProviderSSHBastionAddress    = "address"
+	mkProviderSSHBastionPort       = "port"
+	mkProviderSSHBastionUsername   = "username"
+	mkProviderSSHBastionPassword   = "password"
+	mkProviderSSHBastionPrivateKey = "private_key"
 
-	mkProviderSSHNode        = "node"
-	mkProviderSSHNodeName    = "name"
-	mkProviderSSHNodeAddress = "address"
-	mkProviderSSHNodePort    = "port"
+	mkProviderSSHNode         = "node"
+	mkProviderSSHNodeName     = "name"
+	mkProviderSSHNodeAddress  = "address"
+	mkProviderSSHNodePort     = "port"
+	mkProviderSSHNodeHostKeys = "host_keys"
 )
 
 func createSchema() map[string]*schema.Schema {
@@ -227,6 +241,29 @@ func createSchema() map[string]*schema.Schema {
 						),
 						ValidateFunc: validation.StringIsNotEmpty,
 					},
+					mkProviderSSHKnownHostsFile: {
+						Type:     schema.TypeString,
+						Optional: true,
+						Description: "The path to the known_hosts file used to verify the host keys of " +
+							"the Proxmox VE nodes. Defaults to the value of the " +
+							"`PROXMOX_VE_SSH_KNOWN_HOSTS_FILE` environment variable, or `~/.ssh/known_hosts` if not set.",
+						DefaultFunc: schema.MultiEnvDefaultFunc(
+							[]string{"PROXMOX_VE_SSH_KNOWN_HOSTS_FILE"},
+							nil,
+						),
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					mkProviderSSHStrictHostKey: {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Description: "Whether to reject nodes whose host key is neither pinned in a `node` " +
+							"block nor present in the known_hosts file, instead of adding them to the file " +
+							"on first use. Defaults to the value of the " +
+							"`PROXMOX_VE_SSH_STRICT_HOST_KEY_CHECKING` environment variable, or `false` if not set.",
+						DefaultFunc: func() (any, error) {
+							return utils.GetAnyBoolEnv("PROXMOX_VE_SSH_STRICT_HOST_KEY_CHECKING"), nil
+						},
+					},
 					mkProviderSSHNodeAddressSource: {
 						Type:     schema.TypeString,
 						Optional: true,
@@ -238,6 +275,51 @@ func createSchema() map[string]*schema.Schema {
 							"Defaults to `api`.",
 						ValidateFunc: validation.StringInSlice([]string{"api", "dns"}, false),
 					},
+					mkProviderSSHBastion: {
+						Type:     schema.TypeList,
+						Optional: true,
+						MaxItems: 1,
+						Description: "The SSH jump host (bastion) the connections to the Proxmox VE nodes " +
+							"are tunnelled through, like `ssh -J`. If a SOCKS5 proxy is configured, the " +
+							"bastion is reached through it.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								mkProviderSSHBastionAddress: {
+									Type:         schema.TypeString,
+									Required:     true,
+									Description:  "The address of the bastion host.",
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+								mkProviderSSHBastionPort: {
+									Type:         schema.TypeInt,
+									Optional:     true,
+									Description:  "The SSH port of the bastion host. Defaults to `22`.",
+									Default:      22,
+									ValidateFunc: validation.IsPortNumber,
+								},
+								mkProviderSSHBastionUsername: {
+									Type:     schema.TypeString,
+									Optional: true,
+									Description: "The username used for the bastion host. " +
+										"Defaults to the SSH `username`.",
+								},
+								mkProviderSSHBastionPassword: {
+									Type:      schema.TypeString,
+									Optional:  true,
+									Sensitive: true,
+									Description: "The password used for the bastion host. " +
+										"Defaults to the SSH `password`.",
+								},
+								mkProviderSSHBastionPrivateKey: {
+									Type:      schema.TypeString,
+									Optional:  true,
+									Sensitive: true,
+									Description: "The unencrypted private key (in PEM format) used for the " +
+										"bastion host. Defaults to the SSH `private_key`.",
+								},
+							},
+						},
+					},
 					mkProviderSSHNode: {
 						Type:        schema.TypeList,
 						Optional:    true,
@@ -264,6 +346,14 @@ func createSchema() map[string]*schema.Schema {
 									Default:      22,
 									ValidateFunc: validation.IsPortNumber,
 								},
+								mkProviderSSHNodeHostKeys: {
+									Type:     schema.TypeList,
+									Optional: true,
+									Description: "The pinned SSH host keys of the Proxmox VE node in the " +
+										"`authorized_keys` format, e.g. `ssh-ed25519 AAAA...`. If set, " +
+										"the known_hosts file is not used for the node.",
+									Elem: &schema.Schema{Type: schema.TypeString},
+								},
 							},
 						},
 					},
@@ -293,5 +383,19 @@ func createSchema() map[string]*schema.Schema {
 			Description:  "The ending number for random VM / Container IDs.",
 			ValidateFunc: validation.IntBetween(100, 999999999),
 		},
+		mkProviderRequireQuorum: {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Description: "Whether to refuse the changes when the cluster is not quorate or when the " +
+				"target node is offline, instead of failing halfway through an operation. The cluster status is " +
+				"checked before every modifying API request and every SSH command or upload.",
+		},
+		mkProviderVMIDLeases: {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Description: "Whether to lease generated VM / Container IDs cluster-wide, so that " +
+				"provider instances on different machines do not generate the same ID. Leases are stored as " +
+				"resource pools, which requires the `Pool.Allocate` privilege.",
+		},
 	}
 }
@@ -0,0 +1,133 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+const (
+	dvBackupOnDestroyDatastoreID   = ""
+	dvBackupOnDestroyMode          = nodes.BackupModeSnapshot
+	dvBackupOnDestroyCompress      = nodes.BackupCompressZstd
+	dvBackupOnDestroyNotesTemplate = "{{guestname}} (final backup)"
+	dvBackupOnDestroyTimeout       = 3600
+
+	// MkBackupOnDestroy is the name of the block that configures the final backup of a guest.
+	MkBackupOnDestroy = "backup_on_destroy"
+
+	mkBackupOnDestroyDatastoreID   = "datastore_id"
+	mkBackupOnDestroyMode          = "mode"
+	mkBackupOnDestroyCompress      = "compress"
+	mkBackupOnDestroyNotesTemplate = "notes_template"
+	mkBackupOnDestroyTimeout       = "timeout"
+)
+
+// BackupOnDestroySchema returns the schema of the block that configures a final, protected
+// backup of a VM or container before it is destroyed.
+func BackupOnDestroySchema(guest string) *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeList,
+		Description: "Take a protected vzdump backup of the " + guest + " before destroying it. " +
+			"The " + guest + " is not destroyed if the backup fails",
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				mkBackupOnDestroyDatastoreID: {
+					Type:        schema.TypeString,
+					Description: "The ID of the datastore to store the backup in",
+					Optional:    true,
+					Default:     dvBackupOnDestroyDatastoreID,
+				},
+				mkBackupOnDestroyMode: {
+					Type:        schema.TypeString,
+					Description: "The backup mode",
+					Optional:    true,
+					Default:     dvBackupOnDestroyMode,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+						nodes.BackupModeSnapshot,
+						nodes.BackupModeSuspend,
+						nodes.BackupModeStop,
+					}, false)),
+				},
+				mkBackupOnDestroyCompress: {
+					Type:        schema.TypeString,
+					Description: "The compression algorithm",
+					Optional:    true,
+					Default:     dvBackupOnDestroyCompress,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+						nodes.BackupCompressNone,
+						nodes.BackupCompressGzip,
+						nodes.BackupCompressLZO,
+						nodes.BackupCompressZstd,
+					}, false)),
+				},
+				mkBackupOnDestroyNotesTemplate: {
+					Type:        schema.TypeString,
+					Description: "The template for the notes of the backup",
+					Optional:    true,
+					Default:     dvBackupOnDestroyNotesTemplate,
+				},
+				mkBackupOnDestroyTimeout: {
+					Type:             schema.TypeInt,
+					Description:      "The timeout for the backup in seconds",
+					Optional:         true,
+					Default:          dvBackupOnDestroyTimeout,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				},
+			},
+		},
+		MaxItems: 1,
+		MinItems: 0,
+	}
+}
+
+// BackupOnDestroy takes the backup configured in the `backup_on_destroy` block of the guest,
+// if any. It must be called before the guest is stopped, so that snapshot mode backups
+// capture the running guest.
+func BackupOnDestroy(ctx context.Context, client *nodes.Client, d *schema.ResourceData, vmID int) diag.Diagnostics {
+	block := d.Get(MkBackupOnDestroy).([]any)
+	if len(block) == 0 || block[0] == nil {
+		return nil
+	}
+
+	b := block[0].(map[string]any)
+
+	// the backup has its own timeout, which may exceed the delete timeout of the guest, so it
+	// is not bound by the deadline of the delete operation
+	ctx, cancel := context.WithTimeout(
+		context.WithoutCancel(ctx),
+		time.Duration(b[mkBackupOnDestroyTimeout].(int))*time.Second,
+	)
+	defer cancel()
+
+	body := &nodes.VzdumpRequestBody{
+		VMID:      strconv.Itoa(vmID),
+		Mode:      new(b[mkBackupOnDestroyMode].(string)),
+		Compress:  new(b[mkBackupOnDestroyCompress].(string)),
+		Protected: new(types.CustomBool(true)),
+	}
+
+	if datastoreID := b[mkBackupOnDestroyDatastoreID].(string); datastoreID != "" {
+		body.Storage = &datastoreID
+	}
+
+	if notes := b[mkBackupOnDestroyNotesTemplate].(string); notes != "" {
+		body.NotesTemplate = &notes
+	}
+
+	return TaskResultDiags(client.BackupGuests(ctx, body), "Backup before destroy")
+}
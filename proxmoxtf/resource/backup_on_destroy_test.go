@@ -0,0 +1,95 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/test"
+)
+
+// TestBackupOnDestroySchema tests the backup_on_destroy schema.
+func TestBackupOnDestroySchema(t *testing.T) {
+	t.Parallel()
+
+	s := map[string]*schema.Schema{MkBackupOnDestroy: BackupOnDestroySchema("VM")}
+
+	test.AssertListMaxItems(t, s, MkBackupOnDestroy, 1)
+
+	backupSchema := test.AssertNestedSchemaExistence(t, s, MkBackupOnDestroy)
+
+	test.AssertOptionalArguments(t, backupSchema, []string{
+		mkBackupOnDestroyDatastoreID,
+		mkBackupOnDestroyMode,
+		mkBackupOnDestroyCompress,
+		mkBackupOnDestroyNotesTemplate,
+		mkBackupOnDestroyTimeout,
+	})
+
+	test.AssertValueTypes(t, backupSchema, map[string]schema.ValueType{
+		mkBackupOnDestroyDatastoreID:   schema.TypeString,
+		mkBackupOnDestroyMode:          schema.TypeString,
+		mkBackupOnDestroyCompress:      schema.TypeString,
+		mkBackupOnDestroyNotesTemplate: schema.TypeString,
+		mkBackupOnDestroyTimeout:       schema.TypeInt,
+	})
+}
+
+func TestBackupOnDestroy(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	s := fake.NewServer()
+	t.Cleanup(s.Close)
+
+	apiClient, err := s.Client()
+	require.NoError(t, err)
+
+	nodeClient := proxmox.NewClient(apiClient, nil, "").Node(fake.DefaultNode)
+
+	require.NoError(t, nodeClient.VM(100).CreateVM(ctx, &vms.CreateRequestBody{VMID: 100, Name: new("web")}).Err())
+
+	sch := map[string]*schema.Schema{MkBackupOnDestroy: BackupOnDestroySchema("VM")}
+
+	// without the block, no backup is taken
+	d := schema.TestResourceDataRaw(t, sch, map[string]any{})
+	require.False(t, BackupOnDestroy(ctx, nodeClient, d, 100).HasError())
+
+	backups, err := nodeClient.Storage("local").ListDatastoreBackups(ctx, new(100))
+	require.NoError(t, err)
+	assert.Empty(t, backups)
+
+	d = schema.TestResourceDataRaw(t, sch, map[string]any{
+		MkBackupOnDestroy: []any{map[string]any{
+			mkBackupOnDestroyDatastoreID: "local",
+		}},
+	})
+	diags := BackupOnDestroy(ctx, nodeClient, d, 100)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	backups, err = nodeClient.Storage("local").ListDatastoreBackups(ctx, new(100))
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.True(t, bool(*backups[0].Protected))
+	assert.Equal(t, "web (final backup)", *backups[0].Notes)
+
+	// a failed backup must be reported, so that the guest is not destroyed
+	d = schema.TestResourceDataRaw(t, sch, map[string]any{
+		MkBackupOnDestroy: []any{map[string]any{
+			mkBackupOnDestroyDatastoreID: "local-lvm",
+		}},
+	})
+	require.True(t, BackupOnDestroy(ctx, nodeClient, d, 100).HasError())
+}
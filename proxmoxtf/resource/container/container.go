@@ -8,8 +8,10 @@ package resource
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"regexp"
 	"slices"
 	"sort"
@@ -73,6 +75,7 @@ const (
 	dvHookScript                        = ""
 	dvMemoryDedicated                   = 512
 	dvMemorySwap                        = 0
+	dvMigrate                           = false
 	dvMountPointACL                     = false
 	dvMountPointBackup                  = false
 	dvMountPointPath                    = ""
@@ -91,6 +94,8 @@ const (
 	dvOperatingSystemType               = "unmanaged"
 	dvPoolID                            = ""
 	dvProtection                        = false
+	dvRestoreFromDatastoreID            = ""
+	dvRestoreFromUnique                 = false
 	dvStarted                           = true
 	dvStartupOrder                      = -1
 	dvStartupUpDelay                    = -1
@@ -158,6 +163,10 @@ const (
 	mkMemory                            = "memory"
 	mkMemoryDedicated                   = "dedicated"
 	mkMemorySwap                        = "swap"
+	mkMigrate                           = "migrate"
+	mkMigration                         = "migration"
+	mkMigrationBandwidthLimit           = "bandwidth_limit"
+	mkMigrationTargetStorage            = "target_storage"
 	mkMountPoint                        = "mount_point"
 	mkMountPointACL                     = "acl"
 	mkMountPointBackup                  = "backup"
@@ -197,6 +206,11 @@ const (
 	mkOperatingSystemType               = "type"
 	mkPoolID                            = "pool_id"
 	mkProtection                        = "protection"
+	mkRestoreFrom                       = "restore_from"
+	mkRestoreFromDatastoreID            = "datastore_id"
+	mkRestoreFromUnique                 = "unique"
+	mkRestoreFromVolumeDatastoreIDs     = "volume_datastore_ids"
+	mkRestoreFromVolumeID               = "volume_id"
 	mkStarted                           = "started"
 	mkStartup                           = "startup"
 	mkStartupOrder                      = "order"
@@ -223,6 +237,7 @@ const (
 func Container() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
+			sdkresource.MkBackupOnDestroy: sdkresource.BackupOnDestroySchema("container"),
 			mkClone: {
 				Type:        schema.TypeList,
 				Description: "The cloning configuration",
@@ -743,6 +758,40 @@ func Container() *schema.Resource {
 				MaxItems: 1,
 				MinItems: 0,
 			},
+			mkMigrate: {
+				Type: schema.TypeBool,
+				Description: "Whether to migrate the container on node change instead of re-creating it. " +
+					"Running containers are migrated in restart mode",
+				Optional: true,
+				Default:  dvMigrate,
+			},
+			mkMigration: {
+				Type:        schema.TypeList,
+				Description: "The settings used to migrate the container when `node_name` changes",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						mkMigrationTargetStorage: {
+							Type: schema.TypeMap,
+							Description: "The mapping of source storages to storages on the target node. " +
+								"The `*` key sets the target storage for all storages without a mapping",
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+							},
+						},
+						mkMigrationBandwidthLimit: {
+							Type:             schema.TypeInt,
+							Description:      "The bandwidth limit of the migration in KiB/s",
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+						},
+					},
+				},
+				MaxItems: 1,
+				MinItems: 0,
+			},
 			mkMountPoint: {
 				Type:        schema.TypeList,
 				Description: "A mount point",
@@ -988,7 +1037,6 @@ func Container() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "The node name",
 				Required:    true,
-				ForceNew:    true,
 			},
 			mkOperatingSystem: {
 				Type:        schema.TypeList,
@@ -1031,6 +1079,52 @@ func Container() *schema.Resource {
 				ForceNew: false,
 				Default:  dvProtection,
 			},
+			mkRestoreFrom: {
+				Type:          schema.TypeList,
+				Description:   "The backup to restore the container from",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{mkClone, mkOperatingSystem},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						mkRestoreFromVolumeID: {
+							Type:             schema.TypeString,
+							Description:      "The volume ID of the vzdump or PBS backup archive",
+							Required:         true,
+							ForceNew:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+						},
+						mkRestoreFromDatastoreID: {
+							Type: schema.TypeString,
+							Description: "The ID of the datastore to restore the volumes to, unless " +
+								"mapped in `volume_datastore_ids`",
+							Optional: true,
+							ForceNew: true,
+							Default:  dvRestoreFromDatastoreID,
+						},
+						mkRestoreFromVolumeDatastoreIDs: {
+							Type: schema.TypeMap,
+							Description: "The mapping of the volumes recorded in the backup, i.e. `rootfs` " +
+								"and `mp0` to `mp255`, to the datastores to restore them to",
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+							},
+						},
+						mkRestoreFromUnique: {
+							Type:        schema.TypeBool,
+							Description: "Whether to assign new unique MAC addresses to the network interfaces",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     dvRestoreFromUnique,
+						},
+					},
+				},
+				MaxItems: 1,
+				MinItems: 0,
+			},
 			mkStarted: {
 				Type:        schema.TypeBool,
 				Description: "Whether to start the container",
@@ -1169,6 +1263,16 @@ func Container() *schema.Resource {
 		UpdateContext: containerUpdate,
 		DeleteContext: containerDelete,
 		CustomizeDiff: customdiff.All(
+			customdiff.ForceNewIf(
+				mkNodeName,
+				func(_ context.Context, d *schema.ResourceDiff, _ any) bool {
+					if !d.HasChange(mkNodeName) {
+						return false
+					}
+
+					return !d.Get(mkMigrate).(bool)
+				},
+			),
 			customdiff.ForceNewIf(
 				mkVMID,
 				func(_ context.Context, d *schema.ResourceDiff, _ any) bool {
@@ -1227,9 +1331,18 @@ func Container() *schema.Resource {
 				},
 			),
 		),
+		Identity: sdkresource.GuestIdentity(),
+		ResourceBehavior: schema.ResourceBehavior{
+			MutableIdentity: true,
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(_ context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
-				node, id, err := parseImportIDWithNodeName(d.Id())
+				importID, err := sdkresource.GuestImportID(d)
+				if err != nil {
+					return nil, err
+				}
+
+				node, id, err := parseImportIDWithNodeName(importID)
 				if err != nil {
 					return nil, err
 				}
@@ -1254,9 +1367,236 @@ func containerCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Di
 		return containerCreateClone(ctx, d, m)
 	}
 
+	if len(d.Get(mkRestoreFrom).([]any)) > 0 {
+		return containerCreateRestore(ctx, d, m)
+	}
+
 	return containerCreateCustom(ctx, d, m)
 }
 
+// containerCreatedFromSource returns the source block (clone or restore_from) the container has been
+// created from. Such a container inherits the configuration of its source, so only the declared
+// attributes are tracked.
+func containerCreatedFromSource(d *schema.ResourceData) []any {
+	if clone := d.Get(mkClone).([]any); len(clone) > 0 {
+		return clone
+	}
+
+	return d.Get(mkRestoreFrom).([]any)
+}
+
+func containerCreateRestore(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	createTimeoutSec := d.Get(mkTimeoutCreate).(int)
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(createTimeoutSec)*time.Second)
+	defer cancel()
+
+	config := m.(proxmoxtf.ProviderConfiguration)
+
+	client, err := config.GetClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nodeName := d.Get(mkNodeName).(string)
+	vmIDUntyped, hasVMID := d.GetOk(mkVMID)
+	vmID := vmIDUntyped.(int)
+
+	if !hasVMID {
+		vmID, err = config.GetIDGenerator().NextID(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = d.Set(mkVMID, vmID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	restoreBody, err := containerRestoreRequestBody(ctx, client, d, vmID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	volumeID := *restoreBody.OSTemplateFileVolume
+
+	tflog.Info(ctx, fmt.Sprintf("Restoring container %d from %s", vmID, volumeID))
+
+	diags := sdkresource.TaskResultDiags(
+		client.Node(nodeName).Container(vmID).RestoreContainer(ctx, restoreBody),
+		"Container restore",
+	)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(strconv.Itoa(vmID))
+
+	return append(diags, containerCreateReconcile(ctx, d, m, client, vmID)...)
+}
+
+// containerRestoreRequestBody returns the request body to restore the container from the backup
+// set in the restore_from block.
+func containerRestoreRequestBody(
+	ctx context.Context,
+	client proxmox.Client,
+	d *schema.ResourceData,
+	vmID int,
+) (*containers.CreateRequestBody, error) {
+	restoreBlock := d.Get(mkRestoreFrom).([]any)[0].(map[string]any)
+	volumeID := restoreBlock[mkRestoreFromVolumeID].(string)
+	datastoreID := restoreBlock[mkRestoreFromDatastoreID].(string)
+	volumeDatastoreIDs := restoreBlock[mkRestoreFromVolumeDatastoreIDs].(map[string]any)
+	unique := types.CustomBool(restoreBlock[mkRestoreFromUnique].(bool))
+
+	description := d.Get(mkDescription).(string)
+	nodeName := d.Get(mkNodeName).(string)
+	poolID := d.Get(mkPoolID).(string)
+
+	restore := types.CustomBool(true)
+
+	restoreBody := &containers.CreateRequestBody{
+		OSTemplateFileVolume: &volumeID,
+		Restore:              &restore,
+		VMID:                 &vmID,
+	}
+
+	if datastoreID != "" {
+		restoreBody.DatastoreID = &datastoreID
+	}
+
+	if len(volumeDatastoreIDs) > 0 {
+		rootFS, mountPoints, err := containerRestoreVolumes(
+			ctx, client, nodeName, volumeID, datastoreID, volumeDatastoreIDs,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		restoreBody.RootFS = rootFS
+		restoreBody.MountPoints = mountPoints
+	}
+
+	if unique {
+		restoreBody.Unique = &unique
+	}
+
+	if description != "" {
+		restoreBody.Description = &description
+	}
+
+	if poolID != "" {
+		restoreBody.PoolID = &poolID
+	}
+
+	return restoreBody, nil
+}
+
+// containerRestoreVolumes places the volumes recorded in the backup on the mapped datastores.
+// The API restores only the volumes that are passed explicitly, so all volumes recorded in the
+// backup are passed, the ones without a mapping on datastoreID or their original datastores.
+func containerRestoreVolumes(
+	ctx context.Context,
+	client proxmox.Client,
+	nodeName string,
+	volumeID string,
+	datastoreID string,
+	mapping map[string]any,
+) (*containers.CustomRootFS, containers.CustomMountPoints, error) {
+	recorded, err := client.Node(nodeName).ExtractBackupConfig(ctx, volumeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	volumes := map[string]string{}
+
+	for key, value := range recorded {
+		if key == "rootfs" || (strings.HasPrefix(key, "mp") && strings.Trim(key[2:], "0123456789") == "") {
+			volumes[key] = value
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(mapping)) {
+		if _, ok := volumes[key]; !ok {
+			return nil, nil, fmt.Errorf("volume %s is not recorded in backup %s", key, volumeID)
+		}
+	}
+
+	encoded, err := json.Marshal(volumes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode the volumes of backup %s: %w", volumeID, err)
+	}
+
+	var data containers.GetResponseData
+
+	if err = json.Unmarshal(encoded, &data); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode the volumes of backup %s: %w", volumeID, err)
+	}
+
+	// the target datastore of a volume, or an empty string to keep a bind or device mount
+	target := func(key, volume string) string {
+		source, _, ok := strings.Cut(volume, ":")
+		if !ok || strings.HasPrefix(volume, "/") {
+			return ""
+		}
+
+		if ds, ok := mapping[key]; ok {
+			return ds.(string)
+		}
+
+		if datastoreID != "" {
+			return datastoreID
+		}
+
+		return source
+	}
+
+	if data.RootFS != nil {
+		if ds := target("rootfs", data.RootFS.Volume); ds != "" {
+			data.RootFS.Volume = restoreVolumeSpec(ds, data.RootFS.Size)
+			data.RootFS.Size = nil
+		}
+	}
+
+	for key, mp := range data.MountPoints {
+		ds := target(key, mp.Volume)
+		if ds == "" {
+			if _, ok := mapping[key]; ok {
+				return nil, nil, fmt.Errorf("mount point %s of backup %s is not a volume", key, volumeID)
+			}
+
+			continue
+		}
+
+		var size *types.DiskSize
+
+		if mp.DiskSize != nil {
+			parsed, e := types.ParseDiskSize(*mp.DiskSize)
+			if e != nil {
+				return nil, nil, fmt.Errorf("invalid size of mount point %s of backup %s: %w", key, volumeID, e)
+			}
+
+			size = &parsed
+		}
+
+		mp.Volume = restoreVolumeSpec(ds, size)
+		mp.DiskSize = nil
+	}
+
+	return data.RootFS, data.MountPoints, nil
+}
+
+// restoreVolumeSpec returns the `<datastore>:<size in GiB>` spec to allocate a restored volume.
+func restoreVolumeSpec(datastoreID string, size *types.DiskSize) string {
+	gigabytes := 1.0
+	if size != nil && *size > 0 {
+		gigabytes = float64(*size) / (1 << 30)
+	}
+
+	return datastoreID + ":" + strconv.FormatFloat(gigabytes, 'f', -1, 64)
+}
+
 func containerCreateClone(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	cloneTimeoutSec := d.Get(mkTimeoutClone).(int)
 
@@ -1288,7 +1628,6 @@ func containerCreateClone(ctx context.Context, d *schema.ResourceData, m any) di
 
 	nodeName := d.Get(mkNodeName).(string)
 	poolID := d.Get(mkPoolID).(string)
-	tags := d.Get(mkTags).([]any)
 	vmIDUntyped, hasVMID := d.GetOk(mkVMID)
 	vmID := vmIDUntyped.(int)
 
@@ -1346,9 +1685,24 @@ func containerCreateClone(ctx context.Context, d *schema.ResourceData, m any) di
 
 	d.SetId(strconv.Itoa(vmID))
 
+	return append(diags, containerCreateReconcile(ctx, d, m, client, vmID)...)
+}
+
+// containerCreateReconcile applies the declared configuration to a container that has been
+// created from another source (a clone or a backup), then starts it if requested.
+func containerCreateReconcile(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m any,
+	client proxmox.Client,
+	vmID int,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	nodeName := d.Get(mkNodeName).(string)
 	containerAPI := client.Node(nodeName).Container(vmID)
 
-	// Now that the virtual machine has been cloned, we need to perform some modifications.
+	// Now that the container has been created from its source, we need to perform some modifications.
 	updateBody := &containers.UpdateRequestBody{}
 
 	startOnBoot := types.CustomBool(d.Get(mkStartOnBoot).(bool))
@@ -1412,6 +1766,8 @@ func containerCreateClone(ctx context.Context, d *schema.ResourceData, m any) di
 		updateBody.HookScript = &hookScript
 	}
 
+	initialization := d.Get(mkInitialization).([]any)
+
 	var initializationIPConfigIPv4Address []string
 
 	var initializationIPConfigIPv4Gateway []string
@@ -1444,7 +1800,7 @@ func containerCreateClone(ctx context.Context, d *schema.ResourceData, m any) di
 			}
 		}
 
-		initializationHostname = initializationBlock[mkInitializationHostname].(string)
+		initializationHostname := initializationBlock[mkInitializationHostname].(string)
 
 		if initializationHostname != dvInitializationHostname {
 			updateBody.Hostname = &initializationHostname
@@ -1684,7 +2040,7 @@ func containerCreateClone(ctx context.Context, d *schema.ResourceData, m any) di
 		updateBody.OSType = &operatingSystemType
 	}
 
-	if len(tags) > 0 {
+	if tags := d.Get(mkTags).([]any); len(tags) > 0 {
 		tagString := containerGetTagsString(d)
 		updateBody.Tags = &tagString
 	}
@@ -2688,6 +3044,11 @@ func containerRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diag
 		return diag.FromErr(e)
 	}
 
+	e = sdkresource.SetGuestIdentity(d, nodeName, vmID)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
 	// Fix terraform.tfstate, by replacing '-1' (the old default value) with actual vm_id value
 	if storedVMID := d.Get(mkVMID).(int); storedVMID == -1 {
 		diags = append(diags, diag.Diagnostic{
@@ -2700,7 +3061,7 @@ func containerRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diag
 		diags = append(diags, diag.FromErr(err)...)
 	}
 
-	clone := d.Get(mkClone).([]any)
+	clone := containerCreatedFromSource(d)
 
 	// Compare the primitive values to those stored in the state.
 	currentDescription := d.Get(mkDescription).(string)
@@ -3518,6 +3879,59 @@ func containerRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diag
 	return diags
 }
 
+// containerMigrate migrates the container to the target node, streaming the migration task log to the
+// provider log. Running containers are migrated in restart mode: they are shut down on the source node
+// and started again on the target node.
+func containerMigrate(
+	ctx context.Context,
+	client proxmox.Client,
+	vmID int,
+	sourceNode, targetNode string,
+	migration []any,
+) diag.Diagnostics {
+	containerAPI := client.Node(sourceNode).Container(vmID)
+
+	status, err := containerAPI.GetContainerStatus(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	migrateBody := &containers.MigrateRequestBody{TargetNode: targetNode}
+
+	if len(migration) > 0 && migration[0] != nil {
+		migrationBlock := migration[0].(map[string]any)
+
+		if mapping := migrationBlock[mkMigrationTargetStorage].(map[string]any); len(mapping) > 0 {
+			targetStorage := types.TargetStorageMapping{}
+			for source, target := range mapping {
+				targetStorage[source] = target.(string)
+			}
+
+			migrateBody.TargetStorage = new(targetStorage.String())
+		}
+
+		if bwlimit := migrationBlock[mkMigrationBandwidthLimit].(int); bwlimit > 0 {
+			migrateBody.BandwidthLimit = &bwlimit
+		}
+	}
+
+	if status.Status == "running" {
+		migrateBody.Restart = types.CustomBool(true).Pointer()
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Migrating container %d from node %q to node %q", vmID, sourceNode, targetNode), map[string]any{
+		"restart": status.Status == "running",
+	})
+
+	result := containerAPI.MigrateContainer(ctx, migrateBody, tasks.WithLogHandler(func(line string) {
+		tflog.Info(ctx, "container migration: "+line, map[string]any{
+			"vm_id": vmID,
+		})
+	}))
+
+	return sdkresource.TaskResultDiags(result, "Container migrate")
+}
+
 func containerUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	var updateDiags diag.Diagnostics
 
@@ -3540,6 +3954,17 @@ func containerUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Di
 		return diag.FromErr(e)
 	}
 
+	if d.HasChange(mkNodeName) {
+		oldNodeName, _ := d.GetChange(mkNodeName)
+
+		updateDiags = append(updateDiags, containerMigrate(
+			ctx, client, vmID, oldNodeName.(string), nodeName, d.Get(mkMigration).([]any),
+		)...)
+		if updateDiags.HasError() {
+			return updateDiags
+		}
+	}
+
 	containerAPI := client.Node(nodeName).Container(vmID)
 
 	// Prepare the new request object.
@@ -3556,8 +3981,8 @@ func containerUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Di
 	rebootRequired := false
 	container := Container()
 
-	// Retrieve the clone argument as the update logic varies for clones.
-	clone := d.Get(mkClone).([]any)
+	// Retrieve the creation source as the update logic varies for clones and restored containers.
+	clone := containerCreatedFromSource(d)
 
 	// Prepare the new primitive values.
 	if d.HasChange(mkDescription) {
@@ -4201,14 +4626,17 @@ func containerDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Di
 
 	containerAPI := client.Node(nodeName).Container(vmID)
 
+	diags := sdkresource.BackupOnDestroy(ctx, client.Node(nodeName), d, vmID)
+	if diags.HasError() {
+		return diags
+	}
+
 	// Shut down the container before deleting it.
 	status, err := containerAPI.GetContainerStatus(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var diags diag.Diagnostics
-
 	if status.Status != "stopped" {
 		forceStop := types.CustomBool(true)
 
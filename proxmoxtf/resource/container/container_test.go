@@ -14,6 +14,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/containers"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/test"
 )
 
@@ -46,11 +49,14 @@ func TestContainerSchema(t *testing.T) {
 		mkInitialization,
 		mkHookScriptFileID,
 		mkMemory,
+		mkMigrate,
+		mkMigration,
 		mkDevicePassthrough,
 		mkMountPoint,
 		mkOperatingSystem,
 		mkPoolID,
 		mkProtection,
+		mkRestoreFrom,
 		mkStarted,
 		mkTags,
 		mkTemplate,
@@ -69,11 +75,14 @@ func TestContainerSchema(t *testing.T) {
 		mkInitialization:       schema.TypeList,
 		mkHookScriptFileID:     schema.TypeString,
 		mkMemory:               schema.TypeList,
+		mkMigrate:              schema.TypeBool,
+		mkMigration:            schema.TypeList,
 		mkDevicePassthrough:    schema.TypeList,
 		mkMountPoint:           schema.TypeList,
 		mkOperatingSystem:      schema.TypeList,
 		mkPoolID:               schema.TypeString,
 		mkProtection:           schema.TypeBool,
+		mkRestoreFrom:          schema.TypeList,
 		mkStarted:              schema.TypeBool,
 		mkTags:                 schema.TypeList,
 		mkTemplate:             schema.TypeBool,
@@ -442,3 +451,114 @@ func TestInitializationDnsBlockDiffIgnore(t *testing.T) {
 		assert.Equal(t, tt.expected, actual)
 	}
 }
+
+// TestContainerMigrate tests that running containers are migrated in restart mode.
+func TestContainerMigrate(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	s := fake.NewServer(fake.WithNodes("pve1", "pve2"))
+	t.Cleanup(s.Close)
+
+	tmpl, err := s.AddVolume("pve1", "local", "vztmpl", "debian-12-standard_12.7-1_amd64.tar.zst", 1<<20)
+	require.NoError(t, err)
+
+	apiClient, err := s.Client()
+	require.NoError(t, err)
+
+	client := proxmox.NewClient(apiClient, nil, "")
+
+	ct := client.Node("pve1").Container(200)
+	require.NoError(t, ct.CreateContainer(ctx, &containers.CreateRequestBody{
+		VMID:                 new(200),
+		OSTemplateFileVolume: &tmpl,
+	}).Err())
+	require.NoError(t, ct.StartContainer(ctx).Err())
+
+	// the target storage mapping is passed to the API, which rejects unknown storages
+	diags := containerMigrate(ctx, client, 200, "pve1", "pve2", []any{map[string]any{
+		mkMigrationTargetStorage:  map[string]any{"*": "missing"},
+		mkMigrationBandwidthLimit: 0,
+	}})
+	require.True(t, diags.HasError())
+
+	diags = containerMigrate(ctx, client, 200, "pve1", "pve2", []any{map[string]any{
+		mkMigrationTargetStorage:  map[string]any{"*": "local"},
+		mkMigrationBandwidthLimit: 1024,
+	}})
+	require.False(t, diags.HasError(), "%v", diags)
+
+	status, err := client.Node("pve2").Container(200).GetContainerStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "running", status.Status)
+}
+
+// TestContainerRestoreRequestBody tests that the restore places the volumes of the backup on the mapped datastores.
+func TestContainerRestoreRequestBody(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	s := fake.NewServer(fake.WithStorage("fast", "zfspool", false, "images", "rootdir"))
+	t.Cleanup(s.Close)
+
+	backup, err := s.AddBackup(fake.DefaultNode, "local", 100, fake.Backup{
+		Container: true,
+		Config: map[string]string{
+			"hostname": "restored",
+			"rootfs":   "local-lvm:vm-100-disk-0,size=8G",
+			"mp0":      "local-lvm:vm-100-disk-1,mp=/data,backup=1,size=512M",
+			"mp1":      "/srv/shared,mp=/shared",
+		},
+	})
+	require.NoError(t, err)
+
+	apiClient, err := s.Client()
+	require.NoError(t, err)
+
+	client := proxmox.NewClient(apiClient, nil, "")
+
+	restoreData := func(mapping map[string]any) *schema.ResourceData {
+		return schema.TestResourceDataRaw(t, Container().Schema, map[string]any{
+			mkNodeName: fake.DefaultNode,
+			mkRestoreFrom: []any{map[string]any{
+				mkRestoreFromVolumeID:           backup,
+				mkRestoreFromVolumeDatastoreIDs: mapping,
+			}},
+		})
+	}
+
+	body, err := containerRestoreRequestBody(ctx, client, restoreData(nil), 200)
+	require.NoError(t, err)
+	assert.Equal(t, backup, *body.OSTemplateFileVolume)
+	assert.True(t, bool(*body.Restore))
+	assert.Nil(t, body.DatastoreID)
+	assert.Nil(t, body.RootFS, "the volumes are restored as recorded")
+	assert.Nil(t, body.MountPoints)
+
+	body, err = containerRestoreRequestBody(ctx, client, restoreData(map[string]any{"mp0": "fast"}), 200)
+	require.NoError(t, err)
+	require.NotNil(t, body.RootFS)
+	assert.Equal(t, "local-lvm:8", body.RootFS.Volume)
+	require.Len(t, body.MountPoints, 2)
+	assert.Equal(t, "fast:0.5", body.MountPoints["mp0"].Volume)
+	assert.Equal(t, "/data", body.MountPoints["mp0"].MountPoint)
+	assert.Nil(t, body.MountPoints["mp0"].DiskSize)
+	assert.Equal(t, "/srv/shared", body.MountPoints["mp1"].Volume, "bind mounts are kept")
+
+	require.NoError(t, client.Node(fake.DefaultNode).Container(200).RestoreContainer(ctx, body).Err())
+
+	ct, err := client.Node(fake.DefaultNode).Container(200).GetContainer(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "restored", *ct.Hostname)
+	assert.Regexp(t, `^local-lvm:vm-200-disk-\d+$`, ct.RootFS.Volume)
+	assert.Equal(t, "fast:subvol-200-disk-0", ct.MountPoints["mp0"].Volume)
+	assert.Equal(t, "512M", *ct.MountPoints["mp0"].DiskSize)
+
+	_, err = containerRestoreRequestBody(ctx, client, restoreData(map[string]any{"mp5": "fast"}), 201)
+	require.ErrorContains(t, err, "volume mp5 is not recorded")
+
+	_, err = containerRestoreRequestBody(ctx, client, restoreData(map[string]any{"mp1": "fast"}), 201)
+	require.ErrorContains(t, err, "mount point mp1")
+}
@@ -289,27 +289,15 @@ func (v fileVolumeID) String() string {
 
 // fileParseVolumeID parses a volume ID in the format datastore_id:content_type/file_name.
 func fileParseVolumeID(id string) (fileVolumeID, error) {
-	parts := strings.SplitN(id, ":", 2)
-
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		return fileVolumeID{}, fmt.Errorf("unexpected format of ID (%s), expected datastore_id:content_type/file_name", id)
-	}
-
-	datastoreID := parts[0]
-
-	parts = strings.SplitN(parts[1], "/", 2)
-
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		return fileVolumeID{}, fmt.Errorf("unexpected format of ID (%s), expected datastore_id:content_type/file_name", id)
+	volID, err := storage.ParseVolumeID(id)
+	if err != nil {
+		return fileVolumeID{}, err
 	}
 
-	contentType := parts[0]
-	fileName := parts[1]
-
 	return fileVolumeID{
-		datastoreID: datastoreID,
-		contentType: contentType,
-		fileName:    fileName,
+		datastoreID: volID.DatastoreID,
+		contentType: volID.ContentType,
+		fileName:    volID.FileName,
 	}, nil
 }
 
@@ -0,0 +1,124 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	mkIdentityNodeName = "node_name"
+	mkIdentityVMID     = "vm_id"
+)
+
+// GuestIdentity returns the identity of a VM or container: the node it is on and its ID.
+func GuestIdentity() *schema.ResourceIdentity {
+	return &schema.ResourceIdentity{
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				mkIdentityNodeName: {
+					Type:              schema.TypeString,
+					Description:       "The name of the node the guest is on",
+					RequiredForImport: true,
+				},
+				mkIdentityVMID: {
+					Type:              schema.TypeInt,
+					Description:       "The ID of the guest",
+					RequiredForImport: true,
+				},
+			}
+		},
+	}
+}
+
+// SetGuestIdentity stores the identity of a VM or container.
+func SetGuestIdentity(d *schema.ResourceData, nodeName string, vmID int) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return fmt.Errorf("error getting identity: %w", err)
+	}
+
+	if err = identity.Set(mkIdentityNodeName, nodeName); err != nil {
+		return fmt.Errorf("error setting identity: %w", err)
+	}
+
+	if err = identity.Set(mkIdentityVMID, vmID); err != nil {
+		return fmt.Errorf("error setting identity: %w", err)
+	}
+
+	return nil
+}
+
+// GuestImportID returns the `node/id` import identifier of a VM or container, from its identity
+// when it is imported by identity.
+func GuestImportID(d *schema.ResourceData) (string, error) {
+	if d.Id() != "" {
+		return d.Id(), nil
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		return "", fmt.Errorf("error getting identity: %w", err)
+	}
+
+	nodeName, _ := identity.Get(mkIdentityNodeName).(string)
+	vmID, _ := identity.Get(mkIdentityVMID).(int)
+
+	return fmt.Sprintf("%s/%d", nodeName, vmID), nil
+}
+
+// StringIdentity returns an identity of a single string attribute, for resources identified by
+// their name.
+func StringIdentity(key string, description string) *schema.ResourceIdentity {
+	return &schema.ResourceIdentity{
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				key: {
+					Type:              schema.TypeString,
+					Description:       description,
+					RequiredForImport: true,
+				},
+			}
+		},
+	}
+}
+
+// SetStringIdentity stores an identity of a single string attribute.
+func SetStringIdentity(d *schema.ResourceData, key string, value string) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return fmt.Errorf("error getting identity: %w", err)
+	}
+
+	if err = identity.Set(key, value); err != nil {
+		return fmt.Errorf("error setting identity: %w", err)
+	}
+
+	return nil
+}
+
+// StringImportID returns the import identifier of a resource identified by a single string
+// attribute, from its identity when it is imported by identity.
+func StringImportID(d *schema.ResourceData, key string) (string, error) {
+	if d.Id() != "" {
+		return d.Id(), nil
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		return "", fmt.Errorf("error getting identity: %w", err)
+	}
+
+	id, _ := identity.Get(key).(string)
+	if id == "" {
+		return "", fmt.Errorf("expected identity to contain %q", key)
+	}
+
+	return id, nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/pools"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf"
+	sdkresource "github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource"
 )
 
 const (
@@ -87,11 +88,17 @@ func Pool() *schema.Resource {
 		ReadContext:   poolRead,
 		UpdateContext: poolUpdate,
 		DeleteContext: poolDelete,
+		Identity:      sdkresource.StringIdentity(mkResourceVirtualEnvironmentPoolPoolID, "The pool id"),
 		Importer: &schema.ResourceImporter{
 			StateContext: func(_ context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
-				d.SetId(d.Id())
+				poolID, err := sdkresource.StringImportID(d, mkResourceVirtualEnvironmentPoolPoolID)
+				if err != nil {
+					return nil, err
+				}
 
-				err := d.Set(mkResourceVirtualEnvironmentPoolPoolID, d.Id())
+				d.SetId(poolID)
+
+				err = d.Set(mkResourceVirtualEnvironmentPoolPoolID, poolID)
 				if err != nil {
 					return nil, fmt.Errorf("failed setting state during import: %w", err)
 				}
@@ -149,6 +156,11 @@ func poolRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnosti
 		return diag.FromErr(err)
 	}
 
+	err = sdkresource.SetStringIdentity(d, mkResourceVirtualEnvironmentPoolPoolID, poolID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	if pool.Comment != nil {
 		err = d.Set(mkResourceVirtualEnvironmentPoolComment, pool.Comment)
 	} else {
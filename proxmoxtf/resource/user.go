@@ -148,11 +148,17 @@ func User() *schema.Resource {
 		ReadContext:   userRead,
 		UpdateContext: userUpdate,
 		DeleteContext: userDelete,
+		Identity:      StringIdentity(mkResourceVirtualEnvironmentUserUserID, "The user id"),
 		Importer: &schema.ResourceImporter{
 			StateContext: func(_ context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
-				roleID := d.Id()
+				userID, err := StringImportID(d, mkResourceVirtualEnvironmentUserUserID)
+				if err != nil {
+					return nil, err
+				}
 
-				err := d.Set(mkResourceVirtualEnvironmentUserUserID, roleID)
+				d.SetId(userID)
+
+				err = d.Set(mkResourceVirtualEnvironmentUserUserID, userID)
 				if err != nil {
 					return nil, fmt.Errorf("failed setting state during import: %w", err)
 				}
@@ -263,6 +269,11 @@ func userRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnosti
 		return diag.FromErr(err)
 	}
 
+	err = SetStringIdentity(d, mkResourceVirtualEnvironmentUserUserID, userID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	var diags diag.Diagnostics
 
 	err = d.Set(mkResourceVirtualEnvironmentUserUserID, userID)
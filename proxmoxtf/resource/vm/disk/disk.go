@@ -25,6 +25,7 @@ import (
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/version"
 	sdkresource "github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource"
 	"github.com/bpg/terraform-provider-proxmox/utils"
 )
@@ -308,6 +309,9 @@ func GetDiskDeviceObjects(
 }
 
 // CreateCustomDisks creates custom disks for a VM.
+//
+// The disks are imported through the API (`import-from`) when the cluster supports the
+// `import` content type and the file is importable, and through SSH (`qm disk import`) otherwise.
 func CreateCustomDisks(
 	ctx context.Context,
 	client proxmox.Client,
@@ -317,10 +321,16 @@ func CreateCustomDisks(
 ) diag.Diagnostics {
 	var diags diag.Diagnostics
 
+	var ver *version.ProxmoxVersion
+
 	for iface, disk := range storageDevices {
 		if disk != nil && disk.FileID != nil && *disk.FileID != "" {
+			if ver == nil {
+				ver = proxmoxVersion(ctx, client)
+			}
+
 			// only custom disks with defined file ID
-			diags = append(diags, createCustomDisk(ctx, client, nodeName, vmID, iface, *disk)...)
+			diags = append(diags, createCustomDisk(ctx, client, ver, nodeName, vmID, iface, *disk)...)
 			if diags.HasError() {
 				return diags
 			}
@@ -330,9 +340,34 @@ func CreateCustomDisks(
 	return diags
 }
 
+// proxmoxVersion returns the Proxmox VE version, or the minimum supported one if it can't be determined.
+func proxmoxVersion(ctx context.Context, client proxmox.Client) *version.ProxmoxVersion {
+	ver := version.MinimumProxmoxVersion
+
+	if versionResp, err := client.Version().Version(ctx); err == nil {
+		ver = versionResp.Version
+	} else {
+		tflog.Warn(ctx, fmt.Sprintf("failed to determine Proxmox VE version, assume %v", ver), map[string]any{
+			"error": err,
+		})
+	}
+
+	return &ver
+}
+
+// canImportFromAPI reports whether the file can be imported with `import-from`: the API only
+// accepts volumes of the `import` content type (PVE 8.4+) or existing disk images, so files
+// stored as e.g. `iso` still have to be imported through SSH.
+func canImportFromAPI(ver *version.ProxmoxVersion, fileID string) bool {
+	_, volume, ok := strings.Cut(fileID, ":")
+
+	return ok && ver.SupportImportContentType() && strings.HasPrefix(volume, "import/")
+}
+
 func createCustomDisk(
 	ctx context.Context,
 	client proxmox.Client,
+	ver *version.ProxmoxVersion,
 	nodeName string,
 	vmID int,
 	iface string,
@@ -344,6 +379,72 @@ func createCustomDisk(
 		fileFormat = *disk.Format
 	}
 
+	var diags diag.Diagnostics
+
+	if canImportFromAPI(ver, *disk.FileID) {
+		diags = importCustomDisk(ctx, client, nodeName, vmID, iface, disk, fileFormat)
+	} else {
+		diags = importCustomDiskSSH(ctx, client, nodeName, vmID, iface, disk, fileFormat)
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	result := client.Node(nodeName).VM(vmID).ResizeVMDisk(ctx, &vms.ResizeDiskRequestBody{
+		Disk: iface,
+		Size: *disk.Size,
+	})
+
+	return sdkresource.TaskResultDiags(result, "Disk resize")
+}
+
+// importCustomDisk attaches a new disk imported from the file with `import-from`.
+func importCustomDisk(
+	ctx context.Context,
+	client proxmox.Client,
+	nodeName string,
+	vmID int,
+	iface string,
+	disk vms.CustomStorageDevice,
+	fileFormat string,
+) diag.Diagnostics {
+	tflog.Debug(ctx, "importing custom disk via API", map[string]any{
+		"vm_id":     vmID,
+		"interface": iface,
+		"file_id":   *disk.FileID,
+	})
+
+	disk.ImportFrom = disk.FileID
+	disk.FileID = nil
+	disk.Format = &fileFormat
+	// the size of the imported disk is the size of the file, it is resized afterwards
+	disk.Size = nil
+
+	body := &vms.UpdateRequestBody{}
+	body.AddCustomStorageDevice(iface, disk)
+
+	vmAPI := client.Node(nodeName).VM(vmID)
+
+	taskID, err := vmAPI.UpdateVMAsync(ctx, body)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("creating custom disk: %w", err))
+	}
+
+	return sdkresource.TaskResultDiags(vmAPI.Tasks().WaitForTask(ctx, *taskID), "Disk import")
+}
+
+// importCustomDiskSSH imports the file with `qm disk import` on the node and attaches the disk,
+// for clusters or files the API import is not available for.
+func importCustomDiskSSH(
+	ctx context.Context,
+	client proxmox.Client,
+	nodeName string,
+	vmID int,
+	iface string,
+	disk vms.CustomStorageDevice,
+	fileFormat string,
+) diag.Diagnostics {
 	//nolint:lll
 	commands := []string{
 		`set -e`,
@@ -373,12 +474,7 @@ func createCustomDisk(
 		"output": string(out),
 	})
 
-	result := client.Node(nodeName).VM(vmID).ResizeVMDisk(ctx, &vms.ResizeDiskRequestBody{
-		Disk: iface,
-		Size: *disk.Size,
-	})
-
-	return sdkresource.TaskResultDiags(result, "Disk resize")
+	return nil
 }
 
 // Read reads the disk configuration of a VM.
@@ -639,7 +735,9 @@ func Update(
 			case currentDisks[iface] == nil && disk != nil:
 				if disk.FileID != nil && *disk.FileID != "" {
 					// only disks with defined file ID are custom image disks that need to be created via import.
-					diags = append(diags, createCustomDisk(ctx, client, nodeName, vmID, iface, *disk)...)
+					ver := proxmoxVersion(ctx, client)
+
+					diags = append(diags, createCustomDisk(ctx, client, ver, nodeName, vmID, iface, *disk)...)
 					if diags.HasError() {
 						return false, diags
 					}
@@ -10,14 +10,18 @@ import (
 	"context"
 	"reflect"
 	"slices"
+	"strings"
 	"testing"
 
+	goversion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/require"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/version"
 )
 
 // TestDiskOrderingDeterministic tests that disk ordering is deterministic
@@ -1053,3 +1057,70 @@ func TestDiskQueuesSettings(t *testing.T) {
 	_, err = GetDiskDeviceObjects(resourceData, resource, virtioDiskList)
 	require.ErrorContains(t, err, "queues are only supported for SCSI disks")
 }
+
+func TestCanImportFromAPI(t *testing.T) {
+	t.Parallel()
+
+	v84 := version.ProxmoxVersion{Version: *goversion.Must(goversion.NewVersion("8.4.0"))}
+	v83 := version.ProxmoxVersion{Version: *goversion.Must(goversion.NewVersion("8.3.5"))}
+
+	tests := []struct {
+		name   string
+		ver    version.ProxmoxVersion
+		fileID string
+		want   bool
+	}{
+		{"import content", v84, "local:import/noble.qcow2", true},
+		{"iso content", v84, "local:iso/noble.img", false},
+		{"old version", v83, "local:import/noble.qcow2", false},
+		{"not a volume", v84, "import/noble.qcow2", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, canImportFromAPI(&tt.ver, tt.fileID))
+		})
+	}
+}
+
+func TestCreateCustomDisksImportFromAPI(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	s := fake.NewServer()
+	t.Cleanup(s.Close)
+
+	apiClient, err := s.Client()
+	require.NoError(t, err)
+
+	// no SSH client: the import must not fall back to SSH
+	client := proxmox.NewClient(apiClient, nil, "")
+
+	fileID, err := s.AddVolume(fake.DefaultNode, "local", "import", "noble.qcow2", 1<<30)
+	require.NoError(t, err)
+
+	vmAPI := client.Node(fake.DefaultNode).VM(100)
+	require.NoError(t, vmAPI.CreateVM(ctx, &vms.CreateRequestBody{VMID: 100}).Err())
+
+	diags := CreateCustomDisks(ctx, client, fake.DefaultNode, 100, vms.CustomStorageDevices{
+		"scsi0": &vms.CustomStorageDevice{
+			DatastoreID: new("local-lvm"),
+			FileID:      &fileID,
+			Size:        types.DiskSizeFromGigabytes(8),
+			Discard:     new("on"),
+		},
+	})
+	require.False(t, diags.HasError(), "%v", diags)
+
+	cfg, err := vmAPI.GetVM(ctx)
+	require.NoError(t, err)
+
+	scsi0 := cfg.StorageDevices["scsi0"]
+	require.NotNil(t, scsi0)
+	require.True(t, strings.HasPrefix(scsi0.FileVolume, "local-lvm:vm-100-disk-"), scsi0.FileVolume)
+	require.Equal(t, int64(8), scsi0.Size.InGigabytes())
+	require.Equal(t, "on", *scsi0.Discard)
+}
@@ -11,6 +11,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"maps"
 	"path/filepath"
 	"regexp"
 	"slices"
@@ -112,6 +113,9 @@ const (
 	dvOperatingSystemType              = "other"
 	dvPoolID                           = ""
 	dvProtection                       = false
+	dvRestoreFromDatastoreID           = ""
+	dvRestoreFromLiveRestore           = false
+	dvRestoreFromUnique                = false
 	dvRNGMaxBytes                      = 1024
 	dvRNGPeriod                        = 1000
 	dvSerialDeviceDevice               = "socket"
@@ -274,6 +278,12 @@ const (
 	mkOperatingSystemType              = "type"
 	mkPoolID                           = "pool_id"
 	mkProtection                       = "protection"
+	mkRestoreFrom                      = "restore_from"
+	mkRestoreFromDatastoreID           = "datastore_id"
+	mkRestoreFromDiskDatastoreIDs      = "disk_datastore_ids"
+	mkRestoreFromLiveRestore           = "live_restore"
+	mkRestoreFromUnique                = "unique"
+	mkRestoreFromVolumeID              = "volume_id"
 	mkRNG                              = "rng"
 	mkRNGSource                        = "source"
 	mkRNGMaxBytes                      = "max_bytes"
@@ -1354,6 +1364,59 @@ func VM() *schema.Resource {
 			Optional:    true,
 			Default:     dvProtection,
 		},
+		mkRestoreFrom: {
+			Type:          schema.TypeList,
+			Description:   "The backup to restore the VM from",
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{mkClone},
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					mkRestoreFromVolumeID: {
+						Type:             schema.TypeString,
+						Description:      "The volume ID of the vzdump or PBS backup archive",
+						Required:         true,
+						ForceNew:         true,
+						ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+					},
+					mkRestoreFromDatastoreID: {
+						Type:        schema.TypeString,
+						Description: "The ID of the datastore to restore the disks to",
+						Optional:    true,
+						ForceNew:    true,
+						Default:     dvRestoreFromDatastoreID,
+					},
+					mkRestoreFromDiskDatastoreIDs: {
+						Type: schema.TypeMap,
+						Description: "The mapping of the disks recorded in the backup, e.g. `scsi0`, to the " +
+							"datastores to move them to once restored",
+						Optional: true,
+						ForceNew: true,
+						Elem: &schema.Schema{
+							Type:             schema.TypeString,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+						},
+					},
+					mkRestoreFromUnique: {
+						Type:        schema.TypeBool,
+						Description: "Whether to assign new unique MAC addresses to the network devices",
+						Optional:    true,
+						ForceNew:    true,
+						Default:     dvRestoreFromUnique,
+					},
+					mkRestoreFromLiveRestore: {
+						Type: schema.TypeBool,
+						Description: "Whether to start the VM while the disks are restored in the background " +
+							"(PBS backups only). The VM keeps the configuration recorded in the backup",
+						Optional: true,
+						ForceNew: true,
+						Default:  dvRestoreFromLiveRestore,
+					},
+				},
+			},
+			MaxItems: 1,
+			MinItems: 0,
+		},
 		mkRNG: {
 			Type:        schema.TypeList,
 			Description: "The RNG configuration",
@@ -1683,6 +1746,7 @@ func VM() *schema.Resource {
 			Optional:    true,
 			Default:     dvHookScript,
 		},
+		sdkresource.MkBackupOnDestroy: sdkresource.BackupOnDestroySchema("VM"),
 		mkStopOnDestroy: {
 			Type:        schema.TypeBool,
 			Description: "Whether to stop rather than shutdown on VM destroy",
@@ -1790,10 +1854,20 @@ func VM() *schema.Resource {
 			),
 			forceNewOnTPMVersionChange,
 			forceNewOnEFIDiskTypeChange,
+			validateLiveRestore,
 		),
+		Identity: sdkresource.GuestIdentity(),
+		ResourceBehavior: schema.ResourceBehavior{
+			MutableIdentity: true,
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(_ context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
-				node, id, err := parseImportIDWithNodeName(d.Id())
+				importID, err := sdkresource.GuestImportID(d)
+				if err != nil {
+					return nil, err
+				}
+
+				node, id, err := parseImportIDWithNodeName(importID)
 				if err != nil {
 					return nil, err
 				}
@@ -1937,9 +2011,23 @@ func vmCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnosti
 		return vmCreateClone(ctx, d, m)
 	}
 
+	if len(d.Get(mkRestoreFrom).([]any)) > 0 {
+		return vmCreateRestore(ctx, d, m)
+	}
+
 	return vmCreateCustom(ctx, d, m)
 }
 
+// vmCreatedFromSource returns the source block (clone or restore_from) the VM has been created from.
+// Such a VM inherits the configuration of its source, so only the declared attributes are tracked.
+func vmCreatedFromSource(d *schema.ResourceData) []any {
+	if clone := d.Get(mkClone).([]any); len(clone) > 0 {
+		return clone
+	}
+
+	return d.Get(mkRestoreFrom).([]any)
+}
+
 // Check for an existing CloudInit IDE drive. If no such drive is found, return the specified `defaultValue`.
 func findExistingCloudInitDrive(vmConfig *vms.GetResponseData, vmID int, defaultValue string) (string, *vms.CustomStorageDevice) {
 	devs := vmConfig.StorageDevices.Filter(func(device *vms.CustomStorageDevice) bool {
@@ -2341,7 +2429,6 @@ func vmCreateClone(ctx context.Context, d *schema.ResourceData, m any) diag.Diag
 
 	description := d.Get(mkDescription).(string)
 	name := d.Get(mkName).(string)
-	tags := d.Get(mkTags).([]any)
 	nodeName := d.Get(mkNodeName).(string)
 	poolID := d.Get(mkPoolID).(string)
 	vmIDUntyped, hasVMID := d.GetOk(mkVMID)
@@ -2471,15 +2558,197 @@ func vmCreateClone(ctx context.Context, d *schema.ResourceData, m any) diag.Diag
 
 	d.SetId(strconv.Itoa(vmID))
 
+	return append(cloneDiags, vmCreateReconcile(ctx, d, m, client, vmID)...)
+}
+
+func vmCreateRestore(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	createTimeoutSec := d.Get(mkTimeoutCreate).(int)
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(createTimeoutSec)*time.Second)
+	defer cancel()
+
+	config := m.(proxmoxtf.ProviderConfiguration)
+
+	client, err := config.GetClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nodeName := d.Get(mkNodeName).(string)
+	vmIDUntyped, hasVMID := d.GetOk(mkVMID)
+	vmID := vmIDUntyped.(int)
+
+	if !hasVMID {
+		vmID, err = config.GetIDGenerator().NextID(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = d.Set(mkVMID, vmID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	restoreBody := vmRestoreRequestBody(d, vmID)
+	volumeID := *restoreBody.BackupFile
+
+	tflog.Info(ctx, fmt.Sprintf("Restoring VM %d from %s", vmID, volumeID))
+
+	vmAPI := client.Node(nodeName).VM(vmID)
+
+	diags := sdkresource.TaskResultDiags(vmAPI.RestoreVM(ctx, restoreBody), "VM restore")
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(strconv.Itoa(vmID))
+
+	restoreBlock := d.Get(mkRestoreFrom).([]any)[0].(map[string]any)
+
+	// a live restored VM runs with the configuration of the backup, see validateLiveRestore
+	if restoreBlock[mkRestoreFromLiveRestore].(bool) {
+		return append(diags, vmRead(ctx, d, m)...)
+	}
+
+	diskDatastoreIDs := restoreBlock[mkRestoreFromDiskDatastoreIDs].(map[string]any)
+
+	diags = append(diags, vmRestoreMoveDisks(ctx, vmAPI, diskDatastoreIDs)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, vmCreateReconcile(ctx, d, m, client, vmID)...)
+}
+
+// validateLiveRestore rejects a live restore that needs more than the restore itself. The VM is
+// started by the restore task, which ends once all disks are restored, so the disks cannot be
+// moved and the declared configuration is not applied to the VM afterwards.
+func validateLiveRestore(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	// the blocks are read back from the VM once it exists, so only the creation is checked
+	if d.Id() != "" {
+		return nil
+	}
+
+	restoreFrom := d.Get(mkRestoreFrom).([]any)
+	if len(restoreFrom) == 0 || restoreFrom[0] == nil {
+		return nil
+	}
+
+	restoreBlock := restoreFrom[0].(map[string]any)
+	if !restoreBlock[mkRestoreFromLiveRestore].(bool) {
+		return nil
+	}
+
+	if len(restoreBlock[mkRestoreFromDiskDatastoreIDs].(map[string]any)) > 0 {
+		return fmt.Errorf("%s.%s cannot be combined with %s, as a live restored disk cannot be moved",
+			mkRestoreFrom, mkRestoreFromLiveRestore, mkRestoreFromDiskDatastoreIDs)
+	}
+
+	for _, block := range []string{disk.MkDisk, mkEFIDisk, mkTPMState} {
+		if len(d.Get(block).([]any)) > 0 {
+			return fmt.Errorf("%s.%s cannot be combined with a %s block, as the disks of a live restored VM "+
+				"are taken from the backup", mkRestoreFrom, mkRestoreFromLiveRestore, block)
+		}
+	}
+
+	if !d.Get(mkStarted).(bool) || d.Get(mkTemplate).(bool) {
+		return fmt.Errorf("%s.%s starts the VM, so %s must be true and %s false",
+			mkRestoreFrom, mkRestoreFromLiveRestore, mkStarted, mkTemplate)
+	}
+
+	return nil
+}
+
+// vmRestoreRequestBody returns the request body to restore the VM from the backup set in the
+// restore_from block.
+func vmRestoreRequestBody(d *schema.ResourceData, vmID int) *vms.CreateRequestBody {
+	restoreBlock := d.Get(mkRestoreFrom).([]any)[0].(map[string]any)
+	volumeID := restoreBlock[mkRestoreFromVolumeID].(string)
+	datastoreID := restoreBlock[mkRestoreFromDatastoreID].(string)
+	unique := types.CustomBool(restoreBlock[mkRestoreFromUnique].(bool))
+	liveRestore := types.CustomBool(restoreBlock[mkRestoreFromLiveRestore].(bool))
+
+	description := d.Get(mkDescription).(string)
+	name := d.Get(mkName).(string)
+	poolID := d.Get(mkPoolID).(string)
+
+	restoreBody := &vms.CreateRequestBody{
+		BackupFile: &volumeID,
+		VMID:       vmID,
+	}
+
+	if datastoreID != "" {
+		restoreBody.Storage = &datastoreID
+	}
+
+	if unique {
+		restoreBody.Unique = &unique
+	}
+
+	if liveRestore {
+		restoreBody.LiveRestore = &liveRestore
+	}
+
+	if description != "" {
+		restoreBody.Description = &description
+	}
+
+	if name != "" {
+		restoreBody.Name = &name
+	}
+
+	if poolID != "" {
+		restoreBody.PoolID = &poolID
+	}
+
+	return restoreBody
+}
+
+// vmRestoreMoveDisks moves the restored disks to the mapped datastores. The API restores all disks
+// of a backup to a single datastore, so the disks are placed individually after the restore.
+func vmRestoreMoveDisks(ctx context.Context, vmAPI *vms.Client, mapping map[string]any) diag.Diagnostics {
+	deleteOriginalDisk := types.CustomBool(true)
+
+	for _, disk := range slices.Sorted(maps.Keys(mapping)) {
+		datastoreID := mapping[disk].(string)
+
+		tflog.Info(ctx, fmt.Sprintf("Moving restored disk %s to datastore %s", disk, datastoreID))
+
+		diags := sdkresource.TaskResultDiags(vmAPI.MoveVMDisk(ctx, &vms.MoveDiskRequestBody{
+			DeleteOriginalDisk: &deleteOriginalDisk,
+			Disk:               disk,
+			TargetStorage:      datastoreID,
+		}), "Restored disk move")
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return nil
+}
+
+// vmCreateReconcile applies the declared configuration to a VM that has been created from
+// another source (a clone or a backup), then starts it if requested.
+func vmCreateReconcile(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m any,
+	client proxmox.Client,
+	vmID int,
+) diag.Diagnostics {
+	var cloneDiags diag.Diagnostics
+
+	nodeName := d.Get(mkNodeName).(string)
 	vmAPI := client.Node(nodeName).VM(vmID)
 
 	// Wait for the virtual machine to be created and its configuration lock to be released.
-	e = vmAPI.WaitForVMConfigUnlock(ctx, true)
+	e := vmAPI.WaitForVMConfigUnlock(ctx, true)
 	if e != nil {
 		return diag.FromErr(e)
 	}
 
-	// Now that the virtual machine has been cloned, we need to perform some modifications.
+	// Now that the virtual machine has been created from its source, we need to perform some modifications.
 	audioDevices := vmGetAudioDeviceList(d)
 
 	acpi := types.CustomBool(d.Get(mkACPI).(bool))
@@ -2502,6 +2771,7 @@ func vmCreateClone(ctx context.Context, d *schema.ResourceData, m any) diag.Diag
 	scsiHardware := d.Get(mkSCSIHardware).(string)
 	serialDevice := d.Get(mkSerialDevice).([]any)
 	tabletDevice := types.CustomBool(d.Get(mkTabletDevice).(bool))
+	tags := d.Get(mkTags).([]any)
 	template := d.Get(mkTemplate).(bool)
 	vga := d.Get(mkVGA).([]any)
 	virtiofs := d.Get(mkVirtiofs).([]any)
@@ -4336,6 +4606,11 @@ func vmRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics
 		return diag.FromErr(err)
 	}
 
+	err = sdkresource.SetGuestIdentity(d, nodeName, vmID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	vmStatus, err := vmAPI.GetVMStatus(ctx)
 	if err != nil {
 		return diag.FromErr(err)
@@ -4397,7 +4672,7 @@ func vmReadCustom(
 	}
 
 	nodeName := d.Get(mkNodeName).(string)
-	clone := d.Get(mkClone).([]any)
+	clone := vmCreatedFromSource(d)
 
 	// Compare the agent configuration to the one stored in the state.
 	currentAgent := d.Get(mkAgent).([]any)
@@ -5654,7 +5929,7 @@ func vmReadPrimitiveValues(
 
 	var err error
 
-	clone := d.Get(mkClone).([]any)
+	clone := vmCreatedFromSource(d)
 	currentACPI := d.Get(mkACPI).(bool)
 
 	if len(clone) == 0 || !currentACPI {
@@ -7448,6 +7723,11 @@ func vmDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnosti
 
 	vmAPI := client.Node(nodeName).VM(vmID)
 
+	diags := sdkresource.BackupOnDestroy(ctx, client.Node(nodeName), d, vmID)
+	if diags.HasError() {
+		return diags
+	}
+
 	// Stop or shut down the virtual machine before deleting it.
 	status, err := vmAPI.GetVMStatus(ctx)
 	if err != nil {
@@ -7478,7 +7758,7 @@ func vmDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnosti
 		return nil
 	}
 
-	diags := sdkresource.TaskResultDiags(deleteResult, "VM delete")
+	diags = append(diags, sdkresource.TaskResultDiags(deleteResult, "VM delete")...)
 	if diags.HasError() {
 		return diags
 	}
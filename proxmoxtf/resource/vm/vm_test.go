@@ -10,8 +10,12 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource/vm/disk"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource/vm/network"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/test"
@@ -189,6 +193,7 @@ func TestVMSchema(t *testing.T) {
 		network.MkNetworkDevice,
 		mkOperatingSystem,
 		mkPoolID,
+		mkRestoreFrom,
 		mkSerialDevice,
 		mkStarted,
 		mkTabletDevice,
@@ -219,6 +224,7 @@ func TestVMSchema(t *testing.T) {
 		mkName:            schema.TypeString,
 		mkOperatingSystem: schema.TypeList,
 		mkPoolID:          schema.TypeString,
+		mkRestoreFrom:     schema.TypeList,
 		mkSerialDevice:    schema.TypeList,
 		mkStarted:         schema.TypeBool,
 		mkTabletDevice:    schema.TypeBool,
@@ -616,3 +622,141 @@ func Test_parseImportIDWIthNodeName(t *testing.T) {
 		})
 	}
 }
+
+// TestVMRestore tests that the restore places the disks of the backup on the mapped datastores.
+func TestVMRestore(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	s := fake.NewServer(fake.WithStorage("fast", "zfspool", false, "images", "rootdir"))
+	t.Cleanup(s.Close)
+
+	backup, err := s.AddBackup(fake.DefaultNode, "local", 100, fake.Backup{
+		Config: map[string]string{
+			"name":    "restored",
+			"scsi0":   "local-lvm:vm-100-disk-0,iothread=1,size=8G",
+			"virtio1": "local-lvm:vm-100-disk-1,size=4G",
+			"ide2":    "none,media=cdrom",
+		},
+	})
+	require.NoError(t, err)
+
+	apiClient, err := s.Client()
+	require.NoError(t, err)
+
+	d := schema.TestResourceDataRaw(t, VM().Schema, map[string]any{
+		mkNodeName: fake.DefaultNode,
+		mkRestoreFrom: []any{map[string]any{
+			mkRestoreFromVolumeID:         backup,
+			mkRestoreFromUnique:           true,
+			mkRestoreFromDiskDatastoreIDs: map[string]any{"virtio1": "fast"},
+		}},
+	})
+
+	body := vmRestoreRequestBody(d, 300)
+	assert.Equal(t, backup, *body.BackupFile)
+	assert.Equal(t, 300, body.VMID)
+	assert.True(t, bool(*body.Unique))
+	assert.Nil(t, body.Storage)
+
+	vmAPI := proxmox.NewClient(apiClient, nil, "").Node(fake.DefaultNode).VM(300)
+	require.NoError(t, vmAPI.RestoreVM(ctx, body).Err())
+
+	restoreBlock := d.Get(mkRestoreFrom).([]any)[0].(map[string]any)
+	diags := vmRestoreMoveDisks(ctx, vmAPI, restoreBlock[mkRestoreFromDiskDatastoreIDs].(map[string]any))
+	require.False(t, diags.HasError(), "%v", diags)
+
+	vm, err := vmAPI.GetVM(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "restored", *vm.Name)
+	assert.Equal(t, "local-lvm:vm-300-disk-0", vm.StorageDevices["scsi0"].FileVolume)
+	assert.Equal(t, "fast:vm-300-disk-0", vm.StorageDevices["virtio1"].FileVolume)
+	assert.Equal(t, int64(4), vm.StorageDevices["virtio1"].Size.InGigabytes())
+
+	diags = vmRestoreMoveDisks(ctx, vmAPI, map[string]any{"scsi5": "fast"})
+	require.True(t, diags.HasError(), "disks not recorded in the backup cannot be moved")
+}
+
+func TestVMLiveRestore(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	s := fake.NewServer(fake.WithStorage("pbs", "pbs", true, "backup"))
+	t.Cleanup(s.Close)
+
+	pbsBackup, err := s.AddBackup(fake.DefaultNode, "pbs", 100, fake.Backup{
+		Config: map[string]string{"scsi0": "local-lvm:vm-100-disk-0,size=8G"},
+	})
+	require.NoError(t, err)
+
+	localBackup, err := s.AddBackup(fake.DefaultNode, "local", 100, fake.Backup{
+		Config: map[string]string{"scsi0": "local-lvm:vm-100-disk-0,size=8G"},
+	})
+	require.NoError(t, err)
+
+	apiClient, err := s.Client()
+	require.NoError(t, err)
+
+	nodeAPI := proxmox.NewClient(apiClient, nil, "").Node(fake.DefaultNode)
+
+	restore := func(vmID int, volumeID string) error {
+		d := schema.TestResourceDataRaw(t, VM().Schema, map[string]any{
+			mkNodeName: fake.DefaultNode,
+			mkRestoreFrom: []any{map[string]any{
+				mkRestoreFromVolumeID:    volumeID,
+				mkRestoreFromLiveRestore: true,
+			}},
+		})
+
+		body := vmRestoreRequestBody(d, vmID)
+		require.NotNil(t, body.LiveRestore)
+
+		return nodeAPI.VM(vmID).RestoreVM(ctx, body).Err()
+	}
+
+	require.NoError(t, restore(300, pbsBackup))
+
+	status, err := nodeAPI.VM(300).GetVMStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "running", status.Status, "a live restore starts the VM")
+
+	require.ErrorContains(t, restore(301, localBackup), "only compatible with backup images from a Proxmox Backup Server")
+}
+
+func TestVMLiveRestoreValidation(t *testing.T) {
+	t.Parallel()
+
+	diff := func(config map[string]any) error {
+		config[mkNodeName] = fake.DefaultNode
+
+		_, err := schema.InternalMap(VM().Schema).
+			Diff(t.Context(), nil, terraform.NewResourceConfigRaw(config), validateLiveRestore, nil, true)
+
+		return err
+	}
+
+	restoreFrom := func(block map[string]any) []any {
+		block[mkRestoreFromVolumeID] = "pbs:backup/vm/100/2024-01-01T00:00:00Z"
+		block[mkRestoreFromLiveRestore] = true
+
+		return []any{block}
+	}
+
+	require.NoError(t, diff(map[string]any{mkRestoreFrom: restoreFrom(map[string]any{})}))
+
+	err := diff(map[string]any{
+		mkRestoreFrom: restoreFrom(map[string]any{mkRestoreFromDiskDatastoreIDs: map[string]any{"scsi0": "fast"}}),
+	})
+	require.ErrorContains(t, err, "cannot be combined with disk_datastore_ids")
+
+	err = diff(map[string]any{
+		mkRestoreFrom: restoreFrom(map[string]any{}),
+		disk.MkDisk:   []any{map[string]any{"interface": "scsi0", "datastore_id": "fast"}},
+	})
+	require.ErrorContains(t, err, "cannot be combined with a disk block")
+
+	err = diff(map[string]any{mkRestoreFrom: restoreFrom(map[string]any{}), mkStarted: false})
+	require.ErrorContains(t, err, "started must be true")
+}